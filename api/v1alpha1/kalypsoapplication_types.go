@@ -17,13 +17,18 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // KalypsoApplicationSpec defines the desired state of KalypsoApplication
 type KalypsoApplicationSpec struct {
-	// ProjectRef is the reference to parent KalypsoProject
+	// ProjectRef is the reference to parent KalypsoProject: its name in the application
+	// namespace, or <namespace>/<name> for a project of another namespace allowing the
+	// application namespace
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="projectRef is immutable"
 	ProjectRef string `json:"projectRef"`
 
 	// Description provides a description of the application
@@ -37,6 +42,414 @@ type KalypsoApplicationSpec struct {
 	// Storage defines common storage/secret configuration for all TritonServers
 	// +optional
 	Storage *StorageSpec `json:"storage,omitempty"`
+
+	// ImagePullSecrets are the default pull secrets for TritonServers that do not set their own
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// Routing defines how inference traffic reaches the application gateway
+	// +optional
+	Routing *RoutingSpec `json:"routing,omitempty"`
+
+	// APIVersioning declares the API versions served by the application. Responses to deprecated
+	// versions carry Deprecation and Sunset headers, and requests are counted per version
+	// +optional
+	APIVersioning *APIVersioningSpec `json:"apiVersioning,omitempty"`
+
+	// BlueGreen routes the application traffic to an active server while a preview server is
+	// validated, and swaps them when the serving.kalypso.io/promote annotation is set
+	// +optional
+	BlueGreen *BlueGreenSpec `json:"blueGreen,omitempty"`
+
+	// Certificate requests a cert-manager certificate for the application, covering the Service
+	// names of its servers, for TLS termination at a gateway or on the Triton gRPC endpoints
+	// +optional
+	Certificate *ApplicationCertificateSpec `json:"certificate,omitempty"`
+
+	// Mirror copies a share of the gateway traffic of a server to a candidate server. Responses
+	// of the candidate are discarded, so clients are unaffected
+	// +optional
+	Mirror *MirrorSpec `json:"mirror,omitempty"`
+
+	// ServerDefaults are merged into the KalypsoTritonServers of the application; fields set
+	// on a server take precedence
+	// +optional
+	ServerDefaults *ServerDefaultsSpec `json:"serverDefaults,omitempty"`
+}
+
+// ServerDefaultsSpec defines the settings shared by the servers of an application
+type ServerDefaultsSpec struct {
+	// Resources are the compute requests and limits of servers not setting them
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Networking is the port and Service configuration of servers not setting it
+	// +optional
+	Networking *NetworkingSpec `json:"networking,omitempty"`
+
+	// Observability is the observability configuration of servers without spec.observability
+	// +optional
+	Observability *ObservabilitySpec `json:"observability,omitempty"`
+
+	// Scheduling is the node selection of servers; node selector labels are merged
+	// +optional
+	Scheduling *SchedulingSpec `json:"scheduling,omitempty"`
+}
+
+// MirrorSpec declares the shadow traffic sent to a candidate KalypsoTritonServer
+// +kubebuilder:validation:XValidation:rule="self.sourceServer != self.targetServer",message="sourceServer and targetServer must differ"
+type MirrorSpec struct {
+	// SourceServer is the KalypsoTritonServer whose gateway traffic is mirrored
+	// +kubebuilder:validation:Required
+	SourceServer string `json:"sourceServer"`
+
+	// TargetServer is the candidate KalypsoTritonServer receiving the mirrored requests
+	// +kubebuilder:validation:Required
+	TargetServer string `json:"targetServer"`
+
+	// Percent is the share of the source requests mirrored to the target (default: 10)
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Percent int32 `json:"percent,omitempty"`
+
+	// PrometheusURL is the Prometheus-compatible query endpoint scraping the Istio request
+	// metrics, used to compare the error rate and latency of both servers
+	// +optional
+	PrometheusURL string `json:"prometheusUrl,omitempty"`
+
+	// Interval is the window of the comparison queries (default: 5m)
+	// +optional
+	// +kubebuilder:default="5m"
+	Interval string `json:"interval,omitempty"`
+}
+
+// ApplicationCertificateSpec defines the cert-manager certificate of the application
+type ApplicationCertificateSpec struct {
+	// IssuerRef is the cert-manager issuer signing the certificate
+	// +kubebuilder:validation:Required
+	IssuerRef IssuerReference `json:"issuerRef"`
+
+	// DNSNames are additional names covered by the certificate, e.g. the gateway hostnames
+	// +optional
+	// +listType=set
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// Duration is the requested validity of the certificate
+	// +optional
+	// +kubebuilder:default="2160h"
+	Duration string `json:"duration,omitempty"`
+
+	// RenewBefore is how long before expiry cert-manager renews the certificate
+	// +optional
+	// +kubebuilder:default="360h"
+	RenewBefore string `json:"renewBefore,omitempty"`
+
+	// ServerTLS serves the Triton gRPC endpoints of the application's servers over TLS with the
+	// certificate. The servers are restarted when the certificate is renewed
+	// +optional
+	ServerTLS bool `json:"serverTLS,omitempty"`
+}
+
+// BlueGreenSpec declares the active and preview KalypsoTritonServers of the application
+// +kubebuilder:validation:XValidation:rule="self.activeServer != self.previewServer",message="activeServer and previewServer must differ"
+type BlueGreenSpec struct {
+	// ActiveServer is the KalypsoTritonServer behind the <application>-active Service and the custom domains
+	// +kubebuilder:validation:Required
+	ActiveServer string `json:"activeServer"`
+
+	// PreviewServer is the KalypsoTritonServer behind the <application>-preview Service
+	// +kubebuilder:validation:Required
+	PreviewServer string `json:"previewServer"`
+}
+
+// APIVersioningSpec declares the API versions served by the application
+type APIVersioningSpec struct {
+	// Versions are the supported and deprecated API versions
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	Versions []APIVersion `json:"versions"`
+}
+
+// APIVersion is an API version, matched by request path prefix
+type APIVersion struct {
+	// Name is the version, e.g. v1
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9._-]{1,63}$`
+	Name string `json:"name"`
+
+	// PathPrefix matches the requests of this version (default: /<name>/)
+	// +optional
+	// +kubebuilder:validation:Pattern=`^/[A-Za-z0-9._~/-]*$`
+	PathPrefix string `json:"pathPrefix,omitempty"`
+
+	// Deprecated adds a Deprecation header to the responses of this version
+	// +optional
+	Deprecated bool `json:"deprecated,omitempty"`
+
+	// Sunset is when the version will be retired, advertised in the Sunset header (RFC 8594)
+	// +optional
+	Sunset *metav1.Time `json:"sunset,omitempty"`
+
+	// Link documents the migration off this version, advertised in a Link header
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https?://[^\s"\\]+$`
+	Link string `json:"link,omitempty"`
+}
+
+// RoutingSpec defines the application gateway routing configuration
+// +kubebuilder:validation:XValidation:rule="!has(self.customDomains) || size(self.customDomains) == 0 || has(self.issuerRef)",message="issuerRef is required when customDomains are set"
+type RoutingSpec struct {
+	// CustomDomains are hostnames served by the application gateway, each with its own TLS certificate.
+	// A domain may only be claimed by one application per project
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:Pattern=`^(\*\.)?([a-z0-9]([-a-z0-9]*[a-z0-9])?\.)+[a-z]{2,63}$`
+	CustomDomains []string `json:"customDomains,omitempty"`
+
+	// IssuerRef is the cert-manager issuer used to request the custom domain certificates
+	// +optional
+	IssuerRef *IssuerReference `json:"issuerRef,omitempty"`
+
+	// IngressNamespace is the namespace of the Istio ingress gateway workload,
+	// where the TLS secrets referenced by the gateway must live
+	// +optional
+	// +kubebuilder:default="istio-system"
+	IngressNamespace string `json:"ingressNamespace,omitempty"`
+
+	// GatewayAPI attaches HTTPRoute and GRPCRoute objects to an existing Gateway API Gateway
+	// instead of generating the Istio Gateway and VirtualService
+	// +optional
+	GatewayAPI *GatewayAPIRoutingSpec `json:"gatewayAPI,omitempty"`
+
+	// TrafficPolicy applies connection limits and outlier ejection to the Service of every
+	// server of the application, through one Istio DestinationRule per server
+	// +optional
+	TrafficPolicy *TrafficPolicySpec `json:"trafficPolicy,omitempty"`
+
+	// RateLimit caps the requests reaching each replica of the application's servers,
+	// enforced by an Envoy local rate limit filter in the Istio sidecars
+	// +optional
+	RateLimit *RateLimitSpec `json:"rateLimit,omitempty"`
+
+	// Auth requires a valid JWT on the requests reaching the application's servers, through
+	// an Istio RequestAuthentication and AuthorizationPolicy
+	// +optional
+	Auth *AuthSpec `json:"auth,omitempty"`
+
+	// CORS lets browser applications on other origins call the inference endpoints through
+	// the application gateway
+	// +optional
+	CORS *CORSSpec `json:"cors,omitempty"`
+
+	// RequestTimeout bounds the time the gateway waits for a response, e.g. "60s"
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]{1,5}(h|m|s|ms)){1,4}$`
+	RequestTimeout string `json:"requestTimeout,omitempty"`
+
+	// MaxRequestBodyBytes rejects larger requests with 413 in the Istio sidecars of the
+	// application's servers. Requests are buffered up to the limit before reaching Triton
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4294967295
+	MaxRequestBodyBytes *int64 `json:"maxRequestBodyBytes,omitempty"`
+}
+
+// CORSSpec defines the cross-origin requests accepted by the application gateway
+type CORSSpec struct {
+	// AllowOrigins are the accepted origins, e.g. "https://demo.example.com"; "*" accepts any
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	AllowOrigins []string `json:"allowOrigins"`
+
+	// AllowMethods are the accepted request methods (default: GET, POST, OPTIONS)
+	// +optional
+	// +listType=set
+	AllowMethods []string `json:"allowMethods,omitempty"`
+
+	// AllowHeaders are the request headers the browser may send
+	// +optional
+	// +listType=set
+	AllowHeaders []string `json:"allowHeaders,omitempty"`
+
+	// ExposeHeaders are the response headers the browser may read
+	// +optional
+	// +listType=set
+	ExposeHeaders []string `json:"exposeHeaders,omitempty"`
+
+	// MaxAgeSeconds is how long the browser may cache the preflight response (default: 86400)
+	// +optional
+	// +kubebuilder:default=86400
+	// +kubebuilder:validation:Minimum=0
+	MaxAgeSeconds int32 `json:"maxAgeSeconds,omitempty"`
+
+	// AllowCredentials lets the browser send cookies and authorization headers
+	// +optional
+	AllowCredentials bool `json:"allowCredentials,omitempty"`
+}
+
+// AuthSpec defines the JWT authentication of the inference endpoints
+type AuthSpec struct {
+	// Issuer is the issuer (iss claim) of the accepted tokens
+	// +kubebuilder:validation:Required
+	Issuer string `json:"issuer"`
+
+	// JWKSURI is the URL of the JSON Web Key Set validating the token signatures
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	JWKSURI string `json:"jwksUri"`
+
+	// Audiences are the accepted audiences (aud claim); any audience is accepted when empty
+	// +optional
+	// +listType=set
+	Audiences []string `json:"audiences,omitempty"`
+
+	// PublicPaths are reachable without a token, e.g. "/v2/health/ready" for external load
+	// balancer health checks. The Triton metrics path is always public
+	// +optional
+	// +listType=set
+	PublicPaths []string `json:"publicPaths,omitempty"`
+}
+
+// RateLimitSpec defines the request rate limits of the application. Requests over a limit are
+// answered with 429 Too Many Requests
+type RateLimitSpec struct {
+	// RequestsPerSecond is the total request rate accepted by each replica
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	RequestsPerSecond int32 `json:"requestsPerSecond"`
+
+	// ClientHeader is the request header identifying the client for the per-client limits
+	// +optional
+	// +kubebuilder:default="x-client-id"
+	ClientHeader string `json:"clientHeader,omitempty"`
+
+	// Clients are per-client request rates, within the total rate
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Clients []NamedRateLimit `json:"clients,omitempty"`
+
+	// Models are per-model request rates of the HTTP inference API, within the total rate
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Models []NamedRateLimit `json:"models,omitempty"`
+}
+
+// NamedRateLimit is the request rate of one client or model
+type NamedRateLimit struct {
+	// Name is the client identifier or the model name
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9][-A-Za-z0-9_.]*$`
+	Name string `json:"name"`
+
+	// RequestsPerSecond is the request rate accepted by each replica
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	RequestsPerSecond int32 `json:"requestsPerSecond"`
+}
+
+// TrafficPolicySpec defines the circuit breaking of the application's servers
+type TrafficPolicySpec struct {
+	// ConnectionPool limits the connections and requests to each server
+	// +optional
+	ConnectionPool *ConnectionPoolSpec `json:"connectionPool,omitempty"`
+
+	// OutlierDetection ejects failing replicas from the load balancing pool
+	// +optional
+	OutlierDetection *OutlierDetectionSpec `json:"outlierDetection,omitempty"`
+}
+
+// ConnectionPoolSpec limits the connections of the Envoy proxies to a server
+type ConnectionPoolSpec struct {
+	// MaxConnections is the maximum number of TCP connections to the server
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConnections *int32 `json:"maxConnections,omitempty"`
+
+	// MaxPendingRequests is the maximum number of requests queued while waiting for a connection
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxPendingRequests *int32 `json:"maxPendingRequests,omitempty"`
+
+	// MaxRequests is the maximum number of concurrent requests, including gRPC streams
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxRequests *int32 `json:"maxRequests,omitempty"`
+
+	// MaxRequestsPerConnection recycles connections after this many requests
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxRequestsPerConnection *int32 `json:"maxRequestsPerConnection,omitempty"`
+}
+
+// OutlierDetectionSpec ejects replicas returning consecutive errors, such as a replica that
+// loaded a corrupted model
+type OutlierDetectionSpec struct {
+	// Consecutive5xxErrors is the number of consecutive 5xx responses ejecting a replica
+	// +optional
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+	Consecutive5xxErrors int32 `json:"consecutive5xxErrors,omitempty"`
+
+	// Interval is how often replicas are evaluated for ejection
+	// +optional
+	// +kubebuilder:default="10s"
+	Interval string `json:"interval,omitempty"`
+
+	// BaseEjectionTime is the minimum ejection duration, multiplied by the number of ejections
+	// +optional
+	// +kubebuilder:default="30s"
+	BaseEjectionTime string `json:"baseEjectionTime,omitempty"`
+
+	// MaxEjectionPercent is the highest share of replicas that may be ejected at once
+	// +optional
+	// +kubebuilder:default=50
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxEjectionPercent int32 `json:"maxEjectionPercent,omitempty"`
+}
+
+// GatewayAPIRoutingSpec configures routing through the Kubernetes Gateway API
+type GatewayAPIRoutingSpec struct {
+	// GatewayRef is the Gateway the application routes attach to
+	// +kubebuilder:validation:Required
+	GatewayRef GatewayReference `json:"gatewayRef"`
+}
+
+// GatewayReference references a Gateway API Gateway, and optionally one of its listeners
+type GatewayReference struct {
+	// Name is the name of the Gateway
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Gateway (default: the application namespace).
+	// Its listeners must allow routes from the application namespace
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the listener of the Gateway to attach to (default: all listeners)
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// IssuerReference references a cert-manager Issuer or ClusterIssuer
+type IssuerReference struct {
+	// Name is the name of the issuer
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Kind is the issuer kind: Issuer or ClusterIssuer
+	// +optional
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +kubebuilder:default="ClusterIssuer"
+	Kind string `json:"kind,omitempty"`
 }
 
 // GitSourceSpec defines the Git repository configuration
@@ -56,10 +469,21 @@ type GitSourceSpec struct {
 }
 
 // StorageSpec defines the storage configuration
+// +kubebuilder:validation:XValidation:rule="!has(self.auth) || self.auth != 'workloadIdentity' || has(self.workloadIdentity)",message="workloadIdentity is required with the workloadIdentity auth"
 type StorageSpec struct {
-	// SecretName is the name of secret containing credentials
-	// +kubebuilder:validation:Required
-	SecretName string `json:"secretName"`
+	// SecretName is the name of secret containing S3 credentials
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// Auth is how the servers authenticate to the storage: secret injects the credentials of
+	// secretName, gcs and azure, workloadIdentity runs the pods as a ServiceAccount bound to a
+	// cloud identity instead, so no long-lived credentials are mounted (default: secret)
+	// +optional
+	Auth StorageAuth `json:"auth,omitempty"`
+
+	// WorkloadIdentity is the cloud identity the ServiceAccount of the servers is bound to
+	// +optional
+	WorkloadIdentity *WorkloadIdentitySpec `json:"workloadIdentity,omitempty"`
 
 	// Region is the cloud region for storage
 	// +optional
@@ -68,6 +492,256 @@ type StorageSpec struct {
 	// Endpoint is the S3-compatible endpoint URL (for MinIO, etc.)
 	// +optional
 	Endpoint string `json:"endpoint,omitempty"`
+
+	// S3 configures the addressing, encryption, TLS trust and proxy of s3:// model repositories
+	// +optional
+	S3 *S3OptionsSpec `json:"s3,omitempty"`
+
+	// GCS configures the credentials of gs:// model repositories. Without it, Triton uses the
+	// workload identity of the pod ServiceAccount, e.g. set with the iam.gke.io/gcp-service-account
+	// annotation of spec.serviceAccount
+	// +optional
+	GCS *GCSStorageSpec `json:"gcs,omitempty"`
+
+	// Azure configures the credentials of as://<account>/<container>/<path> model repositories
+	// +optional
+	Azure *AzureStorageSpec `json:"azure,omitempty"`
+
+	// DownloadMode is how the servers read s3:// model repositories: stream reads them from the
+	// bucket with the Triton S3 client, local copies them to a pod volume with parallel s5cmd
+	// transfers before Triton starts, which loads multi-GB models faster (default: stream)
+	// +optional
+	DownloadMode StorageDownloadMode `json:"downloadMode,omitempty"`
+
+	// LocalDownload configures the copy of the local download mode
+	// +optional
+	LocalDownload *LocalDownloadSpec `json:"localDownload,omitempty"`
+
+	// Verification checks the model repositories downloaded at startup before Triton serves
+	// them. s3:// repositories are copied in the local download mode; repositories Triton reads
+	// remotely otherwise are not deployed
+	// +optional
+	Verification *ModelVerificationSpec `json:"verification,omitempty"`
+}
+
+// ModelVerificationSpec defines the checksum and signature verification of model repositories
+type ModelVerificationSpec struct {
+	// Manifest is the sha256sum manifest at the root of the model repository, listing the
+	// checksum of every other file of the repository
+	// +optional
+	// +kubebuilder:default="SHA256SUMS"
+	Manifest string `json:"manifest,omitempty"`
+
+	// Cosign verifies the signature of the manifest, <manifest>.sig, before its checksums
+	// +optional
+	Cosign *CosignVerificationSpec `json:"cosign,omitempty"`
+
+	// Image is the checksum verification image, providing sh, sha256sum, find and awk
+	// (default: busybox:1.36)
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// CosignVerificationSpec defines the cosign signature verification of the manifest
+type CosignVerificationSpec struct {
+	// PublicKeySecret is a Secret with the cosign.pub public key of the signer
+	// +kubebuilder:validation:Required
+	PublicKeySecret string `json:"publicKeySecret"`
+
+	// TransparencyLog also requires the signature to be recorded in the Rekor transparency
+	// log, which the pods must be able to reach
+	// +optional
+	TransparencyLog bool `json:"transparencyLog,omitempty"`
+
+	// Image is the cosign image (default: gcr.io/projectsigstore/cosign:v2.4.1)
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// StorageAuth is how the servers authenticate to the storage
+// +kubebuilder:validation:Enum=secret;workloadIdentity
+type StorageAuth string
+
+const (
+	// StorageAuthSecret injects the credentials of the storage Secrets
+	StorageAuthSecret StorageAuth = "secret"
+	// StorageAuthWorkloadIdentity binds the ServiceAccount of the pods to a cloud identity
+	StorageAuthWorkloadIdentity StorageAuth = "workloadIdentity"
+)
+
+// WorkloadIdentitySpec defines the cloud identities the ServiceAccount of the servers is bound to
+// +kubebuilder:validation:XValidation:rule="has(self.awsRoleArn) || has(self.gcpServiceAccount) || has(self.azureClientId)",message="one of awsRoleArn, gcpServiceAccount and azureClientId is required"
+type WorkloadIdentitySpec struct {
+	// AWSRoleARN is the IAM role assumed through IAM Roles for Service Accounts on EKS, set as
+	// the eks.amazonaws.com/role-arn annotation
+	// +optional
+	// +kubebuilder:validation:Pattern=`^arn:aws[a-z-]*:iam::[0-9]{12}:role/.+`
+	AWSRoleARN string `json:"awsRoleArn,omitempty"`
+
+	// GCPServiceAccount is the Google service account impersonated through GKE Workload Identity,
+	// set as the iam.gke.io/gcp-service-account annotation
+	// +optional
+	GCPServiceAccount string `json:"gcpServiceAccount,omitempty"`
+
+	// AzureClientID is the client ID of the managed identity federated through Microsoft Entra
+	// Workload ID, set as the azure.workload.identity/client-id annotation
+	// +optional
+	AzureClientID string `json:"azureClientId,omitempty"`
+}
+
+// StorageDownloadMode is how the servers read s3:// model repositories
+// +kubebuilder:validation:Enum=stream;local
+type StorageDownloadMode string
+
+const (
+	// StorageDownloadModeStream reads the models from the bucket
+	StorageDownloadModeStream StorageDownloadMode = "stream"
+	// StorageDownloadModeLocal copies the model repository to a pod volume at startup
+	StorageDownloadModeLocal StorageDownloadMode = "local"
+)
+
+// S3OptionsSpec defines the S3 client options of the Triton, s5cmd and model cache containers
+type S3OptionsSpec struct {
+	// ForcePathStyle addresses buckets as <endpoint>/<bucket> instead of <bucket>.<endpoint>, as
+	// MinIO requires. Triton streams the repository from s3://<endpoint>/<bucket>/<path>; s5cmd
+	// addresses custom endpoints path-style already
+	// +optional
+	ForcePathStyle bool `json:"forcePathStyle,omitempty"`
+
+	// KMSKeyID is the SSE-KMS key ID or ARN encrypting the bucket objects, exposed to the
+	// containers as AWS_S3_SSE_KMS_KEY_ID. S3 decrypts the objects on read for principals allowed
+	// to kms:Decrypt with the key; without a region, the region of a key ARN is used
+	// +optional
+	KMSKeyID string `json:"kmsKeyId,omitempty"`
+
+	// CABundleSecret is a Secret with the PEM CA bundle the S3 endpoint certificate is verified
+	// with, e.g. of an in-house MinIO
+	// +optional
+	CABundleSecret string `json:"caBundleSecret,omitempty"`
+
+	// CABundleKey is the key of the CA bundle in the Secret
+	// +optional
+	// +kubebuilder:default="ca.crt"
+	CABundleKey string `json:"caBundleKey,omitempty"`
+
+	// ProxyURL is the HTTP(S) proxy the S3 requests are sent through, e.g. http://proxy.corp:3128
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https?://`
+	ProxyURL string `json:"proxyUrl,omitempty"`
+
+	// NoProxy lists the hosts and domains reached without the proxy, e.g. .svc,.cluster.local
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+}
+
+// LocalDownloadSpec defines the s5cmd copy of s3:// model repositories to a pod volume
+// +kubebuilder:validation:XValidation:rule="!has(self.storageClassName) || has(self.sizeLimit)",message="sizeLimit is required with storageClassName"
+type LocalDownloadSpec struct {
+	// Workers is the number of parallel s5cmd transfers (default: 256)
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Workers *int32 `json:"workers,omitempty"`
+
+	// SizeLimit bounds the volume the repository is copied to. It is the requested size of the
+	// ephemeral volume when storageClassName is set
+	// +optional
+	SizeLimit *resource.Quantity `json:"sizeLimit,omitempty"`
+
+	// StorageClassName copies the repository to a generic ephemeral volume of the class, e.g.
+	// backed by local NVMe, instead of an emptyDir
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// Image is the s5cmd image (default: peakcom/s5cmd:v2.3.0)
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// AzureStorageSpec defines the credentials of Azure Blob Storage model repositories. Exactly
+// one of credentialsSecret and managedIdentity must be set
+// +kubebuilder:validation:XValidation:rule="has(self.credentialsSecret) != has(self.managedIdentity)",message="exactly one of credentialsSecret and managedIdentity must be set"
+type AzureStorageSpec struct {
+	// CredentialsSecret is a Secret with the AZURE_STORAGE_KEY of the storage account, injected
+	// with its other AZURE_STORAGE_* keys as environment variables
+	// +optional
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+
+	// ManagedIdentity authenticates with Microsoft Entra Workload ID instead of an account key
+	// +optional
+	ManagedIdentity *AzureManagedIdentitySpec `json:"managedIdentity,omitempty"`
+}
+
+// AzureManagedIdentitySpec defines the workload identity of the Triton pods. The pod
+// ServiceAccount must be federated with the identity, e.g. with the
+// azure.workload.identity/client-id annotation of spec.serviceAccount
+type AzureManagedIdentitySpec struct {
+	// ClientID is the client ID of the managed identity
+	// +kubebuilder:validation:Required
+	ClientID string `json:"clientId"`
+}
+
+// GCSStorageSpec defines the service account key of gs:// model repositories
+type GCSStorageSpec struct {
+	// CredentialsSecret is the Secret holding the service account key
+	// +kubebuilder:validation:Required
+	CredentialsSecret string `json:"credentialsSecret"`
+
+	// Key is the Secret key of the service account key file (default: key.json)
+	// +optional
+	// +kubebuilder:default="key.json"
+	Key string `json:"key,omitempty"`
+}
+
+// BulkServerState represents the progress of a bulk operation on a single KalypsoTritonServer
+// +kubebuilder:validation:Enum=Pending;Completed;Failed
+type BulkServerState string
+
+const (
+	// BulkServerStatePending indicates the server was updated and has not reached the requested state yet
+	BulkServerStatePending BulkServerState = "Pending"
+	// BulkServerStateCompleted indicates the server reached the requested state
+	BulkServerStateCompleted BulkServerState = "Completed"
+	// BulkServerStateFailed indicates the server could not be updated
+	BulkServerStateFailed BulkServerState = "Failed"
+)
+
+// BulkOperationStatus reports a suspend, resume, or scale operation applied to every
+// KalypsoTritonServer of an application or project
+type BulkOperationStatus struct {
+	// Operation is the requested operation: suspend, resume, or scale=N
+	Operation string `json:"operation"`
+
+	// Message explains why the operation could not be applied
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Total is the number of servers the operation applies to
+	// +optional
+	Total int32 `json:"total,omitempty"`
+
+	// Completed is the number of servers that reached the requested state
+	// +optional
+	Completed int32 `json:"completed,omitempty"`
+
+	// Servers reports the progress of each server
+	// +optional
+	Servers []BulkOperationServerStatus `json:"servers,omitempty"`
+}
+
+// BulkOperationServerStatus reports the progress of a bulk operation on a KalypsoTritonServer
+type BulkOperationServerStatus struct {
+	// Namespace is the namespace of the server
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the server
+	Name string `json:"name"`
+
+	// State is the progress of the operation on the server
+	State BulkServerState `json:"state"`
+
+	// Message is a human-readable progress message
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // ApplicationPhase represents the current phase of the application
@@ -93,10 +767,37 @@ type KalypsoApplicationStatus struct {
 	// +optional
 	ActiveModels int `json:"activeModels,omitempty"`
 
-	// GatewayEndpoint is the Istio Gateway endpoint URL
+	// GatewayEndpoint is the URL of the application behind the Istio ingress gateway
 	// +optional
 	GatewayEndpoint string `json:"gatewayEndpoint,omitempty"`
 
+	// CustomDomains reports the certificate state of each custom domain
+	// +optional
+	CustomDomains []CustomDomainStatus `json:"customDomains,omitempty"`
+
+	// BulkOperation reports the progress of the bulk operation requested on the application
+	// +optional
+	BulkOperation *BulkOperationStatus `json:"bulkOperation,omitempty"`
+
+	// LastPromotionTime is when the preview server was last promoted to active
+	// +optional
+	LastPromotionTime *metav1.Time `json:"lastPromotionTime,omitempty"`
+
+	// CertificateSecret is the secret holding the application certificate once it is issued
+	// +optional
+	CertificateSecret string `json:"certificateSecret,omitempty"`
+
+	// Mirror compares the source and target servers of the traffic mirror
+	// +optional
+	Mirror *MirrorStatus `json:"mirror,omitempty"`
+
+	// ModelRoutes map each model served by the application to the server its
+	// /<application>/v2/models/<model>/ requests are routed to
+	// +optional
+	// +listType=map
+	// +listMapKey=model
+	ModelRoutes []ModelRoute `json:"modelRoutes,omitempty"`
+
 	// Conditions represent the current state of the KalypsoApplication resource
 	// +listType=map
 	// +listMapKey=type
@@ -104,6 +805,52 @@ type KalypsoApplicationStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// ModelRoute maps a model to the KalypsoTritonServer serving it
+type ModelRoute struct {
+	// Model is the Triton model name
+	Model string `json:"model"`
+
+	// Server is the KalypsoTritonServer the model requests are routed to
+	Server string `json:"server"`
+}
+
+// MirrorStatus reports the metrics of the mirrored servers over the comparison window
+type MirrorStatus struct {
+	// LastEvaluationTime is when the metrics were last evaluated
+	// +optional
+	LastEvaluationTime *metav1.Time `json:"lastEvaluationTime,omitempty"`
+
+	// SourceErrorRate is the ratio of 5xx responses of the source server
+	// +optional
+	SourceErrorRate string `json:"sourceErrorRate,omitempty"`
+
+	// TargetErrorRate is the ratio of 5xx responses of the target server
+	// +optional
+	TargetErrorRate string `json:"targetErrorRate,omitempty"`
+
+	// SourceP99LatencyMs is the p99 request latency of the source server in milliseconds
+	// +optional
+	SourceP99LatencyMs string `json:"sourceP99LatencyMs,omitempty"`
+
+	// TargetP99LatencyMs is the p99 request latency of the target server in milliseconds
+	// +optional
+	TargetP99LatencyMs string `json:"targetP99LatencyMs,omitempty"`
+}
+
+// CustomDomainStatus reports the state of a custom domain
+type CustomDomainStatus struct {
+	// Host is the custom domain
+	Host string `json:"host"`
+
+	// SecretName is the TLS secret in the ingress namespace holding the domain certificate
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// CertificateReady indicates that cert-manager issued the certificate
+	// +optional
+	CertificateReady bool `json:"certificateReady,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Project",type=string,JSONPath=`.spec.projectRef`
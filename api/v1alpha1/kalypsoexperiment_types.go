@@ -0,0 +1,181 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KalypsoExperimentSpec defines the desired state of KalypsoExperiment
+type KalypsoExperimentSpec struct {
+	// ApplicationRef is the KalypsoApplication whose gateway routes the experiment traffic
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="applicationRef is immutable"
+	ApplicationRef string `json:"applicationRef"`
+
+	// Variants are the KalypsoTritonServers compared. Requests addressed to the first variant's
+	// Service are split between them
+	// +kubebuilder:validation:MinItems=2
+	// +listType=map
+	// +listMapKey=name
+	Variants []ExperimentVariant `json:"variants"`
+
+	// Analysis compares the variants' request metrics and recommends a winner
+	// +kubebuilder:validation:Required
+	Analysis ExperimentAnalysisSpec `json:"analysis"`
+}
+
+// ExperimentVariant is a KalypsoTritonServer receiving a share of the experiment traffic
+type ExperimentVariant struct {
+	// Name identifies the variant in the status, e.g. control
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// ServerRef is the KalypsoTritonServer serving the variant
+	// +kubebuilder:validation:Required
+	ServerRef string `json:"serverRef"`
+
+	// Weight is the percentage of the requests without a matching header sent to the variant.
+	// The weights of all variants must sum to 100
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+
+	// Headers pins requests carrying all of these exact header values to the variant, e.g. for
+	// internal testers
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// ExperimentObjective is the metric a winning variant minimizes
+// +kubebuilder:validation:Enum=ErrorRate;P99Latency
+type ExperimentObjective string
+
+const (
+	// ExperimentObjectiveErrorRate selects the variant with the lowest ratio of 5xx responses
+	ExperimentObjectiveErrorRate ExperimentObjective = "ErrorRate"
+	// ExperimentObjectiveP99Latency selects the variant with the lowest p99 request latency
+	ExperimentObjectiveP99Latency ExperimentObjective = "P99Latency"
+)
+
+// ExperimentAnalysisSpec defines how the variants are compared
+type ExperimentAnalysisSpec struct {
+	// PrometheusURL is the Prometheus-compatible query endpoint scraping the Istio request metrics
+	// +kubebuilder:validation:Required
+	PrometheusURL string `json:"prometheusUrl"`
+
+	// Interval is how often the variant metrics are aggregated
+	// +optional
+	// +kubebuilder:default="5m"
+	Interval string `json:"interval,omitempty"`
+
+	// Objective is the metric the winning variant minimizes: ErrorRate or P99Latency
+	// +optional
+	// +kubebuilder:default=P99Latency
+	Objective ExperimentObjective `json:"objective,omitempty"`
+
+	// MinRequests is the number of requests every variant must serve before a winner is recommended
+	// +optional
+	// +kubebuilder:default=1000
+	// +kubebuilder:validation:Minimum=1
+	MinRequests int64 `json:"minRequests,omitempty"`
+}
+
+// KalypsoExperimentStatus defines the observed state of KalypsoExperiment.
+type KalypsoExperimentStatus struct {
+	// StartedAt is when the experiment started splitting traffic; metrics are aggregated since then
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// LastEvaluationTime is when the variant metrics were last aggregated
+	// +optional
+	LastEvaluationTime *metav1.Time `json:"lastEvaluationTime,omitempty"`
+
+	// Variants reports the aggregated metrics of each variant
+	// +optional
+	Variants []ExperimentVariantStatus `json:"variants,omitempty"`
+
+	// Winner is the variant recommended once every variant served the minimum number of requests
+	// +optional
+	Winner string `json:"winner,omitempty"`
+
+	// Message is a human-readable message indicating details about the experiment
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Conditions represent the current state of the KalypsoExperiment resource
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ExperimentVariantStatus reports the metrics of a variant since the experiment started
+type ExperimentVariantStatus struct {
+	// Name is the variant name
+	Name string `json:"name"`
+
+	// Requests is the number of requests served by the variant
+	// +optional
+	Requests int64 `json:"requests,omitempty"`
+
+	// ErrorRate is the ratio of 5xx responses of the variant
+	// +optional
+	ErrorRate string `json:"errorRate,omitempty"`
+
+	// P99LatencyMs is the p99 request latency of the variant in milliseconds
+	// +optional
+	P99LatencyMs string `json:"p99LatencyMs,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Application",type=string,JSONPath=`.spec.applicationRef`
+// +kubebuilder:printcolumn:name="Objective",type=string,JSONPath=`.spec.analysis.objective`
+// +kubebuilder:printcolumn:name="Winner",type=string,JSONPath=`.status.winner`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// KalypsoExperiment is the Schema for the kalypsoexperiments API
+// It splits application traffic between KalypsoTritonServer variants and recommends the best one
+type KalypsoExperiment struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of KalypsoExperiment
+	// +required
+	Spec KalypsoExperimentSpec `json:"spec"`
+
+	// status defines the observed state of KalypsoExperiment
+	// +optional
+	Status KalypsoExperimentStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// KalypsoExperimentList contains a list of KalypsoExperiment
+type KalypsoExperimentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []KalypsoExperiment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KalypsoExperiment{}, &KalypsoExperimentList{})
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ModelCacheHostPath is the node directory holding the KalypsoModelCache repositories, under
+// <namespace>/<cache name>. Nodes with local NVMe should mount it there
+const ModelCacheHostPath = "/var/lib/kalypso/model-cache"
+
+// KalypsoModelCacheSpec defines the desired state of KalypsoModelCache
+type KalypsoModelCacheSpec struct {
+	// ApplicationRef is the KalypsoApplication whose storage configuration and credentials the
+	// cache downloads the repositories with
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="applicationRef is immutable"
+	ApplicationRef string `json:"applicationRef"`
+
+	// StorageURIs are the s3:// model repositories pre-pulled onto every cache node
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Pattern=`^s3://`
+	// +listType=set
+	StorageURIs []string `json:"storageUris"`
+
+	// RefreshInterval is how often the cached repositories are synced with the bucket
+	// +optional
+	// +kubebuilder:default="10m"
+	RefreshInterval string `json:"refreshInterval,omitempty"`
+
+	// Workers is the number of parallel s5cmd transfers (default: 256)
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Workers *int32 `json:"workers,omitempty"`
+
+	// ServiceAccountName is the ServiceAccount of the cache pods, e.g. bound to a cloud IAM role
+	// allowed to read the bucket
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// NodeSelector restricts the cache to the nodes running the servers, e.g. GPU nodes
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations let the cache run on tainted nodes
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Resources of the cache sync container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Image is the s5cmd image (default: peakcom/s5cmd:v2.3.0)
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// KalypsoModelCacheStatus defines the observed state of KalypsoModelCache.
+type KalypsoModelCacheStatus struct {
+	// DesiredNodes is the number of nodes the cache should run on
+	// +optional
+	DesiredNodes int32 `json:"desiredNodes,omitempty"`
+
+	// ReadyNodes is the number of nodes holding every repository
+	// +optional
+	ReadyNodes int32 `json:"readyNodes,omitempty"`
+
+	// ObservedGeneration is the last generation reconciled
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions represent the current state of the KalypsoModelCache resource
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Desired",type=integer,JSONPath=`.status.desiredNodes`
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readyNodes`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// KalypsoModelCache is the Schema for the kalypsomodelcaches API
+// It pre-pulls model repositories onto node-local disks, where KalypsoTritonServers referencing
+// the cache load them from instead of the bucket
+type KalypsoModelCache struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of KalypsoModelCache
+	// +required
+	Spec KalypsoModelCacheSpec `json:"spec"`
+
+	// status defines the observed state of KalypsoModelCache
+	// +optional
+	Status KalypsoModelCacheStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// KalypsoModelCacheList contains a list of KalypsoModelCache
+type KalypsoModelCacheList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []KalypsoModelCache `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KalypsoModelCache{}, &KalypsoModelCacheList{})
+}
@@ -38,6 +38,116 @@ type KalypsoProjectSpec struct {
 	// ModelRegistry defines common model registry settings
 	// +optional
 	ModelRegistry *ModelRegistrySpec `json:"modelRegistry,omitempty"`
+
+	// ImagePullSecrets are Secrets of the project namespace copied into every environment
+	// namespace, kept in sync, and attached to its default and Kalypso-created ServiceAccounts
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// Members are the users and groups granted access to the Kalypso resources, and the
+	// resources they create, in every environment namespace
+	// +optional
+	Members []ProjectMember `json:"members,omitempty"`
+
+	// Audit streams the create/update/delete events of the project's Kalypso resources, with
+	// the identity of the requesting user, to a SIEM. Requires the AuditExport feature gate
+	// +optional
+	Audit *AuditSpec `json:"audit,omitempty"`
+
+	// ImageVerification overrides the operator policy verifying the cosign signatures of the
+	// Triton images of the project's servers
+	// +optional
+	ImageVerification *ImageVerificationSpec `json:"imageVerification,omitempty"`
+
+	// DeletionPolicy controls what deleting the project removes: Delete removes its servers,
+	// applications and managed namespaces, RetainData keeps the namespaces and their contents,
+	// and Orphan also keeps the servers and applications
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy ProjectDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// AllowedNamespaces are the namespaces, besides the project and environment namespaces,
+	// whose KalypsoApplications may reference the project, and whose KalypsoTritonServers its
+	// applications, as <namespace>/<name>, like a Gateway API ReferenceGrant
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+
+	// Defaults are the settings inherited by the project's servers that leave them unset
+	// +optional
+	Defaults *ProjectDefaultsSpec `json:"defaults,omitempty"`
+}
+
+// ProjectDefaultsSpec defines the settings inherited by the servers of a project
+type ProjectDefaultsSpec struct {
+	// TritonConfig is the Triton configuration of servers without their own, e.g. the Triton
+	// release approved for the project
+	// +optional
+	TritonConfig *ProjectTritonDefaults `json:"tritonConfig,omitempty"`
+}
+
+// ProjectTritonDefaults defines the Triton configuration inherited by the servers of a project
+type ProjectTritonDefaults struct {
+	// Image is the Triton container image of servers without spec.tritonConfig.image
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Tag is the image tag of servers without spec.tritonConfig.tag
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// Parameters are Triton runtime parameters added to the servers not setting them
+	// +optional
+	Parameters []TritonParameter `json:"parameters,omitempty"`
+
+	// Observability is the observability configuration of servers without spec.observability
+	// +optional
+	Observability *ObservabilitySpec `json:"observability,omitempty"`
+}
+
+// ProjectDeletionPolicy controls the teardown of a deleted project
+// +kubebuilder:validation:Enum=Delete;Orphan;RetainData
+type ProjectDeletionPolicy string
+
+const (
+	// ProjectDeletionDelete removes the servers, applications and managed namespaces
+	ProjectDeletionDelete ProjectDeletionPolicy = "Delete"
+	// ProjectDeletionOrphan leaves the servers, applications and namespaces in place
+	ProjectDeletionOrphan ProjectDeletionPolicy = "Orphan"
+	// ProjectDeletionRetainData removes the servers and applications but keeps the managed
+	// namespaces, with their volumes, Secrets and other contents
+	ProjectDeletionRetainData ProjectDeletionPolicy = "RetainData"
+)
+
+// ProjectRole is the access a member has to the environment namespaces of a project
+// +kubebuilder:validation:Enum=viewer;editor;admin
+type ProjectRole string
+
+const (
+	// ProjectRoleViewer reads the Kalypso resources and their workloads, Services and logs
+	ProjectRoleViewer ProjectRole = "viewer"
+	// ProjectRoleEditor also creates, updates and deletes the Kalypso resources and restarts pods
+	ProjectRoleEditor ProjectRole = "editor"
+	// ProjectRoleAdmin also manages the Secrets and ConfigMaps and execs into pods
+	ProjectRoleAdmin ProjectRole = "admin"
+)
+
+// ProjectMember is a user or group granted a role in the project
+type ProjectMember struct {
+	// Kind is User or Group
+	// +kubebuilder:validation:Enum=User;Group
+	// +kubebuilder:default=User
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name is the user or group name as authenticated by the API server
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Role is viewer, editor or admin
+	// +kubebuilder:validation:Required
+	Role ProjectRole `json:"role"`
 }
 
 // EnvironmentSpec defines the configuration for a specific environment
@@ -57,8 +167,47 @@ type EnvironmentSpec struct {
 	// ResourceQuota defines the K8s ResourceQuota configuration for the namespace
 	// +optional
 	ResourceQuota *ResourceQuotaSpec `json:"resourceQuota,omitempty"`
+
+	// PodSecurity is the Pod Security Standards level enforced, audited and warned about in
+	// the namespace through the pod-security.kubernetes.io labels. Unset leaves the labels alone
+	// +optional
+	PodSecurity PodSecurityLevel `json:"podSecurity,omitempty"`
+
+	// AdoptionPolicy controls what happens when the namespace already exists without being
+	// managed by the project: Adopt labels and manages it, Fail marks the project Failed, and
+	// Ignore leaves the namespace alone and skips the environment. Both report the conflict in
+	// the NamespaceConflict condition
+	// +optional
+	// +kubebuilder:default=Adopt
+	AdoptionPolicy NamespaceAdoptionPolicy `json:"adoptionPolicy,omitempty"`
 }
 
+// NamespaceAdoptionPolicy controls the adoption of an existing environment namespace
+// +kubebuilder:validation:Enum=Adopt;Fail;Ignore
+type NamespaceAdoptionPolicy string
+
+const (
+	// NamespaceAdoptionAdopt labels and manages an existing namespace
+	NamespaceAdoptionAdopt NamespaceAdoptionPolicy = "Adopt"
+	// NamespaceAdoptionFail marks the project Failed while the namespace is not managed by it
+	NamespaceAdoptionFail NamespaceAdoptionPolicy = "Fail"
+	// NamespaceAdoptionIgnore skips the environment while the namespace is not managed by it
+	NamespaceAdoptionIgnore NamespaceAdoptionPolicy = "Ignore"
+)
+
+// PodSecurityLevel is a Pod Security Standards level
+// +kubebuilder:validation:Enum=privileged;baseline;restricted
+type PodSecurityLevel string
+
+const (
+	// PodSecurityPrivileged allows known privilege escalations
+	PodSecurityPrivileged PodSecurityLevel = "privileged"
+	// PodSecurityBaseline prevents known privilege escalations
+	PodSecurityBaseline PodSecurityLevel = "baseline"
+	// PodSecurityRestricted follows the pod hardening best practices
+	PodSecurityRestricted PodSecurityLevel = "restricted"
+)
+
 // LimitRangeSpec defines the LimitRange configuration
 type LimitRangeSpec struct {
 	// Limits is a list of LimitRangeItem objects
@@ -88,6 +237,115 @@ type ModelRegistrySpec struct {
 	SecretRef string `json:"secretRef,omitempty"`
 }
 
+// AuditSpec defines the audit event export of a project
+type AuditSpec struct {
+	// Sink receives the audit events
+	// +kubebuilder:validation:Required
+	Sink AuditSinkSpec `json:"sink"`
+}
+
+// AuditSinkSpec defines where audit events are delivered. Exactly one sink must be set
+// +kubebuilder:validation:XValidation:rule="[has(self.http), has(self.s3), has(self.kafka)].filter(x, x).size() == 1",message="exactly one of http, s3 and kafka must be set"
+type AuditSinkSpec struct {
+	// HTTP posts the events as JSON Lines, e.g. to a Splunk or Elastic ingest endpoint
+	// +optional
+	HTTP *AuditHTTPSink `json:"http,omitempty"`
+
+	// S3 writes each batch of events as a JSON Lines object
+	// +optional
+	S3 *AuditS3Sink `json:"s3,omitempty"`
+
+	// Kafka produces the events to a topic through a Kafka REST Proxy
+	// +optional
+	Kafka *AuditKafkaSink `json:"kafka,omitempty"`
+}
+
+// AuditHTTPSink defines an HTTP audit sink
+type AuditHTTPSink struct {
+	// URL is the endpoint receiving the events
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// SecretRef is the name of a Secret in the project namespace whose "token" key is sent
+	// as a bearer token
+	// +optional
+	SecretRef string `json:"secretRef,omitempty"`
+}
+
+// AuditS3Sink defines an S3 audit sink
+type AuditS3Sink struct {
+	// Bucket is the destination bucket
+	// +kubebuilder:validation:Required
+	Bucket string `json:"bucket"`
+
+	// Prefix is prepended to the object keys
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Region is the bucket region
+	// +kubebuilder:validation:Required
+	Region string `json:"region"`
+
+	// Endpoint is the URL of an S3-compatible storage, e.g. MinIO (default: AWS S3)
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// SecretRef is the name of a Secret in the project namespace with the
+	// AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY keys
+	// +kubebuilder:validation:Required
+	SecretRef string `json:"secretRef"`
+}
+
+// AuditKafkaSink defines a Kafka audit sink reached through a Kafka REST Proxy
+type AuditKafkaSink struct {
+	// RESTProxyURL is the URL of the Kafka REST Proxy (v2 API)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	RESTProxyURL string `json:"restProxyUrl"`
+
+	// Topic receives the events, keyed by project
+	// +kubebuilder:validation:Required
+	Topic string `json:"topic"`
+
+	// SecretRef is the name of a Secret in the project namespace whose "token" key is sent
+	// as a bearer token
+	// +optional
+	SecretRef string `json:"secretRef,omitempty"`
+}
+
+// ImageVerificationMode tells what happens to servers whose Triton image signature does not
+// verify
+// +kubebuilder:validation:Enum=Enforce;Audit;Disabled
+type ImageVerificationMode string
+
+const (
+	// ImageVerificationEnforce fails the servers and keeps their previous pods
+	ImageVerificationEnforce ImageVerificationMode = "Enforce"
+	// ImageVerificationAudit reports the failure in the ImageVerified condition and deploys anyway
+	ImageVerificationAudit ImageVerificationMode = "Audit"
+	// ImageVerificationDisabled skips the verification
+	ImageVerificationDisabled ImageVerificationMode = "Disabled"
+)
+
+// ImageVerificationSpec overrides the operator-wide cosign verification of the Triton images.
+// Unset fields keep the operator settings
+type ImageVerificationSpec struct {
+	// Mode is Enforce, Audit or Disabled
+	// +optional
+	Mode ImageVerificationMode `json:"mode,omitempty"`
+
+	// PublicKeySecret is a Secret in the project namespace with the cosign.pub public key the
+	// images must be signed with
+	// +optional
+	PublicKeySecret string `json:"publicKeySecret,omitempty"`
+
+	// TransparencyLog requires the signatures to be recorded in the Rekor transparency log,
+	// checked against the Rekor public key configured on the operator
+	// +optional
+	TransparencyLog *bool `json:"transparencyLog,omitempty"`
+}
+
 // ProjectPhase represents the current phase of the project
 // +kubebuilder:validation:Enum=Provisioning;Ready;Failed
 type ProjectPhase string
@@ -116,6 +374,94 @@ type KalypsoProjectStatus struct {
 	// CreatedNamespaces lists the namespaces that have been created for this project
 	// +optional
 	CreatedNamespaces []string `json:"createdNamespaces,omitempty"`
+
+	// NamingConflicts lists KalypsoTritonServers whose derived resource names collide
+	// +optional
+	NamingConflicts []NamingConflict `json:"namingConflicts,omitempty"`
+
+	// DuplicateModels lists model repositories pulled by more than one KalypsoTritonServer of the
+	// project, with a consolidation recommendation
+	// +optional
+	DuplicateModels []DuplicateModel `json:"duplicateModels,omitempty"`
+
+	// DeletionProgress tracks the ordered teardown of the project's resources while it is being deleted
+	// +optional
+	DeletionProgress *DeletionProgress `json:"deletionProgress,omitempty"`
+
+	// BulkOperation reports the progress of the bulk operation requested on the project
+	// +optional
+	BulkOperation *BulkOperationStatus `json:"bulkOperation,omitempty"`
+}
+
+// DeletionPhase represents the current step of the project teardown
+// +kubebuilder:validation:Enum=DeletingTritonServers;DeletingApplications;DeletingNamespaces
+type DeletionPhase string
+
+const (
+	// DeletionPhaseTritonServers indicates KalypsoTritonServers are being deleted
+	DeletionPhaseTritonServers DeletionPhase = "DeletingTritonServers"
+	// DeletionPhaseApplications indicates KalypsoApplications are being deleted
+	DeletionPhaseApplications DeletionPhase = "DeletingApplications"
+	// DeletionPhaseNamespaces indicates the managed namespaces are being deleted
+	DeletionPhaseNamespaces DeletionPhase = "DeletingNamespaces"
+)
+
+// DeletionProgress reports the resources remaining in each teardown step
+type DeletionProgress struct {
+	// Phase is the current teardown step
+	Phase DeletionPhase `json:"phase"`
+
+	// RemainingTritonServers is the number of KalypsoTritonServers not yet deleted
+	// +optional
+	RemainingTritonServers int32 `json:"remainingTritonServers,omitempty"`
+
+	// RemainingApplications is the number of KalypsoApplications not yet deleted
+	// +optional
+	RemainingApplications int32 `json:"remainingApplications,omitempty"`
+
+	// RemainingNamespaces is the number of managed namespaces not yet deleted
+	// +optional
+	RemainingNamespaces int32 `json:"remainingNamespaces,omitempty"`
+}
+
+// NamingConflict reports KalypsoTritonServers sharing a derived resource name
+type NamingConflict struct {
+	// Namespace is the namespace of the conflicting servers
+	Namespace string `json:"namespace"`
+
+	// ResourceName is the derived resource name shared by the servers
+	ResourceName string `json:"resourceName"`
+
+	// Servers are the names of the conflicting KalypsoTritonServers
+	Servers []string `json:"servers"`
+}
+
+// ConsolidationRecommendation is the suggested way to stop pulling a model repository more than once
+// +kubebuilder:validation:Enum=SharedServer;SharedCache
+type ConsolidationRecommendation string
+
+const (
+	// ConsolidationSharedServer suggests serving the model from a single server of the application
+	ConsolidationSharedServer ConsolidationRecommendation = "SharedServer"
+	// ConsolidationSharedCache suggests mounting the model from a shared cache PVC
+	ConsolidationSharedCache ConsolidationRecommendation = "SharedCache"
+)
+
+// DuplicateModel reports KalypsoTritonServers pulling the same model repository
+type DuplicateModel struct {
+	// StorageURI is the normalized model repository URI shared by the servers
+	StorageURI string `json:"storageUri"`
+
+	// Servers are the <namespace>/<name> of the servers pulling the repository
+	Servers []string `json:"servers"`
+
+	// Recommendation is SharedServer when the servers belong to the same application, and
+	// SharedCache otherwise
+	Recommendation ConsolidationRecommendation `json:"recommendation"`
+
+	// DuplicateGPUs is the number of GPUs requested by the servers beyond the largest one
+	// +optional
+	DuplicateGPUs int32 `json:"duplicateGPUs,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KalypsoPromotionSpec defines the desired state of KalypsoPromotion
+// +kubebuilder:validation:XValidation:rule="self.from != self.to",message="from and to must be different environments"
+type KalypsoPromotionSpec struct {
+	// ProjectRef is the KalypsoProject defining the environments: its name in the promotion
+	// namespace, or <namespace>/<name> for a project of another namespace allowing the
+	// promotion namespace
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="projectRef is immutable"
+	ProjectRef string `json:"projectRef"`
+
+	// ServerRef is the KalypsoTritonServer promoted, created with the same name in the target
+	// environment
+	// +kubebuilder:validation:Required
+	ServerRef string `json:"serverRef"`
+
+	// From is the project environment the server spec is copied from, e.g. dev
+	// +kubebuilder:validation:Required
+	From string `json:"from"`
+
+	// To is the project environment the server spec is copied to, e.g. staging
+	// +kubebuilder:validation:Required
+	To string `json:"to"`
+
+	// ApplicationRef is the KalypsoApplication of the promoted server in the target environment
+	// (default: the application of the source server)
+	// +optional
+	ApplicationRef string `json:"applicationRef,omitempty"`
+
+	// RequireApproval holds each change of the source server until the
+	// serving.kalypso.io/approved-generation annotation of the promotion is set to the source
+	// server generation
+	// +optional
+	RequireApproval bool `json:"requireApproval,omitempty"`
+}
+
+// PromotionPhase represents the current phase of the promotion
+// +kubebuilder:validation:Enum=AwaitingApproval;Promoted;Failed
+type PromotionPhase string
+
+const (
+	// PromotionPhaseAwaitingApproval indicates a change of the source server waits for approval
+	PromotionPhaseAwaitingApproval PromotionPhase = "AwaitingApproval"
+	// PromotionPhasePromoted indicates the target server runs the source server spec
+	PromotionPhasePromoted PromotionPhase = "Promoted"
+	// PromotionPhaseFailed indicates the environments or the source server cannot be resolved
+	PromotionPhaseFailed PromotionPhase = "Failed"
+)
+
+// KalypsoPromotionStatus defines the observed state of KalypsoPromotion.
+type KalypsoPromotionStatus struct {
+	// Phase represents the current phase of the promotion: AwaitingApproval, Promoted, Failed
+	// +optional
+	Phase PromotionPhase `json:"phase,omitempty"`
+
+	// SourceNamespace is the namespace of the From environment
+	// +optional
+	SourceNamespace string `json:"sourceNamespace,omitempty"`
+
+	// TargetNamespace is the namespace of the To environment
+	// +optional
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+
+	// PendingGeneration is the source server generation awaiting approval
+	// +optional
+	PendingGeneration int64 `json:"pendingGeneration,omitempty"`
+
+	// History records the promotions, most recent last
+	// +optional
+	History []PromotionRecord `json:"history,omitempty"`
+
+	// Message is a human-readable message indicating details about the promotion
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Conditions represent the current state of the KalypsoPromotion resource
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// PromotionRecord describes a server spec copied to the target environment
+type PromotionRecord struct {
+	// SourceGeneration is the generation of the source server promoted
+	SourceGeneration int64 `json:"sourceGeneration"`
+
+	// SpecHash identifies the promoted spec
+	SpecHash string `json:"specHash"`
+
+	// StorageURI is the model repository promoted
+	StorageURI string `json:"storageUri"`
+
+	// Image is the Triton image promoted
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Approved tells whether the promotion was approved, rather than applied automatically
+	// +optional
+	Approved bool `json:"approved,omitempty"`
+
+	// PromotedAt is when the spec was copied
+	PromotedAt metav1.Time `json:"promotedAt"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Server",type=string,JSONPath=`.spec.serverRef`
+// +kubebuilder:printcolumn:name="From",type=string,JSONPath=`.spec.from`
+// +kubebuilder:printcolumn:name="To",type=string,JSONPath=`.spec.to`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// KalypsoPromotion is the Schema for the kalypsopromotions API
+// It copies a KalypsoTritonServer spec from one project environment to the next
+type KalypsoPromotion struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of KalypsoPromotion
+	// +required
+	Spec KalypsoPromotionSpec `json:"spec"`
+
+	// status defines the observed state of KalypsoPromotion
+	// +optional
+	Status KalypsoPromotionStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// KalypsoPromotionList contains a list of KalypsoPromotion
+type KalypsoPromotionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []KalypsoPromotion `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KalypsoPromotion{}, &KalypsoPromotionList{})
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KalypsoRolloutSpec defines the desired state of KalypsoRollout
+type KalypsoRolloutSpec struct {
+	// ApplicationRef is the KalypsoApplication whose gateway routes the rollout traffic
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="applicationRef is immutable"
+	ApplicationRef string `json:"applicationRef"`
+
+	// StableRef is the KalypsoTritonServer receiving the traffic not sent to the canary
+	// +kubebuilder:validation:Required
+	StableRef string `json:"stableRef"`
+
+	// CanaryRef is the KalypsoTritonServer the traffic is progressively shifted to
+	// +kubebuilder:validation:Required
+	CanaryRef string `json:"canaryRef"`
+
+	// Steps are the canary traffic weights, applied in order. The rollout is promoted after the last step
+	// +kubebuilder:validation:MinItems=1
+	Steps []RolloutStep `json:"steps"`
+
+	// Analysis aborts the rollout when the canary metrics breach their thresholds.
+	// Without it, steps advance on their pause alone
+	// +optional
+	Analysis *RolloutAnalysisSpec `json:"analysis,omitempty"`
+}
+
+// RolloutStep is a canary traffic weight held for a pause
+type RolloutStep struct {
+	// Weight is the percentage of traffic sent to the canary
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+
+	// Pause is how long the weight is held before the next step, e.g. "5m"
+	// +optional
+	// +kubebuilder:default="1m"
+	Pause string `json:"pause,omitempty"`
+}
+
+// RolloutAnalysisSpec defines the canary metric thresholds
+type RolloutAnalysisSpec struct {
+	// PrometheusURL is the Prometheus-compatible query endpoint scraping the Istio request metrics
+	// +kubebuilder:validation:Required
+	PrometheusURL string `json:"prometheusUrl"`
+
+	// Interval is how often the canary metrics are evaluated, and their rate window
+	// +optional
+	// +kubebuilder:default="1m"
+	Interval string `json:"interval,omitempty"`
+
+	// MaxErrorRate is the highest accepted ratio of 5xx canary responses, e.g. "0.01"
+	// +optional
+	MaxErrorRate string `json:"maxErrorRate,omitempty"`
+
+	// MaxP99LatencyMs is the highest accepted p99 canary request latency in milliseconds
+	// +optional
+	MaxP99LatencyMs string `json:"maxP99LatencyMs,omitempty"`
+
+	// FailureLimit is the number of failed evaluations that aborts the rollout
+	// +optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	FailureLimit int32 `json:"failureLimit,omitempty"`
+}
+
+// RolloutPhase represents the current phase of the rollout
+// +kubebuilder:validation:Enum=Progressing;Promoted;Aborted
+type RolloutPhase string
+
+const (
+	// RolloutPhaseProgressing indicates traffic is being shifted to the canary
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+	// RolloutPhasePromoted indicates the canary receives all traffic
+	RolloutPhasePromoted RolloutPhase = "Promoted"
+	// RolloutPhaseAborted indicates the canary failed its analysis and traffic went back to the stable server
+	RolloutPhaseAborted RolloutPhase = "Aborted"
+)
+
+// KalypsoRolloutStatus defines the observed state of KalypsoRollout.
+type KalypsoRolloutStatus struct {
+	// Phase represents the current phase of the rollout: Progressing, Promoted, Aborted
+	// +optional
+	Phase RolloutPhase `json:"phase,omitempty"`
+
+	// CurrentStep is the index of the step being applied
+	// +optional
+	CurrentStep int32 `json:"currentStep,omitempty"`
+
+	// CanaryWeight is the percentage of traffic currently sent to the canary
+	// +optional
+	CanaryWeight int32 `json:"canaryWeight,omitempty"`
+
+	// StepStartedAt is when the current step was applied
+	// +optional
+	StepStartedAt *metav1.Time `json:"stepStartedAt,omitempty"`
+
+	// Analysis reports the last evaluation of the canary metrics
+	// +optional
+	Analysis *RolloutAnalysisStatus `json:"analysis,omitempty"`
+
+	// Message is a human-readable message indicating details about the rollout
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Conditions represent the current state of the KalypsoRollout resource
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// RolloutAnalysisStatus reports the last evaluation of the canary metrics
+type RolloutAnalysisStatus struct {
+	// LastEvaluationTime is when the metrics were last evaluated
+	// +optional
+	LastEvaluationTime *metav1.Time `json:"lastEvaluationTime,omitempty"`
+
+	// ErrorRate is the last observed ratio of 5xx canary responses
+	// +optional
+	ErrorRate string `json:"errorRate,omitempty"`
+
+	// P99LatencyMs is the last observed p99 canary request latency in milliseconds
+	// +optional
+	P99LatencyMs string `json:"p99LatencyMs,omitempty"`
+
+	// Failures is the number of failed evaluations since the rollout started
+	// +optional
+	Failures int32 `json:"failures,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Stable",type=string,JSONPath=`.spec.stableRef`
+// +kubebuilder:printcolumn:name="Canary",type=string,JSONPath=`.spec.canaryRef`
+// +kubebuilder:printcolumn:name="Weight",type=integer,JSONPath=`.status.canaryWeight`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// KalypsoRollout is the Schema for the kalypsorollouts API
+// It progressively shifts application traffic from a stable to a canary KalypsoTritonServer
+type KalypsoRollout struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of KalypsoRollout
+	// +required
+	Spec KalypsoRolloutSpec `json:"spec"`
+
+	// status defines the observed state of KalypsoRollout
+	// +optional
+	Status KalypsoRolloutStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// KalypsoRolloutList contains a list of KalypsoRollout
+type KalypsoRolloutList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []KalypsoRollout `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KalypsoRollout{}, &KalypsoRolloutList{})
+}
@@ -18,165 +18,1183 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // KalypsoTritonServerSpec defines the desired state of KalypsoTritonServer
+// +kubebuilder:validation:XValidation:rule="!has(self.modelCache) || self.storageUri.startsWith('s3://')",message="modelCache requires an s3:// storage URI"
+// +kubebuilder:validation:XValidation:rule="!(self.storageUri.startsWith('https://') || self.storageUri.startsWith('http://')) || has(self.archive)",message="archive.sha256 is required for http:// and https:// storage URIs"
+// +kubebuilder:validation:XValidation:rule="!has(self.volumeClaimTemplates) || size(self.volumeClaimTemplates) == 0 || (has(self.workloadType) && self.workloadType == 'StatefulSet')",message="volumeClaimTemplates require workloadType StatefulSet"
+// +kubebuilder:validation:XValidation:rule="!has(self.terminationGracePeriodSeconds) || !has(self.lifecycle) || !has(self.lifecycle.exitTimeoutSeconds) || self.terminationGracePeriodSeconds >= self.lifecycle.exitTimeoutSeconds + (has(self.lifecycle.preStopSleepSeconds) ? self.lifecycle.preStopSleepSeconds : 10)",message="terminationGracePeriodSeconds must cover the preStop sleep and the exit timeout"
 type KalypsoTritonServerSpec struct {
-	// ApplicationRef is the reference to parent KalypsoApplication
+	// ApplicationRef is the reference to parent KalypsoApplication: its name in the server
+	// namespace, or <namespace>/<name> for an application of another namespace whose project
+	// allows the server namespace
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="applicationRef is immutable"
 	ApplicationRef string `json:"applicationRef"`
 
-	// StorageURI is the S3/GCS path to model repository
+	// StorageURI is the S3/GCS/Azure path to model repository, a registered model stage of the
+	// MLflow Model Registry: mlflow://<registry>/<model>/<stage>, or a repository downloaded at
+	// startup from the Hugging Face Hub: hf://<org>/<repo>[@<revision>], or packaged as an OCI
+	// artifact: oci://<registry>/<repo>:<tag> or oci://<registry>/<repo>@<digest>, or cloned from
+	// git: git+https://<host>/<repo>[@<ref>] or git+ssh://<user>@<host>/<repo>[@<ref>], or a
+	// tar or zip archive of the repository: https://<host>/<path>
 	// +kubebuilder:validation:Required
 	StorageURI string `json:"storageUri"`
 
+	// Storage overrides the s3:// storage configuration of the application for this server, e.g.
+	// to pull from a bucket with other credentials. Fields left empty keep the application value
+	// +optional
+	Storage *ServerStorageSpec `json:"storage,omitempty"`
+
+	// MLflow configures the access to the MLflow tracking server of a mlflow:// storage URI
+	// +optional
+	MLflow *MLflowSpec `json:"mlflow,omitempty"`
+
+	// HuggingFace configures the download of a hf:// storage URI
+	// +optional
+	HuggingFace *HuggingFaceSpec `json:"huggingFace,omitempty"`
+
+	// OCI configures the pull of an oci:// storage URI
+	// +optional
+	OCI *OCIArtifactSpec `json:"oci,omitempty"`
+
+	// Git configures the clone of a git+https:// or git+ssh:// storage URI
+	// +optional
+	Git *GitSyncSpec `json:"git,omitempty"`
+
+	// Archive configures the download of a http:// or https:// storage URI
+	// +optional
+	Archive *ArchiveSpec `json:"archive,omitempty"`
+
+	// ModelCache is a KalypsoModelCache of the namespace holding the s3:// storage URI. Pods on a
+	// node the cache has pulled the repository to load it from the node disk; elsewhere they copy
+	// it from the bucket before Triton starts, like the local download mode
+	// +optional
+	ModelCache string `json:"modelCache,omitempty"`
+
+	// RequiredModels must be ready for inference before the server is reported Running. When
+	// empty, every model Triton attempted to load must be ready
+	// +optional
+	// +listType=set
+	RequiredModels []string `json:"requiredModels,omitempty"`
+
 	// TritonConfig defines the Triton server configuration
 	// +kubebuilder:validation:Required
 	TritonConfig TritonConfigSpec `json:"tritonConfig"`
 
-	// Replicas is the number of replicas (default: 1)
+	// Preset is a built-in configuration for a model size and GPU type. It fills resources, GPU,
+	// node selector, shared memory, Triton parameters, startup probe, and rollout strategy
+	// fields left unset; explicitly set fields take precedence
+	// +optional
+	// +kubebuilder:validation:Enum=llm-7b-a10g;llm-13b-a100;llm-70b-h100
+	Preset string `json:"preset,omitempty"`
+
+	// Replicas is the number of replicas (default: 1)
+	// +optional
+	// +kubebuilder:default=1
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Suspend scales the server to zero replicas while keeping its configuration
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// State is the lifecycle state of the model endpoint. ReadOnly keeps serving but rejects spec
+	// changes other than the state; Archived also rejects them, scales the server to zero, and
+	// answers its Service with 410 Gone through the Istio mesh while keeping its configuration
+	// +optional
+	// +kubebuilder:validation:Enum=Active;ReadOnly;Archived
+	// +kubebuilder:default="Active"
+	State ServerState `json:"state,omitempty"`
+
+	// WorkloadType is Deployment (default), StatefulSet, or Rollout. StatefulSet replicas keep a
+	// stable identity and the volumes of volumeClaimTemplates, e.g. a large local model cache.
+	// Rollout renders an Argo Rollouts Rollout, updated by the canary strategy of argoRollout
+	// +optional
+	// +kubebuilder:validation:Enum=Deployment;StatefulSet;Rollout
+	// +kubebuilder:default="Deployment"
+	WorkloadType string `json:"workloadType,omitempty"`
+
+	// ArgoRollout configures the canary strategy of a Rollout workload
+	// +optional
+	ArgoRollout *ArgoRolloutSpec `json:"argoRollout,omitempty"`
+
+	// VolumeClaimTemplates are PersistentVolumeClaims created for each StatefulSet replica and
+	// mounted through spec.volumeMounts by name. They cannot change once the StatefulSet exists
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=8
+	VolumeClaimTemplates []VolumeClaimTemplate `json:"volumeClaimTemplates,omitempty"`
+
+	// Resources defines K8s resource requests/limits
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Labels are added to the generated Deployment, Service, PodDisruptionBudget, ServiceMonitor,
+	// and ServiceAccount, e.g. for cost attribution. Labels set by the operator take precedence
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are added to the generated Deployment, Service, PodDisruptionBudget,
+	// ServiceMonitor, and ServiceAccount
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// PodLabels are added to the Triton pods, e.g. sidecar.istio.io/inject. Labels set by the
+	// operator take precedence
+	// +optional
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// PodAnnotations are added to the Triton pods. Annotations set by the operator take precedence
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// Env are additional environment variables of the Triton container, e.g. proxy settings or
+	// backend tuning. Variables with the same name as the injected storage variables override them
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// EnvFrom are additional ConfigMap or Secret sources of Triton container environment variables
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
+	// Metadata records the training lineage of the served model. It is propagated to pod labels,
+	// Prometheus target labels, and trace resource attributes
+	// +optional
+	Metadata *ModelMetadataSpec `json:"metadata,omitempty"`
+
+	// GPU defines GPU allocation for the Triton container
+	// +optional
+	GPU *GPUSpec `json:"gpu,omitempty"`
+
+	// DegradedProfile is deployed instead of spec.gpu and spec.resources when the GPUs of all
+	// replicas do not fit in the remaining project quota, keeping the server up during GPU shortages
+	// +optional
+	DegradedProfile *DegradedProfileSpec `json:"degradedProfile,omitempty"`
+
+	// SharedMemory defines the memory-backed /dev/shm volume for the Triton container
+	// +optional
+	SharedMemory *SharedMemorySpec `json:"sharedMemory,omitempty"`
+
+	// Probes tunes the readiness, liveness, and startup probes of the Triton container
+	// +optional
+	Probes *ProbesSpec `json:"probes,omitempty"`
+
+	// Warmup runs inference requests on each model while it loads, before the replica reports
+	// ready, so the first client requests do not pay for lazy initialization
+	// +optional
+	Warmup *WarmupSpec `json:"warmup,omitempty"`
+
+	// ModelConfigOverrides tune the instance groups, batch size and optimizations of the
+	// models without rebuilding the model repository; the model name "*" applies to every
+	// model without its own entry
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ModelConfigOverrides []ModelConfigOverride `json:"modelConfigOverrides,omitempty"`
+
+	// Lifecycle configures graceful connection draining when pods terminate during rollouts
+	// +optional
+	Lifecycle *LifecycleSpec `json:"lifecycle,omitempty"`
+
+	// TerminationGracePeriodSeconds bounds the pod shutdown. Defaults to the preStop sleep plus the
+	// exit timeout; when set, the exit timeout defaults to the time left after the preStop sleep
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// Scheduling defines node placement constraints for Triton pods
+	// +optional
+	Scheduling *SchedulingSpec `json:"scheduling,omitempty"`
+
+	// Volumes are added to the Triton pod, e.g. ConfigMaps with a custom config.pbtxt or PVCs
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:XValidation:rule="self.all(v, v.name != 'dshm')",message="the volume name dshm is reserved for spec.sharedMemory"
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// VolumeMounts are added to the Triton container
+	// +optional
+	// +listType=map
+	// +listMapKey=mountPath
+	// +kubebuilder:validation:MaxItems=32
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// InitContainers run to completion before the Triton container starts, e.g. to pre-fetch
+	// tokenizer files or warm caches
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=10
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
+	// Sidecars are additional containers run alongside the Triton container, e.g. proxies or log shippers
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=10
+	// +kubebuilder:validation:XValidation:rule="self.all(c, c.name != 'tritonserver')",message="the container name tritonserver is reserved"
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+
+	// ImagePullSecrets are used to pull the Triton and sidecar images, e.g. from a private nvcr.io mirror.
+	// Overrides the application-level default when set
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// PodSecurityContext is the security context of the Triton pods. Defaults to a context satisfying
+	// the restricted Pod Security Standard, running as the triton-server user of the NGC images
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// ContainerSecurityContext is the security context of the Triton container. Defaults to
+	// disallowing privilege escalation and dropping all capabilities
+	// +optional
+	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
+
+	// ServiceAccount defines the identity of the Triton pods, e.g. for cloud workload identity
+	// +optional
+	ServiceAccount *ServiceAccountSpec `json:"serviceAccount,omitempty"`
+
+	// RuntimeClassName selects the container runtime handler, e.g. "nvidia" on containerd GPU nodes
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// Availability defines the PodDisruptionBudget created when replicas > 1
+	// +optional
+	Availability *AvailabilitySpec `json:"availability,omitempty"`
+
+	// DeploymentStrategy defines how Triton pods are replaced on updates. GPU-constrained
+	// clusters may use Recreate, or a rolling update with maxSurge 0
+	// +optional
+	DeploymentStrategy *DeploymentStrategySpec `json:"deploymentStrategy,omitempty"`
+
+	// CapacityReservation keeps low-priority placeholder pods sized like Triton replicas running, so
+	// scale-ups preempt them instead of waiting for new GPU nodes to be provisioned
+	// +optional
+	CapacityReservation *CapacityReservationSpec `json:"capacityReservation,omitempty"`
+
+	// Networking defines service port configuration
+	// +optional
+	Networking *NetworkingSpec `json:"networking,omitempty"`
+
+	// ProvenanceHeaders injects response headers attributing each response to the serving revision
+	// +optional
+	ProvenanceHeaders *ProvenanceHeadersSpec `json:"provenanceHeaders,omitempty"`
+
+	// Observability defines observability configuration for logging, tracing, profiling, and metrics
+	// +optional
+	Observability *ObservabilitySpec `json:"observability,omitempty"`
+
+	// RetrainingHook emits CloudEvents when drift or SLO burn metrics cross thresholds
+	// +optional
+	RetrainingHook *RetrainingHookSpec `json:"retrainingHook,omitempty"`
+
+	// ChangePolicy controls how spec changes reach the child resources. With Manual, changes are
+	// recorded as a pending plan and applied once the plan generation is approved
+	// +optional
+	// +kubebuilder:default=Automatic
+	ChangePolicy ChangePolicy `json:"changePolicy,omitempty"`
+}
+
+// ChangePolicy represents how spec changes are applied to child resources
+// +kubebuilder:validation:Enum=Automatic;Manual
+type ChangePolicy string
+
+const (
+	// ChangePolicyAutomatic applies spec changes as soon as they are observed
+	ChangePolicyAutomatic ChangePolicy = "Automatic"
+	// ChangePolicyManual holds spec changes in a pending plan until they are approved
+	ChangePolicyManual ChangePolicy = "Manual"
+)
+
+// ModelMetadataSpec defines the training lineage of the served model. Values must be valid label values
+type ModelMetadataSpec struct {
+	// TrainingRunID identifies the training run that produced the model, e.g. an MLflow run ID
+	// +optional
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$`
+	TrainingRunID string `json:"trainingRunId,omitempty"`
+
+	// DatasetVersion is the version of the dataset the model was trained on
+	// +optional
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$`
+	DatasetVersion string `json:"datasetVersion,omitempty"`
+
+	// GitCommit is the commit of the training code
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9a-f]{7,40}$`
+	GitCommit string `json:"gitCommit,omitempty"`
+
+	// Owner is the team or user accountable for the model
+	// +optional
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$`
+	Owner string `json:"owner,omitempty"`
+}
+
+// CapacityReservationSpec defines the placeholder pods reserving capacity for extra replicas
+type CapacityReservationSpec struct {
+	// Replicas is the number of extra Triton replicas to reserve capacity for
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Replicas int32 `json:"replicas"`
+
+	// PriorityClassName is the low-priority class of the placeholder pods. Defaults to the
+	// operator-managed kalypso-capacity-reservation class with a negative priority
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// ServiceAccountSpec defines the ServiceAccount used by the Triton pods
+// +kubebuilder:validation:XValidation:rule="self.autoCreate || (has(self.name) && size(self.name) > 0)",message="name is required unless autoCreate is set"
+// +kubebuilder:validation:XValidation:rule="self.autoCreate || !has(self.annotations) || size(self.annotations) == 0",message="annotations require autoCreate"
+type ServiceAccountSpec struct {
+	// Name is the ServiceAccount used by the pods. With autoCreate, defaults to <server>-sa
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// AutoCreate creates a ServiceAccount owned by the KalypsoTritonServer
+	// +optional
+	AutoCreate bool `json:"autoCreate,omitempty"`
+
+	// Annotations are set on the auto-created ServiceAccount, e.g. eks.amazonaws.com/role-arn
+	// or iam.gke.io/gcp-service-account
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// GPUSpec defines GPU allocation for the Triton container
+// +kubebuilder:validation:XValidation:rule="!has(self.count) || !has(self.mig)",message="count cannot be combined with mig"
+// +kubebuilder:validation:XValidation:rule="!has(self.sharing) || (has(self.count) && !has(self.mig))",message="sharing requires count and cannot be combined with mig"
+// +kubebuilder:validation:XValidation:rule="!has(self.resourceClaims) || size(self.resourceClaims) == 0 || (!has(self.count) && !has(self.mig))",message="resourceClaims cannot be combined with count or mig"
+type GPUSpec struct {
+	// Count is the number of whole GPUs (nvidia.com/gpu) to allocate
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Count *int32 `json:"count,omitempty"`
+
+	// MIG allocates Multi-Instance GPU slices instead of whole GPUs; it cannot be combined with Count
+	// +optional
+	MIG *MIGSpec `json:"mig,omitempty"`
+
+	// Sharing requests Count replicas of a GPU shared with other pods through the NVIDIA
+	// device plugin time-slicing or MPS configuration, instead of dedicated GPUs
+	// +optional
+	Sharing *GPUSharingSpec `json:"sharing,omitempty"`
+
+	// ResourceClaims allocate GPUs through Dynamic Resource Allocation instead of
+	// nvidia.com/gpu limits. Every claim is added to the pod and the Triton container
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=8
+	ResourceClaims []GPUResourceClaim `json:"resourceClaims,omitempty"`
+}
+
+// GPUSharingSpec selects a GPU sharing configuration of the NVIDIA device plugin
+type GPUSharingSpec struct {
+	// Strategy is the sharing strategy configured on the nodes: time-slicing or mps
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=time-slicing;mps
+	Strategy string `json:"strategy"`
+
+	// RenameByDefault matches the renameByDefault option of the sharing configuration,
+	// which advertises shared GPUs as nvidia.com/gpu.shared instead of nvidia.com/gpu
+	// +optional
+	RenameByDefault bool `json:"renameByDefault,omitempty"`
+
+	// Config is the name of the device plugin configuration selected on the nodes through
+	// the nvidia.com/device-plugin.config label. When unset, any node with the strategy is used
+	// +optional
+	Config string `json:"config,omitempty"`
+}
+
+// GPUResourceClaim is a Dynamic Resource Allocation claim of the Triton container.
+// Exactly one of resourceClaimName, resourceClaimTemplateName and deviceClassName must be set
+// +kubebuilder:validation:XValidation:rule="[has(self.resourceClaimName), has(self.resourceClaimTemplateName), has(self.deviceClassName)].filter(x, x).size() == 1",message="exactly one of resourceClaimName, resourceClaimTemplateName and deviceClassName must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.count) || has(self.deviceClassName)",message="count requires deviceClassName"
+type GPUResourceClaim struct {
+	// Name identifies the claim in the pod spec
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// ResourceClaimName references an existing ResourceClaim shared by all replicas
+	// +optional
+	ResourceClaimName *string `json:"resourceClaimName,omitempty"`
+
+	// ResourceClaimTemplateName references an existing ResourceClaimTemplate; every pod gets its own claim
+	// +optional
+	ResourceClaimTemplateName *string `json:"resourceClaimTemplateName,omitempty"`
+
+	// DeviceClassName requests devices of this DeviceClass (e.g. gpu.nvidia.com) through a
+	// ResourceClaimTemplate managed by the operator; every pod gets its own claim
+	// +optional
+	DeviceClassName *string `json:"deviceClassName,omitempty"`
+
+	// Count is the number of devices requested from DeviceClassName (default 1)
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Count *int32 `json:"count,omitempty"`
+}
+
+// DegradedProfileSpec is a reduced-capacity variant of the server, e.g. a time-sliced GPU or CPU only
+type DegradedProfileSpec struct {
+	// GPU replaces spec.gpu, e.g. with a MIG slice. When unset, the degraded pods get no GPU
+	// +optional
+	GPU *GPUSpec `json:"gpu,omitempty"`
+
+	// Resources replaces spec.resources, e.g. with CPU and memory for a CPU variant, or a
+	// time-sliced resource such as nvidia.com/gpu.shared
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector is merged over spec.scheduling.nodeSelector, e.g. to target time-sliced GPU nodes
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// MIGSpec defines a Multi-Instance GPU allocation
+type MIGSpec struct {
+	// Profile is the MIG profile, e.g. 1g.10gb
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[0-9]+g\.[0-9]+gb$`
+	Profile string `json:"profile"`
+
+	// Count is the number of MIG devices to allocate (default: 1)
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	Count *int32 `json:"count,omitempty"`
+
+	// Strategy is the MIG strategy configured on the NVIDIA device plugin: single or mixed
+	// With mixed, slices are exposed as nvidia.com/mig-<profile>; with single, as nvidia.com/gpu
+	// +optional
+	// +kubebuilder:validation:Enum=single;mixed
+	// +kubebuilder:default="mixed"
+	Strategy string `json:"strategy,omitempty"`
+}
+
+// SharedMemorySpec defines the /dev/shm volume used by the Python backend and CUDA shared memory
+type SharedMemorySpec struct {
+	// Size is the size limit of the memory-backed volume mounted at /dev/shm
+	// +kubebuilder:validation:Required
+	Size resource.Quantity `json:"size"`
+}
+
+// LifecycleSpec configures how a terminating Triton pod drains its connections
+type LifecycleSpec struct {
+	// PreStopSleepSeconds delays SIGTERM so endpoints and load balancers stop sending new requests
+	// before Triton shuts down (default: 10). Zero disables the preStop hook
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PreStopSleepSeconds *int32 `json:"preStopSleepSeconds,omitempty"`
+
+	// ExitTimeoutSeconds is how long Triton waits for in-flight requests after SIGTERM,
+	// set with --exit-timeout-secs (default: 30)
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ExitTimeoutSeconds *int32 `json:"exitTimeoutSeconds,omitempty"`
+}
+
+// ProbesSpec tunes the health probes of the Triton container
+type ProbesSpec struct {
+	// Readiness tunes the probe on /v2/health/ready that gates traffic to the pod
+	// +optional
+	Readiness *ProbeSpec `json:"readiness,omitempty"`
+
+	// Liveness tunes the probe on /v2/health/live that restarts a hung container
+	// +optional
+	Liveness *ProbeSpec `json:"liveness,omitempty"`
+
+	// Startup tunes the probe on /v2/health/live that holds off the other probes while models
+	// load. Defaults to a 10s period with a failureThreshold of 60, allowing 10 minutes to start
+	// +optional
+	Startup *ProbeSpec `json:"startup,omitempty"`
+}
+
+// ProbeSpec overrides the timing of a probe. Unset fields keep the operator defaults
+type ProbeSpec struct {
+	// InitialDelaySeconds is the delay after container start before the first probe
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+
+	// PeriodSeconds is how often the probe runs
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+
+	// TimeoutSeconds is how long a single probe may take
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// FailureThreshold is the number of consecutive failures before the probe is considered failed
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// SchedulingSpec defines node placement and spreading constraints for Triton pods
+type SchedulingSpec struct {
+	// NodeSelector pins pods to nodes with matching labels, e.g. a GPU node pool
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations allow pods to schedule onto tainted nodes, e.g. GPU nodes
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity defines node affinity and pod (anti-)affinity rules
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints spread replicas across zones or nodes.
+	// Constraints without a labelSelector select the server's own pods
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// SchedulerName selects a custom scheduler, e.g. volcano for gang scheduling
+	// +optional
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// SchedulingGates keep new pods pending until an external controller, e.g. a batch queue,
+	// removes the gates
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	SchedulingGates []corev1.PodSchedulingGate `json:"schedulingGates,omitempty"`
+}
+
+// AvailabilitySpec defines the voluntary disruption budget for Triton replicas.
+// When neither field is set, at most one replica is unavailable at a time
+// +kubebuilder:validation:XValidation:rule="!(has(self.minAvailable) && has(self.maxUnavailable))",message="minAvailable and maxUnavailable are mutually exclusive"
+type AvailabilitySpec struct {
+	// MinAvailable is the number or percentage of replicas that must stay available
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable is the number or percentage of replicas that may be disrupted
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// ServerState is the lifecycle state of a KalypsoTritonServer endpoint
+type ServerState string
+
+const (
+	// ServerStateActive serves and accepts changes
+	ServerStateActive ServerState = "Active"
+	// ServerStateReadOnly serves but rejects spec changes
+	ServerStateReadOnly ServerState = "ReadOnly"
+	// ServerStateArchived is scaled to zero, rejects spec changes, and answers requests with 410 Gone
+	ServerStateArchived ServerState = "Archived"
+)
+
+// Preset values
+const (
+	PresetLLM7BA10G  = "llm-7b-a10g"
+	PresetLLM13BA100 = "llm-13b-a100"
+	PresetLLM70BH100 = "llm-70b-h100"
+)
+
+// WorkloadType values
+const (
+	WorkloadTypeDeployment  = "Deployment"
+	WorkloadTypeStatefulSet = "StatefulSet"
+	WorkloadTypeRollout     = "Rollout"
+)
+
+// ArgoRolloutSpec configures the canary strategy of an Argo Rollouts Rollout
+type ArgoRolloutSpec struct {
+	// Steps are the canary weights, each held for its pause. Without a traffic router, Argo
+	// Rollouts approximates the weights by the ratio of updated replicas
+	// +optional
+	Steps []RolloutStep `json:"steps,omitempty"`
+
+	// AnalysisTemplates are the names of AnalysisTemplates run in the background during the
+	// update. They receive the Triton Service name as the service-name argument
+	// +optional
+	AnalysisTemplates []string `json:"analysisTemplates,omitempty"`
+}
+
+// VolumeClaimTemplate is a per-replica PersistentVolumeClaim of a StatefulSet server
+type VolumeClaimTemplate struct {
+	// Name is the claim name, referenced by spec.volumeMounts
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// Spec is the PersistentVolumeClaim spec, e.g. the storage class and size of the cache
+	// +kubebuilder:validation:Required
+	Spec corev1.PersistentVolumeClaimSpec `json:"spec"`
+}
+
+// DeploymentStrategySpec defines the update strategy of the Triton Deployment
+// +kubebuilder:validation:XValidation:rule="self.type != 'Recreate' || (!has(self.maxSurge) && !has(self.maxUnavailable))",message="maxSurge and maxUnavailable require the RollingUpdate type"
+type DeploymentStrategySpec struct {
+	// Type is RollingUpdate or Recreate. Recreate terminates all pods before creating new ones,
+	// so the update needs no extra GPUs but the server is unavailable meanwhile
+	// +optional
+	// +kubebuilder:validation:Enum=RollingUpdate;Recreate
+	// +kubebuilder:default="RollingUpdate"
+	Type string `json:"type,omitempty"`
+
+	// MaxSurge is the number or percentage of pods created above the desired replicas (default: 25%)
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// MaxUnavailable is the number or percentage of pods that may be unavailable (default: 25%)
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// ProvenanceHeadersSpec configures the x-kalypso-model, x-model-version and x-served-by
+// response headers, added by the Istio sidecar of each Triton pod
+type ProvenanceHeadersSpec struct {
+	// Enabled enables response header injection
+	// +optional
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// ModelVersion is reported in x-model-version (default: the server generation)
+	// +optional
+	ModelVersion string `json:"modelVersion,omitempty"`
+}
+
+// RetrainingHookSpec defines the retraining hook configuration
+type RetrainingHookSpec struct {
+	// Enabled enables trigger evaluation and CloudEvent emission
+	// +optional
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// SinkURI is the CloudEvents sink, e.g. a Knative Broker URL or any HTTP endpoint
+	// +kubebuilder:validation:Required
+	SinkURI string `json:"sinkUri"`
+
+	// PrometheusURL is the Prometheus-compatible query endpoint used to evaluate triggers
+	// +kubebuilder:validation:Required
+	PrometheusURL string `json:"prometheusUrl"`
+
+	// EvaluationInterval is how often triggers are evaluated
+	// +optional
+	// +kubebuilder:default="1m"
+	EvaluationInterval string `json:"evaluationInterval,omitempty"`
+
+	// Cooldown is the minimum time between two events emitted for the same trigger
+	// +optional
+	// +kubebuilder:default="1h"
+	Cooldown string `json:"cooldown,omitempty"`
+
+	// Triggers are the drift/SLO conditions that emit retraining events
+	// +kubebuilder:validation:MinItems=1
+	Triggers []RetrainingTrigger `json:"triggers"`
+}
+
+// RetrainingTrigger defines a metric threshold that emits a retraining event
+type RetrainingTrigger struct {
+	// Name identifies the trigger in emitted events and status
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Type is the kind of signal: Drift or SLOBurn
+	// +optional
+	// +kubebuilder:validation:Enum=Drift;SLOBurn
+	// +kubebuilder:default="Drift"
+	Type string `json:"type,omitempty"`
+
+	// Query is a PromQL expression returning a single sample
+	// +kubebuilder:validation:Required
+	Query string `json:"query"`
+
+	// Threshold fires the trigger when the query result is greater than this value
+	// +kubebuilder:validation:Required
+	Threshold string `json:"threshold"`
+}
+
+// ObservabilitySpec defines observability configuration
+type ObservabilitySpec struct {
+	// Enabled enables observability features globally
+	// +optional
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// CollectorEndpoint is the unified endpoint for pushing signals (primarily tracing)
+	// Used as the destination for OTLP traces
+	// +optional
+	CollectorEndpoint string `json:"collectorEndpoint,omitempty"`
+
+	// Logging defines Grafana Loki logging configuration
+	// +optional
+	Logging *LoggingSpec `json:"logging,omitempty"`
+
+	// Tracing defines Grafana Tempo tracing configuration
+	// +optional
+	Tracing *TracingSpec `json:"tracing,omitempty"`
+
+	// Profiling defines Pyroscope profiling configuration
+	// +optional
+	Profiling *ProfilingSpec `json:"profiling,omitempty"`
+
+	// Metrics defines Prometheus/Mimir metrics configuration
+	// +optional
+	Metrics *MetricsSpec `json:"metrics,omitempty"`
+}
+
+// LoggingSpec defines logging configuration
+type LoggingSpec struct {
+	// Enabled enables logging configuration
+	// +optional
+	// +kubebuilder:default=true
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Level controls application-level log verbosity
+	// Maps to Triton's --log-verbose / --log-info / --log-error flags
+	// +optional
+	// +kubebuilder:validation:Enum=INFO;WARNING;ERROR;VERBOSE
+	// +kubebuilder:default="INFO"
+	Level string `json:"level,omitempty"`
+}
+
+// TracingSpec defines tracing configuration
+type TracingSpec struct {
+	// Enabled enables distributed tracing with Tempo
+	// +optional
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// SamplingRate is the trace sampling rate (0.0 - 1.0)
+	// +optional
+	// +kubebuilder:default="0.1"
+	SamplingRate string `json:"samplingRate,omitempty"`
+
+	// PythonBackendPropagation configures the OpenTelemetry SDK of Python backend models and
+	// mounts the kalypso_tracing helper module, so pre/post-processing code can record child
+	// spans under the request trace
+	// +optional
+	PythonBackendPropagation bool `json:"pythonBackendPropagation,omitempty"`
+}
+
+// ProfilingSpec defines profiling configuration
+type ProfilingSpec struct {
+	// Enabled enables continuous profiling with Pyroscope
+	// +optional
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Profiles defines which profile types to collect
+	// +optional
+	Profiles *ProfileTypes `json:"profiles,omitempty"`
+}
+
+// ProfileTypes defines the types of profiles to collect
+type ProfileTypes struct {
+	// CPU enables CPU profiling
+	// +optional
+	// +kubebuilder:default=true
+	CPU bool `json:"cpu,omitempty"`
+
+	// Memory enables memory profiling
+	// +optional
+	// +kubebuilder:default=true
+	Memory bool `json:"memory,omitempty"`
+}
+
+// MetricsSpec defines metrics configuration
+type MetricsSpec struct {
+	// Enabled enables metrics collection
+	// +optional
+	// +kubebuilder:default=true
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Interval is the metrics scrape interval
+	// +optional
+	// +kubebuilder:default="15s"
+	Interval string `json:"interval,omitempty"`
+
+	// EnableServiceMonitor enables automatic ServiceMonitor creation for Prometheus Operator
+	// +optional
+	// +kubebuilder:default=false
+	EnableServiceMonitor bool `json:"enableServiceMonitor,omitempty"`
+}
+
+// TritonConfigSpec defines the Triton server configuration
+type TritonConfigSpec struct {
+	// Image is the Triton container image (default: the project default, else
+	// nvcr.io/nvidia/tritonserver)
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Tag is the image tag (default: the project default, else the Triton release of the node
+	// architecture)
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// Parameters are Triton runtime parameters; project default parameters are added unless
+	// set here
+	// +optional
+	Parameters []TritonParameter `json:"parameters,omitempty"`
+
+	// BackendType is the backend type: python, tensorflow, pytorch, etc.
+	// +optional
+	// +kubebuilder:validation:Enum=python;tensorflow;pytorch;onnxruntime;tensorrt
+	BackendType string `json:"backendType,omitempty"`
+
+	// PythonBackend defines Python backend specific settings
+	// +optional
+	PythonBackend *PythonBackendSpec `json:"python_backend,omitempty"`
+
+	// VersionPolicy selects the versions of every model kept loaded, overriding the
+	// version_policy of the model configurations. Models are then loaded in the explicit
+	// model control mode and reloaded by the operator with the policy
+	// +optional
+	VersionPolicy *ModelVersionPolicy `json:"versionPolicy,omitempty"`
+
+	// DynamicBatching overrides the dynamic_batching of the model configurations per model;
+	// the model name "*" applies to every model without its own entry. Models without
+	// batching (max_batch_size 0) are left unchanged
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	DynamicBatching []ModelDynamicBatching `json:"dynamicBatching,omitempty"`
+}
+
+// ModelDynamicBatching defines the dynamic batcher settings of a model
+type ModelDynamicBatching struct {
+	// Name is the model name, or "*" for every model
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// PreferredBatchSizes are the batch sizes the batcher tries to form, at most the
+	// max_batch_size of the model
+	// +optional
+	PreferredBatchSizes []int32 `json:"preferredBatchSizes,omitempty"`
+
+	// MaxQueueDelayMicroseconds is how long a request may wait for a preferred batch
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxQueueDelayMicroseconds int64 `json:"maxQueueDelayMicroseconds,omitempty"`
+
+	// PreserveOrdering returns responses in the order the requests were received
+	// +optional
+	PreserveOrdering bool `json:"preserveOrdering,omitempty"`
+}
+
+// MLflowSpec defines the access to an MLflow tracking server
+type MLflowSpec struct {
+	// CredentialsSecret is a Secret with a bearer token in the token key, or a username and
+	// password in the username and password keys
+	// +optional
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+
+	// Insecure reaches the tracking server over plain HTTP instead of HTTPS
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// Interval is how often the stage is checked for a new version (default: 5m)
+	// +optional
+	// +kubebuilder:default="5m"
+	Interval string `json:"interval,omitempty"`
+}
+
+// HuggingFaceSpec defines the download of a Hugging Face Hub repository
+type HuggingFaceSpec struct {
+	// TokenSecret is a Secret with the Hub access token in the token key, passed as HF_TOKEN
+	// +optional
+	TokenSecret string `json:"tokenSecret,omitempty"`
+
+	// ModelName is the Triton model name of a repository holding plain model files (default:
+	// the Hub repository name). Repositories already laid out as a Triton model or model
+	// repository keep their layout
+	// +optional
+	ModelName string `json:"modelName,omitempty"`
+
+	// Backend writes a config.pbtxt selecting the Triton backend, e.g. vllm, for repositories
+	// holding plain model files
+	// +optional
+	Backend string `json:"backend,omitempty"`
+
+	// Image is the download init container image; huggingface_hub is installed when missing
+	// (default: python:3.12-slim)
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// SizeLimit bounds the emptyDir volume the repository is downloaded to
+	// +optional
+	SizeLimit *resource.Quantity `json:"sizeLimit,omitempty"`
+}
+
+// OCIArtifactSpec defines the pull of a model repository packaged as an OCI artifact
+type OCIArtifactSpec struct {
+	// Digest pins the manifest digest of a tagged artifact. Without it, the tag is resolved to
+	// its current digest, which requires anonymous pull access to the registry
 	// +optional
-	// +kubebuilder:default=1
-	Replicas *int32 `json:"replicas,omitempty"`
+	// +kubebuilder:validation:Pattern=`^sha256:[a-f0-9]{64}$`
+	Digest string `json:"digest,omitempty"`
 
-	// Resources defines K8s resource requests/limits
+	// PullSecret is a kubernetes.io/dockerconfigjson Secret with the registry credentials
 	// +optional
-	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	PullSecret string `json:"pullSecret,omitempty"`
 
-	// Networking defines service port configuration
+	// Path is the model repository directory within the artifact files (default: the root)
 	// +optional
-	Networking *NetworkingSpec `json:"networking,omitempty"`
+	Path string `json:"path,omitempty"`
 
-	// Observability defines observability configuration for logging, tracing, profiling, and metrics
+	// PlainHTTP pulls from a registry serving plain HTTP
 	// +optional
-	Observability *ObservabilitySpec `json:"observability,omitempty"`
+	PlainHTTP bool `json:"plainHTTP,omitempty"`
+
+	// Image is the ORAS init container image (default: ghcr.io/oras-project/oras:v1.2.2)
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// SizeLimit bounds the emptyDir volume the artifact is pulled to
+	// +optional
+	SizeLimit *resource.Quantity `json:"sizeLimit,omitempty"`
 }
 
-// ObservabilitySpec defines observability configuration
-type ObservabilitySpec struct {
-	// Enabled enables observability features globally
+// GitSyncSpec defines the git-sync clone of a model repository
+type GitSyncSpec struct {
+	// Path is the model repository directory within the git repository (default: the root)
 	// +optional
-	// +kubebuilder:default=false
-	Enabled bool `json:"enabled,omitempty"`
+	Path string `json:"path,omitempty"`
 
-	// CollectorEndpoint is the unified endpoint for pushing signals (primarily tracing)
-	// Used as the destination for OTLP traces
+	// CredentialsSecret is a Secret with the username and password keys of git+https:// URIs
 	// +optional
-	CollectorEndpoint string `json:"collectorEndpoint,omitempty"`
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
 
-	// Logging defines Grafana Loki logging configuration
+	// SSHKeySecret is a Secret with the ssh-privatekey deploy key and known_hosts keys of
+	// git+ssh:// URIs
 	// +optional
-	Logging *LoggingSpec `json:"logging,omitempty"`
+	SSHKeySecret string `json:"sshKeySecret,omitempty"`
 
-	// Tracing defines Grafana Tempo tracing configuration
+	// Poll keeps a git-sync sidecar pulling the ref, and Triton polling the repository so
+	// pushed model changes are reloaded. Ignored when the models are loaded explicitly
 	// +optional
-	Tracing *TracingSpec `json:"tracing,omitempty"`
+	Poll bool `json:"poll,omitempty"`
 
-	// Profiling defines Pyroscope profiling configuration
+	// Period is how often the ref is pulled when polling (default: 60s)
 	// +optional
-	Profiling *ProfilingSpec `json:"profiling,omitempty"`
+	// +kubebuilder:default="60s"
+	Period string `json:"period,omitempty"`
 
-	// Metrics defines Prometheus/Mimir metrics configuration
+	// Image is the git-sync image (default: registry.k8s.io/git-sync/git-sync:v4.4.0)
 	// +optional
-	Metrics *MetricsSpec `json:"metrics,omitempty"`
+	Image string `json:"image,omitempty"`
 }
 
-// LoggingSpec defines logging configuration
-type LoggingSpec struct {
-	// Enabled enables logging configuration
+// ArchiveFormat is the packaging of a model repository archive
+// +kubebuilder:validation:Enum=tar;tar.gz;zip
+type ArchiveFormat string
+
+const (
+	// ArchiveFormatTar is an uncompressed tarball
+	ArchiveFormatTar ArchiveFormat = "tar"
+	// ArchiveFormatTarGz is a gzip-compressed tarball
+	ArchiveFormatTarGz ArchiveFormat = "tar.gz"
+	// ArchiveFormatZip is a zip archive
+	ArchiveFormatZip ArchiveFormat = "zip"
+)
+
+// ServerStorageSpec defines the storage settings a server overrides
+type ServerStorageSpec struct {
+	// SecretName is the name of secret containing S3 credentials
 	// +optional
-	// +kubebuilder:default=true
-	Enabled bool `json:"enabled,omitempty"`
+	SecretName string `json:"secretName,omitempty"`
 
-	// Level controls application-level log verbosity
-	// Maps to Triton's --log-verbose / --log-info / --log-error flags
+	// Endpoint is the S3-compatible endpoint URL (for MinIO, etc.)
 	// +optional
-	// +kubebuilder:validation:Enum=INFO;WARNING;ERROR;VERBOSE
-	// +kubebuilder:default="INFO"
-	Level string `json:"level,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Region is the cloud region for storage
+	// +optional
+	Region string `json:"region,omitempty"`
 }
 
-// TracingSpec defines tracing configuration
-type TracingSpec struct {
-	// Enabled enables distributed tracing with Tempo
+// ArchiveSpec defines the download of a model repository archive served over HTTP(S), e.g. by
+// Nexus or Artifactory
+type ArchiveSpec struct {
+	// SHA256 is the hex-encoded checksum the downloaded archive must match before it is unpacked
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-f0-9]{64}$`
+	SHA256 string `json:"sha256"`
+
+	// Format is the archive packaging (default: from the URI extension, .zip, .tar.gz or .tgz,
+	// else tar)
 	// +optional
-	// +kubebuilder:default=false
-	Enabled bool `json:"enabled,omitempty"`
+	Format ArchiveFormat `json:"format,omitempty"`
 
-	// SamplingRate is the trace sampling rate (0.0 - 1.0)
+	// CredentialsSecret is a Secret with the username and password keys sent as basic auth
 	// +optional
-	// +kubebuilder:default="0.1"
-	SamplingRate string `json:"samplingRate,omitempty"`
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+
+	// Path is the model repository directory within the archive (default: the root)
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Image is the download init container image, providing curl, sha256sum, tar and unzip
+	// (default: curlimages/curl:8.10.1)
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// SizeLimit bounds the emptyDir volume the archive is unpacked to
+	// +optional
+	SizeLimit *resource.Quantity `json:"sizeLimit,omitempty"`
 }
 
-// ProfilingSpec defines profiling configuration
-type ProfilingSpec struct {
-	// Enabled enables continuous profiling with Pyroscope
+// WarmupSpec defines the warmup requests of the models
+type WarmupSpec struct {
+	// Models are the warmup settings per model; the model name "*" applies to every model
+	// without its own entry
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	Models []ModelWarmup `json:"models"`
+}
+
+// ModelWarmup defines the warmup requests of a model
+type ModelWarmup struct {
+	// Name is the model name, or "*" for every model
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// BatchSize is the batch size of the warmup requests (default: 1)
 	// +optional
-	// +kubebuilder:default=false
-	Enabled bool `json:"enabled,omitempty"`
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	BatchSize int32 `json:"batchSize,omitempty"`
 
-	// Profiles defines which profile types to collect
+	// Count is the number of warmup requests (default: 1)
 	// +optional
-	Profiles *ProfileTypes `json:"profiles,omitempty"`
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	Count int32 `json:"count,omitempty"`
+
+	// Inputs override the warmup tensors of the listed model inputs. The other inputs are
+	// warmed up with zeros shaped from the model configuration, variable dimensions set to 1
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Inputs []WarmupInput `json:"inputs,omitempty"`
 }
 
-// ProfileTypes defines the types of profiles to collect
-type ProfileTypes struct {
-	// CPU enables CPU profiling
+// WarmupInput defines a warmup tensor
+// +kubebuilder:validation:XValidation:rule="self.data != 'File' || has(self.file)",message="file is required with data File"
+type WarmupInput struct {
+	// Name is the model input name
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// DataType is the Triton data type, e.g. TYPE_FP32 (default: from the model configuration)
 	// +optional
-	// +kubebuilder:default=true
-	CPU bool `json:"cpu,omitempty"`
+	DataType string `json:"dataType,omitempty"`
 
-	// Memory enables memory profiling
+	// Dims is the shape without the batch dimension (default: from the model configuration)
 	// +optional
-	// +kubebuilder:default=true
-	Memory bool `json:"memory,omitempty"`
+	Dims []int64 `json:"dims,omitempty"`
+
+	// Data is the tensor content: zeros, random values, or a sample payload file
+	// +optional
+	// +kubebuilder:validation:Enum=Zero;Random;File
+	// +kubebuilder:default="Zero"
+	Data string `json:"data,omitempty"`
+
+	// File is the sample payload, relative to the warmup directory of the model in the repository
+	// +optional
+	File string `json:"file,omitempty"`
 }
 
-// MetricsSpec defines metrics configuration
-type MetricsSpec struct {
-	// Enabled enables metrics collection
+// ModelConfigOverride defines the configuration fields of a model replaced by the operator
+type ModelConfigOverride struct {
+	// Name is the model name, or "*" for every model
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// InstanceGroups replace the instance_group of the model
 	// +optional
-	// +kubebuilder:default=true
-	Enabled bool `json:"enabled,omitempty"`
+	InstanceGroups []ModelInstanceGroup `json:"instanceGroups,omitempty"`
 
-	// Interval is the metrics scrape interval
+	// MaxBatchSize replaces the max_batch_size of the model; 0 disables batching
 	// +optional
-	// +kubebuilder:default="15s"
-	Interval string `json:"interval,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	MaxBatchSize *int32 `json:"maxBatchSize,omitempty"`
 
-	// EnableServiceMonitor enables automatic ServiceMonitor creation for Prometheus Operator
+	// Optimization is merged into the optimization settings of the model
 	// +optional
-	// +kubebuilder:default=false
-	EnableServiceMonitor bool `json:"enableServiceMonitor,omitempty"`
+	Optimization *ModelOptimization `json:"optimization,omitempty"`
 }
 
-// TritonConfigSpec defines the Triton server configuration
-type TritonConfigSpec struct {
-	// Image is the Triton container image (default: nvcr.io/nvidia/tritonserver)
+// ModelInstanceGroup defines a group of model instances
+type ModelInstanceGroup struct {
+	// Count is the number of instances, per GPU for GPU instances (default: 1)
 	// +optional
-	// +kubebuilder:default="nvcr.io/nvidia/tritonserver"
-	Image string `json:"image,omitempty"`
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	Count int32 `json:"count,omitempty"`
 
-	// Tag is the image tag
+	// Kind is where the instances run (default: Auto)
 	// +optional
-	// +kubebuilder:default="24.12-py3"
-	Tag string `json:"tag,omitempty"`
+	// +kubebuilder:validation:Enum=Auto;GPU;CPU
+	// +kubebuilder:default="Auto"
+	Kind string `json:"kind,omitempty"`
 
-	// Parameters are Triton runtime parameters
+	// GPUs are the GPU indexes of GPU instances (default: every GPU)
 	// +optional
-	Parameters []TritonParameter `json:"parameters,omitempty"`
+	GPUs []int32 `json:"gpus,omitempty"`
+}
 
-	// BackendType is the backend type: python, tensorflow, pytorch, etc.
+// ModelOptimization defines optimization settings of a model
+type ModelOptimization struct {
+	// CUDAGraphs captures CUDA graphs for TensorRT models
 	// +optional
-	// +kubebuilder:validation:Enum=python;tensorflow;pytorch;onnxruntime;tensorrt
-	BackendType string `json:"backendType,omitempty"`
+	CUDAGraphs *bool `json:"cudaGraphs,omitempty"`
 
-	// PythonBackend defines Python backend specific settings
+	// InputPinnedMemory uses pinned memory for the input tensors
 	// +optional
-	PythonBackend *PythonBackendSpec `json:"python_backend,omitempty"`
+	InputPinnedMemory *bool `json:"inputPinnedMemory,omitempty"`
+
+	// OutputPinnedMemory uses pinned memory for the output tensors
+	// +optional
+	OutputPinnedMemory *bool `json:"outputPinnedMemory,omitempty"`
+
+	// TensorRTPrecision enables the TensorRT accelerator of ONNX and TensorFlow models with the
+	// given precision
+	// +optional
+	// +kubebuilder:validation:Enum=FP32;FP16
+	TensorRTPrecision string `json:"tensorRTPrecision,omitempty"`
+}
+
+// ModelVersionPolicy selects the versions of a model served by Triton
+// +kubebuilder:validation:XValidation:rule="(has(self.latest) ? 1 : 0) + (has(self.specific) ? 1 : 0) + (has(self.all) && self.all ? 1 : 0) == 1",message="exactly one of latest, specific or all must be set"
+type ModelVersionPolicy struct {
+	// Latest serves the given number of highest versions
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Latest *int32 `json:"latest,omitempty"`
+
+	// Specific serves the listed versions
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	Specific []int64 `json:"specific,omitempty"`
+
+	// All serves every version in the repository
+	// +optional
+	All bool `json:"all,omitempty"`
 }
 
 // TritonParameter defines a Triton runtime parameter
@@ -203,6 +1221,8 @@ type PythonBackendSpec struct {
 }
 
 // NetworkingSpec defines the service port configuration
+// +kubebuilder:validation:XValidation:rule="!has(self.loadBalancerClass) || (has(self.serviceType) && self.serviceType == 'LoadBalancer')",message="loadBalancerClass requires serviceType LoadBalancer"
+// +kubebuilder:validation:XValidation:rule="!has(self.sessionAffinityTimeoutSeconds) || (has(self.sessionAffinity) && self.sessionAffinity == 'ClientIP')",message="sessionAffinityTimeoutSeconds requires sessionAffinity ClientIP"
 type NetworkingSpec struct {
 	// HTTPPort is the HTTP port (default: 8000)
 	// +optional
@@ -218,10 +1238,159 @@ type NetworkingSpec struct {
 	// +optional
 	// +kubebuilder:default=8002
 	MetricsPort *int32 `json:"metricsPort,omitempty"`
+
+	// ServiceType is the type of the main Service. NodePort and LoadBalancer expose gRPC
+	// directly, e.g. through MetalLB on-premises
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	// +kubebuilder:default="ClusterIP"
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// LoadBalancerClass selects the load balancer implementation of a LoadBalancer Service.
+	// It cannot be changed once the Service is created
+	// +optional
+	LoadBalancerClass *string `json:"loadBalancerClass,omitempty"`
+
+	// ServiceAnnotations are added to the main Service, e.g. to request a MetalLB address pool
+	// +optional
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+
+	// SessionAffinity pins each client to one replica of the main Service, keeping
+	// long-lived streams and sequence batching on the same pod
+	// +optional
+	// +kubebuilder:validation:Enum=None;ClientIP
+	// +kubebuilder:default="None"
+	SessionAffinity corev1.ServiceAffinity `json:"sessionAffinity,omitempty"`
+
+	// SessionAffinityTimeoutSeconds is how long a ClientIP affinity lasts (default: 10800)
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=86400
+	SessionAffinityTimeoutSeconds *int32 `json:"sessionAffinityTimeoutSeconds,omitempty"`
+
+	// GRPCKeepalive tunes the keepalive pings of the Triton gRPC endpoint
+	// +optional
+	GRPCKeepalive *GRPCKeepaliveSpec `json:"grpcKeepalive,omitempty"`
+
+	// NetworkPolicy restricts the ingress traffic of the Triton pods
+	// +optional
+	NetworkPolicy *NetworkPolicySpec `json:"networkPolicy,omitempty"`
+
+	// MetricsService moves the metrics port from the main Service to a dedicated Service
+	// +optional
+	MetricsService *MetricsServiceSpec `json:"metricsService,omitempty"`
+
+	// Ingress exposes the HTTP port through a Kubernetes Ingress, for clusters without
+	// Istio or the Gateway API
+	// +optional
+	Ingress *IngressSpec `json:"ingress,omitempty"`
+
+	// TLS serves the Triton gRPC endpoint over TLS with the certificate of a secret, taking
+	// precedence over the application certificate
+	// +optional
+	TLS *TLSSpec `json:"tls,omitempty"`
+}
+
+// TLSSpec configures the native TLS of the Triton gRPC endpoint. Triton has no TLS support
+// on its HTTP endpoint, which stays in plain text
+type TLSSpec struct {
+	// SecretName is the kubernetes.io/tls secret holding the server certificate
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+
+	// ClientAuth requires clients to present a certificate signed by the ca.crt of the secret
+	// +optional
+	ClientAuth bool `json:"clientAuth,omitempty"`
+}
+
+// IngressSpec configures the Ingress of the Triton HTTP endpoint
+type IngressSpec struct {
+	// Host is the hostname the Ingress serves
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^(\*\.)?([a-z0-9]([-a-z0-9]*[a-z0-9])?\.)+[a-z]{2,63}$`
+	Host string `json:"host"`
+
+	// Path is the path prefix routed to the server (default: /)
+	// +optional
+	// +kubebuilder:default="/"
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path,omitempty"`
+
+	// IngressClassName selects the ingress controller (default: the cluster default class)
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+
+	// TLSSecretName is the secret holding the TLS certificate of the host. TLS is not
+	// terminated at the Ingress when it is empty
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+}
+
+// NetworkPolicySpec configures the NetworkPolicy of the Triton pods. Only the application
+// gateway may reach the inference ports and only Prometheus the metrics port
+type NetworkPolicySpec struct {
+	// Enabled creates the NetworkPolicy
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// PrometheusNamespace is the namespace of the Prometheus scraping the metrics port
+	// +optional
+	// +kubebuilder:default="monitoring"
+	PrometheusNamespace string `json:"prometheusNamespace,omitempty"`
+
+	// AllowedNamespaces are additional namespaces allowed to reach the inference ports
+	// +optional
+	// +listType=set
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
+// GRPCKeepaliveSpec configures the --grpc-keepalive-* and --grpc-http2-* flags of Triton.
+// Unset fields keep the Triton defaults
+type GRPCKeepaliveSpec struct {
+	// TimeSeconds is the interval of the keepalive pings sent to clients
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TimeSeconds *int32 `json:"timeSeconds,omitempty"`
+
+	// TimeoutSeconds is how long to wait for a ping acknowledgement before closing the connection
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// PermitWithoutCalls allows keepalive pings on connections without active calls
+	// +optional
+	PermitWithoutCalls *bool `json:"permitWithoutCalls,omitempty"`
+
+	// MaxPingsWithoutData is the number of pings sent without data frames; 0 allows any number
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxPingsWithoutData *int32 `json:"maxPingsWithoutData,omitempty"`
+
+	// MinRecvPingIntervalSeconds is the minimum interval between client pings without data
+	// before a ping counts as a strike
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MinRecvPingIntervalSeconds *int32 `json:"minRecvPingIntervalSeconds,omitempty"`
+
+	// MaxPingStrikes is the number of bad pings tolerated before the connection is closed;
+	// 0 tolerates any number
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxPingStrikes *int32 `json:"maxPingStrikes,omitempty"`
+}
+
+// MetricsServiceSpec configures the dedicated metrics Service. Inference load balancers never
+// route to the metrics port, and Prometheus scrapes pods directly, outside the sidecar's mTLS
+type MetricsServiceSpec struct {
+	// Enabled creates a headless metrics Service, removes the metrics port from the main Service,
+	// and excludes the metrics port from Istio sidecar interception
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
 }
 
 // TritonServerPhase represents the current phase of the Triton server
-// +kubebuilder:validation:Enum=Pending;Running;Failed
+// +kubebuilder:validation:Enum=Pending;Running;Suspended;Failed
 type TritonServerPhase string
 
 const (
@@ -229,17 +1398,21 @@ const (
 	TritonServerPhasePending TritonServerPhase = "Pending"
 	// TritonServerPhaseRunning indicates the server is running
 	TritonServerPhaseRunning TritonServerPhase = "Running"
+	// TritonServerPhaseSuspended indicates the server is scaled to zero by spec.suspend
+	TritonServerPhaseSuspended TritonServerPhase = "Suspended"
+	// TritonServerPhaseArchived indicates the server is scaled to zero by spec.state Archived
+	TritonServerPhaseArchived TritonServerPhase = "Archived"
 	// TritonServerPhaseFailed indicates the server has failed
 	TritonServerPhaseFailed TritonServerPhase = "Failed"
 )
 
 // KalypsoTritonServerStatus defines the observed state of KalypsoTritonServer
 type KalypsoTritonServerStatus struct {
-	// Phase represents the current phase: Pending, Running, Failed
+	// Phase represents the current phase: Pending, Running, Suspended, Archived, Failed
 	// +optional
 	Phase TritonServerPhase `json:"phase,omitempty"`
 
-	// DeploymentName is the name of created K8s Deployment
+	// DeploymentName is the name of created K8s Deployment, or StatefulSet with workloadType StatefulSet
 	// +optional
 	DeploymentName string `json:"deploymentName,omitempty"`
 
@@ -251,6 +1424,19 @@ type KalypsoTritonServerStatus struct {
 	// +optional
 	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
 
+	// Models are the models ready for inference on the server, from the Triton repository index
+	// +optional
+	// +listType=set
+	Models []string `json:"models,omitempty"`
+
+	// ModelRegistry is the registered model version served for a mlflow:// storage URI
+	// +optional
+	ModelRegistry *ModelRegistryStatus `json:"modelRegistry,omitempty"`
+
+	// ModelArtifact is the verified digest of the OCI artifact served for an oci:// storage URI
+	// +optional
+	ModelArtifact *ModelArtifactStatus `json:"modelArtifact,omitempty"`
+
 	// Message is a human-readable status message
 	// +optional
 	Message string `json:"message,omitempty"`
@@ -260,6 +1446,133 @@ type KalypsoTritonServerStatus struct {
 	// +listMapKey=type
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// RetrainingTriggers reports the last evaluation of each retraining trigger
+	// +optional
+	RetrainingTriggers []RetrainingTriggerStatus `json:"retrainingTriggers,omitempty"`
+
+	// Evacuation reports the progress of a requested node evacuation
+	// +optional
+	Evacuation *EvacuationStatus `json:"evacuation,omitempty"`
+
+	// AppliedGeneration is the spec generation last applied to the child resources
+	// +optional
+	AppliedGeneration int64 `json:"appliedGeneration,omitempty"`
+
+	// AppliedSpecHash identifies the spec last applied to the child resources, including the
+	// defaults inherited from the preset, the application and the project
+	// +optional
+	AppliedSpecHash string `json:"appliedSpecHash,omitempty"`
+
+	// PendingPlan describes the child resource changes awaiting approval under the Manual change policy
+	// +optional
+	PendingPlan *ChangePlan `json:"pendingPlan,omitempty"`
+
+	// Revisions are the last rendered configurations of the Triton container, oldest first. The
+	// serving.kalypso.io/rollback-to annotation restores the configuration of a revision
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	Revisions []ServerRevision `json:"revisions,omitempty"`
+}
+
+// ServerRevision is a rendered configuration of the Triton container
+type ServerRevision struct {
+	// Revision is the revision number, increasing with every configuration change
+	Revision int64 `json:"revision"`
+
+	// Generation is the spec generation the revision was rendered from
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+
+	// Image is the rendered Triton container image
+	Image string `json:"image"`
+
+	// StorageURI is the model repository of the revision
+	StorageURI string `json:"storageUri"`
+
+	// ArgsHash is a hash of the rendered Triton container args
+	ArgsHash string `json:"argsHash"`
+
+	// TritonConfig is the Triton configuration restored by a rollback to the revision
+	// +optional
+	TritonConfig TritonConfigSpec `json:"tritonConfig,omitempty"`
+
+	// CreatedAt is when the revision was first rendered
+	// +optional
+	CreatedAt metav1.Time `json:"createdAt,omitempty"`
+}
+
+// ModelRegistryStatus reports the registered model version resolved from the MLflow Model Registry
+type ModelRegistryStatus struct {
+	// StorageURI is the mlflow:// storage URI the version was resolved for
+	StorageURI string `json:"storageUri"`
+
+	// Version is the model version in the stage
+	Version string `json:"version"`
+
+	// ArtifactURI is the artifact location of the version, served as the model repository
+	ArtifactURI string `json:"artifactUri"`
+
+	// LastCheckTime is when the stage was last checked
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+}
+
+// ModelArtifactStatus reports the manifest digest an OCI model artifact is pulled at
+type ModelArtifactStatus struct {
+	// StorageURI is the oci:// storage URI the digest was resolved for
+	StorageURI string `json:"storageUri"`
+
+	// Digest is the manifest digest pulled by the pods
+	Digest string `json:"digest"`
+}
+
+// EvacuationStatus reports the replicas being replaced ahead of a planned node drain
+type EvacuationStatus struct {
+	// Nodes are the nodes being evacuated
+	Nodes []string `json:"nodes"`
+
+	// PodsOnNodes is the number of Triton pods still running on the evacuated nodes; the
+	// Deployment is surged by the same number of replicas until they are evicted
+	PodsOnNodes int32 `json:"podsOnNodes"`
+}
+
+// ChangePlan describes the rendered changes of a spec generation that has not been applied
+type ChangePlan struct {
+	// Generation is the spec generation the plan was rendered for
+	Generation int64 `json:"generation"`
+
+	// SpecHash identifies the spec the plan was rendered for, including the inherited defaults.
+	// Setting the approval annotation to it also approves changed defaults
+	// +optional
+	SpecHash string `json:"specHash,omitempty"`
+
+	// Diff is a unified diff of the child resource specs, truncated for large changes
+	// +optional
+	Diff string `json:"diff,omitempty"`
+
+	// RenderedAt is when the plan was rendered
+	// +optional
+	RenderedAt metav1.Time `json:"renderedAt,omitempty"`
+}
+
+// RetrainingTriggerStatus defines the observed state of a retraining trigger
+type RetrainingTriggerStatus struct {
+	// Name is the trigger name
+	Name string `json:"name"`
+
+	// LastValue is the query result of the last evaluation
+	// +optional
+	LastValue string `json:"lastValue,omitempty"`
+
+	// LastEventTime is when the last CloudEvent was emitted for this trigger
+	// +optional
+	LastEventTime *metav1.Time `json:"lastEventTime,omitempty"`
+
+	// LastEvaluationTime is when the trigger query was last run; it is not run again before the
+	// evaluation interval has passed
+	// +optional
+	LastEvaluationTime *metav1.Time `json:"lastEvaluationTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -267,6 +1580,7 @@ type KalypsoTritonServerStatus struct {
 // +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.availableReplicas
 // +kubebuilder:printcolumn:name="Application",type=string,JSONPath=`.spec.applicationRef`
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.spec.state`,priority=1
 // +kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=`.spec.replicas`
 // +kubebuilder:printcolumn:name="Available",type=integer,JSONPath=`.status.availableReplicas`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
@@ -20,6 +20,7 @@ import (
 	"crypto/tls"
 	"flag"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -37,7 +38,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/audit"
 	"github.com/kalypsoServing/KalypsoServing/internal/controller"
+	"github.com/kalypsoServing/KalypsoServing/internal/features"
+	"github.com/kalypsoServing/KalypsoServing/internal/imagearch"
+	"github.com/kalypsoServing/KalypsoServing/internal/imagesig"
+	"github.com/kalypsoServing/KalypsoServing/internal/mlflow"
+	"github.com/kalypsoServing/KalypsoServing/internal/retraining"
+	"github.com/kalypsoServing/KalypsoServing/internal/selftest"
+	"github.com/kalypsoServing/KalypsoServing/internal/statusupdater"
+	"github.com/kalypsoServing/KalypsoServing/internal/triton"
+	webhookv1alpha1 "github.com/kalypsoServing/KalypsoServing/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -64,6 +75,12 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var statusUpdateWorkers int
+	var statusCoalesceWindow time.Duration
+	var selfTestNamespace string
+	var selfTestInterval time.Duration
+	var imageVerificationMode string
+	var cosignPublicKeyPath, rekorPublicKeyPath string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -82,6 +99,22 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.IntVar(&statusUpdateWorkers, "status-update-workers", statusupdater.DefaultWorkers,
+		"The number of workers writing KalypsoTritonServer status.")
+	flag.DurationVar(&statusCoalesceWindow, "status-coalesce-window", statusupdater.DefaultCoalesceWindow,
+		"The delay during which successive status updates of one object are merged into a single write.")
+	flag.StringVar(&selfTestNamespace, "self-test-namespace", selftest.DefaultNamespace,
+		"The namespace the ConformanceSelfTest feature deploys its reference model server to.")
+	flag.DurationVar(&selfTestInterval, "self-test-interval", selftest.DefaultInterval,
+		"The delay between two ConformanceSelfTest runs.")
+	flag.StringVar(&imageVerificationMode, "image-verification", string(servingv1alpha1.ImageVerificationDisabled),
+		"Whether the cosign signatures of the Triton images are verified before deploying them: "+
+			"Enforce, Audit or Disabled. Projects override it with spec.imageVerification.")
+	flag.StringVar(&cosignPublicKeyPath, "cosign-public-key", "",
+		"The PEM file of the cosign public key the Triton images must be signed with.")
+	flag.StringVar(&rekorPublicKeyPath, "rekor-public-key", "",
+		"The PEM file of the Rekor public key. If set, image signatures must be recorded in the transparency log.")
+	features.AddFlag(flag.CommandLine)
 	opts := zap.Options{
 		Development: true,
 	}
@@ -90,6 +123,8 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	setupLog.Info("feature gates configured", "featureGates", features.Gate.String())
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -159,6 +194,7 @@ func main() {
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  controller.NewCacheOptions(),
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
@@ -181,27 +217,157 @@ func main() {
 		os.Exit(1)
 	}
 
+	statusUpdater := statusupdater.NewUpdater(mgr.GetClient(), statusUpdateWorkers, statusCoalesceWindow)
+	if err := mgr.Add(statusUpdater); err != nil {
+		setupLog.Error(err, "unable to set up status updater")
+		os.Exit(1)
+	}
+
+	if features.Enabled(features.ConformanceSelfTest) {
+		runner := selftest.NewRunner(mgr.GetClient(), selftest.Config{
+			Namespace: selfTestNamespace,
+			Interval:  selfTestInterval,
+		})
+		if err := mgr.Add(runner); err != nil {
+			setupLog.Error(err, "unable to set up self-test")
+			os.Exit(1)
+		}
+	}
+
 	if err := (&controller.KalypsoProjectReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		APIReader: mgr.GetAPIReader(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KalypsoProject")
 		os.Exit(1)
 	}
 	if err := (&controller.KalypsoApplicationReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		MetricsQuerier: retraining.NewPrometheusQuerier(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KalypsoApplication")
 		os.Exit(1)
 	}
+	imageVerification := controller.ImageVerificationPolicy{
+		Mode: servingv1alpha1.ImageVerificationMode(imageVerificationMode),
+	}
+	switch imageVerification.Mode {
+	case servingv1alpha1.ImageVerificationEnforce, servingv1alpha1.ImageVerificationAudit,
+		servingv1alpha1.ImageVerificationDisabled:
+	default:
+		setupLog.Error(nil, "invalid --image-verification mode", "mode", imageVerificationMode)
+		os.Exit(1)
+	}
+	for _, key := range []struct {
+		path string
+		data *[]byte
+	}{
+		{cosignPublicKeyPath, &imageVerification.PublicKey},
+		{rekorPublicKeyPath, &imageVerification.RekorPublicKey},
+	} {
+		if key.path == "" {
+			continue
+		}
+		data, err := os.ReadFile(key.path)
+		if err != nil {
+			setupLog.Error(err, "unable to read public key", "path", key.path)
+			os.Exit(1)
+		}
+		*key.data = data
+	}
+
+	registryResolver := imagearch.NewRegistryResolver()
 	if err := (&controller.KalypsoTritonServerReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		MetricsQuerier:    retraining.NewPrometheusQuerier(),
+		EventSender:       retraining.NewHTTPSender(),
+		ImageResolver:     registryResolver,
+		StatusUpdater:     statusUpdater,
+		ModelIndex:        triton.NewHTTPClient(),
+		ModelRegistry:     mlflow.NewHTTPClient(),
+		ArtifactResolver:  registryResolver,
+		ImageVerifier:     imagesig.NewCosignVerifier(registryResolver),
+		ImageVerification: imageVerification,
+		APIReader:         mgr.GetAPIReader(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KalypsoTritonServer")
+		os.Exit(1)
+	}
+	if err := (&controller.KalypsoRolloutReconciler{
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		MetricsQuerier: retraining.NewPrometheusQuerier(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KalypsoRollout")
+		os.Exit(1)
+	}
+	if err := (&controller.KalypsoModelCacheReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "KalypsoTritonServer")
+		setupLog.Error(err, "unable to create controller", "controller", "KalypsoModelCache")
+		os.Exit(1)
+	}
+	if err := (&controller.KalypsoPromotionReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KalypsoPromotion")
 		os.Exit(1)
 	}
+	if err := (&controller.KalypsoExperimentReconciler{
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		MetricsQuerier: retraining.NewPrometheusQuerier(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KalypsoExperiment")
+		os.Exit(1)
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err := webhookv1alpha1.SetupKalypsoTritonServerWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "KalypsoTritonServer")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupKalypsoProjectWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "KalypsoProject")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupKalypsoApplicationWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "KalypsoApplication")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupKalypsoRolloutWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "KalypsoRollout")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupKalypsoModelCacheWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "KalypsoModelCache")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupKalypsoPromotionWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "KalypsoPromotion")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupKalypsoExperimentWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "KalypsoExperiment")
+			os.Exit(1)
+		}
+		if features.Enabled(features.AuditExport) {
+			exporter := audit.NewExporter(mgr.GetClient(), mgr.GetAPIReader())
+			if err := mgr.Add(exporter); err != nil {
+				setupLog.Error(err, "unable to set up audit exporter")
+				os.Exit(1)
+			}
+			if err := webhookv1alpha1.SetupAuditWebhookWithManager(mgr, exporter); err != nil {
+				setupLog.Error(err, "unable to create webhook", "webhook", "audit")
+				os.Exit(1)
+			}
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command replay sends captured inference requests to a staging KalypsoTritonServer and compares
+// the latency distribution with the one observed in production.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/kalypsoServing/KalypsoServing/internal/replay"
+)
+
+func main() {
+	var logPath, target string
+	var speed float64
+	var timeout time.Duration
+	flag.StringVar(&logPath, "log", "", "Path to the captured request log (JSON Lines), or - for stdin.")
+	flag.StringVar(&target, "target", "", "Base URL of the server under test, e.g. http://resnet50-staging.ml.svc:8000.")
+	flag.Float64Var(&speed, "speed", 1, "Replay speed multiplier; 2 sends the traffic at twice the captured rate.")
+	flag.DurationVar(&timeout, "timeout", 30*time.Second, "Timeout of a single request.")
+	flag.Parse()
+
+	if logPath == "" || target == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(logPath, target, speed, timeout); err != nil {
+		fmt.Fprintln(os.Stderr, "replay:", err)
+		os.Exit(1)
+	}
+}
+
+func run(logPath, target string, speed float64, timeout time.Duration) error {
+	input := os.Stdin
+	if logPath != "-" {
+		file, err := os.Open(logPath)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = file.Close() }()
+		input = file
+	}
+
+	records, err := replay.ReadRecords(input)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", logPath, err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	replayer := replay.NewReplayer(target, speed)
+	replayer.Client.Timeout = timeout
+	results, err := replayer.Run(ctx, records)
+	if err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	return replay.NewComparison(results).Write(os.Stdout)
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit streams the lifecycle events of Kalypso resources, with the identity of the
+// requesting user captured by the admission webhook, to the SIEM sink of their project.
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+const (
+	// DefaultQueueSize bounds the events waiting for delivery; further events are dropped
+	DefaultQueueSize = 10000
+	// DefaultFlushInterval is the delay between two deliveries
+	DefaultFlushInterval = 5 * time.Second
+	// DefaultBatchSize triggers a delivery before the flush interval
+	DefaultBatchSize = 500
+	// maxAttempts bounds the delivery attempts of a batch
+	maxAttempts = 3
+)
+
+// Event is an audit record of a Kalypso resource change
+type Event struct {
+	// ID is the UID of the admission request
+	ID string `json:"id"`
+	// Time is when the request was admitted
+	Time time.Time `json:"time"`
+	// Operation is CREATE, UPDATE or DELETE
+	Operation string `json:"operation"`
+	// Kind is the resource kind, e.g. KalypsoTritonServer
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Project is the KalypsoProject the resource belongs to
+	Project string `json:"project"`
+	// ProjectNamespace is the namespace of the KalypsoProject when it is not Namespace
+	ProjectNamespace string `json:"projectNamespace,omitempty"`
+	// User is the identity of the requesting user
+	User User `json:"user"`
+}
+
+// User is the authenticated identity of a request
+type User struct {
+	Username string   `json:"username"`
+	UID      string   `json:"uid,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+// ProjectOf returns the KalypsoProject a Kalypso resource belongs to. Servers are resolved
+// through their application, and applications may reference a project of another namespace
+func ProjectOf(ctx context.Context, c client.Reader, obj client.Object) (types.NamespacedName, error) {
+	switch o := obj.(type) {
+	case *servingv1alpha1.KalypsoProject:
+		return client.ObjectKeyFromObject(o), nil
+	case *servingv1alpha1.KalypsoApplication:
+		return naming.Reference(o.Namespace, o.Spec.ProjectRef), nil
+	case *servingv1alpha1.KalypsoTritonServer:
+		app := &servingv1alpha1.KalypsoApplication{}
+		if err := c.Get(ctx, naming.Reference(o.Namespace, o.Spec.ApplicationRef), app); err != nil {
+			return types.NamespacedName{}, fmt.Errorf("failed to resolve application %s: %w", o.Spec.ApplicationRef, err)
+		}
+		return naming.Reference(app.Namespace, app.Spec.ProjectRef), nil
+	default:
+		return types.NamespacedName{}, fmt.Errorf("unsupported kind %T", obj)
+	}
+}
+
+// Exporter queues audit events and delivers them in batches to the sink of each project
+type Exporter struct {
+	client client.Client
+	// secrets reads sink credentials without caching every Secret of the cluster
+	secrets       client.Reader
+	newSink       func(spec servingv1alpha1.AuditSinkSpec, secret map[string][]byte) (Sink, error)
+	queue         chan Event
+	flushInterval time.Duration
+	batchSize     int
+}
+
+// NewExporter creates an Exporter reading projects through c and sink credentials through secrets
+func NewExporter(c client.Client, secrets client.Reader) *Exporter {
+	return &Exporter{
+		client:        c,
+		secrets:       secrets,
+		newSink:       NewSink,
+		queue:         make(chan Event, DefaultQueueSize),
+		flushInterval: DefaultFlushInterval,
+		batchSize:     DefaultBatchSize,
+	}
+}
+
+// Record queues an event without blocking the admission request. Events are dropped when the
+// queue is full, e.g. while a sink is unreachable
+func (e *Exporter) Record(event Event) {
+	select {
+	case e.queue <- event:
+	default:
+		eventsTotal.WithLabelValues(ResultDropped).Inc()
+	}
+}
+
+// Start delivers the queued events until the context is cancelled
+func (e *Exporter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(e.flushInterval)
+	defer ticker.Stop()
+
+	var pending []Event
+	for {
+		select {
+		case <-ctx.Done():
+			// Deliver what was admitted before shutdown, within a bounded time
+			flushCtx, cancel := context.WithTimeout(context.Background(), e.flushInterval)
+			e.flush(flushCtx, pending)
+			cancel()
+			return nil
+		case event := <-e.queue:
+			pending = append(pending, event)
+			if len(pending) < e.batchSize {
+				continue
+			}
+		case <-ticker.C:
+		}
+		e.flush(ctx, pending)
+		pending = nil
+	}
+}
+
+// NeedLeaderElection is false: every replica serves admission requests and exports its events
+func (e *Exporter) NeedLeaderElection() bool {
+	return false
+}
+
+// flush delivers the events grouped by project
+func (e *Exporter) flush(ctx context.Context, events []Event) {
+	log := logf.FromContext(ctx).WithName("audit")
+
+	batches := map[types.NamespacedName][]Event{}
+	var order []types.NamespacedName
+	for _, event := range events {
+		key := types.NamespacedName{Namespace: event.Namespace, Name: event.Project}
+		if event.ProjectNamespace != "" {
+			key.Namespace = event.ProjectNamespace
+		}
+		if _, ok := batches[key]; !ok {
+			order = append(order, key)
+		}
+		batches[key] = append(batches[key], event)
+	}
+
+	for _, key := range order {
+		batch := batches[key]
+		result, err := e.deliver(ctx, key, batch)
+		if err != nil {
+			log.Error(err, "Failed to export audit events", "project", key, "events", len(batch))
+		}
+		eventsTotal.WithLabelValues(result).Add(float64(len(batch)))
+	}
+}
+
+// deliver sends a batch to the sink of the project and returns the metric result
+func (e *Exporter) deliver(ctx context.Context, key types.NamespacedName, events []Event) (string, error) {
+	project := &servingv1alpha1.KalypsoProject{}
+	if err := e.client.Get(ctx, key, project); err != nil {
+		return ResultFailed, client.IgnoreNotFound(err)
+	}
+	if project.Spec.Audit == nil {
+		return ResultSkipped, nil
+	}
+
+	var secret map[string][]byte
+	if name := secretName(project.Spec.Audit.Sink); name != "" {
+		data, err := e.readSecret(ctx, types.NamespacedName{Namespace: key.Namespace, Name: name})
+		if err != nil {
+			return ResultFailed, err
+		}
+		secret = data
+	}
+	sink, err := e.newSink(project.Spec.Audit.Sink, secret)
+	if err != nil {
+		return ResultFailed, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = sink.Write(ctx, key.Name, events)
+		if err == nil {
+			return ResultExported, nil
+		}
+		if attempt == maxAttempts || ctx.Err() != nil {
+			return ResultFailed, err
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Duration(attempt) * time.Second):
+		}
+	}
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+var _ = Describe("Audit export", func() {
+	ctx := context.Background()
+
+	event := func(id, name string) Event {
+		return Event{
+			ID:        id,
+			Time:      time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC),
+			Operation: "UPDATE",
+			Kind:      "KalypsoTritonServer",
+			Namespace: "kalypso-system",
+			Name:      name,
+			Project:   "sample-project",
+			User:      User{Username: "alice@example.com", Groups: []string{"ml-platform"}},
+		}
+	}
+
+	Context("When delivering to an HTTP sink", func() {
+		It("should post the events as JSON Lines with the bearer token", func() {
+			var authorization string
+			var events []Event
+			sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorization = r.Header.Get("Authorization")
+				scanner := bufio.NewScanner(r.Body)
+				for scanner.Scan() {
+					var e Event
+					Expect(json.Unmarshal(scanner.Bytes(), &e)).To(Succeed())
+					events = append(events, e)
+				}
+			}))
+			defer sink.Close()
+
+			s, err := NewSink(servingv1alpha1.AuditSinkSpec{HTTP: &servingv1alpha1.AuditHTTPSink{URL: sink.URL}}, map[string][]byte{TokenKey: []byte("s3cr3t")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s.Write(ctx, "sample-project", []Event{event("uid-1", "recommendation-v1"), event("uid-2", "recommendation-v2")})).To(Succeed())
+
+			Expect(authorization).To(Equal("Bearer s3cr3t"))
+			Expect(events).To(HaveLen(2))
+			Expect(events[1].Name).To(Equal("recommendation-v2"))
+			Expect(events[0].User.Username).To(Equal("alice@example.com"))
+		})
+	})
+
+	Context("When delivering to a Kafka REST Proxy", func() {
+		It("should key the records by project", func() {
+			var path, contentType string
+			var body struct {
+				Records []kafkaRecord `json:"records"`
+			}
+			proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				contentType = r.Header.Get("Content-Type")
+				Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+			}))
+			defer proxy.Close()
+
+			s := &KafkaSink{Client: proxy.Client(), URL: proxy.URL, Topic: "kalypso-audit"}
+			Expect(s.Write(ctx, "sample-project", []Event{event("uid-1", "recommendation-v1")})).To(Succeed())
+
+			Expect(path).To(Equal("/topics/kalypso-audit"))
+			Expect(contentType).To(Equal("application/vnd.kafka.json.v2+json"))
+			Expect(body.Records).To(HaveLen(1))
+			Expect(body.Records[0].Key).To(Equal("sample-project"))
+			Expect(body.Records[0].Value.ID).To(Equal("uid-1"))
+		})
+	})
+
+	Context("When delivering to S3", func() {
+		It("should put a signed object partitioned by project and day", func() {
+			var req *http.Request
+			var body []byte
+			storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				req = r
+				body, _ = io.ReadAll(r.Body)
+			}))
+			defer storage.Close()
+
+			s := &S3Sink{
+				Client:          storage.Client(),
+				Endpoint:        storage.URL,
+				Bucket:          "audit",
+				Prefix:          "kalypso",
+				Region:          "eu-west-1",
+				AccessKeyID:     "AKIDEXAMPLE",
+				SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+				now:             func() time.Time { return time.Date(2025, time.March, 1, 12, 0, 5, 0, time.UTC) },
+			}
+			Expect(s.Write(ctx, "sample-project", []Event{event("uid-1", "recommendation-v1")})).To(Succeed())
+
+			Expect(req.Method).To(Equal(http.MethodPut))
+			Expect(req.URL.Path).To(Equal("/audit/kalypso/sample-project/2025/03/01/20250301T120005Z-uid-1.jsonl"))
+			Expect(req.Header.Get("x-amz-content-sha256")).To(Equal(sha256Hex(body)))
+			Expect(req.Header.Get("Authorization")).To(HavePrefix(
+				"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20250301/eu-west-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature="))
+			Expect(strings.Count(string(body), "\n")).To(Equal(1))
+		})
+
+		It("should require the access keys", func() {
+			_, err := NewSink(servingv1alpha1.AuditSinkSpec{S3: &servingv1alpha1.AuditS3Sink{Bucket: "audit", Region: "eu-west-1", SecretRef: "audit-s3"}}, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When exporting queued events", func() {
+		It("should deliver each project's events to its sink and skip projects without one", func() {
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			audited := &servingv1alpha1.KalypsoProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoProjectSpec{
+					Audit: &servingv1alpha1.AuditSpec{Sink: servingv1alpha1.AuditSinkSpec{
+						HTTP: &servingv1alpha1.AuditHTTPSink{URL: "https://siem.example.com", SecretRef: "siem-token"},
+					}},
+				},
+			}
+			unaudited := &servingv1alpha1.KalypsoProject{ObjectMeta: metav1.ObjectMeta{Name: "other-project", Namespace: "kalypso-system"}}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "siem-token", Namespace: "kalypso-system"},
+				Data:       map[string][]byte{TokenKey: []byte("s3cr3t")},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(audited, unaudited, secret).Build()
+
+			sink := &recordingSink{}
+			exporter := NewExporter(c, c)
+			exporter.newSink = func(spec servingv1alpha1.AuditSinkSpec, data map[string][]byte) (Sink, error) {
+				Expect(spec.HTTP.URL).To(Equal("https://siem.example.com"))
+				Expect(string(data[TokenKey])).To(Equal("s3cr3t"))
+				return sink, nil
+			}
+
+			other := event("uid-3", "other-app")
+			other.Project = "other-project"
+			exporter.flush(ctx, []Event{event("uid-1", "recommendation-v1"), other, event("uid-2", "recommendation-v2")})
+
+			Expect(sink.projects).To(Equal([]string{"sample-project"}))
+			Expect(sink.events).To(HaveLen(2))
+		})
+
+		It("should drop events when the queue is full", func() {
+			exporter := NewExporter(nil, nil)
+			exporter.queue = make(chan Event, 1)
+
+			exporter.Record(event("uid-1", "recommendation-v1"))
+			exporter.Record(event("uid-2", "recommendation-v2"))
+
+			Expect(exporter.queue).To(HaveLen(1))
+		})
+	})
+
+	Context("When resolving the project of a resource", func() {
+		It("should follow the application of a server", func() {
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system"},
+				Spec:       servingv1alpha1.KalypsoApplicationSpec{ProjectRef: "sample-project"},
+			}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system"},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: app.Name},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build()
+
+			project := types.NamespacedName{Namespace: "kalypso-system", Name: "sample-project"}
+			Expect(ProjectOf(ctx, c, server)).To(Equal(project))
+			Expect(ProjectOf(ctx, c, app)).To(Equal(project))
+
+			By("resolving a project of another namespace")
+			app.Namespace = "proj-dev"
+			app.Spec.ProjectRef = "kalypso-system/sample-project"
+			Expect(ProjectOf(ctx, c, app)).To(Equal(project))
+			Expect(ProjectOf(ctx, c, &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "orphan", Namespace: "kalypso-system"},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: "missing"},
+			})).Error().To(HaveOccurred())
+		})
+	})
+})
+
+// recordingSink records the delivered batches
+type recordingSink struct {
+	projects []string
+	events   []Event
+}
+
+func (s *recordingSink) Write(_ context.Context, project string, events []Event) error {
+	s.projects = append(s.projects, project)
+	s.events = append(s.events, events...)
+	return nil
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Results of audit events, reported in the metrics
+const (
+	ResultExported = "exported"
+	ResultFailed   = "failed"
+	ResultDropped  = "dropped"
+	ResultSkipped  = "skipped"
+)
+
+// eventsTotal counts the audit events by delivery result. Skipped events belong to projects
+// without an audit sink
+var eventsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "kalypso_audit_events_total",
+	Help: "Number of Kalypso resource audit events, by delivery result.",
+}, []string{"result"})
+
+func init() {
+	metrics.Registry.MustRegister(eventsTotal)
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+// Secret keys of the sink credentials
+const (
+	TokenKey           = "token"
+	AccessKeyIDKey     = "AWS_ACCESS_KEY_ID"
+	SecretAccessKeyKey = "AWS_SECRET_ACCESS_KEY"
+)
+
+// Sink delivers a batch of audit events of a project
+type Sink interface {
+	Write(ctx context.Context, project string, events []Event) error
+}
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
+
+// NewSink creates the sink described by spec, authenticated with the Secret data
+func NewSink(spec servingv1alpha1.AuditSinkSpec, secret map[string][]byte) (Sink, error) {
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	switch {
+	case spec.HTTP != nil:
+		return &HTTPSink{Client: httpClient, URL: spec.HTTP.URL, Token: string(secret[TokenKey])}, nil
+	case spec.Kafka != nil:
+		return &KafkaSink{Client: httpClient, URL: spec.Kafka.RESTProxyURL, Topic: spec.Kafka.Topic, Token: string(secret[TokenKey])}, nil
+	case spec.S3 != nil:
+		if len(secret[AccessKeyIDKey]) == 0 || len(secret[SecretAccessKeyKey]) == 0 {
+			return nil, fmt.Errorf("secret %s must set %s and %s", spec.S3.SecretRef, AccessKeyIDKey, SecretAccessKeyKey)
+		}
+		endpoint := spec.S3.Endpoint
+		if endpoint == "" {
+			endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", spec.S3.Region)
+		}
+		return &S3Sink{
+			Client:          httpClient,
+			Endpoint:        endpoint,
+			Bucket:          spec.S3.Bucket,
+			Prefix:          spec.S3.Prefix,
+			Region:          spec.S3.Region,
+			AccessKeyID:     string(secret[AccessKeyIDKey]),
+			SecretAccessKey: string(secret[SecretAccessKeyKey]),
+		}, nil
+	default:
+		return nil, fmt.Errorf("no audit sink configured")
+	}
+}
+
+// secretName returns the credentials Secret of the sink, if any
+func secretName(spec servingv1alpha1.AuditSinkSpec) string {
+	switch {
+	case spec.HTTP != nil:
+		return spec.HTTP.SecretRef
+	case spec.Kafka != nil:
+		return spec.Kafka.SecretRef
+	case spec.S3 != nil:
+		return spec.S3.SecretRef
+	}
+	return ""
+}
+
+// readSecret returns the data of a sink credentials Secret
+func (e *Exporter) readSecret(ctx context.Context, key types.NamespacedName) (map[string][]byte, error) {
+	secret := &corev1.Secret{}
+	if err := e.secrets.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("failed to read audit sink secret %s: %w", key.Name, err)
+	}
+	return secret.Data, nil
+}
+
+// encodeLines encodes the events as JSON Lines
+func encodeLines(events []Event) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for i := range events {
+		if err := encoder.Encode(&events[i]); err != nil {
+			return nil, fmt.Errorf("failed to encode audit event: %w", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// do sends the request and reports non-2xx responses as errors
+func do(c *http.Client, req *http.Request) error {
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("audit sink %s responded with status %d: %s", req.URL.Host, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// HTTPSink posts the events as JSON Lines
+type HTTPSink struct {
+	Client *http.Client
+	URL    string
+	Token  string
+}
+
+// Write posts the batch in a single request
+func (s *HTTPSink) Write(ctx context.Context, _ string, events []Event) error {
+	body, err := encodeLines(events)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+	return do(s.Client, req)
+}
+
+// KafkaSink produces the events through the v2 API of a Kafka REST Proxy, keyed by project
+// so the events of a project stay ordered within a partition
+type KafkaSink struct {
+	Client *http.Client
+	URL    string
+	Topic  string
+	Token  string
+}
+
+// kafkaRecord is a record of the REST Proxy JSON embedded format
+type kafkaRecord struct {
+	Key   string `json:"key"`
+	Value Event  `json:"value"`
+}
+
+// Write produces the batch in a single request
+func (s *KafkaSink) Write(ctx context.Context, project string, events []Event) error {
+	records := make([]kafkaRecord, 0, len(events))
+	for _, event := range events {
+		records = append(records, kafkaRecord{Key: project, Value: event})
+	}
+	body, err := json.Marshal(map[string][]kafkaRecord{"records": records})
+	if err != nil {
+		return fmt.Errorf("failed to encode audit events: %w", err)
+	}
+
+	endpoint := strings.TrimSuffix(s.URL, "/") + "/topics/" + url.PathEscape(s.Topic)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+	return do(s.Client, req)
+}
+
+// S3Sink writes each batch as a JSON Lines object under <prefix>/<project>/<date>/, with a
+// path-style PUT signed with AWS Signature Version 4
+type S3Sink struct {
+	Client          *http.Client
+	Endpoint        string
+	Bucket          string
+	Prefix          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// now returns the signing time; time.Now when nil
+	now func() time.Time
+}
+
+// Write uploads the batch as a single object
+func (s *S3Sink) Write(ctx context.Context, project string, events []Event) error {
+	body, err := encodeLines(events)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	if s.now != nil {
+		now = s.now().UTC()
+	}
+	key := s.objectKey(project, now, events[0].ID)
+	endpoint, err := url.Parse(strings.TrimSuffix(s.Endpoint, "/") + "/" + s.Bucket + "/" + key)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	s.sign(req, body, now)
+	return do(s.Client, req)
+}
+
+// objectKey builds a key partitioned by project and day. Keys only contain URI-safe characters,
+// so the path needs no encoding in the signature
+func (s *S3Sink) objectKey(project string, now time.Time, firstID string) string {
+	return path.Join(s.Prefix, project, now.Format("2006/01/02"), now.Format("20060102T150405Z")+"-"+firstID+".jsonl")
+}
+
+// sign adds the AWS Signature Version 4 headers
+func (s *S3Sink) sign(req *http.Request, body []byte, now time.Time) {
+	payloadHash := sha256Hex(body)
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + s.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.SecretAccessKey), day)
+	for _, part := range []string{s.Region, "s3", "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+// BulkOperationAnnotation requests an operation on every KalypsoTritonServer of the annotated
+// KalypsoApplication or KalypsoProject: suspend, resume, or scale=N. The operation is kept
+// applied until the annotation is removed
+const BulkOperationAnnotation = "serving.kalypso.io/bulk-operation"
+
+// bulkOperation is a parsed bulk operation annotation
+type bulkOperation struct {
+	value    string
+	suspend  bool
+	replicas *int32
+}
+
+// parseBulkOperation parses the bulk operation annotation value
+func parseBulkOperation(value string) (*bulkOperation, error) {
+	op := &bulkOperation{value: value}
+	switch {
+	case value == "suspend":
+		op.suspend = true
+	case value == "resume":
+	case strings.HasPrefix(value, "scale="):
+		replicas, err := strconv.ParseInt(strings.TrimPrefix(value, "scale="), 10, 32)
+		if err != nil || replicas < 0 {
+			return nil, fmt.Errorf("invalid replica count in %q", value)
+		}
+		count := int32(replicas)
+		op.replicas = &count
+	default:
+		return nil, fmt.Errorf("unknown bulk operation %q: expected suspend, resume, or scale=N", value)
+	}
+	return op, nil
+}
+
+// applyBulkOperation updates every server to the requested state and reports the progress of each.
+// It returns nil when no operation is requested on the owner
+func applyBulkOperation(ctx context.Context, c client.Client, owner client.Object, servers []servingv1alpha1.KalypsoTritonServer) *servingv1alpha1.BulkOperationStatus {
+	value, ok := owner.GetAnnotations()[BulkOperationAnnotation]
+	if !ok {
+		return nil
+	}
+
+	status := &servingv1alpha1.BulkOperationStatus{Operation: value}
+	op, err := parseBulkOperation(value)
+	if err != nil {
+		status.Message = err.Error()
+		return status
+	}
+
+	sort.Slice(servers, func(i, j int) bool {
+		if servers[i].Namespace != servers[j].Namespace {
+			return servers[i].Namespace < servers[j].Namespace
+		}
+		return servers[i].Name < servers[j].Name
+	})
+
+	for i := range servers {
+		server := &servers[i]
+		serverStatus := servingv1alpha1.BulkOperationServerStatus{Namespace: server.Namespace, Name: server.Name}
+
+		if err := op.apply(ctx, c, server); err != nil {
+			serverStatus.State = servingv1alpha1.BulkServerStateFailed
+			serverStatus.Message = err.Error()
+		} else if want, done := op.reached(server); done {
+			serverStatus.State = servingv1alpha1.BulkServerStateCompleted
+			status.Completed++
+		} else {
+			serverStatus.State = servingv1alpha1.BulkServerStatePending
+			serverStatus.Message = fmt.Sprintf("%d of %d replicas available", server.Status.AvailableReplicas, want)
+		}
+		status.Servers = append(status.Servers, serverStatus)
+	}
+	status.Total = int32(len(servers))
+	return status
+}
+
+// apply patches the server spec when it differs from the requested state
+func (op *bulkOperation) apply(ctx context.Context, c client.Client, server *servingv1alpha1.KalypsoTritonServer) error {
+	patch := client.MergeFrom(server.DeepCopy())
+	changed := false
+	if server.Spec.Suspend != op.suspend {
+		server.Spec.Suspend = op.suspend
+		changed = true
+	}
+	if op.replicas != nil && (server.Spec.Replicas == nil || *server.Spec.Replicas != *op.replicas) {
+		replicas := *op.replicas
+		server.Spec.Replicas = &replicas
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return c.Patch(ctx, server, patch)
+}
+
+// reached returns the expected available replicas of the server and whether it has them
+func (op *bulkOperation) reached(server *servingv1alpha1.KalypsoTritonServer) (int32, bool) {
+	want := int32(1)
+	if server.Spec.Replicas != nil {
+		want = *server.Spec.Replicas
+	}
+	if server.Spec.Suspend {
+		want = 0
+	}
+	// The observed availability is stale until the server controller handles the updated spec
+	if server.Generation != server.Status.AppliedGeneration {
+		return want, false
+	}
+	return want, server.Status.AvailableReplicas == want
+}
+
+// bulkOperationPending reports whether some servers have not reached the requested state yet
+func bulkOperationPending(status *servingv1alpha1.BulkOperationStatus) bool {
+	return status != nil && status.Message == "" && status.Completed < status.Total
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	resourcev1 "k8s.io/api/resource/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewCacheOptions returns the manager cache configuration for large fleets.
+//
+// Child resources are only cached when they carry the managed-by label, so the
+// operator does not mirror every Deployment and Service in the cluster, and
+// managed fields are stripped from all cached objects. Namespaces are cached
+// unfiltered because KalypsoProject adopts pre-existing namespaces that do not
+// carry the label yet, and so are ResourceQuotas and LimitRanges, which
+// namespace admins create and the quota checks must see. Secrets are watched
+// unfiltered to follow rotated credentials, but only the managed ones keep
+// their data in the cache; the others are read through the API reader.
+// Initial lists are chunked by the client-go pager whenever the API server
+// serves them from etcd.
+func NewCacheOptions() cache.Options {
+	managedBy := cache.ByObject{
+		Label: labels.SelectorFromSet(labels.Set{ManagedByLabelKey: ManagedByLabelValue}),
+	}
+
+	return cache.Options{
+		DefaultTransform: cache.TransformStripManagedFields(),
+		ByObject: map[client.Object]cache.ByObject{
+			&appsv1.Deployment{}:                managedBy,
+			&appsv1.StatefulSet{}:               managedBy,
+			&corev1.Service{}:                   managedBy,
+			&corev1.Pod{}:                       managedBy,
+			&corev1.ServiceAccount{}:            managedBy,
+			&policyv1.PodDisruptionBudget{}:     managedBy,
+			&monitoringv1.ServiceMonitor{}:      managedBy,
+			&corev1.ConfigMap{}:                 managedBy,
+			&resourcev1.ResourceClaimTemplate{}: managedBy,
+			&corev1.Secret{}:                    {Transform: stripUnmanagedSecretData},
+		},
+	}
+}
+
+// stripUnmanagedSecretData drops the data of the Secrets the operator does not manage before they
+// are cached, along with the managed fields of every Secret
+func stripUnmanagedSecretData(in any) (any, error) {
+	if secret, ok := in.(*corev1.Secret); ok && secret.Labels[ManagedByLabelKey] != ManagedByLabelValue {
+		secret.Data = nil
+		secret.StringData = nil
+	}
+	return cache.TransformStripManagedFields()(in)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// newCachedClient returns a client reading through c like the manager cache configured by
+// NewCacheOptions: objects filtered out by its label selectors are not found and not listed.
+// Writes go to c
+func newCachedClient(c client.WithWatch) client.WithWatch {
+	selectors := make(map[reflect.Type]labels.Selector)
+	for obj, byObject := range NewCacheOptions().ByObject {
+		if byObject.Label != nil {
+			selectors[reflect.TypeOf(obj)] = byObject.Label
+		}
+	}
+	cached := func(obj runtime.Object) bool {
+		selector, ok := selectors[reflect.TypeOf(obj)]
+		if !ok {
+			return true
+		}
+		accessor, err := meta.Accessor(obj)
+		return err == nil && selector.Matches(labels.Set(accessor.GetLabels()))
+	}
+
+	return interceptor.NewClient(c, interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if err := c.Get(ctx, key, obj, opts...); err != nil {
+				return err
+			}
+			if !cached(obj) {
+				return errors.NewNotFound(schema.GroupResource{}, key.Name)
+			}
+			return nil
+		},
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if err := c.List(ctx, list, opts...); err != nil {
+				return err
+			}
+			items, err := meta.ExtractList(list)
+			if err != nil {
+				return err
+			}
+			kept := make([]runtime.Object, 0, len(items))
+			for _, item := range items {
+				if cached(item) {
+					kept = append(kept, item)
+				}
+			}
+			return meta.SetList(list, kept)
+		},
+	})
+}
+
+var _ = Describe("Manager cache", func() {
+	It("should only hold the ServiceAccounts created by the operator", func() {
+		ctx := context.Background()
+		managed := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+			Name: "recommendation-v1-sa", Namespace: "sample-project-dev",
+			Labels: map[string]string{ManagedByLabelKey: ManagedByLabelValue},
+		}}
+		defaultAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "sample-project-dev"}}
+		c := newCachedClient(fake.NewClientBuilder().WithObjects(managed, defaultAccount).Build())
+
+		serviceAccounts := &corev1.ServiceAccountList{}
+		Expect(c.List(ctx, serviceAccounts, client.InNamespace("sample-project-dev"))).To(Succeed())
+		Expect(serviceAccounts.Items).To(ConsistOf(HaveField("Name", managed.Name)))
+		Expect(errors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(defaultAccount), &corev1.ServiceAccount{}))).To(BeTrue())
+	})
+
+	It("should hold every ResourceQuota and LimitRange of a namespace", func() {
+		ctx := context.Background()
+		quota := &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: "namespace-quota", Namespace: "sample-project-dev"}}
+		limits := &corev1.LimitRange{ObjectMeta: metav1.ObjectMeta{Name: "namespace-limits", Namespace: "sample-project-dev"}}
+		c := newCachedClient(fake.NewClientBuilder().WithObjects(quota, limits).Build())
+
+		quotas := &corev1.ResourceQuotaList{}
+		Expect(c.List(ctx, quotas, client.InNamespace("sample-project-dev"))).To(Succeed())
+		Expect(quotas.Items).To(ConsistOf(HaveField("Name", quota.Name)))
+		limitRanges := &corev1.LimitRangeList{}
+		Expect(c.List(ctx, limitRanges, client.InNamespace("sample-project-dev"))).To(Succeed())
+		Expect(limitRanges.Items).To(ConsistOf(HaveField("Name", limits.Name)))
+	})
+
+	It("should only keep the data of the Secrets created by the operator", func() {
+		managed := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "recommendation-v1-pull", Namespace: "sample-project-dev",
+				Labels: map[string]string{ManagedByLabelKey: ManagedByLabelValue},
+			},
+			Data: map[string][]byte{".dockerconfigjson": []byte("{}")},
+		}
+		unmanaged := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "model-store-credentials", Namespace: "sample-project-dev"},
+			Data:       map[string][]byte{"AWS_SECRET_ACCESS_KEY": []byte("secret")},
+		}
+
+		out, err := stripUnmanagedSecretData(managed.DeepCopy())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.(*corev1.Secret).Data).To(Equal(managed.Data))
+		out, err = stripUnmanagedSecretData(unmanaged.DeepCopy())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.(*corev1.Secret).Data).To(BeNil())
+		Expect(out.(*corev1.Secret).Name).To(Equal(unmanaged.Name))
+	})
+})
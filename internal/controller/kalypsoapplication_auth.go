@@ -0,0 +1,172 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+var (
+	// requestAuthenticationGVK is the Istio RequestAuthentication kind
+	requestAuthenticationGVK = schema.GroupVersionKind{Group: "security.istio.io", Version: "v1", Kind: "RequestAuthentication"}
+	// authorizationPolicyGVK is the Istio AuthorizationPolicy kind
+	authorizationPolicyGVK = schema.GroupVersionKind{Group: "security.istio.io", Version: "v1", Kind: "AuthorizationPolicy"}
+)
+
+// reconcileAuth ensures the RequestAuthentication validating the JWTs of the requests to the
+// application's Triton pods, and the AuthorizationPolicy rejecting requests without one.
+// Both are removed when authentication is not configured
+func (r *KalypsoApplicationReconciler) reconcileAuth(ctx context.Context, app *servingv1alpha1.KalypsoApplication) error {
+	enabled := app.Spec.Routing != nil && app.Spec.Routing.Auth != nil
+	children := []struct {
+		gvk   schema.GroupVersionKind
+		build func(*servingv1alpha1.KalypsoApplication) map[string]interface{}
+	}{
+		{requestAuthenticationGVK, buildRequestAuthenticationSpec},
+		{authorizationPolicyGVK, buildAuthorizationPolicySpec},
+	}
+
+	for _, child := range children {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(child.gvk)
+		obj.SetName(naming.Auth(app.Name))
+		obj.SetNamespace(app.Namespace)
+
+		if !enabled {
+			if err := r.deleteApplicationChild(ctx, app, obj); err != nil {
+				return err
+			}
+			continue
+		}
+
+		_, err := controllerutil.CreateOrUpdate(ctx, r.Client, obj, func() error {
+			labels := obj.GetLabels()
+			if labels == nil {
+				labels = make(map[string]string)
+			}
+			labels[ApplicationLabelKey] = app.Name
+			labels[ManagedByLabelKey] = ManagedByLabelValue
+			obj.SetLabels(labels)
+
+			if err := unstructured.SetNestedMap(obj.Object, child.build(app), "spec"); err != nil {
+				return err
+			}
+
+			// Set owner reference
+			return controllerutil.SetControllerReference(app, obj, r.Scheme)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applicationSelector selects the Triton pods of the application
+func applicationSelector(app *servingv1alpha1.KalypsoApplication) map[string]interface{} {
+	return map[string]interface{}{
+		"matchLabels": map[string]interface{}{
+			ApplicationLabelKey: app.Name,
+		},
+	}
+}
+
+// buildRequestAuthenticationSpec builds the RequestAuthentication spec validating the tokens
+// of the configured issuer. Requests with an invalid token are rejected by the sidecar
+func buildRequestAuthenticationSpec(app *servingv1alpha1.KalypsoApplication) map[string]interface{} {
+	auth := app.Spec.Routing.Auth
+	rule := map[string]interface{}{
+		"issuer":  auth.Issuer,
+		"jwksUri": auth.JWKSURI,
+	}
+	if len(auth.Audiences) > 0 {
+		audiences := make([]interface{}, 0, len(auth.Audiences))
+		for _, audience := range auth.Audiences {
+			audiences = append(audiences, audience)
+		}
+		rule["audiences"] = audiences
+	}
+
+	return map[string]interface{}{
+		"selector": applicationSelector(app),
+		"jwtRules": []interface{}{rule},
+	}
+}
+
+// buildAuthorizationPolicySpec builds the AuthorizationPolicy spec allowing the requests
+// carrying a validated token, the public paths, and the Prometheus scrapes. Requests without
+// a token are denied, since a RequestAuthentication alone lets them through
+func buildAuthorizationPolicySpec(app *servingv1alpha1.KalypsoApplication) map[string]interface{} {
+	publicPaths := []interface{}{"/metrics"}
+	for _, path := range app.Spec.Routing.Auth.PublicPaths {
+		publicPaths = append(publicPaths, path)
+	}
+
+	return map[string]interface{}{
+		"selector": applicationSelector(app),
+		"action":   "ALLOW",
+		"rules": []interface{}{
+			map[string]interface{}{
+				"from": []interface{}{
+					map[string]interface{}{
+						"source": map[string]interface{}{"requestPrincipals": []interface{}{"*"}},
+					},
+				},
+			},
+			map[string]interface{}{
+				"to": []interface{}{
+					map[string]interface{}{
+						"operation": map[string]interface{}{"paths": publicPaths},
+					},
+				},
+			},
+		},
+	}
+}
+
+// applyAuthStatus records whether the inference endpoints require a token. Without
+// authentication configured the condition is removed
+func applyAuthStatus(app *servingv1alpha1.KalypsoApplication, err error) {
+	if app.Spec.Routing == nil || app.Spec.Routing.Auth == nil {
+		meta.RemoveStatusCondition(&app.Status.Conditions, "AuthReady")
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               "AuthReady",
+		Status:             metav1.ConditionTrue,
+		Reason:             "PoliciesApplied",
+		Message:            fmt.Sprintf("Requests to the application's servers require a token issued by %s", app.Spec.Routing.Auth.Issuer),
+		LastTransitionTime: metav1.Now(),
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "PoliciesFailed"
+		condition.Message = err.Error()
+	}
+	meta.SetStatusCondition(&app.Status.Conditions, condition)
+}
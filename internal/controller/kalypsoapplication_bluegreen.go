@@ -0,0 +1,246 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// PromoteAnnotation requests the swap of the active and preview servers of a KalypsoApplication.
+// The controller removes it once the request is processed
+const PromoteAnnotation = "serving.kalypso.io/promote"
+
+// reconcilePromotion swaps the active and preview servers when promotion is requested. The preview
+// server must serve at least one replica, so the swap never routes traffic to an empty Service.
+// It returns true when a request was processed
+func (r *KalypsoApplicationReconciler) reconcilePromotion(ctx context.Context, app *servingv1alpha1.KalypsoApplication) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	if _, ok := app.Annotations[PromoteAnnotation]; !ok {
+		return false, nil
+	}
+
+	condition := metav1.Condition{
+		Type:               "Promoted",
+		Status:             metav1.ConditionTrue,
+		Reason:             "PreviewPromoted",
+		LastTransitionTime: metav1.Now(),
+	}
+
+	blueGreen := app.Spec.BlueGreen
+	if blueGreen == nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "BlueGreenNotConfigured"
+		condition.Message = "spec.blueGreen is not set"
+	} else {
+		preview := &servingv1alpha1.KalypsoTritonServer{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: app.Namespace, Name: blueGreen.PreviewServer}, preview)
+		switch {
+		case err != nil && !errors.IsNotFound(err):
+			return false, err
+		case err != nil || !servesApplication(preview, app):
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "PreviewNotFound"
+			condition.Message = fmt.Sprintf("KalypsoTritonServer '%s' not found in the application", blueGreen.PreviewServer)
+		case preview.Status.AvailableReplicas == 0:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "PreviewNotAvailable"
+			condition.Message = fmt.Sprintf("KalypsoTritonServer '%s' has no available replicas", blueGreen.PreviewServer)
+		default:
+			condition.Message = fmt.Sprintf("KalypsoTritonServer '%s' is active, '%s' is the preview", blueGreen.PreviewServer, blueGreen.ActiveServer)
+			blueGreen.ActiveServer, blueGreen.PreviewServer = blueGreen.PreviewServer, blueGreen.ActiveServer
+		}
+	}
+
+	// The swap and the annotation removal are a single update
+	delete(app.Annotations, PromoteAnnotation)
+	if err := r.Update(ctx, app); err != nil {
+		return false, err
+	}
+
+	meta.SetStatusCondition(&app.Status.Conditions, condition)
+	if condition.Status == metav1.ConditionTrue {
+		now := metav1.Now()
+		app.Status.LastPromotionTime = &now
+	}
+	log.Info("Processed promotion request", "application", app.Name, "reason", condition.Reason)
+	return true, r.Status().Update(ctx, app)
+}
+
+// reconcileBlueGreen ensures the Services selecting the active and preview servers, and removes
+// them when blue/green routing is not configured
+func (r *KalypsoApplicationReconciler) reconcileBlueGreen(ctx context.Context, app *servingv1alpha1.KalypsoApplication) error {
+	services := []struct {
+		name   string
+		server string
+	}{
+		{naming.ActiveService(app.Name), ""},
+		{naming.PreviewService(app.Name), ""},
+	}
+	if app.Spec.BlueGreen != nil {
+		services[0].server = app.Spec.BlueGreen.ActiveServer
+		services[1].server = app.Spec.BlueGreen.PreviewServer
+	}
+
+	for _, svc := range services {
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      svc.name,
+				Namespace: app.Namespace,
+			},
+		}
+		if app.Spec.BlueGreen == nil {
+			if err := r.deleteApplicationChild(ctx, app, service); err != nil {
+				return err
+			}
+			continue
+		}
+
+		server := &servingv1alpha1.KalypsoTritonServer{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: app.Namespace, Name: svc.server}, server); err != nil {
+			if errors.IsNotFound(err) {
+				return fmt.Errorf("KalypsoTritonServer '%s' not found", svc.server)
+			}
+			return err
+		}
+		if !servesApplication(server, app) {
+			return fmt.Errorf("KalypsoTritonServer '%s' does not belong to the application", svc.server)
+		}
+
+		_, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+			if service.Labels == nil {
+				service.Labels = make(map[string]string)
+			}
+			service.Labels[ApplicationLabelKey] = app.Name
+			service.Labels[ManagedByLabelKey] = ManagedByLabelValue
+
+			// Target ports by name, so the servers may listen on different ports
+			service.Spec.Selector = map[string]string{TritonServerLabelKey: svc.server}
+			service.Spec.Ports = []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       8000,
+					TargetPort: intstr.FromString("http"),
+					Protocol:   corev1.ProtocolTCP,
+				},
+				{
+					Name:       "grpc",
+					Port:       8001,
+					TargetPort: intstr.FromString("grpc"),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			}
+			service.Spec.Type = corev1.ServiceTypeClusterIP
+
+			// Set owner reference
+			return controllerutil.SetControllerReference(app, service, r.Scheme)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileBlueGreenRoute ensures the VirtualService routing the custom domains on the application
+// gateway to the active Service, and removes it when there is nothing to route
+func (r *KalypsoApplicationReconciler) reconcileBlueGreenRoute(ctx context.Context, app *servingv1alpha1.KalypsoApplication) error {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(virtualServiceGVK)
+	route.SetName(naming.BlueGreenRoute(app.Name))
+	route.SetNamespace(app.Namespace)
+
+	if app.Spec.BlueGreen == nil || app.Spec.Routing == nil || len(app.Spec.Routing.CustomDomains) == 0 {
+		return r.deleteApplicationChild(ctx, app, route)
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, route, func() error {
+		labels := route.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[ApplicationLabelKey] = app.Name
+		labels[ManagedByLabelKey] = ManagedByLabelValue
+		route.SetLabels(labels)
+
+		hosts := make([]interface{}, 0, len(app.Spec.Routing.CustomDomains))
+		for _, domain := range app.Spec.Routing.CustomDomains {
+			hosts = append(hosts, domain)
+		}
+		routes := []interface{}{
+			map[string]interface{}{
+				"name": "active",
+				"route": []interface{}{
+					map[string]interface{}{
+						"destination": map[string]interface{}{
+							"host": fmt.Sprintf("%s.%s.svc.cluster.local", naming.ActiveService(app.Name), app.Namespace),
+							"port": map[string]interface{}{"number": int64(8000)},
+						},
+					},
+				},
+			},
+		}
+		applyIstioRequestPolicy(routes, app)
+		if err := unstructured.SetNestedMap(route.Object, map[string]interface{}{
+			"hosts":    hosts,
+			"gateways": []interface{}{naming.Gateway(app.Name)},
+			"http":     routes,
+		}, "spec"); err != nil {
+			return err
+		}
+
+		// Set owner reference
+		return controllerutil.SetControllerReference(app, route, r.Scheme)
+	})
+	return err
+}
+
+// applyBlueGreenStatus records whether the active and preview Services select their servers
+func applyBlueGreenStatus(app *servingv1alpha1.KalypsoApplication, err error) {
+	if app.Spec.BlueGreen == nil {
+		meta.RemoveStatusCondition(&app.Status.Conditions, "BlueGreenReady")
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               "BlueGreenReady",
+		Status:             metav1.ConditionTrue,
+		Reason:             "ServicesReady",
+		Message:            fmt.Sprintf("Active server '%s', preview server '%s'", app.Spec.BlueGreen.ActiveServer, app.Spec.BlueGreen.PreviewServer),
+		LastTransitionTime: metav1.Now(),
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ServicesFailed"
+		condition.Message = err.Error()
+	}
+	meta.SetStatusCondition(&app.Status.Conditions, condition)
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// reconcileApplicationCertificate ensures the cert-manager Certificate of the application and
+// reports whether it is issued. The Certificate is removed when it is no longer requested
+func (r *KalypsoApplicationReconciler) reconcileApplicationCertificate(ctx context.Context, app *servingv1alpha1.KalypsoApplication, servers []servingv1alpha1.KalypsoTritonServer) (bool, error) {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certificateGVK)
+	certificate.SetName(naming.ApplicationCertificate(app.Name))
+	certificate.SetNamespace(app.Namespace)
+
+	if app.Spec.Certificate == nil {
+		return false, r.deleteApplicationChild(ctx, app, certificate)
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, certificate, func() error {
+		labels := certificate.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[ApplicationLabelKey] = app.Name
+		labels[ManagedByLabelKey] = ManagedByLabelValue
+		certificate.SetLabels(labels)
+
+		if err := unstructured.SetNestedMap(certificate.Object, buildApplicationCertificateSpec(app, servers), "spec"); err != nil {
+			return err
+		}
+
+		// Set owner reference
+		return controllerutil.SetControllerReference(app, certificate, r.Scheme)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(certificate.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == "Ready" && condition["status"] == "True" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// buildApplicationCertificateSpec builds the Certificate spec covering every name of the
+// Services of the servers in the application namespace and the additional names. The secret carries the application label so
+// the TritonServer controller restarts the servers when it is renewed
+func buildApplicationCertificateSpec(app *servingv1alpha1.KalypsoApplication, servers []servingv1alpha1.KalypsoTritonServer) map[string]interface{} {
+	spec := app.Spec.Certificate
+
+	names := make(map[string]bool)
+	for _, name := range spec.DNSNames {
+		names[name] = true
+	}
+	for i := range servers {
+		if servers[i].Namespace != app.Namespace {
+			continue
+		}
+		service := naming.Service(servers[i].Name)
+		for _, name := range []string{
+			service,
+			fmt.Sprintf("%s.%s", service, app.Namespace),
+			fmt.Sprintf("%s.%s.svc", service, app.Namespace),
+			serviceHost(&servers[i]),
+		} {
+			names[name] = true
+		}
+	}
+	dnsNames := make([]string, 0, len(names))
+	for name := range names {
+		dnsNames = append(dnsNames, name)
+	}
+	sort.Strings(dnsNames)
+	dnsNameValues := make([]interface{}, 0, len(dnsNames))
+	for _, name := range dnsNames {
+		dnsNameValues = append(dnsNameValues, name)
+	}
+
+	issuerKind := "ClusterIssuer"
+	if spec.IssuerRef.Kind != "" {
+		issuerKind = spec.IssuerRef.Kind
+	}
+	duration := spec.Duration
+	if duration == "" {
+		duration = "2160h"
+	}
+	renewBefore := spec.RenewBefore
+	if renewBefore == "" {
+		renewBefore = "360h"
+	}
+
+	return map[string]interface{}{
+		"secretName": naming.ApplicationCertificate(app.Name),
+		"secretTemplate": map[string]interface{}{
+			"labels": map[string]interface{}{
+				ApplicationLabelKey: app.Name,
+				ManagedByLabelKey:   ManagedByLabelValue,
+			},
+		},
+		"dnsNames":    dnsNameValues,
+		"duration":    duration,
+		"renewBefore": renewBefore,
+		"issuerRef": map[string]interface{}{
+			"name":  spec.IssuerRef.Name,
+			"kind":  issuerKind,
+			"group": "cert-manager.io",
+		},
+	}
+}
+
+// applyCertificateStatus records whether the application certificate is issued
+func applyCertificateStatus(app *servingv1alpha1.KalypsoApplication, ready bool, err error) {
+	if app.Spec.Certificate == nil {
+		app.Status.CertificateSecret = ""
+		meta.RemoveStatusCondition(&app.Status.Conditions, "CertificateReady")
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               "CertificateReady",
+		Status:             metav1.ConditionTrue,
+		Reason:             "CertificateIssued",
+		Message:            fmt.Sprintf("Certificate %s is issued", naming.ApplicationCertificate(app.Name)),
+		LastTransitionTime: metav1.Now(),
+	}
+	switch {
+	case err != nil:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "CertificateFailed"
+		condition.Message = err.Error()
+	case !ready:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "CertificatePending"
+		condition.Message = fmt.Sprintf("Waiting for cert-manager to issue Certificate %s", naming.ApplicationCertificate(app.Name))
+	}
+	meta.SetStatusCondition(&app.Status.Conditions, condition)
+
+	app.Status.CertificateSecret = ""
+	if condition.Status == metav1.ConditionTrue {
+		app.Status.CertificateSecret = naming.ApplicationCertificate(app.Name)
+	}
+}
@@ -19,38 +19,57 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+	"github.com/kalypsoServing/KalypsoServing/internal/retraining"
 )
 
 const (
 	// ApplicationFinalizerName is the finalizer name for KalypsoApplication
 	ApplicationFinalizerName = "serving.kalypso.io/application-finalizer"
 	// ApplicationLabelKey is the label key for application identification
-	ApplicationLabelKey = "kalypso-serving.io/application"
+	ApplicationLabelKey = defaults.ApplicationLabelKey
 )
 
 // KalypsoApplicationReconciler reconciles a KalypsoApplication object
 type KalypsoApplicationReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// MetricsQuerier evaluates the traffic mirror comparison queries
+	MetricsQuerier retraining.Querier
 }
 
 // +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsoapplications,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsoapplications/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsoapplications/finalizers,verbs=update
 // +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsoprojects,verbs=get;list;watch
-// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsotritionservers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsotritonservers,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.istio.io,resources=gateways,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.istio.io,resources=destinationrules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;grpcroutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.istio.io,resources=envoyfilters,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=security.istio.io,resources=requestauthentications;authorizationpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=telemetry.istio.io,resources=telemetries,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -93,10 +112,7 @@ func (r *KalypsoApplicationReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	// Validate projectRef existence
 	project := &servingv1alpha1.KalypsoProject{}
-	projectKey := types.NamespacedName{
-		Name:      app.Spec.ProjectRef,
-		Namespace: app.Namespace,
-	}
+	projectKey := naming.Reference(app.Namespace, app.Spec.ProjectRef)
 	if err := r.Get(ctx, projectKey, project); err != nil {
 		if errors.IsNotFound(err) {
 			log.Error(err, "Referenced KalypsoProject not found", "projectRef", app.Spec.ProjectRef)
@@ -107,6 +123,13 @@ func (r *KalypsoApplicationReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, err
 	}
 
+	// A project of another namespace must allow the application namespace
+	if !defaults.ProjectAllowsNamespace(project, app.Namespace) {
+		log.Info("Referenced KalypsoProject does not allow the application namespace", "projectRef", app.Spec.ProjectRef)
+		r.setFailedStatus(ctx, app, fmt.Sprintf("KalypsoProject '%s' does not allow references from namespace %s", app.Spec.ProjectRef, app.Namespace))
+		return ctrl.Result{RequeueAfter: 30000000000}, nil // 30 seconds
+	}
+
 	// Verify project is ready
 	if project.Status.Phase != servingv1alpha1.ProjectPhaseReady {
 		log.Info("Referenced KalypsoProject is not ready yet", "projectRef", app.Spec.ProjectRef, "phase", project.Status.Phase)
@@ -130,6 +153,18 @@ func (r *KalypsoApplicationReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{RequeueAfter: 10000000000}, nil // 10 seconds
 	}
 
+	// Swap the active and preview servers when promotion is requested
+	promoted, err := r.reconcilePromotion(ctx, app)
+	if err != nil {
+		if errors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if promoted {
+		return ctrl.Result{Requeue: true}, nil
+	}
+
 	// Count active TritonServers for this application
 	activeModels, err := r.countActiveTritonServers(ctx, app)
 	if err != nil {
@@ -137,15 +172,117 @@ func (r *KalypsoApplicationReconciler) Reconcile(ctx context.Context, req ctrl.R
 		// Continue anyway, just log the error
 	}
 
+	// Reconcile custom domain certificates
+	routingResult := r.reconcileRouting(ctx, app)
+
+	// Route /<application>/<server>/ paths and the custom domains on the application gateway
+	var ingressErr error
+	servers, serversErr := r.listApplicationTritonServers(ctx, app)
+	if serversErr != nil {
+		ingressErr = serversErr
+	} else {
+		ingressErr = r.reconcileIngress(ctx, app, routingResult, servers)
+	}
+	if ingressErr != nil {
+		// Gateway routing failure is not fatal - just log warning
+		log.Info("Failed to reconcile gateway routes (Istio may not be installed)", "error", ingressErr)
+	}
+
+	// Reconcile circuit breaking and outlier ejection of the servers
+	if serversErr == nil {
+		if err := r.reconcileTrafficPolicy(ctx, app, servers); err != nil {
+			// DestinationRule failure is not fatal - just log warning
+			log.Info("Failed to reconcile traffic policy (Istio may not be installed)", "error", err)
+		}
+	}
+
+	// Request the application certificate covering the servers' Service names
+	certificateReady, certificateErr := false, serversErr
+	if serversErr == nil {
+		certificateReady, certificateErr = r.reconcileApplicationCertificate(ctx, app, servers)
+	}
+	if certificateErr != nil {
+		// Certificate failure is not fatal - just log warning
+		log.Info("Failed to reconcile application certificate (cert-manager may not be installed)", "error", certificateErr)
+	}
+
+	// Compare the servers of the traffic mirror
+	var mirrorComparison *servingv1alpha1.MirrorStatus
+	if serversErr == nil {
+		mirrorComparison = r.compareMirroredServers(ctx, app, servers, time.Now())
+	}
+
+	gatewayEndpoint, err := r.ingressGatewayEndpoint(ctx, app)
+	if err != nil {
+		log.Error(err, "Failed to look up the Istio ingress gateway")
+	}
+
+	// Reconcile API version tagging and deprecation headers (requires the Istio sidecar)
+	if err := r.reconcileAPIVersioning(ctx, app); err != nil {
+		// API versioning failure is not fatal - just log warning
+		log.Info("Failed to reconcile API versioning (Istio may not be installed)", "error", err)
+	}
+
+	// Reconcile JWT authentication of the inference endpoints (requires the Istio sidecar)
+	authErr := r.reconcileAuth(ctx, app)
+	if authErr != nil {
+		// Authentication failure is not fatal - just log warning
+		log.Info("Failed to reconcile authentication policies (Istio may not be installed)", "error", authErr)
+	}
+
+	// Reconcile the request rate limits (requires the Istio sidecar)
+	if err := r.reconcileRateLimit(ctx, app); err != nil {
+		// Rate limit failure is not fatal - just log warning
+		log.Info("Failed to reconcile rate limits (Istio may not be installed)", "error", err)
+	}
+
+	// Reconcile the request body size limit (requires the Istio sidecar)
+	if err := r.reconcileRequestSize(ctx, app); err != nil {
+		// Request size failure is not fatal - just log warning
+		log.Info("Failed to reconcile request size limit (Istio may not be installed)", "error", err)
+	}
+
+	// Point the active and preview Services at their servers
+	blueGreenErr := r.reconcileBlueGreen(ctx, app)
+	if blueGreenErr != nil {
+		log.Error(blueGreenErr, "Failed to reconcile blue/green Services")
+	}
+	if err := r.reconcileBlueGreenRoute(ctx, app); err != nil {
+		// Custom domain routing failure is not fatal - just log warning
+		log.Info("Failed to reconcile blue/green VirtualService (Istio may not be installed)", "error", err)
+	}
+
+	// Apply the bulk operation requested on the application to its servers
+	var bulkOperation *servingv1alpha1.BulkOperationStatus
+	if servers, err := r.listApplicationTritonServers(ctx, app); err != nil {
+		log.Error(err, "Failed to list TritonServers for the bulk operation")
+	} else {
+		bulkOperation = applyBulkOperation(ctx, r.Client, app, servers)
+	}
+
 	// Re-fetch the app to get the latest version before updating status
 	if err := r.Get(ctx, req.NamespacedName, app); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	applyRoutingStatus(ctx, app, routingResult)
+	app.Status.BulkOperation = bulkOperation
+	applyBlueGreenStatus(app, blueGreenErr)
+
 	// Update status to Ready
 	app.Status.Phase = servingv1alpha1.ApplicationPhaseReady
 	app.Status.ActiveModels = activeModels
-	app.Status.GatewayEndpoint = fmt.Sprintf("http://istio-gateway.istio-system.svc/%s", app.Name)
+	app.Status.GatewayEndpoint = ""
+	if ingressErr == nil {
+		app.Status.GatewayEndpoint = gatewayEndpoint
+	}
+	applyIngressStatus(app, ingressErr)
+	applyAuthStatus(app, authErr)
+	applyCertificateStatus(app, certificateReady, certificateErr)
+	if serversErr == nil {
+		applyMirrorStatus(app, servers, mirrorComparison)
+		app.Status.ModelRoutes = modelRoutes(servers)
+	}
 
 	meta.SetStatusCondition(&app.Status.Conditions, metav1.Condition{
 		Type:               "ProjectReady",
@@ -176,6 +313,18 @@ func (r *KalypsoApplicationReconciler) Reconcile(ctx context.Context, req ctrl.R
 		"project", app.Spec.ProjectRef,
 		"activeModels", activeModels)
 
+	if bulkOperationPending(bulkOperation) {
+		// Follow the servers until they reach the requested state
+		return ctrl.Result{RequeueAfter: 10000000000}, nil // 10 seconds
+	}
+	if routingResult.pending() || (app.Spec.Certificate != nil && !certificateReady) {
+		// Re-check until certificates are issued and routing errors are resolved
+		return ctrl.Result{RequeueAfter: 30000000000}, nil // 30 seconds
+	}
+	if mirrorComparison != nil {
+		// Refresh the comparison once per window
+		return ctrl.Result{RequeueAfter: mirrorInterval(app)}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -183,7 +332,11 @@ func (r *KalypsoApplicationReconciler) Reconcile(ctx context.Context, req ctrl.R
 func (r *KalypsoApplicationReconciler) reconcileDelete(ctx context.Context, app *servingv1alpha1.KalypsoApplication) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
-	// TODO: Add cleanup logic for Istio Gateway resources if needed
+	// The Gateway is garbage collected via OwnerReferences; custom domain
+	// certificates live in the ingress namespace and are removed explicitly
+	if err := r.pruneDomainCertificates(ctx, app, nil); err != nil && !meta.IsNoMatchError(err) {
+		return ctrl.Result{}, err
+	}
 
 	// Remove finalizer
 	controllerutil.RemoveFinalizer(app, ApplicationFinalizerName)
@@ -200,18 +353,23 @@ func (r *KalypsoApplicationReconciler) reconcileDelete(ctx context.Context, app
 
 // countActiveTritonServers counts the number of TritonServers belonging to this application
 func (r *KalypsoApplicationReconciler) countActiveTritonServers(ctx context.Context, app *servingv1alpha1.KalypsoApplication) (int, error) {
-	tritonServers := &servingv1alpha1.KalypsoTritonServerList{}
-	if err := r.List(ctx, tritonServers, client.InNamespace(app.Namespace)); err != nil {
-		return 0, err
-	}
+	servers, err := r.listApplicationTritonServers(ctx, app)
+	return len(servers), err
+}
 
-	count := 0
-	for _, server := range tritonServers.Items {
-		if server.Spec.ApplicationRef == app.Name {
-			count++
-		}
+// listApplicationTritonServers lists the TritonServers belonging to this application, including
+// those of the namespaces its project allows
+func (r *KalypsoApplicationReconciler) listApplicationTritonServers(ctx context.Context, app *servingv1alpha1.KalypsoApplication) ([]servingv1alpha1.KalypsoTritonServer, error) {
+	return listApplicationServers(ctx, r.Client, app)
+}
+
+// applicationForTritonServer maps a KalypsoTritonServer to its application
+func (r *KalypsoApplicationReconciler) applicationForTritonServer(ctx context.Context, obj client.Object) []reconcile.Request {
+	server, ok := obj.(*servingv1alpha1.KalypsoTritonServer)
+	if !ok {
+		return nil
 	}
-	return count, nil
+	return []reconcile.Request{{NamespacedName: serverApplicationKey(server)}}
 }
 
 // setFailedStatus updates the application status to Failed
@@ -231,6 +389,9 @@ func (r *KalypsoApplicationReconciler) setFailedStatus(ctx context.Context, app
 func (r *KalypsoApplicationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&servingv1alpha1.KalypsoApplication{}).
+		Owns(&corev1.Service{}).
+		Watches(&servingv1alpha1.KalypsoApplication{}, handler.EnqueueRequestsFromMapFunc(r.applicationsSharingProject)).
+		Watches(&servingv1alpha1.KalypsoTritonServer{}, handler.EnqueueRequestsFromMapFunc(r.applicationForTritonServer)).
 		Named("kalypsoapplication").
 		Complete(r)
 }
@@ -18,16 +18,27 @@ package controller
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
 )
 
 var _ = Describe("KalypsoApplication Controller", func() {
@@ -81,4 +92,881 @@ var _ = Describe("KalypsoApplication Controller", func() {
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
 	})
+	Context("When configuring custom domains", func() {
+		It("should request certificates only for domains not claimed by an older application", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			issuer := &servingv1alpha1.IssuerReference{Name: "letsencrypt", Kind: "ClusterIssuer"}
+			project := &servingv1alpha1.KalypsoProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "kalypso-system"},
+				Spec:       servingv1alpha1.KalypsoProjectSpec{AllowedNamespaces: []string{"search-dev"}},
+			}
+			otherNamespace := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "search-application",
+					Namespace:         "search-dev",
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+				},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ProjectRef: "kalypso-system/sample-project",
+					Routing:    &servingv1alpha1.RoutingSpec{CustomDomains: []string{"search.example.com"}, IssuerRef: issuer},
+				},
+			}
+			older := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "search-application",
+					Namespace:         "kalypso-system",
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+				},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ProjectRef: "sample-project",
+					Routing:    &servingv1alpha1.RoutingSpec{CustomDomains: []string{"api.example.com"}, IssuerRef: issuer},
+				},
+			}
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "recommendation-application",
+					Namespace:         "kalypso-system",
+					CreationTimestamp: metav1.Now(),
+				},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ProjectRef: "sample-project",
+					Routing: &servingv1alpha1.RoutingSpec{
+						CustomDomains: []string{"api.example.com", "ml.example.com", "search.example.com"},
+						IssuerRef:     issuer,
+					},
+				},
+			}
+			reconciler := &KalypsoApplicationReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(project, otherNamespace, older, app).Build(),
+				Scheme: scheme,
+			}
+
+			result := reconciler.reconcileRouting(ctx, app)
+
+			Expect(result.err).NotTo(HaveOccurred())
+			Expect(result.conflicts).To(ConsistOf(
+				"api.example.com (claimed by search-application)",
+				"search.example.com (claimed by search-dev/search-application)",
+			))
+			Expect(reconciler.applicationsSharingProject(ctx, app)).To(ConsistOf(
+				reconcile.Request{NamespacedName: client.ObjectKeyFromObject(older)},
+				reconcile.Request{NamespacedName: client.ObjectKeyFromObject(otherNamespace)},
+			))
+			Expect(result.domains).To(HaveLen(1))
+			Expect(result.domains[0].Host).To(Equal("ml.example.com"))
+			Expect(result.pending()).To(BeTrue(), "certificate is not issued yet")
+
+			certificate := &unstructured.Unstructured{}
+			certificate.SetGroupVersionKind(certificateGVK)
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: "istio-system", Name: result.domains[0].SecretName}, certificate)).To(Succeed())
+			dnsNames, _, _ := unstructured.NestedStringSlice(certificate.Object, "spec", "dnsNames")
+			Expect(dnsNames).To(ConsistOf("ml.example.com"))
+
+			Expect(reconciler.reconcileIngress(ctx, app, result, nil)).To(Succeed())
+			gateway := &unstructured.Unstructured{}
+			gateway.SetGroupVersionKind(istioGatewayGVK)
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: "recommendation-application-gateway"}, gateway)).To(Succeed())
+			servers, _, _ := unstructured.NestedSlice(gateway.Object, "spec", "servers")
+			Expect(servers).To(HaveLen(2), "plain HTTP and the unclaimed domain")
+		})
+	})
+
+	Context("When routing the gateway to the servers", func() {
+		It("should route a path per server and report the ingress gateway address", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system"},
+				Spec:       servingv1alpha1.KalypsoApplicationSpec{ProjectRef: "sample-project"},
+			}
+			servers := []servingv1alpha1.KalypsoTritonServer{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v2", Namespace: app.Namespace},
+					Spec: servingv1alpha1.KalypsoTritonServerSpec{
+						ApplicationRef: app.Name,
+						Networking:     &servingv1alpha1.NetworkingSpec{HTTPPort: ptrTo(int32(9000))},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: app.Namespace},
+					Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: app.Name, State: servingv1alpha1.ServerStateArchived},
+				},
+			}
+			ingress := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "istio-ingressgateway", Namespace: "istio-system", Labels: map[string]string{"istio": IngressGatewaySelectorValue}},
+				Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+					Ingress: []corev1.LoadBalancerIngress{{IP: "203.0.113.10"}},
+				}},
+			}
+			reconciler := &KalypsoApplicationReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(app, ingress).Build(),
+				Scheme: scheme,
+			}
+
+			Expect(reconciler.reconcileIngress(ctx, app, nil, servers)).To(Succeed())
+
+			route := &unstructured.Unstructured{}
+			route.SetGroupVersionKind(virtualServiceGVK)
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: "recommendation-application-routes"}, route)).To(Succeed())
+			routes, _, _ := unstructured.NestedSlice(route.Object, "spec", "http")
+			Expect(routes).To(HaveLen(1), "archived servers are not routed")
+			Expect(routes[0]).To(HaveKeyWithValue("match", []interface{}{
+				map[string]interface{}{"uri": map[string]interface{}{"prefix": "/recommendation-application/recommendation-v2/"}},
+			}))
+			Expect(routes[0]).To(HaveKeyWithValue("route", []interface{}{
+				map[string]interface{}{"destination": map[string]interface{}{
+					"host": "recommendation-v2-svc.kalypso-system.svc.cluster.local",
+					"port": map[string]interface{}{"number": int64(9000)},
+				}},
+			}))
+
+			endpoint, err := reconciler.ingressGatewayEndpoint(ctx, app)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(endpoint).To(Equal("http://203.0.113.10/recommendation-application/"))
+		})
+	})
+
+	Context("When routing requests by model name", func() {
+		It("should route each model to the first server serving it", func() {
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system"},
+				Spec:       servingv1alpha1.KalypsoApplicationSpec{ProjectRef: "sample-project"},
+			}
+			servers := []servingv1alpha1.KalypsoTritonServer{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v2", Namespace: app.Namespace},
+					Status:     servingv1alpha1.KalypsoTritonServerStatus{Models: []string{"ranker", "embedder"}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: app.Namespace},
+					Status:     servingv1alpha1.KalypsoTritonServerStatus{Models: []string{"ranker"}},
+				},
+			}
+
+			Expect(modelRoutes(servers)).To(Equal([]servingv1alpha1.ModelRoute{
+				{Model: "embedder", Server: "recommendation-v2"},
+				{Model: "ranker", Server: "recommendation-v1"},
+			}))
+
+			routes, _, _ := unstructured.NestedSlice(buildIngressRouteSpec(app, servers), "http")
+			Expect(routes).To(HaveLen(4))
+			Expect(routes[1]).To(HaveKeyWithValue("match", []interface{}{
+				map[string]interface{}{"uri": map[string]interface{}{"prefix": "/recommendation-application/v2/models/ranker/"}},
+			}))
+			Expect(routes[1]).To(HaveKeyWithValue("rewrite", map[string]interface{}{"uri": "/v2/models/ranker/"}))
+			Expect(routes[1]).To(HaveKeyWithValue("route", ContainElement(HaveKeyWithValue("destination",
+				HaveKeyWithValue("host", "recommendation-v1-svc.kalypso-system.svc.cluster.local")))))
+
+			app.Spec.Routing = &servingv1alpha1.RoutingSpec{
+				GatewayAPI: &servingv1alpha1.GatewayAPIRoutingSpec{GatewayRef: servingv1alpha1.GatewayReference{Name: "shared-gateway"}},
+			}
+			rules, _, _ := unstructured.NestedSlice(buildHTTPRouteSpec(app, nil, servers), "rules")
+			Expect(rules).To(HaveLen(4))
+			Expect(rules[0]).To(HaveKeyWithValue("backendRefs", []interface{}{
+				map[string]interface{}{"name": "recommendation-v2-svc", "port": int64(8000)},
+			}))
+		})
+	})
+
+	Context("When routing to servers inheriting the application networking", func() {
+		It("should use the ports of the application server defaults", func() {
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ProjectRef: "sample-project",
+					ServerDefaults: &servingv1alpha1.ServerDefaultsSpec{
+						Networking: &servingv1alpha1.NetworkingSpec{HTTPPort: ptrTo(int32(9000)), GrpcPort: ptrTo(int32(9001))},
+					},
+				},
+			}
+			servers := []servingv1alpha1.KalypsoTritonServer{
+				{ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: app.Namespace}},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v2", Namespace: app.Namespace},
+					Spec: servingv1alpha1.KalypsoTritonServerSpec{
+						Networking: &servingv1alpha1.NetworkingSpec{HTTPPort: ptrTo(int32(8080))},
+					},
+				},
+			}
+
+			routes, _, _ := unstructured.NestedSlice(buildIngressRouteSpec(app, servers), "http")
+			Expect(routes).To(HaveLen(2))
+			Expect(routes[0]).To(HaveKeyWithValue("route", ContainElement(HaveKeyWithValue("destination",
+				HaveKeyWithValue("port", map[string]interface{}{"number": int64(9000)})))))
+			Expect(routes[1]).To(HaveKeyWithValue("route", ContainElement(HaveKeyWithValue("destination",
+				HaveKeyWithValue("port", map[string]interface{}{"number": int64(8080)})))))
+
+			app.Spec.Routing = &servingv1alpha1.RoutingSpec{
+				GatewayAPI: &servingv1alpha1.GatewayAPIRoutingSpec{GatewayRef: servingv1alpha1.GatewayReference{Name: "shared-gateway"}},
+			}
+			rules, _, _ := unstructured.NestedSlice(buildGRPCRouteSpec(app, nil, servers), "rules")
+			Expect(rules[0]).To(HaveKeyWithValue("backendRefs", []interface{}{
+				map[string]interface{}{"name": "recommendation-v1-svc", "port": int64(9001)},
+			}))
+			Expect(tritonServiceEndpoint(&servers[0], app)).To(Equal("http://recommendation-v1-svc.kalypso-system.svc:9000"))
+		})
+	})
+
+	Context("When mirroring traffic to a candidate server", func() {
+		It("should mirror the source route and compare both servers", func() {
+			ctx := context.Background()
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ProjectRef: "sample-project",
+					Mirror: &servingv1alpha1.MirrorSpec{
+						SourceServer:  "recommendation-v1",
+						TargetServer:  "recommendation-v2",
+						Percent:       25,
+						PrometheusURL: "http://prometheus.monitoring:9090",
+					},
+				},
+			}
+			servers := []servingv1alpha1.KalypsoTritonServer{
+				{ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: app.Namespace}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v2", Namespace: app.Namespace}},
+			}
+
+			routes, _, _ := unstructured.NestedSlice(buildIngressRouteSpec(app, servers), "http")
+			Expect(routes).To(HaveLen(2))
+			Expect(routes[0]).To(HaveKeyWithValue("mirror", map[string]interface{}{
+				"host": "recommendation-v2-svc.kalypso-system.svc.cluster.local",
+				"port": map[string]interface{}{"number": int64(8000)},
+			}))
+			Expect(routes[0]).To(HaveKeyWithValue("mirrorPercentage", map[string]interface{}{"value": float64(25)}))
+			Expect(routes[1]).NotTo(HaveKey("mirror"))
+
+			app.Spec.Routing = &servingv1alpha1.RoutingSpec{
+				GatewayAPI: &servingv1alpha1.GatewayAPIRoutingSpec{GatewayRef: servingv1alpha1.GatewayReference{Name: "shared-gateway"}},
+			}
+			rules, _, _ := unstructured.NestedSlice(buildGRPCRouteSpec(app, nil, servers), "rules")
+			Expect(rules[0]).To(HaveKeyWithValue("filters", ContainElement(HaveKeyWithValue("requestMirror", map[string]interface{}{
+				"backendRef": map[string]interface{}{"name": "recommendation-v2-svc", "port": int64(8001)},
+				"percent":    int64(25),
+			}))))
+
+			reconciler := &KalypsoApplicationReconciler{
+				MetricsQuerier: &stubQuerier{values: map[string]float64{"istio_requests_total": 0.01}},
+			}
+			comparison := reconciler.compareMirroredServers(ctx, app, servers, time.Now())
+			Expect(comparison.SourceErrorRate).To(Equal("0.01"))
+			Expect(comparison.TargetErrorRate).To(Equal("0.01"))
+			Expect(comparison.TargetP99LatencyMs).To(BeEmpty(), "queries without samples are left empty")
+
+			applyMirrorStatus(app, servers, comparison)
+			Expect(meta.IsStatusConditionTrue(app.Status.Conditions, "Mirroring")).To(BeTrue())
+			applyMirrorStatus(app, servers[:1], nil)
+			Expect(meta.FindStatusCondition(app.Status.Conditions, "Mirroring").Reason).To(Equal("TargetNotRouted"))
+			Expect(app.Status.Mirror).To(Equal(comparison))
+		})
+	})
+
+	Context("When applying a traffic policy", func() {
+		It("should create a DestinationRule per routed server and remove stale ones", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system", UID: "app-uid"},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ProjectRef: "sample-project",
+					Routing: &servingv1alpha1.RoutingSpec{
+						TrafficPolicy: &servingv1alpha1.TrafficPolicySpec{
+							ConnectionPool:   &servingv1alpha1.ConnectionPoolSpec{MaxRequests: ptrTo(int32(64))},
+							OutlierDetection: &servingv1alpha1.OutlierDetectionSpec{Consecutive5xxErrors: 3, MaxEjectionPercent: 50},
+						},
+					},
+				},
+			}
+			servers := []servingv1alpha1.KalypsoTritonServer{
+				{ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: app.Namespace}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v2", Namespace: app.Namespace}},
+			}
+			reconciler := &KalypsoApplicationReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
+				Scheme: scheme,
+			}
+
+			Expect(reconciler.reconcileTrafficPolicy(ctx, app, servers)).To(Succeed())
+			rule := &unstructured.Unstructured{}
+			rule.SetGroupVersionKind(destinationRuleGVK)
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: "recommendation-v1-traffic"}, rule)).To(Succeed())
+			host, _, _ := unstructured.NestedString(rule.Object, "spec", "host")
+			Expect(host).To(Equal("recommendation-v1-svc.kalypso-system.svc.cluster.local"))
+			maxRequests, _, _ := unstructured.NestedInt64(rule.Object, "spec", "trafficPolicy", "connectionPool", "http", "http2MaxRequests")
+			Expect(maxRequests).To(Equal(int64(64)))
+			outlier, _, _ := unstructured.NestedMap(rule.Object, "spec", "trafficPolicy", "outlierDetection")
+			Expect(outlier).To(Equal(map[string]interface{}{
+				"consecutive5xxErrors": int64(3),
+				"interval":             "10s",
+				"baseEjectionTime":     "30s",
+				"maxEjectionPercent":   int64(50),
+			}))
+
+			Expect(reconciler.reconcileTrafficPolicy(ctx, app, servers[1:])).To(Succeed())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(rule), rule))).To(BeTrue())
+
+			app.Spec.Routing = nil
+			Expect(reconciler.reconcileTrafficPolicy(ctx, app, servers[1:])).To(Succeed())
+			rule.SetName("recommendation-v2-traffic")
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(rule), rule))).To(BeTrue())
+		})
+	})
+
+	Context("When servers of other namespaces reference the application", func() {
+		It("should only serve the application from the namespaces its project allows", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			project := &servingv1alpha1.KalypsoProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoProjectSpec{
+					Environments: map[string]servingv1alpha1.EnvironmentSpec{"dev": {Namespace: "proj-dev"}},
+				},
+			}
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ProjectRef: project.Name,
+					Routing: &servingv1alpha1.RoutingSpec{
+						GatewayAPI: &servingv1alpha1.GatewayAPIRoutingSpec{GatewayRef: servingv1alpha1.GatewayReference{Name: "shared-gateway"}},
+					},
+				},
+			}
+			server := func(namespace, name, ref string) *servingv1alpha1.KalypsoTritonServer {
+				return &servingv1alpha1.KalypsoTritonServer{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+					Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: ref},
+				}
+			}
+			local := server(app.Namespace, "recommendation-v1", app.Name)
+			environment := server("proj-dev", "recommendation-v2", "kalypso-system/recommendation-application")
+			denied := server("other-team", "recommendation-v3", "kalypso-system/recommendation-application")
+			namesake := server("proj-dev", "recommendation-v4", app.Name)
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(project, app, local, environment, denied, namesake).
+				WithStatusSubresource(&servingv1alpha1.KalypsoTritonServer{}).Build()
+
+			for _, s := range []*servingv1alpha1.KalypsoTritonServer{local, environment} {
+				resolved, err := defaults.ServerApplication(ctx, c, s)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(client.ObjectKeyFromObject(resolved)).To(Equal(client.ObjectKeyFromObject(app)))
+			}
+			_, err := defaults.ServerApplication(ctx, c, denied)
+			Expect(errors.IsNotFound(err)).To(BeTrue(), "an application whose project does not allow the namespace is not resolved")
+
+			servers, err := listApplicationServers(ctx, c, app)
+			Expect(err).NotTo(HaveOccurred())
+			keys := make([]string, 0, len(servers))
+			for _, s := range servers {
+				keys = append(keys, client.ObjectKeyFromObject(&s).String())
+			}
+			Expect(keys).To(ConsistOf("kalypso-system/recommendation-v1", "proj-dev/recommendation-v2"))
+			Expect((&KalypsoApplicationReconciler{}).applicationForTritonServer(ctx, environment)).To(Equal([]reconcile.Request{
+				{NamespacedName: client.ObjectKeyFromObject(app)},
+			}))
+
+			By("routing to the Service of the server namespace")
+			rules, _, _ := unstructured.NestedSlice(buildHTTPRouteSpec(app, nil, servers), "rules")
+			Expect(rules).To(HaveLen(2))
+			Expect(rules[1]).To(HaveKeyWithValue("backendRefs", []interface{}{
+				map[string]interface{}{"name": "recommendation-v2-svc", "namespace": "proj-dev", "port": int64(8000)},
+			}))
+
+			By("requiring servers of other namespaces to bring their own Secrets")
+			app.Spec.Certificate = &servingv1alpha1.ApplicationCertificateSpec{ServerTLS: true}
+			app.Spec.Storage = &servingv1alpha1.StorageSpec{SecretName: "aws-s3-credentials"}
+			app.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "nvcr-pull"}}
+			Expect(crossNamespaceSecretsMessage(local, app)).To(BeEmpty())
+			message := crossNamespaceSecretsMessage(environment, app)
+			Expect(message).To(ContainSubstring("storage Secrets 'aws-s3-credentials'"))
+			Expect(message).To(ContainSubstring("image pull Secrets 'nvcr-pull'"))
+			Expect(message).To(ContainSubstring("certificate Secret 'recommendation-application-cert'"))
+
+			own := environment.DeepCopy()
+			own.Spec.Storage = &servingv1alpha1.ServerStorageSpec{SecretName: "dev-s3-credentials"}
+			own.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "dev-pull"}}
+			own.Spec.Networking = &servingv1alpha1.NetworkingSpec{TLS: &servingv1alpha1.TLSSpec{SecretName: "dev-tls"}}
+			Expect(crossNamespaceSecretsMessage(own, app)).To(BeEmpty())
+
+			By("failing the server instead of deploying pods that cannot start")
+			Expect(c.Update(ctx, app)).To(Succeed())
+			environment.Finalizers = []string{TritonServerFinalizerName}
+			Expect(c.Update(ctx, environment)).To(Succeed())
+			environment.Status.Phase = servingv1alpha1.TritonServerPhasePending
+			Expect(c.Status().Update(ctx, environment)).To(Succeed())
+			reconciler := &KalypsoTritonServerReconciler{Client: c, Scheme: scheme}
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(environment)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(environment), environment)).To(Succeed())
+			Expect(environment.Status.Phase).To(Equal(servingv1alpha1.TritonServerPhaseFailed))
+			Expect(environment.Status.Message).To(ContainSubstring("image pull Secrets 'nvcr-pull'"))
+		})
+	})
+
+	Context("When routing through the Gateway API", func() {
+		It("should attach HTTP and gRPC routes to the referenced Gateway instead of the Istio gateway", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system", UID: "app-uid"},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ProjectRef: "sample-project",
+					Routing: &servingv1alpha1.RoutingSpec{
+						GatewayAPI: &servingv1alpha1.GatewayAPIRoutingSpec{
+							GatewayRef: servingv1alpha1.GatewayReference{Name: "shared", Namespace: "gateways", SectionName: "https"},
+						},
+					},
+				},
+			}
+			servers := []servingv1alpha1.KalypsoTritonServer{
+				{ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: app.Namespace}, Spec: servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: app.Name}},
+			}
+
+			istioGateway := &unstructured.Unstructured{}
+			istioGateway.SetGroupVersionKind(istioGatewayGVK)
+			istioGateway.SetName("recommendation-application-gateway")
+			istioGateway.SetNamespace(app.Namespace)
+			Expect(controllerutil.SetControllerReference(app, istioGateway, scheme)).To(Succeed())
+
+			gateway := &unstructured.Unstructured{}
+			gateway.SetGroupVersionKind(gatewayAPIGatewayGVK)
+			gateway.SetName("shared")
+			gateway.SetNamespace("gateways")
+			Expect(unstructured.SetNestedSlice(gateway.Object, []interface{}{
+				map[string]interface{}{"type": "Hostname", "value": "gateway.example.com"},
+			}, "status", "addresses")).To(Succeed())
+
+			reconciler := &KalypsoApplicationReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(app, istioGateway, gateway).Build(),
+				Scheme: scheme,
+			}
+
+			routing := &routingResult{domains: []servingv1alpha1.CustomDomainStatus{{Host: "recommend.example.com"}}}
+			Expect(reconciler.reconcileIngress(ctx, app, routing, servers)).To(Succeed())
+
+			err := reconciler.Get(ctx, client.ObjectKeyFromObject(istioGateway), istioGateway)
+			Expect(errors.IsNotFound(err)).To(BeTrue(), "the Istio gateway is replaced by the Gateway API routes")
+
+			httpRoute := &unstructured.Unstructured{}
+			httpRoute.SetGroupVersionKind(httpRouteGVK)
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: "recommendation-application-http"}, httpRoute)).To(Succeed())
+			parentRefs, _, _ := unstructured.NestedSlice(httpRoute.Object, "spec", "parentRefs")
+			Expect(parentRefs).To(ConsistOf(HaveKeyWithValue("sectionName", "https")))
+			hostnames, _, _ := unstructured.NestedStringSlice(httpRoute.Object, "spec", "hostnames")
+			Expect(hostnames).To(Equal([]string{"recommend.example.com"}))
+			rules, _, _ := unstructured.NestedSlice(httpRoute.Object, "spec", "rules")
+			Expect(rules).To(HaveLen(1))
+			Expect(rules[0]).To(HaveKeyWithValue("backendRefs", []interface{}{
+				map[string]interface{}{"name": "recommendation-v1-svc", "port": int64(8000)},
+			}))
+
+			grpcRoute := &unstructured.Unstructured{}
+			grpcRoute.SetGroupVersionKind(grpcRouteGVK)
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: "recommendation-application-grpc"}, grpcRoute)).To(Succeed())
+			rules, _, _ = unstructured.NestedSlice(grpcRoute.Object, "spec", "rules")
+			Expect(rules).To(HaveLen(1))
+			Expect(rules[0]).To(HaveKeyWithValue("backendRefs", []interface{}{
+				map[string]interface{}{"name": "recommendation-v1-svc", "port": int64(8001)},
+			}))
+
+			endpoint, err := reconciler.ingressGatewayEndpoint(ctx, app)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(endpoint).To(Equal("http://gateway.example.com/recommendation-application/"))
+		})
+	})
+	Context("When declaring API versions", func() {
+		It("should tag requests and add deprecation headers for deprecated versions", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			sunset := metav1.NewTime(time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC))
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system", UID: "app-uid"},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ProjectRef: "sample-project",
+					APIVersioning: &servingv1alpha1.APIVersioningSpec{
+						Versions: []servingv1alpha1.APIVersion{
+							{Name: "v1", Deprecated: true, Sunset: &sunset, Link: "https://docs.example.com/migrate-v2"},
+							{Name: "v2", PathPrefix: "/v2/models/"},
+						},
+					},
+				},
+			}
+			reconciler := &KalypsoApplicationReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
+				Scheme: scheme,
+			}
+
+			Expect(reconciler.reconcileAPIVersioning(ctx, app)).To(Succeed())
+
+			filter := &unstructured.Unstructured{}
+			filter.SetGroupVersionKind(envoyFilterGVK)
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: "recommendation-application-api-versions"}, filter)).To(Succeed())
+			patches, _, _ := unstructured.NestedSlice(filter.Object, "spec", "configPatches")
+			Expect(patches).To(HaveLen(1))
+
+			code := buildAPIVersioningLua(app.Spec.APIVersioning.Versions)
+			Expect(code).To(ContainSubstring(`{prefix = "/v1/", name = "v1", deprecated = true, sunset = "Sun, 01 Mar 2026 00:00:00 GMT", link = "<https://docs.example.com/migrate-v2>; rel=\"deprecation\""}`))
+			Expect(strings.Index(code, `"/v2/models/"`)).To(BeNumerically("<", strings.Index(code, `"/v1/"`)), "longer prefixes match first")
+
+			telemetry := &unstructured.Unstructured{}
+			telemetry.SetGroupVersionKind(telemetryGVK)
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(filter), telemetry)).To(Succeed())
+
+			app.Spec.APIVersioning = nil
+			Expect(reconciler.reconcileAPIVersioning(ctx, app)).To(Succeed())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(filter), filter))).To(BeTrue())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(telemetry), telemetry))).To(BeTrue())
+		})
+	})
+
+	Context("When limiting the request rate", func() {
+		It("should hold a token bucket per replica, client and model", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system", UID: "app-uid"},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ProjectRef: "sample-project",
+					Routing: &servingv1alpha1.RoutingSpec{
+						RateLimit: &servingv1alpha1.RateLimitSpec{
+							RequestsPerSecond: 200,
+							Clients:           []servingv1alpha1.NamedRateLimit{{Name: "batch-scorer", RequestsPerSecond: 20}},
+							Models:            []servingv1alpha1.NamedRateLimit{{Name: "ranker", RequestsPerSecond: 50}},
+						},
+					},
+				},
+			}
+			reconciler := &KalypsoApplicationReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
+				Scheme: scheme,
+			}
+
+			Expect(reconciler.reconcileRateLimit(ctx, app)).To(Succeed())
+			filter := &unstructured.Unstructured{}
+			filter.SetGroupVersionKind(envoyFilterGVK)
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: "recommendation-application-ratelimit"}, filter)).To(Succeed())
+			patches, _, _ := unstructured.NestedSlice(filter.Object, "spec", "configPatches")
+			Expect(patches).To(HaveLen(2))
+
+			config := buildLocalRateLimitConfig(app.Spec.Routing.RateLimit)
+			Expect(config["token_bucket"]).To(HaveKeyWithValue("max_tokens", int64(200)))
+			Expect(config["descriptors"]).To(ConsistOf(
+				HaveKeyWithValue("entries", []interface{}{map[string]interface{}{"key": rateLimitClientKey, "value": "batch-scorer"}}),
+				HaveKeyWithValue("entries", []interface{}{map[string]interface{}{"key": rateLimitModelKey, "value": "ranker"}}),
+			))
+
+			actions := buildRateLimitActions(app.Spec.Routing.RateLimit)
+			Expect(actions).To(HaveLen(2))
+			Expect(actions[0]).To(HaveKeyWithValue("actions", ContainElement(HaveKeyWithValue("request_headers", HaveKeyWithValue("header_name", DefaultRateLimitClientHeader)))))
+
+			app.Spec.Routing = nil
+			Expect(reconciler.reconcileRateLimit(ctx, app)).To(Succeed())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(filter), filter))).To(BeTrue())
+		})
+	})
+
+	Context("When serving browser clients", func() {
+		It("should render CORS, timeouts and the request size limit", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system", UID: "app-uid"},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ProjectRef: "sample-project",
+					Routing: &servingv1alpha1.RoutingSpec{
+						CORS: &servingv1alpha1.CORSSpec{
+							AllowOrigins: []string{"https://demo.example.com", "*"},
+							AllowHeaders: []string{"content-type"},
+						},
+						RequestTimeout:      "60s",
+						MaxRequestBodyBytes: ptrTo(int64(8 << 20)),
+					},
+				},
+			}
+			servers := []servingv1alpha1.KalypsoTritonServer{
+				{ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: app.Namespace}},
+			}
+
+			routes, _, _ := unstructured.NestedSlice(buildIngressRouteSpec(app, servers), "http")
+			Expect(routes[0]).To(HaveKeyWithValue("timeout", "60s"))
+			Expect(routes[0]).To(HaveKeyWithValue("corsPolicy", map[string]interface{}{
+				"allowOrigins": []interface{}{
+					map[string]interface{}{"exact": "https://demo.example.com"},
+					map[string]interface{}{"regex": ".*"},
+				},
+				"allowMethods":     []interface{}{"GET", "POST", "OPTIONS"},
+				"allowHeaders":     []interface{}{"content-type"},
+				"maxAge":           "86400s",
+				"allowCredentials": false,
+			}))
+
+			app.Spec.Routing.GatewayAPI = &servingv1alpha1.GatewayAPIRoutingSpec{GatewayRef: servingv1alpha1.GatewayReference{Name: "shared-gateway"}}
+			rules, _, _ := unstructured.NestedSlice(buildHTTPRouteSpec(app, nil, servers), "rules")
+			Expect(rules[0]).To(HaveKeyWithValue("timeouts", map[string]interface{}{"request": "60s"}))
+			Expect(rules[0]).To(HaveKeyWithValue("filters", ContainElement(HaveKeyWithValue("type", "CORS"))))
+
+			reconciler := &KalypsoApplicationReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
+				Scheme: scheme,
+			}
+			Expect(reconciler.reconcileRequestSize(ctx, app)).To(Succeed())
+			filter := &unstructured.Unstructured{}
+			filter.SetGroupVersionKind(envoyFilterGVK)
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: "recommendation-application-requestsize"}, filter)).To(Succeed())
+			patches, _, _ := unstructured.NestedSlice(filter.Object, "spec", "configPatches")
+			Expect(patches).To(ConsistOf(HaveKeyWithValue("patch", HaveKeyWithValue("value",
+				HaveKeyWithValue("typed_config", HaveKeyWithValue("max_request_bytes", int64(8<<20)))))))
+
+			app.Spec.Routing.MaxRequestBodyBytes = nil
+			Expect(reconciler.reconcileRequestSize(ctx, app)).To(Succeed())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(filter), filter))).To(BeTrue())
+		})
+	})
+
+	Context("When requiring authenticated callers", func() {
+		It("should validate tokens of the issuer and deny requests without one", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system", UID: "app-uid"},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ProjectRef: "sample-project",
+					Routing: &servingv1alpha1.RoutingSpec{
+						Auth: &servingv1alpha1.AuthSpec{
+							Issuer:      "https://auth.example.com",
+							JWKSURI:     "https://auth.example.com/.well-known/jwks.json",
+							Audiences:   []string{"inference"},
+							PublicPaths: []string{"/v2/health/ready"},
+						},
+					},
+				},
+			}
+			reconciler := &KalypsoApplicationReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
+				Scheme: scheme,
+			}
+
+			Expect(reconciler.reconcileAuth(ctx, app)).To(Succeed())
+
+			authentication := &unstructured.Unstructured{}
+			authentication.SetGroupVersionKind(requestAuthenticationGVK)
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: "recommendation-application-auth"}, authentication)).To(Succeed())
+			rules, _, _ := unstructured.NestedSlice(authentication.Object, "spec", "jwtRules")
+			Expect(rules).To(ConsistOf(map[string]interface{}{
+				"issuer":    "https://auth.example.com",
+				"jwksUri":   "https://auth.example.com/.well-known/jwks.json",
+				"audiences": []interface{}{"inference"},
+			}))
+
+			policy := &unstructured.Unstructured{}
+			policy.SetGroupVersionKind(authorizationPolicyGVK)
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(authentication), policy)).To(Succeed())
+			action, _, _ := unstructured.NestedString(policy.Object, "spec", "action")
+			Expect(action).To(Equal("ALLOW"))
+			Expect(buildAuthorizationPolicySpec(app)["rules"]).To(ContainElement(HaveKeyWithValue("to", ContainElement(
+				HaveKeyWithValue("operation", HaveKeyWithValue("paths", []interface{}{"/metrics", "/v2/health/ready"})),
+			))))
+
+			applyAuthStatus(app, nil)
+			Expect(meta.IsStatusConditionTrue(app.Status.Conditions, "AuthReady")).To(BeTrue())
+
+			app.Spec.Routing = nil
+			Expect(reconciler.reconcileAuth(ctx, app)).To(Succeed())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(authentication), authentication))).To(BeTrue())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(policy), policy))).To(BeTrue())
+			applyAuthStatus(app, nil)
+			Expect(meta.FindStatusCondition(app.Status.Conditions, "AuthReady")).To(BeNil())
+		})
+	})
+
+	Context("When requesting the application certificate", func() {
+		It("should cover the Service names of every server and report the issued secret", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system", UID: "app-uid"},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ProjectRef: "sample-project",
+					Certificate: &servingv1alpha1.ApplicationCertificateSpec{
+						IssuerRef: servingv1alpha1.IssuerReference{Name: "internal-ca", Kind: "Issuer"},
+						DNSNames:  []string{"recommend.internal.example.com"},
+						ServerTLS: true,
+					},
+				},
+			}
+			servers := []servingv1alpha1.KalypsoTritonServer{
+				{ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: app.Namespace}},
+			}
+			reconciler := &KalypsoApplicationReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
+				Scheme: scheme,
+			}
+
+			ready, err := reconciler.reconcileApplicationCertificate(ctx, app, servers)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ready).To(BeFalse())
+
+			certificate := &unstructured.Unstructured{}
+			certificate.SetGroupVersionKind(certificateGVK)
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: "recommendation-application-cert"}, certificate)).To(Succeed())
+			dnsNames, _, _ := unstructured.NestedStringSlice(certificate.Object, "spec", "dnsNames")
+			Expect(dnsNames).To(Equal([]string{
+				"recommend.internal.example.com",
+				"recommendation-v1-svc",
+				"recommendation-v1-svc.kalypso-system",
+				"recommendation-v1-svc.kalypso-system.svc",
+				"recommendation-v1-svc.kalypso-system.svc.cluster.local",
+			}))
+			secretLabels, _, _ := unstructured.NestedStringMap(certificate.Object, "spec", "secretTemplate", "labels")
+			Expect(secretLabels).To(HaveKeyWithValue(ApplicationLabelKey, app.Name))
+
+			applyCertificateStatus(app, ready, nil)
+			Expect(meta.FindStatusCondition(app.Status.Conditions, "CertificateReady").Reason).To(Equal("CertificatePending"))
+			Expect(app.Status.CertificateSecret).To(BeEmpty())
+			applyCertificateStatus(app, true, nil)
+			Expect(app.Status.CertificateSecret).To(Equal("recommendation-application-cert"))
+
+			app.Spec.Certificate = nil
+			_, err = reconciler.reconcileApplicationCertificate(ctx, app, servers)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(certificate), certificate))).To(BeTrue())
+		})
+	})
+
+	Context("When a bulk operation is requested", func() {
+		It("should scale every server and report their progress", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "recommendation-application",
+					Namespace:   "kalypso-system",
+					Annotations: map[string]string{BulkOperationAnnotation: "scale=2"},
+				},
+			}
+			scaled := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: app.Namespace, Generation: 2},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: app.Name, Replicas: ptrTo(int32(2))},
+				Status:     servingv1alpha1.KalypsoTritonServerStatus{AppliedGeneration: 2, AvailableReplicas: 2},
+			}
+			pending := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v2", Namespace: app.Namespace, Generation: 1},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: app.Name, Replicas: ptrTo(int32(1))},
+				Status:     servingv1alpha1.KalypsoTritonServerStatus{AppliedGeneration: 1, AvailableReplicas: 1},
+			}
+			reconciler := &KalypsoApplicationReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(app, scaled, pending).Build(),
+				Scheme: scheme,
+			}
+
+			servers, err := reconciler.listApplicationTritonServers(ctx, app)
+			Expect(err).NotTo(HaveOccurred())
+			status := applyBulkOperation(ctx, reconciler.Client, app, servers)
+
+			Expect(status.Total).To(Equal(int32(2)))
+			Expect(status.Completed).To(Equal(int32(1)))
+			Expect(status.Servers[0].State).To(Equal(servingv1alpha1.BulkServerStateCompleted))
+			Expect(status.Servers[1].State).To(Equal(servingv1alpha1.BulkServerStatePending))
+			Expect(bulkOperationPending(status)).To(BeTrue())
+
+			updated := &servingv1alpha1.KalypsoTritonServer{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(pending), updated)).To(Succeed())
+			Expect(*updated.Spec.Replicas).To(Equal(int32(2)))
+		})
+
+		It("should reject unknown operations", func() {
+			_, err := parseBulkOperation("restart")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When switching between blue and green servers", func() {
+		It("should flip the active Service only to an available preview server", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "recommendation-application",
+					Namespace:   "kalypso-system",
+					Annotations: map[string]string{PromoteAnnotation: "true"},
+				},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ProjectRef: "sample-project",
+					BlueGreen:  &servingv1alpha1.BlueGreenSpec{ActiveServer: "recommendation-blue", PreviewServer: "recommendation-green"},
+				},
+			}
+			blue := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-blue", Namespace: app.Namespace},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: app.Name},
+				Status:     servingv1alpha1.KalypsoTritonServerStatus{AvailableReplicas: 1},
+			}
+			green := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-green", Namespace: app.Namespace},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: app.Name},
+			}
+			reconciler := &KalypsoApplicationReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).
+					WithObjects(app, blue, green).
+					WithStatusSubresource(&servingv1alpha1.KalypsoApplication{}, &servingv1alpha1.KalypsoTritonServer{}).
+					Build(),
+				Scheme: scheme,
+			}
+
+			Expect(reconciler.reconcileBlueGreen(ctx, app)).To(Succeed())
+			active := &corev1.Service{}
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: "recommendation-application-active"}, active)).To(Succeed())
+			Expect(active.Spec.Selector).To(HaveKeyWithValue(TritonServerLabelKey, "recommendation-blue"))
+
+			By("refusing to promote a preview server without available replicas")
+			Expect(reconciler.reconcilePromotion(ctx, app)).To(BeTrue())
+			Expect(app.Annotations).NotTo(HaveKey(PromoteAnnotation))
+			Expect(app.Spec.BlueGreen.ActiveServer).To(Equal("recommendation-blue"))
+			Expect(meta.FindStatusCondition(app.Status.Conditions, "Promoted").Reason).To(Equal("PreviewNotAvailable"))
+
+			By("swapping the servers once the preview server is available")
+			green.Status.AvailableReplicas = 1
+			Expect(reconciler.Status().Update(ctx, green)).To(Succeed())
+			app.Annotations = map[string]string{PromoteAnnotation: "true"}
+			Expect(reconciler.Update(ctx, app)).To(Succeed())
+			Expect(reconciler.reconcilePromotion(ctx, app)).To(BeTrue())
+			Expect(app.Spec.BlueGreen.ActiveServer).To(Equal("recommendation-green"))
+			Expect(app.Spec.BlueGreen.PreviewServer).To(Equal("recommendation-blue"))
+			Expect(app.Status.LastPromotionTime).NotTo(BeNil())
+
+			Expect(reconciler.reconcileBlueGreen(ctx, app)).To(Succeed())
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(active), active)).To(Succeed())
+			Expect(active.Spec.Selector).To(HaveKeyWithValue(TritonServerLabelKey, "recommendation-green"))
+
+			app.Spec.BlueGreen = nil
+			Expect(reconciler.reconcileBlueGreen(ctx, app)).To(Succeed())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(active), active))).To(BeTrue())
+		})
+	})
 })
@@ -0,0 +1,250 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// ServerHeader selects the KalypsoTritonServer of a gRPC request on the Gateway API GRPCRoute,
+// since all Triton servers expose the same gRPC service
+const ServerHeader = "kalypso-server"
+
+var (
+	// gatewayAPIGatewayGVK is the Gateway API Gateway kind
+	gatewayAPIGatewayGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "Gateway"}
+	// httpRouteGVK is the Gateway API HTTPRoute kind
+	httpRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+	// grpcRouteGVK is the Gateway API GRPCRoute kind
+	grpcRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "GRPCRoute"}
+)
+
+// usesGatewayAPI reports whether the application is routed through a Gateway API Gateway
+func usesGatewayAPI(app *servingv1alpha1.KalypsoApplication) bool {
+	return app.Spec.Routing != nil && app.Spec.Routing.GatewayAPI != nil
+}
+
+// reconcileGatewayAPIRoutes ensures the HTTPRoute and GRPCRoute attaching the application's
+// servers to the referenced Gateway
+func (r *KalypsoApplicationReconciler) reconcileGatewayAPIRoutes(ctx context.Context, app *servingv1alpha1.KalypsoApplication, hostnames []string, servers []servingv1alpha1.KalypsoTritonServer) error {
+	routes := []struct {
+		gvk  schema.GroupVersionKind
+		name string
+		spec map[string]interface{}
+	}{
+		{httpRouteGVK, naming.HTTPRoute(app.Name), buildHTTPRouteSpec(app, hostnames, servers)},
+		{grpcRouteGVK, naming.GRPCRoute(app.Name), buildGRPCRouteSpec(app, hostnames, servers)},
+	}
+
+	for _, desired := range routes {
+		route := &unstructured.Unstructured{}
+		route.SetGroupVersionKind(desired.gvk)
+		route.SetName(desired.name)
+		route.SetNamespace(app.Namespace)
+
+		_, err := controllerutil.CreateOrUpdate(ctx, r.Client, route, func() error {
+			labels := route.GetLabels()
+			if labels == nil {
+				labels = make(map[string]string)
+			}
+			labels[ApplicationLabelKey] = app.Name
+			labels[ManagedByLabelKey] = ManagedByLabelValue
+			route.SetLabels(labels)
+
+			if err := unstructured.SetNestedMap(route.Object, desired.spec, "spec"); err != nil {
+				return err
+			}
+
+			// Set owner reference
+			return controllerutil.SetControllerReference(app, route, r.Scheme)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteGatewayAPIRoutes removes the HTTPRoute and GRPCRoute of an application routed
+// through the Istio gateway
+func (r *KalypsoApplicationReconciler) deleteGatewayAPIRoutes(ctx context.Context, app *servingv1alpha1.KalypsoApplication) error {
+	for gvk, name := range map[schema.GroupVersionKind]string{
+		httpRouteGVK: naming.HTTPRoute(app.Name),
+		grpcRouteGVK: naming.GRPCRoute(app.Name),
+	} {
+		route := &unstructured.Unstructured{}
+		route.SetGroupVersionKind(gvk)
+		route.SetName(name)
+		route.SetNamespace(app.Namespace)
+		if err := r.deleteApplicationChild(ctx, app, route); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildHTTPRouteSpec builds the HTTPRoute spec stripping the /<application>/<server> prefix
+// and forwarding requests to the HTTP port of the server's Service, plus the model routes.
+// gRPC requests carry the model name in their body, so the GRPCRoute has no model routes
+func buildHTTPRouteSpec(app *servingv1alpha1.KalypsoApplication, hostnames []string, servers []servingv1alpha1.KalypsoTritonServer) map[string]interface{} {
+	rules := buildModelHTTPRouteRules(app, servers)
+	for _, server := range routedServers(servers) {
+		filters := []interface{}{
+			map[string]interface{}{
+				"type": "URLRewrite",
+				"urlRewrite": map[string]interface{}{
+					"path": map[string]interface{}{"type": "ReplacePrefixMatch", "replacePrefixMatch": "/"},
+				},
+			},
+		}
+		if target := mirrorTarget(app, server, servers); target != nil {
+			filters = append(filters, gatewayAPIMirrorFilter(app, target, tritonHTTPPort(target, app)))
+		}
+		rules = append(rules, map[string]interface{}{
+			"matches": []interface{}{
+				map[string]interface{}{
+					"path": map[string]interface{}{
+						"type":  "PathPrefix",
+						"value": fmt.Sprintf("/%s/%s/", app.Name, server.Name),
+					},
+				},
+			},
+			"filters": filters,
+			"backendRefs": []interface{}{
+				gatewayAPIBackendRef(app, server, tritonHTTPPort(server, app)),
+			},
+		})
+	}
+	applyGatewayAPIRequestPolicy(rules, app)
+	return gatewayAPIRouteSpec(app, hostnames, rules)
+}
+
+// buildGRPCRouteSpec builds the GRPCRoute spec forwarding requests to the gRPC port of the
+// server named by the ServerHeader
+func buildGRPCRouteSpec(app *servingv1alpha1.KalypsoApplication, hostnames []string, servers []servingv1alpha1.KalypsoTritonServer) map[string]interface{} {
+	rules := make([]interface{}, 0, len(servers))
+	for _, server := range routedServers(servers) {
+		rule := map[string]interface{}{
+			"matches": []interface{}{
+				map[string]interface{}{
+					"headers": []interface{}{
+						map[string]interface{}{"type": "Exact", "name": ServerHeader, "value": server.Name},
+					},
+				},
+			},
+			"backendRefs": []interface{}{
+				gatewayAPIBackendRef(app, server, tritonGRPCPort(server, app)),
+			},
+		}
+		if target := mirrorTarget(app, server, servers); target != nil {
+			rule["filters"] = []interface{}{gatewayAPIMirrorFilter(app, target, tritonGRPCPort(target, app))}
+		}
+		rules = append(rules, rule)
+	}
+	return gatewayAPIRouteSpec(app, hostnames, rules)
+}
+
+// gatewayAPIRouteSpec assembles a route spec attached to the application's Gateway
+func gatewayAPIRouteSpec(app *servingv1alpha1.KalypsoApplication, hostnames []string, rules []interface{}) map[string]interface{} {
+	ref := app.Spec.Routing.GatewayAPI.GatewayRef
+	parentRef := map[string]interface{}{
+		"group": gatewayAPIGatewayGVK.Group,
+		"kind":  gatewayAPIGatewayGVK.Kind,
+		"name":  ref.Name,
+	}
+	if ref.Namespace != "" {
+		parentRef["namespace"] = ref.Namespace
+	}
+	if ref.SectionName != "" {
+		parentRef["sectionName"] = ref.SectionName
+	}
+
+	spec := map[string]interface{}{
+		"parentRefs": []interface{}{parentRef},
+		"rules":      rules,
+	}
+	if len(hostnames) > 0 {
+		hosts := make([]interface{}, 0, len(hostnames))
+		for _, host := range hostnames {
+			hosts = append(hosts, host)
+		}
+		spec["hostnames"] = hosts
+	}
+	return spec
+}
+
+// gatewayAPIBackendRef references the Service of the server on the port. The Service of a server
+// of another namespace than the application is only reachable once a ReferenceGrant of the
+// server namespace allows the routes of the application namespace
+func gatewayAPIBackendRef(app *servingv1alpha1.KalypsoApplication, server *servingv1alpha1.KalypsoTritonServer, port int32) map[string]interface{} {
+	ref := map[string]interface{}{"name": naming.Service(server.Name), "port": int64(port)}
+	if server.Namespace != app.Namespace {
+		ref["namespace"] = server.Namespace
+	}
+	return ref
+}
+
+// routedServers returns the servers served by the application routes, sorted by name.
+// Archived servers are left out, so their paths answer 404
+func routedServers(servers []servingv1alpha1.KalypsoTritonServer) []*servingv1alpha1.KalypsoTritonServer {
+	routed := make([]*servingv1alpha1.KalypsoTritonServer, 0, len(servers))
+	for i := range servers {
+		if !isArchived(&servers[i]) {
+			routed = append(routed, &servers[i])
+		}
+	}
+	sort.Slice(routed, func(i, j int) bool { return routed[i].Name < routed[j].Name })
+	return routed
+}
+
+// gatewayAPIEndpoint returns the URL of the application behind its Gateway API Gateway, from
+// the first address the Gateway reports. It is empty until the Gateway is programmed
+func (r *KalypsoApplicationReconciler) gatewayAPIEndpoint(ctx context.Context, app *servingv1alpha1.KalypsoApplication) (string, error) {
+	ref := app.Spec.Routing.GatewayAPI.GatewayRef
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = app.Namespace
+	}
+
+	gateway := &unstructured.Unstructured{}
+	gateway.SetGroupVersionKind(gatewayAPIGatewayGVK)
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, gateway); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+
+	addresses, _, _ := unstructured.NestedSlice(gateway.Object, "status", "addresses")
+	for _, address := range addresses {
+		entry, ok := address.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if value, _, _ := unstructured.NestedString(entry, "value"); value != "" {
+			return fmt.Sprintf("http://%s/%s/", value, app.Name), nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// reconcileIngress ensures the application Gateway and the VirtualService routing
+// /<application>/<server>/ paths on it to the Services of the application's servers.
+// Applications routed through the Gateway API get HTTPRoute and GRPCRoute objects instead
+func (r *KalypsoApplicationReconciler) reconcileIngress(ctx context.Context, app *servingv1alpha1.KalypsoApplication, routing *routingResult, servers []servingv1alpha1.KalypsoTritonServer) error {
+	var domains []servingv1alpha1.CustomDomainStatus
+	if routing != nil {
+		domains = routing.domains
+	}
+
+	if usesGatewayAPI(app) {
+		if err := r.deleteIstioIngress(ctx, app); err != nil {
+			return err
+		}
+		hostnames := make([]string, 0, len(domains))
+		for _, domain := range domains {
+			hostnames = append(hostnames, domain.Host)
+		}
+		return r.reconcileGatewayAPIRoutes(ctx, app, hostnames, servers)
+	}
+	if err := r.deleteGatewayAPIRoutes(ctx, app); err != nil {
+		return err
+	}
+
+	if err := r.reconcileGateway(ctx, app, domains); err != nil {
+		return err
+	}
+
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(virtualServiceGVK)
+	route.SetName(naming.Routes(app.Name))
+	route.SetNamespace(app.Namespace)
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, route, func() error {
+		labels := route.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[ApplicationLabelKey] = app.Name
+		labels[ManagedByLabelKey] = ManagedByLabelValue
+		route.SetLabels(labels)
+
+		if err := unstructured.SetNestedMap(route.Object, buildIngressRouteSpec(app, servers), "spec"); err != nil {
+			return err
+		}
+
+		// Set owner reference
+		return controllerutil.SetControllerReference(app, route, r.Scheme)
+	})
+	return err
+}
+
+// buildIngressRouteSpec builds the VirtualService spec stripping the /<application>/<server>
+// prefix from gateway requests and forwarding them to the server's Service. Requests under
+// /<application>/v2/models/<model>/ go to the server serving the model
+func buildIngressRouteSpec(app *servingv1alpha1.KalypsoApplication, servers []servingv1alpha1.KalypsoTritonServer) map[string]interface{} {
+	// Model routes come first, as they are more specific than the route of a server named v2
+	routes := buildModelVirtualServiceRoutes(app, servers)
+	for _, server := range routedServers(servers) {
+		route := map[string]interface{}{
+			"name": server.Name,
+			"match": []interface{}{
+				map[string]interface{}{
+					"uri": map[string]interface{}{"prefix": fmt.Sprintf("/%s/%s/", app.Name, server.Name)},
+				},
+			},
+			"rewrite": map[string]interface{}{"uri": "/"},
+			"route": []interface{}{
+				map[string]interface{}{
+					"destination": map[string]interface{}{
+						"host": serviceHost(server),
+						"port": map[string]interface{}{"number": int64(tritonHTTPPort(server, app))},
+					},
+				},
+			},
+		}
+		applyIstioMirror(route, app, mirrorTarget(app, server, servers))
+		routes = append(routes, route)
+	}
+
+	applyIstioRequestPolicy(routes, app)
+
+	return map[string]interface{}{
+		"hosts":    []interface{}{"*"},
+		"gateways": []interface{}{naming.Gateway(app.Name)},
+		"http":     routes,
+	}
+}
+
+// deleteIstioIngress removes the Istio Gateway and VirtualService of an application routed
+// through the Gateway API
+func (r *KalypsoApplicationReconciler) deleteIstioIngress(ctx context.Context, app *servingv1alpha1.KalypsoApplication) error {
+	for gvk, name := range map[schema.GroupVersionKind]string{
+		istioGatewayGVK:   naming.Gateway(app.Name),
+		virtualServiceGVK: naming.Routes(app.Name),
+	} {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		obj.SetName(name)
+		obj.SetNamespace(app.Namespace)
+		if err := r.deleteApplicationChild(ctx, app, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ingressGatewayEndpoint returns the URL of the application behind the Istio ingress gateway:
+// its load balancer address when one is assigned, and its cluster DNS name otherwise
+func (r *KalypsoApplicationReconciler) ingressGatewayEndpoint(ctx context.Context, app *servingv1alpha1.KalypsoApplication) (string, error) {
+	if usesGatewayAPI(app) {
+		return r.gatewayAPIEndpoint(ctx, app)
+	}
+
+	services := &corev1.ServiceList{}
+	if err := r.List(ctx, services, client.MatchingLabels{"istio": IngressGatewaySelectorValue}); err != nil {
+		return "", err
+	}
+	if len(services.Items) == 0 {
+		return "", nil
+	}
+	sort.Slice(services.Items, func(i, j int) bool {
+		return services.Items[i].Namespace+"/"+services.Items[i].Name < services.Items[j].Namespace+"/"+services.Items[j].Name
+	})
+
+	service := services.Items[0]
+	host := fmt.Sprintf("%s.%s.svc.cluster.local", service.Name, service.Namespace)
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if ingress.Hostname != "" {
+			host = ingress.Hostname
+			break
+		}
+		if ingress.IP != "" {
+			host = ingress.IP
+			break
+		}
+	}
+	return fmt.Sprintf("http://%s/%s/", host, app.Name), nil
+}
+
+// applyIngressStatus records whether the gateway routes the application's servers
+func applyIngressStatus(app *servingv1alpha1.KalypsoApplication, err error) {
+	condition := metav1.Condition{
+		Type:               "IngressReady",
+		Status:             metav1.ConditionTrue,
+		Reason:             "RoutesConfigured",
+		Message:            fmt.Sprintf("Gateway %s routes /%s/<server>/ to the application's servers", naming.Gateway(app.Name), app.Name),
+		LastTransitionTime: metav1.Now(),
+	}
+	if usesGatewayAPI(app) {
+		condition.Message = fmt.Sprintf("HTTPRoute %s and GRPCRoute %s attach the application's servers to Gateway %s",
+			naming.HTTPRoute(app.Name), naming.GRPCRoute(app.Name), app.Spec.Routing.GatewayAPI.GatewayRef.Name)
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "IngressFailed"
+		condition.Message = err.Error()
+	}
+	meta.SetStatusCondition(&app.Status.Conditions, condition)
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/retraining"
+)
+
+// mirrorTarget returns the server receiving a copy of the gateway traffic of the given server,
+// nil when its traffic is not mirrored or the target is not routed
+func mirrorTarget(app *servingv1alpha1.KalypsoApplication, server *servingv1alpha1.KalypsoTritonServer, servers []servingv1alpha1.KalypsoTritonServer) *servingv1alpha1.KalypsoTritonServer {
+	mirror := app.Spec.Mirror
+	if mirror == nil || server.Name != mirror.SourceServer {
+		return nil
+	}
+	for _, target := range routedServers(servers) {
+		if target.Name == mirror.TargetServer {
+			return target
+		}
+	}
+	return nil
+}
+
+// mirrorPercent returns the share of the source requests mirrored to the target
+func mirrorPercent(app *servingv1alpha1.KalypsoApplication) int32 {
+	if app.Spec.Mirror.Percent == 0 {
+		return 10
+	}
+	return app.Spec.Mirror.Percent
+}
+
+// applyIstioMirror adds the mirror of the VirtualService route of the source server
+func applyIstioMirror(route map[string]interface{}, app *servingv1alpha1.KalypsoApplication, target *servingv1alpha1.KalypsoTritonServer) {
+	if target == nil {
+		return
+	}
+	route["mirror"] = map[string]interface{}{
+		"host": serviceHost(target),
+		"port": map[string]interface{}{"number": int64(tritonHTTPPort(target, app))},
+	}
+	route["mirrorPercentage"] = map[string]interface{}{"value": float64(mirrorPercent(app))}
+}
+
+// gatewayAPIMirrorFilter returns the RequestMirror filter of a Gateway API route rule of the
+// source server, sending the copies to the given port of the target
+func gatewayAPIMirrorFilter(app *servingv1alpha1.KalypsoApplication, target *servingv1alpha1.KalypsoTritonServer, port int32) map[string]interface{} {
+	return map[string]interface{}{
+		"type": "RequestMirror",
+		"requestMirror": map[string]interface{}{
+			"backendRef": gatewayAPIBackendRef(app, target, port),
+			"percent":    int64(mirrorPercent(app)),
+		},
+	}
+}
+
+// compareMirroredServers queries the error rate and p99 latency of the source and target
+// servers. Queries without samples, e.g. before the target received traffic, are left empty
+func (r *KalypsoApplicationReconciler) compareMirroredServers(ctx context.Context, app *servingv1alpha1.KalypsoApplication, servers []servingv1alpha1.KalypsoTritonServer, now time.Time) *servingv1alpha1.MirrorStatus {
+	log := logf.FromContext(ctx)
+
+	mirror := app.Spec.Mirror
+	if mirror == nil || mirror.PrometheusURL == "" {
+		return nil
+	}
+	if r.MetricsQuerier == nil {
+		log.Info("Traffic mirror comparison is configured but no metrics querier is configured")
+		return nil
+	}
+
+	var source, target *servingv1alpha1.KalypsoTritonServer
+	for i := range servers {
+		switch servers[i].Name {
+		case mirror.SourceServer:
+			source = &servers[i]
+		case mirror.TargetServer:
+			target = &servers[i]
+		}
+	}
+	if source == nil || target == nil {
+		return nil
+	}
+
+	evaluatedAt := metav1.NewTime(now)
+	status := &servingv1alpha1.MirrorStatus{LastEvaluationTime: &evaluatedAt}
+	window := fmt.Sprintf("%ds", int64(mirrorInterval(app).Seconds()))
+	checks := []struct {
+		name     string
+		query    string
+		observed *string
+	}{
+		{"source error rate", serverErrorRateQuery(source, window), &status.SourceErrorRate},
+		{"target error rate", serverErrorRateQuery(target, window), &status.TargetErrorRate},
+		{"source p99 latency", serverP99LatencyQuery(source, window), &status.SourceP99LatencyMs},
+		{"target p99 latency", serverP99LatencyQuery(target, window), &status.TargetP99LatencyMs},
+	}
+	for _, check := range checks {
+		value, err := r.MetricsQuerier.Query(ctx, mirror.PrometheusURL, check.query)
+		if err != nil {
+			if !errors.Is(err, retraining.ErrNoSamples) {
+				log.Info("Failed to evaluate traffic mirror comparison", "metric", check.name, "error", err)
+			}
+			continue
+		}
+		*check.observed = strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return status
+}
+
+// mirrorInterval returns the window of the mirror comparison queries
+func mirrorInterval(app *servingv1alpha1.KalypsoApplication) time.Duration {
+	return parseDurationOrDefault(app.Spec.Mirror.Interval, 5*time.Minute)
+}
+
+// applyMirrorStatus records whether the traffic of the source server is mirrored, and the last
+// comparison of the mirrored servers
+func applyMirrorStatus(app *servingv1alpha1.KalypsoApplication, servers []servingv1alpha1.KalypsoTritonServer, comparison *servingv1alpha1.MirrorStatus) {
+	mirror := app.Spec.Mirror
+	if mirror == nil {
+		app.Status.Mirror = nil
+		meta.RemoveStatusCondition(&app.Status.Conditions, "Mirroring")
+		return
+	}
+	if comparison != nil {
+		app.Status.Mirror = comparison
+	}
+
+	condition := metav1.Condition{
+		Type:               "Mirroring",
+		Status:             metav1.ConditionTrue,
+		Reason:             "TrafficMirrored",
+		Message:            fmt.Sprintf("%d%% of the '%s' requests are mirrored to '%s'", mirrorPercent(app), mirror.SourceServer, mirror.TargetServer),
+		LastTransitionTime: metav1.Now(),
+	}
+	source := &servingv1alpha1.KalypsoTritonServer{ObjectMeta: metav1.ObjectMeta{Name: mirror.SourceServer}}
+	if mirrorTarget(app, source, servers) == nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "TargetNotRouted"
+		condition.Message = fmt.Sprintf("KalypsoTritonServer '%s' is not routed by the application", mirror.TargetServer)
+	}
+	meta.SetStatusCondition(&app.Status.Conditions, condition)
+}
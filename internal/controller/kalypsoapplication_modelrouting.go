@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sort"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+// modelRoutes maps each model ready on a routed server to the server serving it, sorted by
+// model. A model loaded by several servers is routed to the first of them by name
+func modelRoutes(servers []servingv1alpha1.KalypsoTritonServer) []servingv1alpha1.ModelRoute {
+	routes := make(map[string]string)
+	for _, server := range routedServers(servers) {
+		for _, model := range server.Status.Models {
+			if _, ok := routes[model]; !ok {
+				routes[model] = server.Name
+			}
+		}
+	}
+
+	result := make([]servingv1alpha1.ModelRoute, 0, len(routes))
+	for model, server := range routes {
+		result = append(result, servingv1alpha1.ModelRoute{Model: model, Server: server})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Model < result[j].Model })
+	return result
+}
+
+// modelPathPrefix returns the gateway path prefix of the requests of a model
+func modelPathPrefix(app *servingv1alpha1.KalypsoApplication, model string) string {
+	return fmt.Sprintf("/%s%s", app.Name, modelPath(model))
+}
+
+// modelPath returns the Triton path prefix of the requests of a model
+func modelPath(model string) string {
+	return fmt.Sprintf("/v2/models/%s/", model)
+}
+
+// buildModelVirtualServiceRoutes builds the VirtualService routes forwarding
+// /<application>/v2/models/<model>/ requests to the server serving the model
+func buildModelVirtualServiceRoutes(app *servingv1alpha1.KalypsoApplication, servers []servingv1alpha1.KalypsoTritonServer) []interface{} {
+	byName := serversByName(servers)
+	routes := make([]interface{}, 0)
+	for _, modelRoute := range modelRoutes(servers) {
+		server := byName[modelRoute.Server]
+		routes = append(routes, map[string]interface{}{
+			"name": fmt.Sprintf("model-%s", modelRoute.Model),
+			"match": []interface{}{
+				map[string]interface{}{
+					"uri": map[string]interface{}{"prefix": modelPathPrefix(app, modelRoute.Model)},
+				},
+			},
+			"rewrite": map[string]interface{}{"uri": modelPath(modelRoute.Model)},
+			"route": []interface{}{
+				map[string]interface{}{
+					"destination": map[string]interface{}{
+						"host": serviceHost(server),
+						"port": map[string]interface{}{"number": int64(tritonHTTPPort(server, app))},
+					},
+				},
+			},
+		})
+	}
+	return routes
+}
+
+// buildModelHTTPRouteRules builds the HTTPRoute rules forwarding
+// /<application>/v2/models/<model>/ requests to the server serving the model
+func buildModelHTTPRouteRules(app *servingv1alpha1.KalypsoApplication, servers []servingv1alpha1.KalypsoTritonServer) []interface{} {
+	byName := serversByName(servers)
+	rules := make([]interface{}, 0)
+	for _, modelRoute := range modelRoutes(servers) {
+		server := byName[modelRoute.Server]
+		rules = append(rules, map[string]interface{}{
+			"matches": []interface{}{
+				map[string]interface{}{
+					"path": map[string]interface{}{
+						"type":  "PathPrefix",
+						"value": modelPathPrefix(app, modelRoute.Model),
+					},
+				},
+			},
+			"filters": []interface{}{
+				map[string]interface{}{
+					"type": "URLRewrite",
+					"urlRewrite": map[string]interface{}{
+						"path": map[string]interface{}{"type": "ReplacePrefixMatch", "replacePrefixMatch": modelPath(modelRoute.Model)},
+					},
+				},
+			},
+			"backendRefs": []interface{}{
+				gatewayAPIBackendRef(app, server, tritonHTTPPort(server, app)),
+			},
+		})
+	}
+	return rules
+}
+
+// serversByName indexes the servers by name
+func serversByName(servers []servingv1alpha1.KalypsoTritonServer) map[string]*servingv1alpha1.KalypsoTritonServer {
+	byName := make(map[string]*servingv1alpha1.KalypsoTritonServer, len(servers))
+	for i := range servers {
+		byName[servers[i].Name] = &servers[i]
+	}
+	return byName
+}
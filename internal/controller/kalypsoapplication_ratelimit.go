@@ -0,0 +1,217 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+const (
+	// DefaultRateLimitClientHeader identifies the client of a request for the per-client limits
+	DefaultRateLimitClientHeader = "x-client-id"
+	// rateLimitClientKey is the rate limit descriptor key of the client
+	rateLimitClientKey = "kalypso_client"
+	// rateLimitModelKey is the rate limit descriptor key of the model
+	rateLimitModelKey = "kalypso_model"
+)
+
+// reconcileRateLimit ensures the EnvoyFilter enforcing the application rate limits in the
+// sidecars of its Triton pods, and removes it when no rate limit is configured
+func (r *KalypsoApplicationReconciler) reconcileRateLimit(ctx context.Context, app *servingv1alpha1.KalypsoApplication) error {
+	filter := &unstructured.Unstructured{}
+	filter.SetGroupVersionKind(envoyFilterGVK)
+	filter.SetName(naming.RateLimit(app.Name))
+	filter.SetNamespace(app.Namespace)
+
+	if app.Spec.Routing == nil || app.Spec.Routing.RateLimit == nil {
+		return r.deleteApplicationChild(ctx, app, filter)
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, filter, func() error {
+		labels := filter.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[ApplicationLabelKey] = app.Name
+		labels[ManagedByLabelKey] = ManagedByLabelValue
+		filter.SetLabels(labels)
+
+		if err := unstructured.SetNestedMap(filter.Object, buildRateLimitFilterSpec(app), "spec"); err != nil {
+			return err
+		}
+
+		// Set owner reference
+		return controllerutil.SetControllerReference(app, filter, r.Scheme)
+	})
+	return err
+}
+
+// buildRateLimitFilterSpec builds the EnvoyFilter spec adding the rate limit descriptors to the
+// inbound routes of the application's Triton pods' sidecars, and the local rate limit filter
+// holding a token bucket per replica, client and model
+func buildRateLimitFilterSpec(app *servingv1alpha1.KalypsoApplication) map[string]interface{} {
+	limit := app.Spec.Routing.RateLimit
+
+	return map[string]interface{}{
+		"workloadSelector": map[string]interface{}{
+			"labels": map[string]interface{}{
+				ApplicationLabelKey: app.Name,
+			},
+		},
+		"configPatches": []interface{}{
+			map[string]interface{}{
+				"applyTo": "HTTP_FILTER",
+				"match": map[string]interface{}{
+					"context": "SIDECAR_INBOUND",
+					"listener": map[string]interface{}{
+						"filterChain": map[string]interface{}{
+							"filter": map[string]interface{}{
+								"name": "envoy.filters.network.http_connection_manager",
+								"subFilter": map[string]interface{}{
+									"name": "envoy.filters.http.router",
+								},
+							},
+						},
+					},
+				},
+				"patch": map[string]interface{}{
+					"operation": "INSERT_BEFORE",
+					"value": map[string]interface{}{
+						"name": "envoy.filters.http.local_ratelimit",
+						"typed_config": map[string]interface{}{
+							"@type":    "type.googleapis.com/udpa.type.v1.TypedStruct",
+							"type_url": "type.googleapis.com/envoy.extensions.filters.http.local_ratelimit.v3.LocalRateLimit",
+							"value":    buildLocalRateLimitConfig(limit),
+						},
+					},
+				},
+			},
+			map[string]interface{}{
+				"applyTo": "HTTP_ROUTE",
+				"match": map[string]interface{}{
+					"context": "SIDECAR_INBOUND",
+				},
+				"patch": map[string]interface{}{
+					"operation": "MERGE",
+					"value": map[string]interface{}{
+						"route": map[string]interface{}{
+							"rate_limits": buildRateLimitActions(limit),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildLocalRateLimitConfig builds the local rate limit filter configuration: the replica
+// bucket, and a bucket per client and model descriptor
+func buildLocalRateLimitConfig(limit *servingv1alpha1.RateLimitSpec) map[string]interface{} {
+	descriptors := make([]interface{}, 0, len(limit.Clients)+len(limit.Models))
+	for _, client := range limit.Clients {
+		descriptors = append(descriptors, rateLimitDescriptor(rateLimitClientKey, client))
+	}
+	for _, model := range limit.Models {
+		descriptors = append(descriptors, rateLimitDescriptor(rateLimitModelKey, model))
+	}
+
+	config := map[string]interface{}{
+		"stat_prefix":     "kalypso_rate_limit",
+		"token_bucket":    tokenBucket(limit.RequestsPerSecond),
+		"filter_enabled":  allRequests("kalypso_rate_limit_enabled"),
+		"filter_enforced": allRequests("kalypso_rate_limit_enforced"),
+	}
+	if len(descriptors) > 0 {
+		config["descriptors"] = descriptors
+	}
+	return config
+}
+
+// buildRateLimitActions builds the route actions generating the client descriptor from the
+// client header, and a model descriptor per model from the HTTP inference API path
+func buildRateLimitActions(limit *servingv1alpha1.RateLimitSpec) []interface{} {
+	actions := make([]interface{}, 0, len(limit.Models)+1)
+	if len(limit.Clients) > 0 {
+		header := limit.ClientHeader
+		if header == "" {
+			header = DefaultRateLimitClientHeader
+		}
+		actions = append(actions, map[string]interface{}{
+			"actions": []interface{}{
+				map[string]interface{}{
+					"request_headers": map[string]interface{}{
+						"header_name":    header,
+						"descriptor_key": rateLimitClientKey,
+					},
+				},
+			},
+		})
+	}
+	for _, model := range limit.Models {
+		actions = append(actions, map[string]interface{}{
+			"actions": []interface{}{
+				map[string]interface{}{
+					"header_value_match": map[string]interface{}{
+						"descriptor_key":   rateLimitModelKey,
+						"descriptor_value": model.Name,
+						"headers": []interface{}{
+							map[string]interface{}{
+								"name":         ":path",
+								"string_match": map[string]interface{}{"prefix": fmt.Sprintf("/v2/models/%s/", model.Name)},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	return actions
+}
+
+// rateLimitDescriptor returns the local rate limit descriptor of a client or model
+func rateLimitDescriptor(key string, limit servingv1alpha1.NamedRateLimit) map[string]interface{} {
+	return map[string]interface{}{
+		"entries": []interface{}{
+			map[string]interface{}{"key": key, "value": limit.Name},
+		},
+		"token_bucket": tokenBucket(limit.RequestsPerSecond),
+	}
+}
+
+// tokenBucket returns a token bucket refilled every second, allowing bursts of one second
+func tokenBucket(requestsPerSecond int32) map[string]interface{} {
+	return map[string]interface{}{
+		"max_tokens":      int64(requestsPerSecond),
+		"tokens_per_fill": int64(requestsPerSecond),
+		"fill_interval":   "1s",
+	}
+}
+
+// allRequests returns a runtime fractional percent applying to every request by default
+func allRequests(runtimeKey string) map[string]interface{} {
+	return map[string]interface{}{
+		"runtime_key":   runtimeKey,
+		"default_value": map[string]interface{}{"numerator": int64(100), "denominator": "HUNDRED"},
+	}
+}
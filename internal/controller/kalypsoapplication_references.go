@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// serverApplicationKey returns the key of the application referenced by the server: its name
+// in the server namespace, or <namespace>/<name>
+func serverApplicationKey(server *servingv1alpha1.KalypsoTritonServer) types.NamespacedName {
+	return naming.Reference(server.Namespace, server.Spec.ApplicationRef)
+}
+
+// servesApplication reports whether the server references the application
+func servesApplication(server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) bool {
+	return serverApplicationKey(server) == client.ObjectKeyFromObject(app)
+}
+
+// listApplicationServers lists the servers of every namespace allowed to reference the
+// application that reference it
+func listApplicationServers(ctx context.Context, c client.Reader, app *servingv1alpha1.KalypsoApplication) ([]servingv1alpha1.KalypsoTritonServer, error) {
+	servers := &servingv1alpha1.KalypsoTritonServerList{}
+	if err := c.List(ctx, servers); err != nil {
+		return nil, err
+	}
+	allowed := map[string]bool{app.Namespace: true}
+	var result []servingv1alpha1.KalypsoTritonServer
+	for _, server := range servers.Items {
+		if !servesApplication(&server, app) {
+			continue
+		}
+		ok, checked := allowed[server.Namespace]
+		if !checked {
+			var err error
+			if ok, err = defaults.ApplicationAllowsNamespace(ctx, c, app, server.Namespace); err != nil {
+				return nil, err
+			}
+			allowed[server.Namespace] = ok
+		}
+		if ok {
+			result = append(result, server)
+		}
+	}
+	return result, nil
+}
+
+// crossNamespaceSecretsMessage explains which Secrets of the application a server of another
+// namespace would have to mount from its own namespace, where they do not exist. It is empty
+// when the server is in the application namespace or brings its own Secrets
+func crossNamespaceSecretsMessage(server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) string {
+	if server.Namespace == app.Namespace {
+		return ""
+	}
+
+	var missing []string
+	var storageSecrets []string
+	for _, name := range applicationCredentialSecrets(withServerStorage(app, server)) {
+		if server.Spec.Storage == nil || name != server.Spec.Storage.SecretName {
+			storageSecrets = append(storageSecrets, name)
+		}
+	}
+	if len(storageSecrets) > 0 {
+		missing = append(missing, fmt.Sprintf("storage Secrets %s (set spec.storage.secretName or a workload identity)", quotedNames(storageSecrets)))
+	}
+	if len(server.Spec.ImagePullSecrets) == 0 && len(app.Spec.ImagePullSecrets) > 0 {
+		pullSecrets := make([]string, 0, len(app.Spec.ImagePullSecrets))
+		for _, ref := range app.Spec.ImagePullSecrets {
+			pullSecrets = append(pullSecrets, ref.Name)
+		}
+		missing = append(missing, fmt.Sprintf("image pull Secrets %s (set spec.imagePullSecrets)", quotedNames(pullSecrets)))
+	}
+	if serverTLS(server) == nil && app.Spec.Certificate != nil && app.Spec.Certificate.ServerTLS {
+		missing = append(missing, fmt.Sprintf("certificate Secret %s (set spec.networking.tls.secretName)", quotedNames([]string{naming.ApplicationCertificate(app.Name)})))
+	}
+	if len(missing) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("KalypsoApplication '%s/%s' relies on Secrets of namespace '%s' the server cannot mount: %s",
+		app.Namespace, app.Name, app.Namespace, strings.Join(missing, "; "))
+}
+
+// quotedNames lists the names quoted and sorted, without duplicates
+func quotedNames(names []string) string {
+	names = slices.Compact(slices.Sorted(slices.Values(names)))
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, "'"+name+"'")
+	}
+	return strings.Join(quoted, ", ")
+}
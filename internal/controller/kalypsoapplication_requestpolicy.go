@@ -0,0 +1,199 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// defaultCORSAllowMethods are the methods accepted from other origins when none are set
+var defaultCORSAllowMethods = []string{"GET", "POST", "OPTIONS"}
+
+// applyIstioRequestPolicy adds the CORS policy and request timeout of the application to the
+// VirtualService routes
+func applyIstioRequestPolicy(routes []interface{}, app *servingv1alpha1.KalypsoApplication) {
+	routing := app.Spec.Routing
+	if routing == nil {
+		return
+	}
+	for _, r := range routes {
+		route := r.(map[string]interface{})
+		if routing.CORS != nil {
+			route["corsPolicy"] = buildIstioCORSPolicy(routing.CORS)
+		}
+		if routing.RequestTimeout != "" {
+			route["timeout"] = routing.RequestTimeout
+		}
+	}
+}
+
+// buildIstioCORSPolicy builds the VirtualService CORS policy
+func buildIstioCORSPolicy(cors *servingv1alpha1.CORSSpec) map[string]interface{} {
+	origins := make([]interface{}, 0, len(cors.AllowOrigins))
+	for _, origin := range cors.AllowOrigins {
+		if origin == "*" {
+			origins = append(origins, map[string]interface{}{"regex": ".*"})
+			continue
+		}
+		origins = append(origins, map[string]interface{}{"exact": origin})
+	}
+
+	policy := map[string]interface{}{
+		"allowOrigins":     origins,
+		"allowMethods":     stringValues(corsAllowMethods(cors)),
+		"maxAge":           strconv.Itoa(int(corsMaxAgeSeconds(cors))) + "s",
+		"allowCredentials": cors.AllowCredentials,
+	}
+	if len(cors.AllowHeaders) > 0 {
+		policy["allowHeaders"] = stringValues(cors.AllowHeaders)
+	}
+	if len(cors.ExposeHeaders) > 0 {
+		policy["exposeHeaders"] = stringValues(cors.ExposeHeaders)
+	}
+	return policy
+}
+
+// applyGatewayAPIRequestPolicy adds the CORS filter and request timeout of the application to
+// the HTTPRoute rules. The CORS filter requires the experimental Gateway API channel
+func applyGatewayAPIRequestPolicy(rules []interface{}, app *servingv1alpha1.KalypsoApplication) {
+	routing := app.Spec.Routing
+	for _, r := range rules {
+		rule := r.(map[string]interface{})
+		if cors := routing.CORS; cors != nil {
+			filter := map[string]interface{}{
+				"allowOrigins":     stringValues(cors.AllowOrigins),
+				"allowMethods":     stringValues(corsAllowMethods(cors)),
+				"maxAge":           int64(corsMaxAgeSeconds(cors)),
+				"allowCredentials": cors.AllowCredentials,
+			}
+			if len(cors.AllowHeaders) > 0 {
+				filter["allowHeaders"] = stringValues(cors.AllowHeaders)
+			}
+			if len(cors.ExposeHeaders) > 0 {
+				filter["exposeHeaders"] = stringValues(cors.ExposeHeaders)
+			}
+			filters, _ := rule["filters"].([]interface{})
+			rule["filters"] = append(filters, map[string]interface{}{"type": "CORS", "cors": filter})
+		}
+		if routing.RequestTimeout != "" {
+			rule["timeouts"] = map[string]interface{}{"request": routing.RequestTimeout}
+		}
+	}
+}
+
+// corsAllowMethods returns the methods accepted from other origins
+func corsAllowMethods(cors *servingv1alpha1.CORSSpec) []string {
+	if len(cors.AllowMethods) == 0 {
+		return defaultCORSAllowMethods
+	}
+	return cors.AllowMethods
+}
+
+// corsMaxAgeSeconds returns how long browsers may cache the preflight response
+func corsMaxAgeSeconds(cors *servingv1alpha1.CORSSpec) int32 {
+	if cors.MaxAgeSeconds == 0 {
+		return 86400
+	}
+	return cors.MaxAgeSeconds
+}
+
+// stringValues converts strings to unstructured list values
+func stringValues(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		result = append(result, value)
+	}
+	return result
+}
+
+// reconcileRequestSize ensures the EnvoyFilter rejecting oversized requests in the sidecars of
+// the application's Triton pods, and removes it when no limit is configured
+func (r *KalypsoApplicationReconciler) reconcileRequestSize(ctx context.Context, app *servingv1alpha1.KalypsoApplication) error {
+	filter := &unstructured.Unstructured{}
+	filter.SetGroupVersionKind(envoyFilterGVK)
+	filter.SetName(naming.RequestSize(app.Name))
+	filter.SetNamespace(app.Namespace)
+
+	if app.Spec.Routing == nil || app.Spec.Routing.MaxRequestBodyBytes == nil {
+		return r.deleteApplicationChild(ctx, app, filter)
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, filter, func() error {
+		labels := filter.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[ApplicationLabelKey] = app.Name
+		labels[ManagedByLabelKey] = ManagedByLabelValue
+		filter.SetLabels(labels)
+
+		if err := unstructured.SetNestedMap(filter.Object, buildRequestSizeFilterSpec(app), "spec"); err != nil {
+			return err
+		}
+
+		// Set owner reference
+		return controllerutil.SetControllerReference(app, filter, r.Scheme)
+	})
+	return err
+}
+
+// buildRequestSizeFilterSpec builds the EnvoyFilter spec inserting a buffer filter, which
+// answers 413 once a request body exceeds the limit, in the inbound sidecar chain
+func buildRequestSizeFilterSpec(app *servingv1alpha1.KalypsoApplication) map[string]interface{} {
+	return map[string]interface{}{
+		"workloadSelector": map[string]interface{}{
+			"labels": map[string]interface{}{
+				ApplicationLabelKey: app.Name,
+			},
+		},
+		"configPatches": []interface{}{
+			map[string]interface{}{
+				"applyTo": "HTTP_FILTER",
+				"match": map[string]interface{}{
+					"context": "SIDECAR_INBOUND",
+					"listener": map[string]interface{}{
+						"filterChain": map[string]interface{}{
+							"filter": map[string]interface{}{
+								"name": "envoy.filters.network.http_connection_manager",
+								"subFilter": map[string]interface{}{
+									"name": "envoy.filters.http.router",
+								},
+							},
+						},
+					},
+				},
+				"patch": map[string]interface{}{
+					"operation": "INSERT_BEFORE",
+					"value": map[string]interface{}{
+						"name": "envoy.filters.http.buffer",
+						"typed_config": map[string]interface{}{
+							"@type":             "type.googleapis.com/envoy.extensions.filters.http.buffer.v3.Buffer",
+							"max_request_bytes": *app.Spec.Routing.MaxRequestBodyBytes,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,380 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+const (
+	// ApplicationNamespaceLabelKey is the label key for the namespace of the owning application,
+	// set on resources created outside of it
+	ApplicationNamespaceLabelKey = "kalypso-serving.io/application-namespace"
+	// IngressGatewaySelectorValue is the istio label value of the default Istio ingress gateway
+	IngressGatewaySelectorValue = "ingressgateway"
+)
+
+var (
+	// certificateGVK is the cert-manager Certificate kind
+	certificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+	// istioGatewayGVK is the Istio Gateway kind
+	istioGatewayGVK = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1", Kind: "Gateway"}
+)
+
+// routingResult is the outcome of the custom domain reconciliation
+type routingResult struct {
+	domains   []servingv1alpha1.CustomDomainStatus
+	conflicts []string
+	err       error
+}
+
+// pending reports whether routing needs to be re-checked: failed, or certificates not issued yet
+func (r *routingResult) pending() bool {
+	if r == nil {
+		return false
+	}
+	if r.err != nil {
+		return true
+	}
+	for _, domain := range r.domains {
+		if !domain.CertificateReady {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcileRouting requests a certificate per custom domain. Domains already claimed by another
+// application of the project are skipped; the others are served by the gateway
+func (r *KalypsoApplicationReconciler) reconcileRouting(ctx context.Context, app *servingv1alpha1.KalypsoApplication) *routingResult {
+	if app.Spec.Routing == nil {
+		// Clean up the custom domains of a previously configured routing
+		if len(app.Status.CustomDomains) > 0 {
+			if err := r.pruneDomainCertificates(ctx, app, nil); err != nil {
+				return &routingResult{err: err}
+			}
+		}
+		return nil
+	}
+
+	result := &routingResult{}
+	claimed, err := r.findDomainConflicts(ctx, app)
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	var hosts []string
+	for _, host := range app.Spec.Routing.CustomDomains {
+		if owner, ok := claimed[host]; ok {
+			result.conflicts = append(result.conflicts, fmt.Sprintf("%s (claimed by %s)", host, owner))
+			continue
+		}
+		hosts = append(hosts, host)
+	}
+
+	for _, host := range hosts {
+		status, err := r.reconcileDomainCertificate(ctx, app, host)
+		if err != nil {
+			result.err = err
+			return result
+		}
+		result.domains = append(result.domains, status)
+	}
+
+	result.err = r.pruneDomainCertificates(ctx, app, hosts)
+	return result
+}
+
+// findDomainConflicts returns the domains of this application that are already claimed by
+// an application of the same project created before it, in any namespace allowed by the project,
+// mapped to the reference of the claiming application
+func (r *KalypsoApplicationReconciler) findDomainConflicts(ctx context.Context, app *servingv1alpha1.KalypsoApplication) (map[string]string, error) {
+	project, err := defaults.ApplicationProject(ctx, r.Client, app)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	apps, err := listProjectReferrers(ctx, r.Client, project)
+	if err != nil {
+		return nil, err
+	}
+
+	appKey := client.ObjectKeyFromObject(app)
+	claimed := make(map[string]string)
+	for _, other := range apps {
+		otherKey := client.ObjectKeyFromObject(&other)
+		if otherKey == appKey || other.Spec.Routing == nil {
+			continue
+		}
+		createdBefore := other.CreationTimestamp.Before(&app.CreationTimestamp) ||
+			(other.CreationTimestamp.Equal(&app.CreationTimestamp) && otherKey.String() < appKey.String())
+		if !createdBefore {
+			continue
+		}
+		for _, host := range other.Spec.Routing.CustomDomains {
+			if slices.Contains(app.Spec.Routing.CustomDomains, host) {
+				claimed[host] = naming.RelativeReference(app.Namespace, otherKey)
+			}
+		}
+	}
+	return claimed, nil
+}
+
+// applicationsSharingProject maps an application with custom domains to the other applications of its
+// project, so that domains released by one application are picked up by the next claimant
+func (r *KalypsoApplicationReconciler) applicationsSharingProject(ctx context.Context, obj client.Object) []reconcile.Request {
+	app, ok := obj.(*servingv1alpha1.KalypsoApplication)
+	if !ok || app.Spec.Routing == nil && len(app.Status.CustomDomains) == 0 {
+		return nil
+	}
+
+	project, err := defaults.ApplicationProject(ctx, r.Client, app)
+	if err != nil {
+		return nil
+	}
+	apps, err := listProjectReferrers(ctx, r.Client, project)
+	if err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, other := range apps {
+		if client.ObjectKeyFromObject(&other) != client.ObjectKeyFromObject(app) && other.Spec.Routing != nil {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: other.Name, Namespace: other.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
+// reconcileDomainCertificate ensures the cert-manager Certificate of a custom domain in the ingress namespace
+func (r *KalypsoApplicationReconciler) reconcileDomainCertificate(ctx context.Context, app *servingv1alpha1.KalypsoApplication, host string) (servingv1alpha1.CustomDomainStatus, error) {
+	routing := app.Spec.Routing
+	name := naming.DomainCertificate(app.Namespace, app.Name, host)
+	status := servingv1alpha1.CustomDomainStatus{Host: host, SecretName: name}
+	if routing.IssuerRef == nil {
+		return status, fmt.Errorf("issuerRef is required to request a certificate for %s", host)
+	}
+
+	issuerKind := "ClusterIssuer"
+	if routing.IssuerRef.Kind != "" {
+		issuerKind = routing.IssuerRef.Kind
+	}
+
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certificateGVK)
+	certificate.SetName(name)
+	certificate.SetNamespace(ingressNamespace(app))
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, certificate, func() error {
+		// Certificates live outside the application namespace, so they are tracked by labels
+		// instead of an owner reference and removed by the application finalizer
+		labels := certificate.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[ApplicationLabelKey] = app.Name
+		labels[ApplicationNamespaceLabelKey] = app.Namespace
+		labels[ManagedByLabelKey] = ManagedByLabelValue
+		certificate.SetLabels(labels)
+
+		return unstructured.SetNestedMap(certificate.Object, map[string]interface{}{
+			"secretName": name,
+			"dnsNames":   []interface{}{host},
+			"issuerRef": map[string]interface{}{
+				"name":  routing.IssuerRef.Name,
+				"kind":  issuerKind,
+				"group": "cert-manager.io",
+			},
+		}, "spec")
+	})
+	if err != nil {
+		return status, err
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(certificate.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == "Ready" && condition["status"] == "True" {
+			status.CertificateReady = true
+		}
+	}
+	return status, nil
+}
+
+// pruneDomainCertificates deletes the application certificates whose domain is no longer served
+func (r *KalypsoApplicationReconciler) pruneDomainCertificates(ctx context.Context, app *servingv1alpha1.KalypsoApplication, hosts []string) error {
+	certificates := &unstructured.UnstructuredList{}
+	certificates.SetGroupVersionKind(certificateGVK.GroupVersion().WithKind("CertificateList"))
+	if err := r.List(ctx, certificates, client.MatchingLabels{
+		ApplicationLabelKey:          app.Name,
+		ApplicationNamespaceLabelKey: app.Namespace,
+	}); err != nil {
+		return err
+	}
+
+	desired := make(map[string]bool)
+	for _, host := range hosts {
+		desired[naming.DomainCertificate(app.Namespace, app.Name, host)] = true
+	}
+	for i := range certificates.Items {
+		certificate := &certificates.Items[i]
+		if desired[certificate.GetName()] && certificate.GetNamespace() == ingressNamespace(app) {
+			continue
+		}
+		if err := r.Delete(ctx, certificate); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileGateway ensures the Istio Gateway of the application: plain HTTP on any host, and TLS
+// terminated for each custom domain
+func (r *KalypsoApplicationReconciler) reconcileGateway(ctx context.Context, app *servingv1alpha1.KalypsoApplication, domains []servingv1alpha1.CustomDomainStatus) error {
+	gateway := &unstructured.Unstructured{}
+	gateway.SetGroupVersionKind(istioGatewayGVK)
+	gateway.SetName(naming.Gateway(app.Name))
+	gateway.SetNamespace(app.Namespace)
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, gateway, func() error {
+		labels := gateway.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[ApplicationLabelKey] = app.Name
+		labels[ManagedByLabelKey] = ManagedByLabelValue
+		gateway.SetLabels(labels)
+
+		servers := make([]interface{}, 0, len(domains)+1)
+		servers = append(servers, map[string]interface{}{
+			"port": map[string]interface{}{
+				"number":   int64(80),
+				"name":     "http",
+				"protocol": "HTTP",
+			},
+			"hosts": []interface{}{"*"},
+		})
+		for i, domain := range domains {
+			servers = append(servers, map[string]interface{}{
+				"port": map[string]interface{}{
+					"number":   int64(443),
+					"name":     fmt.Sprintf("https-%d", i),
+					"protocol": "HTTPS",
+				},
+				"hosts": []interface{}{domain.Host},
+				"tls": map[string]interface{}{
+					"mode":           "SIMPLE",
+					"credentialName": domain.SecretName,
+				},
+			})
+		}
+		if err := unstructured.SetNestedMap(gateway.Object, map[string]interface{}{
+			"selector": map[string]interface{}{
+				"istio": IngressGatewaySelectorValue,
+			},
+			"servers": servers,
+		}, "spec"); err != nil {
+			return err
+		}
+
+		// Set owner reference
+		return controllerutil.SetControllerReference(app, gateway, r.Scheme)
+	})
+	return err
+}
+
+// applyRoutingStatus records the custom domain state in the application status
+func applyRoutingStatus(ctx context.Context, app *servingv1alpha1.KalypsoApplication, result *routingResult) {
+	if result == nil {
+		app.Status.CustomDomains = nil
+		meta.RemoveStatusCondition(&app.Status.Conditions, "DomainsUnique")
+		meta.RemoveStatusCondition(&app.Status.Conditions, "RoutingReady")
+		return
+	}
+
+	if result.err != nil {
+		// Routing failures are not fatal - cert-manager or Istio may not be installed
+		logf.FromContext(ctx).Info("Failed to reconcile custom domain routing (cert-manager or Istio may not be installed)", "error", result.err)
+		meta.SetStatusCondition(&app.Status.Conditions, metav1.Condition{
+			Type:               "RoutingReady",
+			Status:             metav1.ConditionFalse,
+			Reason:             "ReconciliationFailed",
+			Message:            result.err.Error(),
+			LastTransitionTime: metav1.Now(),
+		})
+		// Keep the last known domains so the cleanup is retried
+		return
+	}
+
+	if len(result.conflicts) > 0 {
+		meta.SetStatusCondition(&app.Status.Conditions, metav1.Condition{
+			Type:               "DomainsUnique",
+			Status:             metav1.ConditionFalse,
+			Reason:             "DomainConflict",
+			Message:            fmt.Sprintf("Custom domains claimed by other applications of the project: %s", strings.Join(result.conflicts, ", ")),
+			LastTransitionTime: metav1.Now(),
+		})
+	} else {
+		meta.SetStatusCondition(&app.Status.Conditions, metav1.Condition{
+			Type:               "DomainsUnique",
+			Status:             metav1.ConditionTrue,
+			Reason:             "NoConflicts",
+			Message:            "All custom domains are unique within the project",
+			LastTransitionTime: metav1.Now(),
+		})
+	}
+
+	app.Status.CustomDomains = result.domains
+	meta.SetStatusCondition(&app.Status.Conditions, metav1.Condition{
+		Type:               "RoutingReady",
+		Status:             metav1.ConditionTrue,
+		Reason:             "GatewayConfigured",
+		Message:            fmt.Sprintf("Gateway serves %d custom domains", len(result.domains)),
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// ingressNamespace returns the namespace of the Istio ingress gateway workload
+func ingressNamespace(app *servingv1alpha1.KalypsoApplication) string {
+	if app.Spec.Routing != nil && app.Spec.Routing.IngressNamespace != "" {
+		return app.Spec.Routing.IngressNamespace
+	}
+	return "istio-system"
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// destinationRuleGVK is the Istio DestinationRule kind
+var destinationRuleGVK = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1", Kind: "DestinationRule"}
+
+// reconcileTrafficPolicy ensures a DestinationRule per server applying the application traffic
+// policy, and removes the DestinationRules of servers no longer routed or of a removed policy
+func (r *KalypsoApplicationReconciler) reconcileTrafficPolicy(ctx context.Context, app *servingv1alpha1.KalypsoApplication, servers []servingv1alpha1.KalypsoTritonServer) error {
+	desired := make(map[string]bool)
+	if app.Spec.Routing != nil && app.Spec.Routing.TrafficPolicy != nil {
+		for _, server := range routedServers(servers) {
+			name := naming.DestinationRule(server.Name)
+			if err := r.reconcileDestinationRule(ctx, app, server, name); err != nil {
+				return err
+			}
+			desired[name] = true
+		}
+	}
+
+	existing := &unstructured.UnstructuredList{}
+	existing.SetGroupVersionKind(destinationRuleGVK.GroupVersion().WithKind(destinationRuleGVK.Kind + "List"))
+	if err := r.List(ctx, existing, client.InNamespace(app.Namespace), client.MatchingLabels{ApplicationLabelKey: app.Name}); err != nil {
+		if meta.IsNoMatchError(err) && len(desired) == 0 {
+			// Istio is not installed, so there is nothing to clean up
+			return nil
+		}
+		return err
+	}
+	for i := range existing.Items {
+		rule := &existing.Items[i]
+		if desired[rule.GetName()] || !metav1.IsControlledBy(rule, app) {
+			continue
+		}
+		if err := client.IgnoreNotFound(r.Delete(ctx, rule)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileDestinationRule ensures the DestinationRule of one server's Service
+func (r *KalypsoApplicationReconciler) reconcileDestinationRule(ctx context.Context, app *servingv1alpha1.KalypsoApplication, server *servingv1alpha1.KalypsoTritonServer, name string) error {
+	rule := &unstructured.Unstructured{}
+	rule.SetGroupVersionKind(destinationRuleGVK)
+	rule.SetName(name)
+	rule.SetNamespace(app.Namespace)
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, rule, func() error {
+		labels := rule.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[ApplicationLabelKey] = app.Name
+		labels[TritonServerLabelKey] = server.Name
+		labels[ManagedByLabelKey] = ManagedByLabelValue
+		rule.SetLabels(labels)
+
+		spec := map[string]interface{}{
+			"host":          serviceHost(server),
+			"trafficPolicy": buildTrafficPolicy(app.Spec.Routing.TrafficPolicy),
+		}
+		if err := unstructured.SetNestedMap(rule.Object, spec, "spec"); err != nil {
+			return err
+		}
+
+		// Set owner reference
+		return controllerutil.SetControllerReference(app, rule, r.Scheme)
+	})
+	return err
+}
+
+// buildTrafficPolicy renders the Istio traffic policy of the connection limits and outlier
+// ejection. Unset limits keep the Istio defaults
+func buildTrafficPolicy(policy *servingv1alpha1.TrafficPolicySpec) map[string]interface{} {
+	trafficPolicy := map[string]interface{}{}
+
+	if pool := policy.ConnectionPool; pool != nil {
+		tcp := map[string]interface{}{}
+		if pool.MaxConnections != nil {
+			tcp["maxConnections"] = int64(*pool.MaxConnections)
+		}
+		http := map[string]interface{}{}
+		if pool.MaxPendingRequests != nil {
+			http["http1MaxPendingRequests"] = int64(*pool.MaxPendingRequests)
+		}
+		if pool.MaxRequests != nil {
+			http["http2MaxRequests"] = int64(*pool.MaxRequests)
+		}
+		if pool.MaxRequestsPerConnection != nil {
+			http["maxRequestsPerConnection"] = int64(*pool.MaxRequestsPerConnection)
+		}
+		connectionPool := map[string]interface{}{}
+		if len(tcp) > 0 {
+			connectionPool["tcp"] = tcp
+		}
+		if len(http) > 0 {
+			connectionPool["http"] = http
+		}
+		trafficPolicy["connectionPool"] = connectionPool
+	}
+
+	if outlier := policy.OutlierDetection; outlier != nil {
+		consecutiveErrors := outlier.Consecutive5xxErrors
+		if consecutiveErrors == 0 {
+			consecutiveErrors = 5
+		}
+		interval := outlier.Interval
+		if interval == "" {
+			interval = "10s"
+		}
+		baseEjectionTime := outlier.BaseEjectionTime
+		if baseEjectionTime == "" {
+			baseEjectionTime = "30s"
+		}
+		trafficPolicy["outlierDetection"] = map[string]interface{}{
+			"consecutive5xxErrors": int64(consecutiveErrors),
+			"interval":             interval,
+			"baseEjectionTime":     baseEjectionTime,
+			"maxEjectionPercent":   int64(outlier.MaxEjectionPercent),
+		}
+	}
+
+	return trafficPolicy
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+const (
+	// APIVersionHeader carries the matched API version from the sidecar to the request metrics
+	APIVersionHeader = "x-kalypso-api-version"
+	// APIDeprecatedHeader carries whether the matched API version is deprecated
+	APIDeprecatedHeader = "x-kalypso-api-deprecated"
+	// APIVersionMetricTag is the istio_requests_total label holding the API version
+	APIVersionMetricTag = "api_version"
+	// APIDeprecatedMetricTag is the istio_requests_total label holding whether the version is deprecated
+	APIDeprecatedMetricTag = "api_deprecated"
+)
+
+// telemetryGVK is the Istio Telemetry kind
+var telemetryGVK = schema.GroupVersionKind{Group: "telemetry.istio.io", Version: "v1", Kind: "Telemetry"}
+
+// reconcileAPIVersioning ensures the EnvoyFilter tagging requests with their API version and
+// adding deprecation headers, and the Telemetry exposing the version in the request metrics.
+// Both are removed when API versioning is not configured
+func (r *KalypsoApplicationReconciler) reconcileAPIVersioning(ctx context.Context, app *servingv1alpha1.KalypsoApplication) error {
+	enabled := app.Spec.APIVersioning != nil && len(app.Spec.APIVersioning.Versions) > 0
+	children := []struct {
+		gvk   schema.GroupVersionKind
+		build func(*servingv1alpha1.KalypsoApplication) map[string]interface{}
+	}{
+		{envoyFilterGVK, buildAPIVersioningFilterSpec},
+		{telemetryGVK, buildAPIVersioningTelemetrySpec},
+	}
+
+	for _, child := range children {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(child.gvk)
+		obj.SetName(naming.APIVersioning(app.Name))
+		obj.SetNamespace(app.Namespace)
+
+		if !enabled {
+			if err := r.deleteApplicationChild(ctx, app, obj); err != nil {
+				return err
+			}
+			continue
+		}
+
+		_, err := controllerutil.CreateOrUpdate(ctx, r.Client, obj, func() error {
+			labels := obj.GetLabels()
+			if labels == nil {
+				labels = make(map[string]string)
+			}
+			labels[ApplicationLabelKey] = app.Name
+			labels[ManagedByLabelKey] = ManagedByLabelValue
+			obj.SetLabels(labels)
+
+			if err := unstructured.SetNestedMap(obj.Object, child.build(app), "spec"); err != nil {
+				return err
+			}
+
+			// Set owner reference
+			return controllerutil.SetControllerReference(app, obj, r.Scheme)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteApplicationChild removes a resource owned by the application
+func (r *KalypsoApplicationReconciler) deleteApplicationChild(ctx context.Context, app *servingv1alpha1.KalypsoApplication, obj client.Object) error {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		if meta.IsNoMatchError(err) {
+			// Istio is not installed, so there is nothing to clean up
+			return nil
+		}
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(obj, app) {
+		return nil
+	}
+	return client.IgnoreNotFound(r.Delete(ctx, obj))
+}
+
+// apiVersionPathPrefix returns the path prefix matching the requests of a version
+func apiVersionPathPrefix(version servingv1alpha1.APIVersion) string {
+	if version.PathPrefix != "" {
+		return version.PathPrefix
+	}
+	return "/" + version.Name + "/"
+}
+
+// buildAPIVersioningLua builds the Lua filter matching the request path against the versions,
+// longest prefix first, tagging the request and adding the deprecation headers to its response
+func buildAPIVersioningLua(versions []servingv1alpha1.APIVersion) string {
+	var code strings.Builder
+	code.WriteString("local versions = {\n")
+	for _, version := range sortedAPIVersions(versions) {
+		sunset := ""
+		if version.Sunset != nil {
+			sunset = version.Sunset.UTC().Format(http.TimeFormat)
+		}
+		link := ""
+		if version.Link != "" {
+			link = fmt.Sprintf(`<%s>; rel="deprecation"`, version.Link)
+		}
+		fmt.Fprintf(&code, "  {prefix = %q, name = %q, deprecated = %t, sunset = %q, link = %q},\n",
+			apiVersionPathPrefix(version), version.Name, version.Deprecated, sunset, link)
+	}
+	code.WriteString(`}
+
+function envoy_on_request(request_handle)
+  local path = request_handle:headers():get(":path") or ""
+  request_handle:headers():remove("` + APIVersionHeader + `")
+  request_handle:headers():remove("` + APIDeprecatedHeader + `")
+  for i, version in ipairs(versions) do
+    if string.sub(path, 1, #version.prefix) == version.prefix then
+      request_handle:headers():add("` + APIVersionHeader + `", version.name)
+      request_handle:headers():add("` + APIDeprecatedHeader + `", tostring(version.deprecated))
+      request_handle:streamInfo():dynamicMetadata():set("kalypso.api_versioning", "version", i)
+      return
+    end
+  end
+end
+
+function envoy_on_response(response_handle)
+  local metadata = response_handle:streamInfo():dynamicMetadata():get("kalypso.api_versioning")
+  if metadata == nil then
+    return
+  end
+  local version = versions[metadata["version"]]
+  if version == nil or not version.deprecated then
+    return
+  end
+  response_handle:headers():replace("Deprecation", "true")
+  if version.sunset ~= "" then
+    response_handle:headers():replace("Sunset", version.sunset)
+  end
+  if version.link ~= "" then
+    response_handle:headers():add("Link", version.link)
+  end
+end
+`)
+	return code.String()
+}
+
+// sortedAPIVersions orders the versions by decreasing path prefix length so that the most
+// specific prefix matches first
+func sortedAPIVersions(versions []servingv1alpha1.APIVersion) []servingv1alpha1.APIVersion {
+	sorted := append([]servingv1alpha1.APIVersion(nil), versions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(apiVersionPathPrefix(sorted[i])) > len(apiVersionPathPrefix(sorted[j]))
+	})
+	return sorted
+}
+
+// buildAPIVersioningFilterSpec builds the EnvoyFilter spec inserting the Lua filter on the
+// inbound listener of the application's Triton pods' sidecars
+func buildAPIVersioningFilterSpec(app *servingv1alpha1.KalypsoApplication) map[string]interface{} {
+	return map[string]interface{}{
+		"workloadSelector": map[string]interface{}{
+			"labels": map[string]interface{}{
+				ApplicationLabelKey: app.Name,
+			},
+		},
+		"configPatches": []interface{}{
+			map[string]interface{}{
+				"applyTo": "HTTP_FILTER",
+				"match": map[string]interface{}{
+					"context": "SIDECAR_INBOUND",
+					"listener": map[string]interface{}{
+						"filterChain": map[string]interface{}{
+							"filter": map[string]interface{}{
+								"name": "envoy.filters.network.http_connection_manager",
+								"subFilter": map[string]interface{}{
+									"name": "envoy.filters.http.router",
+								},
+							},
+						},
+					},
+				},
+				"patch": map[string]interface{}{
+					"operation": "INSERT_BEFORE",
+					"value": map[string]interface{}{
+						"name": "kalypso.api_versioning",
+						"typed_config": map[string]interface{}{
+							"@type":      "type.googleapis.com/envoy.extensions.filters.http.lua.v3.Lua",
+							"inlineCode": buildAPIVersioningLua(app.Spec.APIVersioning.Versions),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildAPIVersioningTelemetrySpec builds the Telemetry spec adding the API version labels to
+// istio_requests_total for the application's Triton pods
+func buildAPIVersioningTelemetrySpec(app *servingv1alpha1.KalypsoApplication) map[string]interface{} {
+	tag := func(header string) interface{} {
+		return map[string]interface{}{
+			"operation": "UPSERT",
+			"value":     fmt.Sprintf("request.headers['%s']", header),
+		}
+	}
+
+	return map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{
+				ApplicationLabelKey: app.Name,
+			},
+		},
+		"metrics": []interface{}{
+			map[string]interface{}{
+				"providers": []interface{}{
+					map[string]interface{}{"name": "prometheus"},
+				},
+				"overrides": []interface{}{
+					map[string]interface{}{
+						"match": map[string]interface{}{
+							"metric": "REQUEST_COUNT",
+							"mode":   "SERVER",
+						},
+						"tagOverrides": map[string]interface{}{
+							APIVersionMetricTag:    tag(APIVersionHeader),
+							APIDeprecatedMetricTag: tag(APIDeprecatedHeader),
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/retraining"
+)
+
+// serverRequestCountQuery returns the number of requests served by a server over the window
+func serverRequestCountQuery(server *servingv1alpha1.KalypsoTritonServer, window string) string {
+	return fmt.Sprintf(`sum(increase(istio_requests_total{%s}[%s]))`, serverSelector(server), window)
+}
+
+// analyzeExperiment aggregates the request count, error rate and p99 latency of every variant
+// since the experiment started. Queries without samples leave the metric empty
+func (r *KalypsoExperimentReconciler) analyzeExperiment(ctx context.Context, experiment *servingv1alpha1.KalypsoExperiment, servers []*servingv1alpha1.KalypsoTritonServer, now time.Time) {
+	log := logf.FromContext(ctx)
+
+	if r.MetricsQuerier == nil {
+		log.Info("Experiment analysis is configured but no metrics querier is configured")
+		return
+	}
+
+	analysis := experiment.Spec.Analysis
+	interval := parseDurationOrDefault(analysis.Interval, 5*time.Minute)
+	elapsed := interval
+	if experiment.Status.StartedAt != nil && now.Sub(experiment.Status.StartedAt.Time) > elapsed {
+		elapsed = now.Sub(experiment.Status.StartedAt.Time)
+	}
+	window := fmt.Sprintf("%ds", int64(elapsed.Seconds()))
+
+	query := func(name, query string) (float64, bool) {
+		value, err := r.MetricsQuerier.Query(ctx, analysis.PrometheusURL, query)
+		if err != nil {
+			if !errors.Is(err, retraining.ErrNoSamples) {
+				log.Info("Failed to evaluate experiment analysis", "metric", name, "error", err)
+			}
+			return 0, false
+		}
+		return value, true
+	}
+
+	variants := make([]servingv1alpha1.ExperimentVariantStatus, 0, len(servers))
+	for i, server := range servers {
+		status := servingv1alpha1.ExperimentVariantStatus{Name: experiment.Spec.Variants[i].Name}
+		if value, ok := query("requests", serverRequestCountQuery(server, window)); ok {
+			status.Requests = int64(math.Round(value))
+		}
+		if value, ok := query("error rate", serverErrorRateQuery(server, window)); ok {
+			status.ErrorRate = strconv.FormatFloat(value, 'f', -1, 64)
+		}
+		if value, ok := query("p99 latency", serverP99LatencyQuery(server, window)); ok {
+			status.P99LatencyMs = strconv.FormatFloat(value, 'f', -1, 64)
+		}
+		variants = append(variants, status)
+	}
+
+	evaluatedAt := metav1.NewTime(now)
+	experiment.Status.LastEvaluationTime = &evaluatedAt
+	experiment.Status.Variants = variants
+}
+
+// experimentObjectiveValue returns the objective metric of a variant, and whether it was observed
+func experimentObjectiveValue(objective servingv1alpha1.ExperimentObjective, variant servingv1alpha1.ExperimentVariantStatus) (float64, bool) {
+	observed := variant.P99LatencyMs
+	if objective == servingv1alpha1.ExperimentObjectiveErrorRate {
+		observed = variant.ErrorRate
+	}
+	value, err := strconv.ParseFloat(observed, 64)
+	return value, err == nil
+}
+
+// recommendWinner selects the variant with the lowest objective metric once every variant served
+// the minimum number of requests. Ties go to the variant listed first
+func recommendWinner(experiment *servingv1alpha1.KalypsoExperiment) (string, string) {
+	analysis := experiment.Spec.Analysis
+	objective := analysis.Objective
+	if objective == "" {
+		objective = servingv1alpha1.ExperimentObjectiveP99Latency
+	}
+	minRequests := analysis.MinRequests
+	if minRequests == 0 {
+		minRequests = 1000
+	}
+
+	if len(experiment.Status.Variants) == 0 {
+		return "", "Waiting for the variant metrics"
+	}
+	winner := ""
+	best := 0.0
+	for _, variant := range experiment.Status.Variants {
+		if variant.Requests < minRequests {
+			return "", fmt.Sprintf("Waiting for %d requests per variant, %s served %d", minRequests, variant.Name, variant.Requests)
+		}
+		value, ok := experimentObjectiveValue(objective, variant)
+		if !ok {
+			return "", fmt.Sprintf("No %s observed for %s", objective, variant.Name)
+		}
+		if winner == "" || value < best {
+			winner, best = variant.Name, value
+		}
+	}
+	return winner, fmt.Sprintf("%s has the lowest %s (%s) after at least %d requests per variant", winner, objective, strconv.FormatFloat(best, 'f', -1, 64), minRequests)
+}
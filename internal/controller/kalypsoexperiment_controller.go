@@ -0,0 +1,299 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+	"github.com/kalypsoServing/KalypsoServing/internal/retraining"
+)
+
+const (
+	// ExperimentLabelKey is the label key for the KalypsoExperiment owning a traffic splitting route
+	ExperimentLabelKey = "kalypso-serving.io/experiment"
+)
+
+// KalypsoExperimentReconciler reconciles a KalypsoExperiment object
+type KalypsoExperimentReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// MetricsQuerier aggregates the variant metrics
+	MetricsQuerier retraining.Querier
+}
+
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsoexperiments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsoexperiments/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsoexperiments/finalizers,verbs=update
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsoapplications,verbs=get;list;watch
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsotritonservers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile splits the application traffic between the experiment variants, aggregates their
+// metrics every analysis interval and recommends the winning variant
+func (r *KalypsoExperimentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	// Fetch the KalypsoExperiment instance
+	experiment := &servingv1alpha1.KalypsoExperiment{}
+	if err := r.Get(ctx, req.NamespacedName, experiment); err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("KalypsoExperiment resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get KalypsoExperiment")
+		return ctrl.Result{}, err
+	}
+
+	// Validate the application and variant references
+	app, servers, missing, err := r.resolveExperimentReferences(ctx, experiment)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if missing != "" {
+		log.Info("Experiment references cannot be resolved", "reason", missing)
+		meta.SetStatusCondition(&experiment.Status.Conditions, metav1.Condition{
+			Type:               "ReferencesResolved",
+			Status:             metav1.ConditionFalse,
+			Reason:             "InvalidVariants",
+			Message:            missing,
+			LastTransitionTime: metav1.Now(),
+		})
+		_ = r.Status().Update(ctx, experiment)
+		return ctrl.Result{RequeueAfter: 30000000000}, nil // 30 seconds
+	}
+	meta.SetStatusCondition(&experiment.Status.Conditions, metav1.Condition{
+		Type:               "ReferencesResolved",
+		Status:             metav1.ConditionTrue,
+		Reason:             "ReferencesFound",
+		Message:            "Application and variant servers found",
+		LastTransitionTime: metav1.Now(),
+	})
+
+	// Split the traffic
+	if err := r.reconcileExperimentRoute(ctx, experiment, app, servers); err != nil {
+		log.Error(err, "Failed to reconcile experiment VirtualService")
+		return ctrl.Result{}, err
+	}
+
+	now := time.Now()
+	if experiment.Status.StartedAt == nil {
+		startedAt := metav1.NewTime(now)
+		experiment.Status.StartedAt = &startedAt
+	}
+
+	// Aggregate the variant metrics when due
+	interval := parseDurationOrDefault(experiment.Spec.Analysis.Interval, 5*time.Minute)
+	requeueAfter := interval
+	if last := experiment.Status.LastEvaluationTime; last == nil || now.Sub(last.Time) >= interval {
+		r.analyzeExperiment(ctx, experiment, servers, now)
+	} else {
+		requeueAfter = interval - now.Sub(last.Time)
+	}
+	applyExperimentRecommendation(experiment)
+
+	if err := r.Status().Update(ctx, experiment); err != nil {
+		if errors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "Failed to update KalypsoExperiment status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// resolveExperimentReferences fetches the application and the variant servers, in variant order.
+// It returns a message instead when one is missing, a server does not belong to the application,
+// or the variant weights do not sum to 100
+func (r *KalypsoExperimentReconciler) resolveExperimentReferences(ctx context.Context, experiment *servingv1alpha1.KalypsoExperiment) (*servingv1alpha1.KalypsoApplication, []*servingv1alpha1.KalypsoTritonServer, string, error) {
+	total := int32(0)
+	for _, variant := range experiment.Spec.Variants {
+		total += variant.Weight
+	}
+	if total != 100 {
+		return nil, nil, fmt.Sprintf("Variant weights sum to %d instead of 100", total), nil
+	}
+
+	app := &servingv1alpha1.KalypsoApplication{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: experiment.Namespace, Name: experiment.Spec.ApplicationRef}, app); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil, fmt.Sprintf("KalypsoApplication '%s' not found", experiment.Spec.ApplicationRef), nil
+		}
+		return nil, nil, "", err
+	}
+
+	servers := make([]*servingv1alpha1.KalypsoTritonServer, 0, len(experiment.Spec.Variants))
+	for _, variant := range experiment.Spec.Variants {
+		server := &servingv1alpha1.KalypsoTritonServer{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: experiment.Namespace, Name: variant.ServerRef}, server); err != nil {
+			if errors.IsNotFound(err) {
+				return nil, nil, fmt.Sprintf("KalypsoTritonServer '%s' not found", variant.ServerRef), nil
+			}
+			return nil, nil, "", err
+		}
+		if !servesApplication(server, app) {
+			return nil, nil, fmt.Sprintf("KalypsoTritonServer '%s' does not belong to KalypsoApplication '%s'", variant.ServerRef, app.Name), nil
+		}
+		servers = append(servers, server)
+	}
+	return app, servers, "", nil
+}
+
+// applyExperimentRecommendation records the winning variant and the WinnerSelected condition
+func applyExperimentRecommendation(experiment *servingv1alpha1.KalypsoExperiment) {
+	winner, message := recommendWinner(experiment)
+	experiment.Status.Winner = winner
+	experiment.Status.Message = message
+
+	condition := metav1.Condition{
+		Type:               "WinnerSelected",
+		Status:             metav1.ConditionTrue,
+		Reason:             "WinnerRecommended",
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	}
+	if winner == "" {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "InsufficientData"
+	}
+	meta.SetStatusCondition(&experiment.Status.Conditions, condition)
+}
+
+// reconcileExperimentRoute ensures the VirtualService splitting the traffic between the variants
+func (r *KalypsoExperimentReconciler) reconcileExperimentRoute(ctx context.Context, experiment *servingv1alpha1.KalypsoExperiment, app *servingv1alpha1.KalypsoApplication, servers []*servingv1alpha1.KalypsoTritonServer) error {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(virtualServiceGVK)
+	route.SetName(naming.ExperimentRoute(experiment.Name))
+	route.SetNamespace(experiment.Namespace)
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, route, func() error {
+		labels := route.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[ExperimentLabelKey] = experiment.Name
+		labels[ApplicationLabelKey] = app.Name
+		labels[ManagedByLabelKey] = ManagedByLabelValue
+		route.SetLabels(labels)
+
+		if err := unstructured.SetNestedMap(route.Object, buildExperimentRouteSpec(experiment, app, servers), "spec"); err != nil {
+			return err
+		}
+
+		// Set owner reference
+		return controllerutil.SetControllerReference(experiment, route, r.Scheme)
+	})
+	return err
+}
+
+// buildExperimentRouteSpec builds the VirtualService spec routing mesh requests to the first
+// variant's Service, and the application gateway custom domains, to the variants: requests
+// carrying a variant's headers go to that variant, the others are split by weight
+func buildExperimentRouteSpec(experiment *servingv1alpha1.KalypsoExperiment, app *servingv1alpha1.KalypsoApplication, servers []*servingv1alpha1.KalypsoTritonServer) map[string]interface{} {
+	hosts := []interface{}{serviceHost(servers[0])}
+	gateways := []interface{}{"mesh"}
+	if app.Spec.Routing != nil && len(app.Spec.Routing.CustomDomains) > 0 {
+		for _, domain := range app.Spec.Routing.CustomDomains {
+			hosts = append(hosts, domain)
+		}
+		gateways = append(gateways, naming.Gateway(app.Name))
+	}
+
+	destination := func(server *servingv1alpha1.KalypsoTritonServer) map[string]interface{} {
+		return map[string]interface{}{
+			"host": serviceHost(server),
+			"port": map[string]interface{}{"number": int64(tritonHTTPPort(server, app))},
+		}
+	}
+
+	var routes []interface{}
+	for i, variant := range experiment.Spec.Variants {
+		if len(variant.Headers) == 0 {
+			continue
+		}
+		headers := make(map[string]interface{}, len(variant.Headers))
+		for name, value := range variant.Headers {
+			headers[name] = map[string]interface{}{"exact": value}
+		}
+		routes = append(routes, map[string]interface{}{
+			"name":  "variant-" + variant.Name,
+			"match": []interface{}{map[string]interface{}{"headers": headers}},
+			"route": []interface{}{map[string]interface{}{"destination": destination(servers[i])}},
+		})
+	}
+
+	split := make([]interface{}, 0, len(servers))
+	for i, variant := range experiment.Spec.Variants {
+		split = append(split, map[string]interface{}{
+			"destination": destination(servers[i]),
+			"weight":      int64(variant.Weight),
+		})
+	}
+	routes = append(routes, map[string]interface{}{
+		"name":  "experiment",
+		"route": split,
+	})
+
+	return map[string]interface{}{
+		"hosts":    hosts,
+		"gateways": gateways,
+		"http":     routes,
+	}
+}
+
+// experimentsForApplication maps a KalypsoApplication to the experiments routing its traffic, so
+// custom domain changes reach their VirtualServices
+func (r *KalypsoExperimentReconciler) experimentsForApplication(ctx context.Context, obj client.Object) []reconcile.Request {
+	experiments := &servingv1alpha1.KalypsoExperimentList{}
+	if err := r.List(ctx, experiments, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, experiment := range experiments.Items {
+		if experiment.Spec.ApplicationRef == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&experiment)})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KalypsoExperimentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&servingv1alpha1.KalypsoExperiment{}).
+		Watches(&servingv1alpha1.KalypsoApplication{}, handler.EnqueueRequestsFromMapFunc(r.experimentsForApplication)).
+		Named("kalypsoexperiment").
+		Complete(r)
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/retraining"
+)
+
+var _ = Describe("KalypsoExperiment Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default", // TODO(user):Modify as needed
+		}
+		kalypsoexperiment := &servingv1alpha1.KalypsoExperiment{}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind KalypsoExperiment")
+			err := k8sClient.Get(ctx, typeNamespacedName, kalypsoexperiment)
+			if err != nil && errors.IsNotFound(err) {
+				resource := &servingv1alpha1.KalypsoExperiment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: "default",
+					},
+					Spec: servingv1alpha1.KalypsoExperimentSpec{
+						ApplicationRef: "test-application",
+						Variants: []servingv1alpha1.ExperimentVariant{
+							{Name: "control", ServerRef: "test-control", Weight: 50},
+							{Name: "candidate", ServerRef: "test-candidate", Weight: 50},
+						},
+						Analysis: servingv1alpha1.ExperimentAnalysisSpec{PrometheusURL: "http://prometheus:9090"},
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			// TODO(user): Cleanup logic after each test, like removing the resource instance.
+			resource := &servingv1alpha1.KalypsoExperiment{}
+			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Cleanup the specific resource instance KalypsoExperiment")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+		It("should successfully reconcile the resource", func() {
+			By("Reconciling the created resource")
+			controllerReconciler := &KalypsoExperimentReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When comparing model variants", func() {
+		var (
+			ctx        context.Context
+			reconciler *KalypsoExperimentReconciler
+			querier    *variantQuerier
+			experiment *servingv1alpha1.KalypsoExperiment
+		)
+
+		reconcileExperiment := func() *servingv1alpha1.KalypsoExperiment {
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(experiment)})
+			Expect(err).NotTo(HaveOccurred())
+			updated := &servingv1alpha1.KalypsoExperiment{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(experiment), updated)).To(Succeed())
+			return updated
+		}
+
+		expireEvaluation := func() {
+			updated := &servingv1alpha1.KalypsoExperiment{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(experiment), updated)).To(Succeed())
+			evaluatedAt := metav1.NewTime(time.Now().Add(-time.Hour))
+			updated.Status.LastEvaluationTime = &evaluatedAt
+			Expect(reconciler.Status().Update(ctx, updated)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system"},
+				Spec:       servingv1alpha1.KalypsoApplicationSpec{ProjectRef: "sample-project"},
+			}
+			control := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: app.Namespace},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: app.Name},
+			}
+			candidate := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v2", Namespace: app.Namespace},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: app.Name},
+			}
+			experiment = &servingv1alpha1.KalypsoExperiment{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-ab", Namespace: app.Namespace},
+				Spec: servingv1alpha1.KalypsoExperimentSpec{
+					ApplicationRef: app.Name,
+					Variants: []servingv1alpha1.ExperimentVariant{
+						{Name: "control", ServerRef: control.Name, Weight: 80},
+						{Name: "candidate", ServerRef: candidate.Name, Weight: 20, Headers: map[string]string{"x-kalypso-variant": "candidate"}},
+					},
+					Analysis: servingv1alpha1.ExperimentAnalysisSpec{
+						PrometheusURL: "http://prometheus:9090",
+						Interval:      "5m",
+						Objective:     servingv1alpha1.ExperimentObjectiveP99Latency,
+						MinRequests:   1000,
+					},
+				},
+			}
+			querier = &variantQuerier{values: map[string]map[string]float64{}}
+			reconciler = &KalypsoExperimentReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).
+					WithObjects(app, control, candidate, experiment).
+					WithStatusSubresource(&servingv1alpha1.KalypsoExperiment{}).
+					Build(),
+				Scheme:         scheme,
+				MetricsQuerier: querier,
+			}
+		})
+
+		It("should pin header matches to their variant and split the rest by weight", func() {
+			reconcileExperiment()
+
+			route := &unstructured.Unstructured{}
+			route.SetGroupVersionKind(virtualServiceGVK)
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: experiment.Namespace, Name: "recommendation-ab-experiment"}, route)).To(Succeed())
+			hosts, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hosts")
+			Expect(hosts).To(ConsistOf("recommendation-v1-svc.kalypso-system.svc.cluster.local"))
+
+			routes, _, _ := unstructured.NestedSlice(route.Object, "spec", "http")
+			Expect(routes).To(HaveLen(2))
+			pinned := routes[0].(map[string]interface{})
+			Expect(pinned["name"]).To(Equal("variant-candidate"))
+			exact, _, _ := unstructured.NestedString(pinned["match"].([]interface{})[0].(map[string]interface{}), "headers", "x-kalypso-variant", "exact")
+			Expect(exact).To(Equal("candidate"))
+
+			var weights []int64
+			for _, destination := range routes[1].(map[string]interface{})["route"].([]interface{}) {
+				weights = append(weights, destination.(map[string]interface{})["weight"].(int64))
+			}
+			Expect(weights).To(Equal([]int64{80, 20}))
+		})
+
+		It("should recommend the variant with the lowest objective once both served enough requests", func() {
+			querier.values["recommendation-v1-svc"] = map[string]float64{"requests": 4000, "latency": 180, "errors": 0.001}
+			querier.values["recommendation-v2-svc"] = map[string]float64{"requests": 400, "latency": 120, "errors": 0.002}
+
+			updated := reconcileExperiment()
+			Expect(updated.Status.StartedAt).NotTo(BeNil())
+			Expect(updated.Status.Variants).To(HaveLen(2))
+			Expect(updated.Status.Variants[1].Requests).To(Equal(int64(400)))
+			Expect(updated.Status.Winner).To(BeEmpty())
+			Expect(meta.IsStatusConditionFalse(updated.Status.Conditions, "WinnerSelected")).To(BeTrue())
+
+			By("aggregating again after the candidate served enough requests")
+			querier.values["recommendation-v2-svc"]["requests"] = 1200
+			expireEvaluation()
+			updated = reconcileExperiment()
+			Expect(updated.Status.Winner).To(Equal("candidate"))
+			Expect(updated.Status.Variants[1].P99LatencyMs).To(Equal("120"))
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, "WinnerSelected")).To(BeTrue())
+		})
+
+		It("should reject variant weights not summing to 100", func() {
+			updated := &servingv1alpha1.KalypsoExperiment{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(experiment), updated)).To(Succeed())
+			updated.Spec.Variants[1].Weight = 30
+			Expect(reconciler.Update(ctx, updated)).To(Succeed())
+
+			updated = reconcileExperiment()
+			condition := meta.FindStatusCondition(updated.Status.Conditions, "ReferencesResolved")
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Message).To(ContainSubstring("sum to 110"))
+		})
+	})
+})
+
+// variantQuerier answers queries with the value recorded for the queried Service and metric
+type variantQuerier struct {
+	values map[string]map[string]float64
+}
+
+func (q *variantQuerier) Query(_ context.Context, _, query string) (float64, error) {
+	metric := "requests"
+	switch {
+	case strings.Contains(query, "response_code"):
+		metric = "errors"
+	case strings.Contains(query, "histogram_quantile"):
+		metric = "latency"
+	}
+	for service, values := range q.values {
+		if value, ok := values[metric]; ok && strings.Contains(query, `"`+service+`"`) {
+			return value, nil
+		}
+	}
+	return 0, retraining.ErrNoSamples
+}
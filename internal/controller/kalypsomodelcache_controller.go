@@ -0,0 +1,269 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+const (
+	// ModelCacheLabelKey is the label key for the KalypsoModelCache owning a cache DaemonSet
+	ModelCacheLabelKey = "kalypso-serving.io/model-cache"
+	// modelCacheDefaultRefresh is how often the cached repositories are synced with the bucket
+	modelCacheDefaultRefresh = 10 * time.Minute
+)
+
+// modelCacheSyncScript syncs every repository of CACHE_MODELS, one "<key> <source> [flags]" line
+// each, to the node cache and marks it ready once fully pulled. Failed syncs are retried on the
+// next refresh, leaving the previous copy in place
+const modelCacheSyncScript = `set -u
+mkdir -p "$MODEL_CACHE/` + modelCacheReadyDir + `"
+while true; do
+  echo "$CACHE_MODELS" | while read -r key source flags; do
+    [ -n "$key" ] || continue
+    if /s5cmd $S5CMD_FLAGS $flags sync --delete "$source" "$MODEL_CACHE/$key/"; then
+      touch "$MODEL_CACHE/` + modelCacheReadyDir + `/$key"
+    else
+      echo "failed to sync $source" >&2
+    fi
+  done
+  sleep "$CACHE_REFRESH_SECONDS"
+done
+`
+
+// modelCacheReadyScript succeeds once every repository is marked ready
+const modelCacheReadyScript = `for key in $CACHE_KEYS; do test -f "$MODEL_CACHE/` + modelCacheReadyDir + `/$key" || exit 1; done`
+
+// KalypsoModelCacheReconciler reconciles a KalypsoModelCache object
+type KalypsoModelCacheReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsomodelcaches,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsomodelcaches/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsomodelcaches/finalizers,verbs=update
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsoapplications,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile ensures the DaemonSet pulling the model repositories of the cache onto its nodes and
+// reports how many nodes hold them
+func (r *KalypsoModelCacheReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	// Fetch the KalypsoModelCache instance
+	cache := &servingv1alpha1.KalypsoModelCache{}
+	if err := r.Get(ctx, req.NamespacedName, cache); err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("KalypsoModelCache resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get KalypsoModelCache")
+		return ctrl.Result{}, err
+	}
+
+	// The application provides the storage configuration and credentials
+	app := &servingv1alpha1.KalypsoApplication{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cache.Namespace, Name: cache.Spec.ApplicationRef}, app); err != nil {
+		if !errors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		log.Info("Application of the model cache not found", "application", cache.Spec.ApplicationRef)
+		meta.SetStatusCondition(&cache.Status.Conditions, metav1.Condition{
+			Type:               "Ready",
+			Status:             metav1.ConditionFalse,
+			Reason:             "ApplicationNotFound",
+			Message:            fmt.Sprintf("KalypsoApplication '%s' not found", cache.Spec.ApplicationRef),
+			LastTransitionTime: metav1.Now(),
+		})
+		_ = r.Status().Update(ctx, cache)
+		return ctrl.Result{RequeueAfter: 30000000000}, nil // 30 seconds
+	}
+
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.ModelCache(cache.Name),
+			Namespace: cache.Namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, daemonSet, func() error {
+		mutateModelCacheDaemonSet(daemonSet, cache, app)
+		return controllerutil.SetControllerReference(cache, daemonSet, r.Scheme)
+	}); err != nil {
+		log.Error(err, "Failed to reconcile model cache DaemonSet")
+		return ctrl.Result{}, err
+	}
+
+	applyModelCacheStatus(cache, daemonSet)
+	if err := r.Status().Update(ctx, cache); err != nil {
+		if errors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "Failed to update KalypsoModelCache status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// buildModelCacheModels returns the CACHE_MODELS lines and the keys of the cached repositories
+func buildModelCacheModels(cache *servingv1alpha1.KalypsoModelCache, app *servingv1alpha1.KalypsoApplication) (string, []string) {
+	uris := append([]string(nil), cache.Spec.StorageURIs...)
+	sort.Strings(uris)
+
+	lines := make([]string, 0, len(uris))
+	keys := make([]string, 0, len(uris))
+	for _, uri := range uris {
+		source, endpoint := s5cmdSource(uri, app.Spec.Storage)
+		line := []string{modelCacheKey(uri), source}
+		if endpoint != "" {
+			line = append(line, "--endpoint-url", endpoint)
+		}
+		lines = append(lines, strings.Join(line, " "))
+		keys = append(keys, modelCacheKey(uri))
+	}
+	return strings.Join(lines, "\n"), keys
+}
+
+// mutateModelCacheDaemonSet applies the desired cache sync pods to the DaemonSet
+func mutateModelCacheDaemonSet(daemonSet *appsv1.DaemonSet, cache *servingv1alpha1.KalypsoModelCache, app *servingv1alpha1.KalypsoApplication) {
+	labels := map[string]string{
+		ModelCacheLabelKey: cache.Name,
+		ManagedByLabelKey:  ManagedByLabelValue,
+	}
+	if daemonSet.Labels == nil {
+		daemonSet.Labels = make(map[string]string)
+	}
+	for k, v := range labels {
+		daemonSet.Labels[k] = v
+	}
+	// The selector is immutable
+	if daemonSet.Spec.Selector == nil {
+		daemonSet.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{ModelCacheLabelKey: cache.Name}}
+	}
+
+	image := cache.Spec.Image
+	if image == "" {
+		image = localDownloadDefaultImage
+	}
+	models, keys := buildModelCacheModels(cache, app)
+	refresh := parseDurationOrDefault(cache.Spec.RefreshInterval, modelCacheDefaultRefresh)
+	env, envFrom := buildS3Env(app.Spec.Storage)
+	env = append(env,
+		corev1.EnvVar{Name: "MODEL_CACHE", Value: ModelCacheMountPath},
+		corev1.EnvVar{Name: "CACHE_MODELS", Value: models},
+		corev1.EnvVar{Name: "CACHE_KEYS", Value: strings.Join(keys, " ")},
+		corev1.EnvVar{Name: "CACHE_REFRESH_SECONDS", Value: strconv.Itoa(int(refresh.Seconds()))},
+		corev1.EnvVar{Name: "S5CMD_FLAGS", Value: strings.Join(buildS5cmdFlags(cache.Spec.Workers, ""), " ")},
+	)
+
+	daemonSet.Spec.Template = corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: labels},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:      "cache-sync",
+				Image:     image,
+				Command:   []string{"sh", "-c", modelCacheSyncScript},
+				Env:       env,
+				EnvFrom:   envFrom,
+				Resources: *cache.Spec.Resources.DeepCopy(),
+				ReadinessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						Exec: &corev1.ExecAction{Command: []string{"sh", "-c", modelCacheReadyScript}},
+					},
+					PeriodSeconds: 10,
+				},
+				VolumeMounts: []corev1.VolumeMount{{Name: ModelCacheVolumeName, MountPath: ModelCacheMountPath}},
+			}},
+			Volumes:            []corev1.Volume{modelCacheVolume(cache.Namespace, cache.Name)},
+			ServiceAccountName: cache.Spec.ServiceAccountName,
+			NodeSelector:       cache.Spec.NodeSelector,
+			Tolerations:        cache.Spec.Tolerations,
+		},
+	}
+	podSpec := &daemonSet.Spec.Template.Spec
+	applyS3CABundle(podSpec, app.Spec.Storage, &podSpec.Containers[0])
+}
+
+// applyModelCacheStatus reports the nodes holding every repository of the cache
+func applyModelCacheStatus(cache *servingv1alpha1.KalypsoModelCache, daemonSet *appsv1.DaemonSet) {
+	cache.Status.DesiredNodes = daemonSet.Status.DesiredNumberScheduled
+	cache.Status.ReadyNodes = daemonSet.Status.NumberReady
+	cache.Status.ObservedGeneration = cache.Generation
+
+	condition := metav1.Condition{
+		Type:               "Ready",
+		Status:             metav1.ConditionTrue,
+		Reason:             "RepositoriesCached",
+		Message:            fmt.Sprintf("%d/%d nodes hold the model repositories under %s", cache.Status.ReadyNodes, cache.Status.DesiredNodes, path.Join(servingv1alpha1.ModelCacheHostPath, cache.Namespace, cache.Name)),
+		LastTransitionTime: metav1.Now(),
+	}
+	if cache.Status.DesiredNodes == 0 || cache.Status.ReadyNodes < cache.Status.DesiredNodes {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Caching"
+	}
+	meta.SetStatusCondition(&cache.Status.Conditions, condition)
+}
+
+// modelCachesForApplication maps a KalypsoApplication to the caches using its storage
+// configuration, so credential changes reach their DaemonSets
+func (r *KalypsoModelCacheReconciler) modelCachesForApplication(ctx context.Context, obj client.Object) []reconcile.Request {
+	caches := &servingv1alpha1.KalypsoModelCacheList{}
+	if err := r.List(ctx, caches, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, cache := range caches.Items {
+		if cache.Spec.ApplicationRef == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cache)})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KalypsoModelCacheReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&servingv1alpha1.KalypsoModelCache{}).
+		Owns(&appsv1.DaemonSet{}).
+		Watches(&servingv1alpha1.KalypsoApplication{}, handler.EnqueueRequestsFromMapFunc(r.modelCachesForApplication)).
+		Named("kalypsomodelcache").
+		Complete(r)
+}
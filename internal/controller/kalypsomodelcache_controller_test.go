@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+var _ = Describe("KalypsoModelCache Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default", // TODO(user):Modify as needed
+		}
+		kalypsomodelcache := &servingv1alpha1.KalypsoModelCache{}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind KalypsoModelCache")
+			err := k8sClient.Get(ctx, typeNamespacedName, kalypsomodelcache)
+			if err != nil && errors.IsNotFound(err) {
+				resource := &servingv1alpha1.KalypsoModelCache{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: "default",
+					},
+					Spec: servingv1alpha1.KalypsoModelCacheSpec{
+						ApplicationRef: "test-application",
+						StorageURIs:    []string{"s3://models/recommendation"},
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			// TODO(user): Cleanup logic after each test, like removing the resource instance.
+			resource := &servingv1alpha1.KalypsoModelCache{}
+			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Cleanup the specific resource instance KalypsoModelCache")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+		It("should successfully reconcile the resource", func() {
+			By("Reconciling the created resource")
+			controllerReconciler := &KalypsoModelCacheReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When pre-pulling model repositories onto the nodes", func() {
+		It("should sync every repository with the application credentials", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(appsv1.AddToScheme(scheme)).To(Succeed())
+
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ProjectRef: "sample-project",
+					Storage:    &servingv1alpha1.StorageSpec{SecretName: "s3-credentials", Region: "us-east-1"},
+				},
+			}
+			cache := &servingv1alpha1.KalypsoModelCache{
+				ObjectMeta: metav1.ObjectMeta{Name: "gpu-nodes", Namespace: app.Namespace},
+				Spec: servingv1alpha1.KalypsoModelCacheSpec{
+					ApplicationRef:  app.Name,
+					StorageURIs:     []string{"s3://models/recommendation/", "s3://minio.minio.svc:9000/models/llm"},
+					RefreshInterval: "5m",
+					NodeSelector:    map[string]string{"nvidia.com/gpu.present": "true"},
+				},
+			}
+			reconciler := &KalypsoModelCacheReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).
+					WithObjects(app, cache).
+					WithStatusSubresource(&servingv1alpha1.KalypsoModelCache{}).
+					Build(),
+				Scheme: scheme,
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cache)})
+			Expect(err).NotTo(HaveOccurred())
+
+			daemonSet := &appsv1.DaemonSet{}
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: "gpu-nodes-model-cache"}, daemonSet)).To(Succeed())
+			podSpec := daemonSet.Spec.Template.Spec
+			Expect(podSpec.NodeSelector).To(HaveKeyWithValue("nvidia.com/gpu.present", "true"))
+			Expect(podSpec.Volumes[0].HostPath.Path).To(Equal("/var/lib/kalypso/model-cache/kalypso-system/gpu-nodes"))
+			container := podSpec.Containers[0]
+			Expect(container.EnvFrom).To(HaveLen(1))
+			env := map[string]string{}
+			for _, e := range container.Env {
+				env[e.Name] = e.Value
+			}
+			Expect(env).To(HaveKeyWithValue("AWS_REGION", "us-east-1"))
+			Expect(env).To(HaveKeyWithValue("CACHE_REFRESH_SECONDS", "300"))
+			Expect(env["CACHE_MODELS"]).To(ContainSubstring(
+				modelCacheKey("s3://models/recommendation") + " s3://models/recommendation/*"))
+			Expect(env["CACHE_MODELS"]).To(ContainSubstring(
+				" s3://models/llm/* --endpoint-url http://minio.minio.svc:9000"))
+
+			updated := &servingv1alpha1.KalypsoModelCache{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(cache), updated)).To(Succeed())
+			Expect(meta.IsStatusConditionFalse(updated.Status.Conditions, "Ready")).To(BeTrue())
+		})
+	})
+})
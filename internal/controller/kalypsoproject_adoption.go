@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+// namespaceConflict is an existing environment namespace the project does not manage and,
+// following the environment adoption policy, does not adopt
+type namespaceConflict struct {
+	namespace string
+	policy    servingv1alpha1.NamespaceAdoptionPolicy
+}
+
+// findNamespaceConflict returns the conflict of an environment namespace that exists without
+// the project and managed-by labels of the project, nil when it may be created or managed
+func (r *KalypsoProjectReconciler) findNamespaceConflict(ctx context.Context, project *servingv1alpha1.KalypsoProject, nsName string, policy servingv1alpha1.NamespaceAdoptionPolicy) (*namespaceConflict, error) {
+	if policy == "" || policy == servingv1alpha1.NamespaceAdoptionAdopt {
+		return nil, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, client.ObjectKey{Name: nsName}, ns); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if hasProjectLabels(ns.Labels, project) && ns.Labels[ManagedByLabelKey] == ManagedByLabelValue {
+		return nil, nil
+	}
+	return &namespaceConflict{namespace: nsName, policy: policy}, nil
+}
+
+// applyNamespaceConflictStatus reports the namespaces left alone in the NamespaceConflict
+// condition, and marks the project Failed when one of them has the Fail adoption policy
+func applyNamespaceConflictStatus(project *servingv1alpha1.KalypsoProject, conflicts []namespaceConflict) {
+	if len(conflicts) == 0 {
+		meta.SetStatusCondition(&project.Status.Conditions, metav1.Condition{
+			Type:               "NamespaceConflict",
+			Status:             metav1.ConditionFalse,
+			Reason:             "NoConflicts",
+			Message:            "All environment namespaces are managed by the project",
+			LastTransitionTime: metav1.Now(),
+		})
+		return
+	}
+
+	var descriptions []string
+	failed := false
+	for _, conflict := range conflicts {
+		descriptions = append(descriptions, fmt.Sprintf("%s (%s)", conflict.namespace, conflict.policy))
+		failed = failed || conflict.policy == servingv1alpha1.NamespaceAdoptionFail
+	}
+	meta.SetStatusCondition(&project.Status.Conditions, metav1.Condition{
+		Type:               "NamespaceConflict",
+		Status:             metav1.ConditionTrue,
+		Reason:             "AdoptionConflict",
+		Message:            fmt.Sprintf("Existing namespaces not managed by the project were left alone: %s", strings.Join(descriptions, ", ")),
+		LastTransitionTime: metav1.Now(),
+	})
+	if failed {
+		project.Status.Phase = servingv1alpha1.ProjectPhaseFailed
+	}
+}
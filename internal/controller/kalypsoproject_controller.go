@@ -19,23 +19,32 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
 )
 
 const (
 	// ProjectLabelKey is the label key for project identification
-	ProjectLabelKey = "kalypso-serving.io/project"
+	ProjectLabelKey = defaults.ProjectLabelKey
+	// ProjectNamespaceLabelKey is the label key for the namespace of the identified project
+	ProjectNamespaceLabelKey = defaults.ProjectNamespaceLabelKey
 	// EnvironmentLabelKey is the label key for environment identification
 	EnvironmentLabelKey = "kalypso-serving.io/environment"
 	// ManagedByLabelKey is the label key for managed-by identification
@@ -46,10 +55,20 @@ const (
 	FinalizerName = "serving.kalypso.io/finalizer"
 )
 
+// podSecurityLabelKeys are the namespace labels of the Pod Security admission modes
+var podSecurityLabelKeys = []string{
+	"pod-security.kubernetes.io/enforce",
+	"pod-security.kubernetes.io/audit",
+	"pod-security.kubernetes.io/warn",
+}
+
 // KalypsoProjectReconciler reconciles a KalypsoProject object
 type KalypsoProjectReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// APIReader reads the objects the manager cache filters out, such as the default
+	// ServiceAccounts of the environment namespaces. The client is used when unset
+	APIReader client.Reader
 }
 
 // +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsoprojects,verbs=get;list;watch;create;update;patch;delete
@@ -59,6 +78,11 @@ type KalypsoProjectReconciler struct {
 // +kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=limitranges,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;create;update;patch;delete;escalate;bind
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsoapplications,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsotritonservers,verbs=get;list;watch;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -101,14 +125,28 @@ func (r *KalypsoProjectReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	// Reconcile namespaces for each environment
 	createdNamespaces := []string{}
+	var namespaceConflicts []namespaceConflict
+	var pullSecretConflicts []string
 	for envName, envSpec := range project.Spec.Environments {
 		nsName := envSpec.Namespace
 		if nsName == "" {
 			nsName = fmt.Sprintf("%s-%s", project.Name, envName)
 		}
 
+		// Leave existing namespaces alone unless the environment adopts them
+		conflict, err := r.findNamespaceConflict(ctx, project, nsName, envSpec.AdoptionPolicy)
+		if err != nil {
+			log.Error(err, "Failed to check the namespace adoption", "namespace", nsName)
+			return ctrl.Result{}, err
+		}
+		if conflict != nil {
+			log.Info("Skipping an existing namespace not managed by the project", "namespace", nsName, "adoptionPolicy", conflict.policy)
+			namespaceConflicts = append(namespaceConflicts, *conflict)
+			continue
+		}
+
 		// Reconcile namespace
-		if err := r.reconcileNamespace(ctx, project, envName, nsName); err != nil {
+		if err := r.reconcileNamespace(ctx, project, envName, nsName, envSpec.PodSecurity); err != nil {
 			log.Error(err, "Failed to reconcile namespace", "namespace", nsName)
 			r.setFailedStatus(ctx, project, fmt.Sprintf("Failed to create namespace %s: %v", nsName, err))
 			return ctrl.Result{}, err
@@ -132,9 +170,48 @@ func (r *KalypsoProjectReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			}
 		}
 
+		// Copy the image pull Secrets of the project into the namespace
+		conflicts, err := r.reconcileImagePullSecrets(ctx, project, envName, nsName)
+		if err != nil {
+			log.Error(err, "Failed to reconcile image pull Secrets", "namespace", nsName)
+			r.setFailedStatus(ctx, project, fmt.Sprintf("Failed to replicate image pull Secrets to %s: %v", nsName, err))
+			return ctrl.Result{}, err
+		}
+		if len(conflicts) > 0 {
+			log.Info("Skipping existing Secrets not copied by the project", "secrets", conflicts)
+			pullSecretConflicts = append(pullSecretConflicts, conflicts...)
+		}
+
+		// Grant the project members their role in the namespace
+		if err := r.reconcileMembers(ctx, project, envName, nsName); err != nil {
+			log.Error(err, "Failed to reconcile member RBAC", "namespace", nsName)
+			r.setFailedStatus(ctx, project, fmt.Sprintf("Failed to grant the project members access to %s: %v", nsName, err))
+			return ctrl.Result{}, err
+		}
+
 		createdNamespaces = append(createdNamespaces, nsName)
 	}
 
+	// Build the naming uniqueness report for the project's servers
+	namingConflicts, err := r.findNamingConflicts(ctx, project, createdNamespaces)
+	if err != nil {
+		log.Error(err, "Failed to build naming conflict report")
+		// Continue anyway, just log the error
+	}
+
+	// Build the model repository deduplication report for the project's servers
+	duplicateModels, err := r.findDuplicateModels(ctx, project)
+	if err != nil {
+		log.Error(err, "Failed to build duplicate model report")
+		// Continue anyway, just log the error
+	}
+
+	// Apply the bulk operation requested on the project to all of its servers
+	bulkOperation, err := r.reconcileBulkOperation(ctx, project)
+	if err != nil {
+		log.Error(err, "Failed to list TritonServers for the bulk operation")
+	}
+
 	// Re-fetch the project to get the latest version before updating status
 	if err := r.Get(ctx, req.NamespacedName, project); err != nil {
 		return ctrl.Result{}, err
@@ -143,6 +220,27 @@ func (r *KalypsoProjectReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// Update status to Ready
 	project.Status.Phase = servingv1alpha1.ProjectPhaseReady
 	project.Status.CreatedNamespaces = createdNamespaces
+	project.Status.NamingConflicts = namingConflicts
+	project.Status.DuplicateModels = duplicateModels
+	recordDuplicateModelMetrics(project, duplicateModels)
+	project.Status.BulkOperation = bulkOperation
+	if len(namingConflicts) > 0 {
+		meta.SetStatusCondition(&project.Status.Conditions, metav1.Condition{
+			Type:               "NamesUnique",
+			Status:             metav1.ConditionFalse,
+			Reason:             "DerivedNameCollision",
+			Message:            fmt.Sprintf("%d derived resource names are shared by multiple KalypsoTritonServers", len(namingConflicts)),
+			LastTransitionTime: metav1.Now(),
+		})
+	} else {
+		meta.SetStatusCondition(&project.Status.Conditions, metav1.Condition{
+			Type:               "NamesUnique",
+			Status:             metav1.ConditionTrue,
+			Reason:             "NoCollisions",
+			Message:            "All derived resource names are unique",
+			LastTransitionTime: metav1.Now(),
+		})
+	}
 	meta.SetStatusCondition(&project.Status.Conditions, metav1.Condition{
 		Type:               "NamespaceCreated",
 		Status:             metav1.ConditionTrue,
@@ -150,6 +248,8 @@ func (r *KalypsoProjectReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		Message:            fmt.Sprintf("All %d namespaces are ready", len(createdNamespaces)),
 		LastTransitionTime: metav1.Now(),
 	})
+	applyNamespaceConflictStatus(project, namespaceConflicts)
+	applyPullSecretConflictStatus(project, pullSecretConflicts)
 
 	if err := r.Status().Update(ctx, project); err != nil {
 		if errors.IsConflict(err) {
@@ -160,6 +260,15 @@ func (r *KalypsoProjectReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	}
 
 	log.Info("Successfully reconciled KalypsoProject", "project", project.Name, "namespaces", createdNamespaces)
+
+	if bulkOperationPending(bulkOperation) {
+		// Follow the servers until they reach the requested state
+		return ctrl.Result{RequeueAfter: 10000000000}, nil // 10 seconds
+	}
+	if len(namespaceConflicts) > 0 || len(pullSecretConflicts) > 0 {
+		// Unmanaged namespaces and Secrets are not watched, so re-check until they are labeled or removed
+		return ctrl.Result{RequeueAfter: 30000000000}, nil // 30 seconds
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -167,25 +276,29 @@ func (r *KalypsoProjectReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 func (r *KalypsoProjectReconciler) reconcileDelete(ctx context.Context, project *servingv1alpha1.KalypsoProject) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
-	// Delete all managed namespaces
-	for _, nsName := range project.Status.CreatedNamespaces {
-		ns := &corev1.Namespace{}
-		if err := r.Get(ctx, client.ObjectKey{Name: nsName}, ns); err != nil {
-			if errors.IsNotFound(err) {
-				continue
-			}
-			return ctrl.Result{}, err
+	// Delete TritonServers, applications and namespaces in dependency order
+	progress, err := r.deleteProjectResources(ctx, project)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if progress != nil {
+		log.Info("Waiting for project resources to be deleted",
+			"phase", progress.Phase,
+			"tritonServers", progress.RemainingTritonServers,
+			"applications", progress.RemainingApplications,
+			"namespaces", progress.RemainingNamespaces)
+		if cond := meta.FindStatusCondition(project.Status.Conditions, "NamespaceDeletionStuck"); cond != nil {
+			log.Info("Namespace deletion is stuck", "details", cond.Message)
 		}
-
-		// Check if namespace is managed by this project
-		if ns.Labels[ProjectLabelKey] == project.Name {
-			log.Info("Deleting namespace", "namespace", nsName)
-			if err := r.Delete(ctx, ns); err != nil && !errors.IsNotFound(err) {
-				return ctrl.Result{}, err
-			}
+		project.Status.DeletionProgress = progress
+		if err := r.Status().Update(ctx, project); err != nil && !errors.IsConflict(err) {
+			return ctrl.Result{}, err
 		}
+		return ctrl.Result{RequeueAfter: 5000000000}, nil // 5 seconds
 	}
 
+	deleteDuplicateModelMetrics(project)
+
 	// Remove finalizer
 	controllerutil.RemoveFinalizer(project, FinalizerName)
 	if err := r.Update(ctx, project); err != nil {
@@ -199,8 +312,19 @@ func (r *KalypsoProjectReconciler) reconcileDelete(ctx context.Context, project
 	return ctrl.Result{}, nil
 }
 
-// reconcileNamespace ensures the namespace exists with proper labels
-func (r *KalypsoProjectReconciler) reconcileNamespace(ctx context.Context, project *servingv1alpha1.KalypsoProject, envName, nsName string) error {
+// hasProjectLabels reports whether the labels identify the project. Resources labeled before the
+// project namespace label existed only carry the project name, which is then enough
+func hasProjectLabels(labels map[string]string, project *servingv1alpha1.KalypsoProject) bool {
+	if labels[ProjectLabelKey] != project.Name {
+		return false
+	}
+	namespace, ok := labels[ProjectNamespaceLabelKey]
+	return !ok || namespace == project.Namespace
+}
+
+// reconcileNamespace ensures the namespace exists with proper labels, including the Pod
+// Security Standards labels of the environment, which are restored when edited
+func (r *KalypsoProjectReconciler) reconcileNamespace(ctx context.Context, project *servingv1alpha1.KalypsoProject, envName, nsName string, podSecurity servingv1alpha1.PodSecurityLevel) error {
 	ns := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: nsName,
@@ -212,8 +336,14 @@ func (r *KalypsoProjectReconciler) reconcileNamespace(ctx context.Context, proje
 			ns.Labels = make(map[string]string)
 		}
 		ns.Labels[ProjectLabelKey] = project.Name
+		ns.Labels[ProjectNamespaceLabelKey] = project.Namespace
 		ns.Labels[EnvironmentLabelKey] = envName
 		ns.Labels[ManagedByLabelKey] = ManagedByLabelValue
+		if podSecurity != "" {
+			for _, key := range podSecurityLabelKeys {
+				ns.Labels[key] = string(podSecurity)
+			}
+		}
 		return nil
 	})
 
@@ -245,6 +375,7 @@ func (r *KalypsoProjectReconciler) reconcileResourceQuota(ctx context.Context, p
 			quota.Labels = make(map[string]string)
 		}
 		quota.Labels[ProjectLabelKey] = project.Name
+		quota.Labels[ProjectNamespaceLabelKey] = project.Namespace
 		quota.Labels[EnvironmentLabelKey] = envName
 		quota.Labels[ManagedByLabelKey] = ManagedByLabelValue
 		quota.Spec.Hard = hard
@@ -270,6 +401,7 @@ func (r *KalypsoProjectReconciler) reconcileLimitRange(ctx context.Context, proj
 			limitRange.Labels = make(map[string]string)
 		}
 		limitRange.Labels[ProjectLabelKey] = project.Name
+		limitRange.Labels[ProjectNamespaceLabelKey] = project.Namespace
 		limitRange.Labels[EnvironmentLabelKey] = envName
 		limitRange.Labels[ManagedByLabelKey] = ManagedByLabelValue
 		limitRange.Spec.Limits = limitSpec.Limits
@@ -279,6 +411,126 @@ func (r *KalypsoProjectReconciler) reconcileLimitRange(ctx context.Context, proj
 	return err
 }
 
+// findNamingConflicts reports derived resource name collisions among the project's KalypsoTritonServers,
+// covering servers of the project's applications and servers in the project's environment namespaces
+func (r *KalypsoProjectReconciler) findNamingConflicts(ctx context.Context, project *servingv1alpha1.KalypsoProject, namespaces []string) ([]servingv1alpha1.NamingConflict, error) {
+	apps, err := listProjectReferrers(ctx, r.Client, project)
+	if err != nil {
+		return nil, err
+	}
+	projectApps := make(map[types.NamespacedName]bool)
+	scanned := append([]string{project.Namespace}, namespaces...)
+	scanned = append(scanned, project.Spec.AllowedNamespaces...)
+	for _, app := range apps {
+		projectApps[types.NamespacedName{Namespace: app.Namespace, Name: app.Name}] = true
+		scanned = append(scanned, app.Namespace)
+	}
+	sort.Strings(scanned)
+
+	var conflicts []servingv1alpha1.NamingConflict
+	seen := make(map[string]bool)
+	for _, nsName := range scanned {
+		if seen[nsName] {
+			continue
+		}
+		seen[nsName] = true
+
+		servers := &servingv1alpha1.KalypsoTritonServerList{}
+		if err := r.List(ctx, servers, client.InNamespace(nsName)); err != nil {
+			return nil, err
+		}
+
+		serverNames := make([]string, 0, len(servers.Items))
+		projectServers := make(map[string]bool)
+		for _, server := range servers.Items {
+			serverNames = append(serverNames, server.Name)
+			if (nsName != project.Namespace && slices.Contains(namespaces, nsName)) || projectApps[serverApplicationKey(&server)] {
+				projectServers[server.Name] = true
+			}
+		}
+
+		collisions := naming.FindCollisions(serverNames)
+		resourceNames := make([]string, 0, len(collisions))
+		for resourceName := range collisions {
+			resourceNames = append(resourceNames, resourceName)
+		}
+		sort.Strings(resourceNames)
+
+		for _, resourceName := range resourceNames {
+			owners := collisions[resourceName]
+			for _, owner := range owners {
+				if projectServers[owner] {
+					conflicts = append(conflicts, servingv1alpha1.NamingConflict{
+						Namespace:    nsName,
+						ResourceName: resourceName,
+						Servers:      owners,
+					})
+					break
+				}
+			}
+		}
+	}
+	return conflicts, nil
+}
+
+// reconcileBulkOperation applies the bulk operation annotation to every server of the project
+func (r *KalypsoProjectReconciler) reconcileBulkOperation(ctx context.Context, project *servingv1alpha1.KalypsoProject) (*servingv1alpha1.BulkOperationStatus, error) {
+	if _, ok := project.Annotations[BulkOperationAnnotation]; !ok {
+		return nil, nil
+	}
+
+	apps, err := r.listProjectApplications(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	servers, err := r.listProjectTritonServers(ctx, project, apps)
+	if err != nil {
+		return nil, err
+	}
+	return applyBulkOperation(ctx, r.Client, project, servers), nil
+}
+
+// projectsForTritonServer maps a KalypsoTritonServer to the projects whose naming and duplicate
+// model reports cover its namespace
+func (r *KalypsoProjectReconciler) projectsForTritonServer(ctx context.Context, obj client.Object) []reconcile.Request {
+	projects := &servingv1alpha1.KalypsoProjectList{}
+	if err := r.List(ctx, projects); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, project := range projects.Items {
+		if project.Namespace == obj.GetNamespace() || slices.Contains(project.Status.CreatedNamespaces, obj.GetNamespace()) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: project.Name, Namespace: project.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
+// projectsForNamespace maps a namespace to the projects that created it, so edits of its
+// labels are reverted
+func (r *KalypsoProjectReconciler) projectsForNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetLabels()[ManagedByLabelKey] != ManagedByLabelValue {
+		return nil
+	}
+	projects := &servingv1alpha1.KalypsoProjectList{}
+	if err := r.List(ctx, projects); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, project := range projects.Items {
+		if slices.Contains(project.Status.CreatedNamespaces, obj.GetName()) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: project.Name, Namespace: project.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
 // setFailedStatus updates the project status to Failed
 func (r *KalypsoProjectReconciler) setFailedStatus(ctx context.Context, project *servingv1alpha1.KalypsoProject, message string) {
 	project.Status.Phase = servingv1alpha1.ProjectPhaseFailed
@@ -297,6 +549,10 @@ func (r *KalypsoProjectReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&servingv1alpha1.KalypsoProject{}).
 		Owns(&corev1.Namespace{}).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.projectsForNamespace)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.projectsForImagePullSecret)).
+		Watches(&corev1.ServiceAccount{}, handler.EnqueueRequestsFromMapFunc(r.projectsForServiceAccount)).
+		Watches(&servingv1alpha1.KalypsoTritonServer{}, handler.EnqueueRequestsFromMapFunc(r.projectsForTritonServer)).
 		Named("kalypsoproject").
 		Complete(r)
 }
@@ -18,16 +18,25 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
 )
 
 var _ = Describe("KalypsoProject Controller", func() {
@@ -81,4 +90,492 @@ var _ = Describe("KalypsoProject Controller", func() {
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
 	})
+	Context("When deleting a project", func() {
+		It("should delete TritonServers, then applications, then namespaces", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			project := &servingv1alpha1.KalypsoProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "kalypso-system"},
+				Status:     servingv1alpha1.KalypsoProjectStatus{CreatedNamespaces: []string{"sample-project-dev"}},
+			}
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "sample-project-dev",
+				Labels: map[string]string{ProjectLabelKey: project.Name},
+			}}
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system"},
+				Spec:       servingv1alpha1.KalypsoApplicationSpec{ProjectRef: project.Name},
+			}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system"},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: app.Name},
+			}
+			reconciler := &KalypsoProjectReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(project, namespace, app, server).Build(),
+				Scheme: scheme,
+			}
+
+			By("deleting the TritonServers first")
+			progress, err := reconciler.deleteProjectResources(ctx, project)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(progress.Phase).To(Equal(servingv1alpha1.DeletionPhaseTritonServers))
+			Expect(progress.RemainingApplications).To(Equal(int32(1)))
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(server), server))).To(BeTrue())
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(app), app)).To(Succeed())
+
+			By("deleting the applications once no TritonServers remain")
+			progress, err = reconciler.deleteProjectResources(ctx, project)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(progress.Phase).To(Equal(servingv1alpha1.DeletionPhaseApplications))
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(namespace), namespace)).To(Succeed())
+
+			By("deleting the namespaces last")
+			progress, err = reconciler.deleteProjectResources(ctx, project)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(progress.Phase).To(Equal(servingv1alpha1.DeletionPhaseNamespaces))
+
+			progress, err = reconciler.deleteProjectResources(ctx, project)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(progress).To(BeNil())
+		})
+
+		It("should keep the namespaces, or everything, according to the deletion policy", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			project := &servingv1alpha1.KalypsoProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "kalypso-system"},
+				Spec:       servingv1alpha1.KalypsoProjectSpec{DeletionPolicy: servingv1alpha1.ProjectDeletionOrphan},
+				Status:     servingv1alpha1.KalypsoProjectStatus{CreatedNamespaces: []string{"sample-project-dev"}},
+			}
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "sample-project-dev",
+				Labels: map[string]string{ProjectLabelKey: project.Name},
+			}}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "sample-project-dev"},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: "recommendation-application"},
+			}
+			reconciler := &KalypsoProjectReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(project, namespace, server).Build(),
+				Scheme: scheme,
+			}
+
+			By("orphaning every resource")
+			progress, err := reconciler.deleteProjectResources(ctx, project)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(progress).To(BeNil())
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(server), server)).To(Succeed())
+
+			By("deleting the servers but retaining the namespaces")
+			project.Spec.DeletionPolicy = servingv1alpha1.ProjectDeletionRetainData
+			progress, err = reconciler.deleteProjectResources(ctx, project)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(progress.Phase).To(Equal(servingv1alpha1.DeletionPhaseTritonServers))
+			Expect(progress.RemainingNamespaces).To(BeZero())
+
+			progress, err = reconciler.deleteProjectResources(ctx, project)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(progress).To(BeNil())
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(namespace), namespace)).To(Succeed())
+			Expect(namespace.DeletionTimestamp).To(BeNil())
+		})
+	})
+	Context("When an environment sets a Pod Security level", func() {
+		It("should label the namespace and revert label edits", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			project := &servingv1alpha1.KalypsoProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "kalypso-system"},
+				Status:     servingv1alpha1.KalypsoProjectStatus{CreatedNamespaces: []string{"sample-project-prod"}},
+			}
+			reconciler := &KalypsoProjectReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(project).Build(),
+				Scheme: scheme,
+			}
+
+			Expect(reconciler.reconcileNamespace(ctx, project, "prod", "sample-project-prod", servingv1alpha1.PodSecurityRestricted)).To(Succeed())
+			namespace := &corev1.Namespace{}
+			Expect(reconciler.Get(ctx, client.ObjectKey{Name: "sample-project-prod"}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("pod-security.kubernetes.io/enforce", "restricted"))
+			Expect(namespace.Labels).To(HaveKeyWithValue("pod-security.kubernetes.io/audit", "restricted"))
+			Expect(namespace.Labels).To(HaveKeyWithValue("pod-security.kubernetes.io/warn", "restricted"))
+
+			By("mapping label edits of the namespace to the project")
+			Expect(reconciler.projectsForNamespace(ctx, namespace)).To(ConsistOf(reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: project.Name, Namespace: project.Namespace},
+			}))
+			Expect(reconciler.projectsForNamespace(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})).To(BeEmpty())
+
+			namespace.Labels["pod-security.kubernetes.io/enforce"] = "privileged"
+			Expect(reconciler.Update(ctx, namespace)).To(Succeed())
+			Expect(reconciler.reconcileNamespace(ctx, project, "prod", "sample-project-prod", servingv1alpha1.PodSecurityRestricted)).To(Succeed())
+			Expect(reconciler.Get(ctx, client.ObjectKey{Name: "sample-project-prod"}, namespace)).To(Succeed())
+			Expect(namespace.Labels).To(HaveKeyWithValue("pod-security.kubernetes.io/enforce", "restricted"))
+		})
+	})
+
+	Context("When an environment namespace already exists", func() {
+		It("should adopt, skip, or fail on it according to the adoption policy", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			project := &servingv1alpha1.KalypsoProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "kalypso-system"},
+			}
+			shared := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shared-models", Labels: map[string]string{"team": "platform"}}}
+			managed := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "sample-project-dev", Labels: map[string]string{
+				ProjectLabelKey:   project.Name,
+				ManagedByLabelKey: ManagedByLabelValue,
+			}}}
+			namesake := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b-sample-project-dev", Labels: map[string]string{
+				ProjectLabelKey:          project.Name,
+				ProjectNamespaceLabelKey: "team-b",
+				ManagedByLabelKey:        ManagedByLabelValue,
+			}}}
+			reconciler := &KalypsoProjectReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(project, shared, managed, namesake).Build(),
+				Scheme: scheme,
+			}
+
+			By("adopting any namespace by default")
+			Expect(reconciler.findNamespaceConflict(ctx, project, shared.Name, "")).To(BeNil())
+
+			By("managing namespaces already labeled for the project")
+			Expect(reconciler.findNamespaceConflict(ctx, project, managed.Name, servingv1alpha1.NamespaceAdoptionFail)).To(BeNil())
+			Expect(reconciler.findNamespaceConflict(ctx, project, "sample-project-prod", servingv1alpha1.NamespaceAdoptionFail)).To(BeNil())
+
+			By("reporting namespaces managed for a same-named project of another namespace")
+			Expect(reconciler.findNamespaceConflict(ctx, project, namesake.Name, servingv1alpha1.NamespaceAdoptionFail)).NotTo(BeNil())
+
+			By("reporting unmanaged namespaces")
+			ignored, err := reconciler.findNamespaceConflict(ctx, project, shared.Name, servingv1alpha1.NamespaceAdoptionIgnore)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ignored).NotTo(BeNil())
+			project.Status.Phase = servingv1alpha1.ProjectPhaseReady
+			applyNamespaceConflictStatus(project, []namespaceConflict{*ignored})
+			Expect(meta.IsStatusConditionTrue(project.Status.Conditions, "NamespaceConflict")).To(BeTrue())
+			Expect(project.Status.Phase).To(Equal(servingv1alpha1.ProjectPhaseReady))
+
+			failed, err := reconciler.findNamespaceConflict(ctx, project, shared.Name, servingv1alpha1.NamespaceAdoptionFail)
+			Expect(err).NotTo(HaveOccurred())
+			applyNamespaceConflictStatus(project, []namespaceConflict{*failed})
+			Expect(meta.FindStatusCondition(project.Status.Conditions, "NamespaceConflict").Message).To(ContainSubstring("shared-models (Fail)"))
+			Expect(project.Status.Phase).To(Equal(servingv1alpha1.ProjectPhaseFailed))
+		})
+	})
+
+	Context("When applications of other namespaces reference the project", func() {
+		It("should only resolve the project from the namespaces it allows", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			project := &servingv1alpha1.KalypsoProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoProjectSpec{
+					Environments:      map[string]servingv1alpha1.EnvironmentSpec{"dev": {Namespace: "proj-dev"}},
+					AllowedNamespaces: []string{"shared-models"},
+				},
+			}
+			reference := func(namespace, name, ref string) *servingv1alpha1.KalypsoApplication {
+				return &servingv1alpha1.KalypsoApplication{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+					Spec:       servingv1alpha1.KalypsoApplicationSpec{ProjectRef: ref},
+				}
+			}
+			local := reference(project.Namespace, "local-application", project.Name)
+			environment := reference("proj-dev", "dev-application", "kalypso-system/sample-project")
+			allowed := reference("shared-models", "shared-application", "kalypso-system/sample-project")
+			denied := reference("other-team", "other-application", "kalypso-system/sample-project")
+			unrelated := reference("proj-dev", "unrelated-application", "other-project")
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(project, local, environment, allowed, denied, unrelated).Build()
+
+			for _, app := range []*servingv1alpha1.KalypsoApplication{local, environment, allowed} {
+				resolved, err := defaults.ApplicationProject(ctx, c, app)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resolved.Name).To(Equal(project.Name))
+			}
+			_, err := defaults.ApplicationProject(ctx, c, denied)
+			Expect(errors.IsNotFound(err)).To(BeTrue(), "a project not allowing the namespace is not resolved")
+
+			referrers, err := listProjectReferrers(ctx, c, project)
+			Expect(err).NotTo(HaveOccurred())
+			names := make([]string, 0, len(referrers))
+			for _, app := range referrers {
+				names = append(names, app.Name)
+			}
+			Expect(names).To(ConsistOf("local-application", "dev-application", "shared-application"))
+		})
+	})
+
+	Context("When the project lists members", func() {
+		It("should bind each role held by a member in the environment namespace", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			project := &servingv1alpha1.KalypsoProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoProjectSpec{Members: []servingv1alpha1.ProjectMember{
+					{Name: "alice@example.com", Role: servingv1alpha1.ProjectRoleEditor},
+					{Kind: rbacv1.GroupKind, Name: "ml-platform", Role: servingv1alpha1.ProjectRoleAdmin},
+					{Name: "bob@example.com", Role: servingv1alpha1.ProjectRoleEditor},
+				}},
+			}
+			reconciler := &KalypsoProjectReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(project).Build(),
+				Scheme: scheme,
+			}
+
+			Expect(reconciler.reconcileMembers(ctx, project, "dev", "sample-project-dev")).To(Succeed())
+			binding := &rbacv1.RoleBinding{}
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: "sample-project-dev", Name: "sample-project-editor"}, binding)).To(Succeed())
+			Expect(binding.RoleRef.Name).To(Equal("sample-project-editor"))
+			Expect(binding.Subjects).To(ConsistOf(
+				rbacv1.Subject{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "alice@example.com"},
+				rbacv1.Subject{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "bob@example.com"},
+			))
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: "sample-project-dev", Name: "sample-project-admin"}, binding)).To(Succeed())
+			Expect(binding.Subjects).To(ConsistOf(HaveField("Kind", rbacv1.GroupKind)))
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKey{Namespace: "sample-project-dev", Name: "sample-project-viewer"}, binding))).To(BeTrue())
+
+			role := &rbacv1.Role{}
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: "sample-project-dev", Name: "sample-project-editor"}, role)).To(Succeed())
+			Expect(role.Rules).To(ContainElement(rbacv1.PolicyRule{
+				APIGroups: []string{servingv1alpha1.GroupVersion.Group},
+				Resources: kalypsoResources,
+				Verbs:     []string{"create", "update", "patch", "delete"},
+			}))
+			Expect(role.Rules).NotTo(ContainElement(HaveField("Resources", ContainElement("secrets"))))
+
+			By("removing the bindings of roles no member holds anymore")
+			project.Spec.Members = project.Spec.Members[:1]
+			Expect(reconciler.reconcileMembers(ctx, project, "dev", "sample-project-dev")).To(Succeed())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKey{Namespace: "sample-project-dev", Name: "sample-project-admin"}, binding))).To(BeTrue())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKey{Namespace: "sample-project-dev", Name: "sample-project-admin"}, role))).To(BeTrue())
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: "sample-project-dev", Name: "sample-project-editor"}, binding)).To(Succeed())
+			Expect(binding.Subjects).To(HaveLen(1))
+		})
+	})
+
+	Context("When the project lists image pull Secrets", func() {
+		It("should copy them into the namespace and attach them to its ServiceAccounts", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			project := &servingv1alpha1.KalypsoProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoProjectSpec{
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "nvcr-mirror"}},
+				},
+				Status: servingv1alpha1.KalypsoProjectStatus{CreatedNamespaces: []string{"sample-project-dev"}},
+			}
+			source := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "nvcr-mirror", Namespace: project.Namespace},
+				Type:       corev1.SecretTypeDockerConfigJson,
+				Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`)},
+			}
+			defaultAccount := &corev1.ServiceAccount{
+				ObjectMeta:       metav1.ObjectMeta{Name: "default", Namespace: "sample-project-dev"},
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "team-registry"}},
+			}
+			serverAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+				Name: "recommendation-v1-sa", Namespace: "sample-project-dev",
+				Labels: map[string]string{ManagedByLabelKey: ManagedByLabelValue},
+			}}
+			otherAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "ci", Namespace: "sample-project-dev"}}
+			// The manager cache does not hold the default ServiceAccount, which is read from the
+			// API server
+			apiServer := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(project, source, defaultAccount, serverAccount, otherAccount).Build()
+			reconciler := &KalypsoProjectReconciler{
+				Client:    newCachedClient(apiServer),
+				Scheme:    scheme,
+				APIReader: apiServer,
+			}
+
+			Expect(reconciler.reconcileImagePullSecrets(ctx, project, "dev", "sample-project-dev")).To(BeEmpty())
+			replica := &corev1.Secret{}
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: "sample-project-dev", Name: "nvcr-mirror"}, replica)).To(Succeed())
+			Expect(replica.Type).To(Equal(corev1.SecretTypeDockerConfigJson))
+			Expect(replica.Data).To(Equal(source.Data))
+			Expect(apiServer.Get(ctx, client.ObjectKeyFromObject(defaultAccount), defaultAccount)).To(Succeed())
+			Expect(defaultAccount.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{{Name: "team-registry"}, {Name: "nvcr-mirror"}}))
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(serverAccount), serverAccount)).To(Succeed())
+			Expect(serverAccount.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{{Name: "nvcr-mirror"}}))
+			Expect(apiServer.Get(ctx, client.ObjectKeyFromObject(otherAccount), otherAccount)).To(Succeed())
+			Expect(otherAccount.ImagePullSecrets).To(BeEmpty())
+
+			By("mapping the source Secret and its copy to the project")
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: project.Name, Namespace: project.Namespace}}
+			Expect(reconciler.projectsForImagePullSecret(ctx, source)).To(ConsistOf(request))
+			Expect(reconciler.projectsForImagePullSecret(ctx, replica)).To(ConsistOf(request))
+			Expect(reconciler.projectsForServiceAccount(ctx, serverAccount)).To(ConsistOf(request))
+			Expect(reconciler.projectsForServiceAccount(ctx, otherAccount)).To(BeEmpty())
+
+			By("syncing rotated credentials")
+			source.Data[corev1.DockerConfigJsonKey] = []byte(`{"auths":{"nvcr.io":{}}}`)
+			Expect(reconciler.Update(ctx, source)).To(Succeed())
+			Expect(reconciler.reconcileImagePullSecrets(ctx, project, "dev", "sample-project-dev")).To(BeEmpty())
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(replica), replica)).To(Succeed())
+			Expect(replica.Data).To(Equal(source.Data))
+
+			By("deleting and detaching Secrets removed from the project")
+			project.Spec.ImagePullSecrets = nil
+			Expect(reconciler.reconcileImagePullSecrets(ctx, project, "dev", "sample-project-dev")).To(BeEmpty())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(replica), replica))).To(BeTrue())
+			Expect(apiServer.Get(ctx, client.ObjectKeyFromObject(defaultAccount), defaultAccount)).To(Succeed())
+			Expect(defaultAccount.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{{Name: "team-registry"}}))
+		})
+	})
+
+	Context("When an environment namespace already holds a Secret of the same name", func() {
+		It("should leave it alone and report the conflict", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			project := &servingv1alpha1.KalypsoProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoProjectSpec{
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "nvcr-mirror"}},
+				},
+			}
+			source := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "nvcr-mirror", Namespace: project.Namespace},
+				Type:       corev1.SecretTypeDockerConfigJson,
+				Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`)},
+			}
+			existing := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "nvcr-mirror", Namespace: "sample-project-dev"},
+				Type:       corev1.SecretTypeDockerConfigJson,
+				Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{"team.example.com":{}}}`)},
+			}
+			defaultAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "sample-project-dev"}}
+			apiServer := fake.NewClientBuilder().WithScheme(scheme).WithObjects(project, source, existing, defaultAccount).Build()
+			reconciler := &KalypsoProjectReconciler{Client: apiServer, Scheme: scheme, APIReader: apiServer}
+
+			Expect(reconciler.reconcileImagePullSecrets(ctx, project, "dev", "sample-project-dev")).To(Equal([]string{"sample-project-dev/nvcr-mirror"}))
+			secret := &corev1.Secret{}
+			Expect(apiServer.Get(ctx, client.ObjectKeyFromObject(existing), secret)).To(Succeed())
+			Expect(secret.Data).To(Equal(existing.Data))
+			Expect(secret.Labels).To(BeEmpty())
+			Expect(apiServer.Get(ctx, client.ObjectKeyFromObject(defaultAccount), defaultAccount)).To(Succeed())
+			Expect(defaultAccount.ImagePullSecrets).To(BeEmpty())
+
+			applyPullSecretConflictStatus(project, []string{"sample-project-dev/nvcr-mirror"})
+			condition := meta.FindStatusCondition(project.Status.Conditions, "ImagePullSecretConflict")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring("sample-project-dev/nvcr-mirror"))
+
+			By("copying over a Secret once it is removed")
+			Expect(apiServer.Delete(ctx, existing)).To(Succeed())
+			Expect(reconciler.reconcileImagePullSecrets(ctx, project, "dev", "sample-project-dev")).To(BeEmpty())
+			Expect(apiServer.Get(ctx, client.ObjectKeyFromObject(existing), secret)).To(Succeed())
+			Expect(secret.Data).To(Equal(source.Data))
+			applyPullSecretConflictStatus(project, nil)
+			Expect(meta.IsStatusConditionFalse(project.Status.Conditions, "ImagePullSecretConflict")).To(BeTrue())
+		})
+	})
+
+	Context("When a managed namespace is stuck terminating", func() {
+		It("should report the blocking finalizers", func() {
+			project := &servingv1alpha1.KalypsoProject{}
+			deletedAt := metav1.NewTime(time.Now().Add(-2 * NamespaceDeletionTimeout))
+			namespace := corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project-dev", DeletionTimestamp: &deletedAt},
+				Status: corev1.NamespaceStatus{
+					Phase: corev1.NamespaceTerminating,
+					Conditions: []corev1.NamespaceCondition{{
+						Type:    corev1.NamespaceFinalizersRemaining,
+						Status:  corev1.ConditionTrue,
+						Message: "Some content in the namespace has finalizers remaining: example.com/cleanup in 1 resource instances",
+					}},
+				},
+			}
+
+			reportStuckNamespaces(project, []corev1.Namespace{namespace}, time.Now())
+
+			cond := meta.FindStatusCondition(project.Status.Conditions, "NamespaceDeletionStuck")
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Message).To(ContainSubstring("sample-project-dev: Some content in the namespace has finalizers remaining: example.com/cleanup"))
+
+			By("clearing the condition once no namespace is stuck")
+			reportStuckNamespaces(project, nil, time.Now())
+			Expect(meta.FindStatusCondition(project.Status.Conditions, "NamespaceDeletionStuck")).To(BeNil())
+		})
+	})
+
+	Context("When servers pull the same model repository", func() {
+		It("should recommend a consolidation for each shared repository", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			project := &servingv1alpha1.KalypsoProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "kalypso-system"},
+				Status:     servingv1alpha1.KalypsoProjectStatus{CreatedNamespaces: []string{"sample-project-prod"}},
+			}
+			search := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "search-application", Namespace: "kalypso-system"},
+				Spec:       servingv1alpha1.KalypsoApplicationSpec{ProjectRef: project.Name},
+			}
+			server := func(namespace, name, app, uri string, gpus int32) *servingv1alpha1.KalypsoTritonServer {
+				return &servingv1alpha1.KalypsoTritonServer{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+					Spec: servingv1alpha1.KalypsoTritonServerSpec{
+						ApplicationRef: app,
+						StorageURI:     uri,
+						Replicas:       ptrTo(int32(1)),
+						GPU:            &servingv1alpha1.GPUSpec{Count: ptrTo(gpus)},
+					},
+				}
+			}
+			reconciler := &KalypsoProjectReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					project, search,
+					server("kalypso-system", "search-v1", "search-application", "s3://Models/bert/", 1),
+					server("kalypso-system", "search-v1-copy", "search-application", "s3://models/bert", 1),
+					server("sample-project-prod", "ranking-v1", "ranking-application", "gs://models/llama", 4),
+					server("kalypso-system", "search-llama", "search-application", "gs://models/llama/", 2),
+					server("kalypso-system", "search-t5", "search-application", "gs://models/t5", 1),
+				).Build(),
+				Scheme: scheme,
+			}
+
+			duplicates, err := reconciler.findDuplicateModels(ctx, project)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(duplicates).To(Equal([]servingv1alpha1.DuplicateModel{
+				{
+					StorageURI:     "gs://models/llama",
+					Servers:        []string{"kalypso-system/search-llama", "sample-project-prod/ranking-v1"},
+					Recommendation: servingv1alpha1.ConsolidationSharedCache,
+					DuplicateGPUs:  2,
+				},
+				{
+					StorageURI:     "s3://models/bert",
+					Servers:        []string{"kalypso-system/search-v1", "kalypso-system/search-v1-copy"},
+					Recommendation: servingv1alpha1.ConsolidationSharedServer,
+					DuplicateGPUs:  1,
+				},
+			}))
+		})
+	})
 })
@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+var (
+	// duplicateModelServers counts the servers pulling a model repository already pulled by
+	// another server of the project
+	duplicateModelServers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kalypso_project_duplicate_model_servers",
+		Help: "Number of KalypsoTritonServers pulling a model repository already pulled by another server of the project.",
+	}, []string{"namespace", "project"})
+	// duplicateModelGPUs counts the GPUs held by those servers
+	duplicateModelGPUs = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kalypso_project_duplicate_model_gpus",
+		Help: "Number of GPUs requested by KalypsoTritonServers duplicating a model repository of the project.",
+	}, []string{"namespace", "project"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(duplicateModelServers, duplicateModelGPUs)
+}
+
+// findDuplicateModels groups the project's servers by model repository and reports every
+// repository pulled by more than one server
+func (r *KalypsoProjectReconciler) findDuplicateModels(ctx context.Context, project *servingv1alpha1.KalypsoProject) ([]servingv1alpha1.DuplicateModel, error) {
+	apps, err := r.listProjectApplications(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	servers, err := r.listProjectTritonServers(ctx, project, apps)
+	if err != nil {
+		return nil, err
+	}
+	return groupDuplicateModels(servers), nil
+}
+
+// groupDuplicateModels reports the model repositories shared by several servers, sorted by URI
+func groupDuplicateModels(servers []servingv1alpha1.KalypsoTritonServer) []servingv1alpha1.DuplicateModel {
+	groups := make(map[string][]servingv1alpha1.KalypsoTritonServer)
+	for _, server := range servers {
+		if server.Spec.StorageURI == "" {
+			continue
+		}
+		uri := normalizeStorageURI(server.Spec.StorageURI)
+		groups[uri] = append(groups[uri], server)
+	}
+
+	uris := make([]string, 0, len(groups))
+	for uri, group := range groups {
+		if len(group) > 1 {
+			uris = append(uris, uri)
+		}
+	}
+	sort.Strings(uris)
+
+	duplicates := make([]servingv1alpha1.DuplicateModel, 0, len(uris))
+	for _, uri := range uris {
+		group := groups[uri]
+		duplicate := servingv1alpha1.DuplicateModel{
+			StorageURI:     uri,
+			Recommendation: servingv1alpha1.ConsolidationSharedServer,
+		}
+
+		var totalGPUs, largestGPUs int32
+		for _, server := range group {
+			duplicate.Servers = append(duplicate.Servers, server.Namespace+"/"+server.Name)
+			if server.Namespace != group[0].Namespace || serverApplicationKey(&server) != serverApplicationKey(&group[0]) {
+				duplicate.Recommendation = servingv1alpha1.ConsolidationSharedCache
+			}
+
+			gpus := int32(0)
+			if server.Spec.GPU != nil && server.Spec.GPU.Count != nil && !scaledToZero(&server) {
+				replicas := int32(1)
+				if server.Spec.Replicas != nil {
+					replicas = *server.Spec.Replicas
+				}
+				gpus = *server.Spec.GPU.Count * replicas
+			}
+			totalGPUs += gpus
+			largestGPUs = max(largestGPUs, gpus)
+		}
+		sort.Strings(duplicate.Servers)
+		duplicate.DuplicateGPUs = totalGPUs - largestGPUs
+		duplicates = append(duplicates, duplicate)
+	}
+	return duplicates
+}
+
+// normalizeStorageURI lowercases the scheme and bucket of a model repository URI and drops the
+// trailing slash, so spellings of the same repository match
+func normalizeStorageURI(uri string) string {
+	parsed, err := url.Parse(strings.TrimSpace(uri))
+	if err != nil || parsed.Scheme == "" {
+		return strings.TrimSuffix(uri, "/")
+	}
+	return strings.ToLower(parsed.Scheme) + "://" + strings.ToLower(parsed.Host) + strings.TrimSuffix(parsed.Path, "/")
+}
+
+// recordDuplicateModelMetrics exports the deduplication report of the project
+func recordDuplicateModelMetrics(project *servingv1alpha1.KalypsoProject, duplicates []servingv1alpha1.DuplicateModel) {
+	var servers, gpus int
+	for _, duplicate := range duplicates {
+		servers += len(duplicate.Servers) - 1
+		gpus += int(duplicate.DuplicateGPUs)
+	}
+	duplicateModelServers.WithLabelValues(project.Namespace, project.Name).Set(float64(servers))
+	duplicateModelGPUs.WithLabelValues(project.Namespace, project.Name).Set(float64(gpus))
+}
+
+// deleteDuplicateModelMetrics drops the deduplication metrics of a deleted project
+func deleteDuplicateModelMetrics(project *servingv1alpha1.KalypsoProject) {
+	duplicateModelServers.DeleteLabelValues(project.Namespace, project.Name)
+	duplicateModelGPUs.DeleteLabelValues(project.Namespace, project.Name)
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+// NamespaceDeletionTimeout is how long a managed namespace may stay terminating before it is reported as stuck
+const NamespaceDeletionTimeout = 5 * time.Minute
+
+// deleteProjectResources tears down the project's resources in dependency order:
+// TritonServers first so their finalizers run while the namespaces still exist,
+// then applications, then the managed namespaces. It returns the progress while
+// resources remain and nil once everything is gone. The deletion policy of the project
+// keeps the namespaces, or everything, in place.
+func (r *KalypsoProjectReconciler) deleteProjectResources(ctx context.Context, project *servingv1alpha1.KalypsoProject) (*servingv1alpha1.DeletionProgress, error) {
+	if project.Spec.DeletionPolicy == servingv1alpha1.ProjectDeletionOrphan {
+		return nil, nil
+	}
+
+	apps, err := r.listProjectApplications(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	servers, err := r.listProjectTritonServers(ctx, project, apps)
+	if err != nil {
+		return nil, err
+	}
+	var namespaces []corev1.Namespace
+	if project.Spec.DeletionPolicy != servingv1alpha1.ProjectDeletionRetainData {
+		if namespaces, err = r.listProjectNamespaces(ctx, project); err != nil {
+			return nil, err
+		}
+	}
+
+	progress := &servingv1alpha1.DeletionProgress{
+		RemainingTritonServers: int32(len(servers)),
+		RemainingApplications:  int32(len(apps)),
+		RemainingNamespaces:    int32(len(namespaces)),
+	}
+
+	var pending []client.Object
+	switch {
+	case len(servers) > 0:
+		progress.Phase = servingv1alpha1.DeletionPhaseTritonServers
+		for i := range servers {
+			pending = append(pending, &servers[i])
+		}
+	case len(apps) > 0:
+		progress.Phase = servingv1alpha1.DeletionPhaseApplications
+		for i := range apps {
+			pending = append(pending, &apps[i])
+		}
+	case len(namespaces) > 0:
+		progress.Phase = servingv1alpha1.DeletionPhaseNamespaces
+		reportStuckNamespaces(project, namespaces, time.Now())
+		for i := range namespaces {
+			pending = append(pending, &namespaces[i])
+		}
+	default:
+		return nil, nil
+	}
+
+	for _, obj := range pending {
+		if !obj.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	return progress, nil
+}
+
+// listProjectApplications lists the applications referencing the project, from any namespace it
+// allows, and every application in the project's managed namespaces
+func (r *KalypsoProjectReconciler) listProjectApplications(ctx context.Context, project *servingv1alpha1.KalypsoProject) ([]servingv1alpha1.KalypsoApplication, error) {
+	referrers, err := listProjectReferrers(ctx, r.Client, project)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []servingv1alpha1.KalypsoApplication
+	for _, app := range referrers {
+		if !isManagedNamespace(project, app.Namespace) {
+			result = append(result, app)
+		}
+	}
+
+	for _, nsName := range project.Status.CreatedNamespaces {
+		if nsName == project.Namespace {
+			continue
+		}
+		nsApps := &servingv1alpha1.KalypsoApplicationList{}
+		if err := r.List(ctx, nsApps, client.InNamespace(nsName)); err != nil {
+			return nil, err
+		}
+		result = append(result, nsApps.Items...)
+	}
+	return result, nil
+}
+
+// isManagedNamespace reports whether the namespace is one of the project's managed namespaces,
+// whose resources are all removed with the project
+func isManagedNamespace(project *servingv1alpha1.KalypsoProject, namespace string) bool {
+	return namespace != project.Namespace && slices.Contains(project.Status.CreatedNamespaces, namespace)
+}
+
+// listProjectTritonServers lists the servers of the given applications, which may live in any
+// namespace the project allows, and every server in the project's managed namespaces
+func (r *KalypsoProjectReconciler) listProjectTritonServers(ctx context.Context, project *servingv1alpha1.KalypsoProject, apps []servingv1alpha1.KalypsoApplication) ([]servingv1alpha1.KalypsoTritonServer, error) {
+	projectApps := make(map[types.NamespacedName]bool)
+	var appNamespaces []string
+	for _, nsName := range append([]string{project.Namespace}, project.Spec.AllowedNamespaces...) {
+		if !isManagedNamespace(project, nsName) && !slices.Contains(appNamespaces, nsName) {
+			appNamespaces = append(appNamespaces, nsName)
+		}
+	}
+	for _, app := range apps {
+		projectApps[types.NamespacedName{Namespace: app.Namespace, Name: app.Name}] = true
+		if !isManagedNamespace(project, app.Namespace) && !slices.Contains(appNamespaces, app.Namespace) {
+			appNamespaces = append(appNamespaces, app.Namespace)
+		}
+	}
+
+	var result []servingv1alpha1.KalypsoTritonServer
+	for _, nsName := range appNamespaces {
+		servers := &servingv1alpha1.KalypsoTritonServerList{}
+		if err := r.List(ctx, servers, client.InNamespace(nsName)); err != nil {
+			return nil, err
+		}
+		for _, server := range servers.Items {
+			if projectApps[serverApplicationKey(&server)] {
+				result = append(result, server)
+			}
+		}
+	}
+
+	for _, nsName := range project.Status.CreatedNamespaces {
+		if nsName == project.Namespace {
+			continue
+		}
+		nsServers := &servingv1alpha1.KalypsoTritonServerList{}
+		if err := r.List(ctx, nsServers, client.InNamespace(nsName)); err != nil {
+			return nil, err
+		}
+		result = append(result, nsServers.Items...)
+	}
+	return result, nil
+}
+
+// listProjectNamespaces lists the existing namespaces labeled as managed by this project
+func (r *KalypsoProjectReconciler) listProjectNamespaces(ctx context.Context, project *servingv1alpha1.KalypsoProject) ([]corev1.Namespace, error) {
+	var result []corev1.Namespace
+	for _, nsName := range project.Status.CreatedNamespaces {
+		ns := &corev1.Namespace{}
+		if err := r.Get(ctx, client.ObjectKey{Name: nsName}, ns); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		// Check if namespace is managed by this project
+		if hasProjectLabels(ns.Labels, project) {
+			result = append(result, *ns)
+		}
+	}
+	return result, nil
+}
+
+// reportStuckNamespaces sets the NamespaceDeletionStuck condition when managed namespaces have been
+// terminating longer than NamespaceDeletionTimeout, listing the remaining resources and finalizers
+// recorded by the namespace controller
+func reportStuckNamespaces(project *servingv1alpha1.KalypsoProject, namespaces []corev1.Namespace, now time.Time) {
+	var reports []string
+	for _, ns := range namespaces {
+		if ns.DeletionTimestamp.IsZero() || now.Sub(ns.DeletionTimestamp.Time) < NamespaceDeletionTimeout {
+			continue
+		}
+
+		var blockers []string
+		for _, condition := range ns.Status.Conditions {
+			if condition.Status != corev1.ConditionTrue {
+				continue
+			}
+			switch condition.Type {
+			case corev1.NamespaceContentRemaining,
+				corev1.NamespaceFinalizersRemaining,
+				corev1.NamespaceDeletionContentFailure,
+				corev1.NamespaceDeletionDiscoveryFailure:
+				blockers = append(blockers, condition.Message)
+			}
+		}
+		if len(blockers) == 0 {
+			blockers = append(blockers, "no blocking resources reported by the namespace controller")
+		}
+		reports = append(reports, fmt.Sprintf("%s: %s", ns.Name, strings.Join(blockers, "; ")))
+	}
+
+	if len(reports) == 0 {
+		meta.RemoveStatusCondition(&project.Status.Conditions, "NamespaceDeletionStuck")
+		return
+	}
+	meta.SetStatusCondition(&project.Status.Conditions, metav1.Condition{
+		Type:               "NamespaceDeletionStuck",
+		Status:             metav1.ConditionTrue,
+		Reason:             "NamespaceTerminating",
+		Message:            fmt.Sprintf("Namespaces terminating for more than %s: %s", NamespaceDeletionTimeout, strings.Join(reports, " | ")),
+		LastTransitionTime: metav1.Now(),
+	})
+}
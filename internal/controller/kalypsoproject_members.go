@@ -0,0 +1,149 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// projectRoles are the roles granted to project members, each including the previous one
+var projectRoles = []servingv1alpha1.ProjectRole{
+	servingv1alpha1.ProjectRoleViewer,
+	servingv1alpha1.ProjectRoleEditor,
+	servingv1alpha1.ProjectRoleAdmin,
+}
+
+// kalypsoResources are the namespaced Kalypso resources members work with
+var kalypsoResources = []string{
+	"kalypsoapplications",
+	"kalypsotritonservers",
+	"kalypsorollouts",
+	"kalypsomodelcaches",
+	"kalypsopromotions",
+	"kalypsoexperiments",
+}
+
+var readVerbs = []string{"get", "list", "watch"}
+
+var writeVerbs = []string{"create", "update", "patch", "delete"}
+
+// projectRoleRules returns the rules of a project role
+func projectRoleRules(role servingv1alpha1.ProjectRole) []rbacv1.PolicyRule {
+	statusResources := make([]string, 0, len(kalypsoResources))
+	for _, resource := range kalypsoResources {
+		statusResources = append(statusResources, resource+"/status")
+	}
+
+	rules := []rbacv1.PolicyRule{
+		{APIGroups: []string{servingv1alpha1.GroupVersion.Group}, Resources: slices.Concat(kalypsoResources, statusResources), Verbs: readVerbs},
+		{APIGroups: []string{""}, Resources: []string{"pods", "pods/log", "services", "endpoints", "configmaps", "events", "persistentvolumeclaims", "serviceaccounts"}, Verbs: readVerbs},
+		{APIGroups: []string{"apps"}, Resources: []string{"deployments", "statefulsets", "replicasets"}, Verbs: readVerbs},
+		{APIGroups: []string{"autoscaling"}, Resources: []string{"horizontalpodautoscalers"}, Verbs: readVerbs},
+		{APIGroups: []string{"networking.k8s.io"}, Resources: []string{"ingresses", "networkpolicies"}, Verbs: readVerbs},
+		{APIGroups: []string{"policy"}, Resources: []string{"poddisruptionbudgets"}, Verbs: readVerbs},
+	}
+	if role == servingv1alpha1.ProjectRoleViewer {
+		return rules
+	}
+
+	rules = append(rules,
+		rbacv1.PolicyRule{APIGroups: []string{servingv1alpha1.GroupVersion.Group}, Resources: kalypsoResources, Verbs: writeVerbs},
+		rbacv1.PolicyRule{APIGroups: []string{servingv1alpha1.GroupVersion.Group}, Resources: []string{"kalypsotritonservers/scale"}, Verbs: []string{"get", "update", "patch"}},
+		rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"delete"}},
+	)
+	if role == servingv1alpha1.ProjectRoleEditor {
+		return rules
+	}
+
+	return append(rules,
+		rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: slices.Concat(readVerbs, writeVerbs)},
+		rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: writeVerbs},
+		rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods/exec", "pods/portforward"}, Verbs: []string{"create"}},
+	)
+}
+
+// projectRoleSubjects returns the members of a project granted a role
+func projectRoleSubjects(project *servingv1alpha1.KalypsoProject, role servingv1alpha1.ProjectRole) []rbacv1.Subject {
+	var subjects []rbacv1.Subject
+	for _, member := range project.Spec.Members {
+		if member.Role != role {
+			continue
+		}
+		kind := member.Kind
+		if kind == "" {
+			kind = rbacv1.UserKind
+		}
+		subjects = append(subjects, rbacv1.Subject{Kind: kind, APIGroup: rbacv1.GroupName, Name: member.Name})
+	}
+	return subjects
+}
+
+// reconcileMembers grants the project members their role in an environment namespace through
+// a Role and RoleBinding per role. Roles no member holds are removed
+func (r *KalypsoProjectReconciler) reconcileMembers(ctx context.Context, project *servingv1alpha1.KalypsoProject, envName, nsName string) error {
+	for _, role := range projectRoles {
+		name := naming.ProjectRole(project.Name, string(role))
+		subjects := projectRoleSubjects(project, role)
+		if len(subjects) == 0 {
+			binding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: nsName}}
+			if err := r.Delete(ctx, binding); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			policy := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: nsName}}
+			if err := r.Delete(ctx, policy); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			continue
+		}
+
+		labels := map[string]string{
+			ProjectLabelKey:          project.Name,
+			ProjectNamespaceLabelKey: project.Namespace,
+			EnvironmentLabelKey:      envName,
+			ManagedByLabelKey:        ManagedByLabelValue,
+		}
+		policy := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: nsName}}
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, policy, func() error {
+			policy.Labels = defaults.MergeStringMaps(policy.Labels, labels)
+			policy.Rules = projectRoleRules(role)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		binding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: nsName}}
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, binding, func() error {
+			binding.Labels = defaults.MergeStringMaps(binding.Labels, labels)
+			binding.RoleRef = rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name}
+			binding.Subjects = subjects
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+)
+
+const (
+	// ReplicatedSecretLabelKey marks the image pull Secrets copied from the project namespace
+	ReplicatedSecretLabelKey = "kalypso-serving.io/replicated-secret"
+)
+
+// projectPullSecretNames returns the names of the image pull Secrets of a project
+func projectPullSecretNames(project *servingv1alpha1.KalypsoProject) []string {
+	names := make([]string, 0, len(project.Spec.ImagePullSecrets))
+	for _, ref := range project.Spec.ImagePullSecrets {
+		names = append(names, ref.Name)
+	}
+	return names
+}
+
+// reconcileImagePullSecrets copies the image pull Secrets of the project into an environment
+// namespace and attaches them to its default and Kalypso-created ServiceAccounts. Copies of
+// Secrets removed from the project are deleted and detached. Existing same-named Secrets that
+// are not copies of the project are left alone and returned as <namespace>/<name>
+func (r *KalypsoProjectReconciler) reconcileImagePullSecrets(ctx context.Context, project *servingv1alpha1.KalypsoProject, envName, nsName string) ([]string, error) {
+	names := projectPullSecretNames(project)
+	attached := names
+	var conflicts []string
+
+	if nsName != project.Namespace {
+		attached = nil
+		// The manager cache strips the data of the Secrets the operator does not manage
+		reader := r.APIReader
+		if reader == nil {
+			reader = r.Client
+		}
+		for _, name := range names {
+			replica := &corev1.Secret{}
+			err := r.Get(ctx, client.ObjectKey{Namespace: nsName, Name: name}, replica)
+			if client.IgnoreNotFound(err) != nil {
+				return nil, err
+			}
+			if err == nil && (replica.Labels[ReplicatedSecretLabelKey] != "true" || !hasProjectLabels(replica.Labels, project)) {
+				conflicts = append(conflicts, nsName+"/"+name)
+				continue
+			}
+
+			source := &corev1.Secret{}
+			if err := reader.Get(ctx, client.ObjectKey{Namespace: project.Namespace, Name: name}, source); err != nil {
+				return nil, fmt.Errorf("failed to read image pull Secret %s: %w", name, err)
+			}
+			replica = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: nsName}}
+			if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, replica, func() error {
+				replica.Labels = defaults.MergeStringMaps(replica.Labels, map[string]string{
+					ProjectLabelKey:          project.Name,
+					ProjectNamespaceLabelKey: project.Namespace,
+					EnvironmentLabelKey:      envName,
+					ManagedByLabelKey:        ManagedByLabelValue,
+					ReplicatedSecretLabelKey: "true",
+				})
+				replica.Type = source.Type
+				replica.Data = source.Data
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+			attached = append(attached, name)
+		}
+	}
+
+	// Delete the copies of Secrets the project no longer lists
+	replicas := &corev1.SecretList{}
+	if err := r.List(ctx, replicas, client.InNamespace(nsName), client.MatchingLabels{
+		ProjectLabelKey:          project.Name,
+		ReplicatedSecretLabelKey: "true",
+	}); err != nil {
+		return nil, err
+	}
+	var removed []string
+	for i := range replicas.Items {
+		if slices.Contains(names, replicas.Items[i].Name) {
+			continue
+		}
+		removed = append(removed, replicas.Items[i].Name)
+		if err := r.Delete(ctx, &replicas.Items[i]); client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+	}
+
+	serviceAccounts, err := r.pullSecretServiceAccounts(ctx, nsName)
+	if err != nil {
+		return nil, err
+	}
+	for _, serviceAccount := range serviceAccounts {
+		pullSecrets := attachPullSecrets(serviceAccount.ImagePullSecrets, attached, removed)
+		if slices.Equal(pullSecrets, serviceAccount.ImagePullSecrets) {
+			continue
+		}
+		patch := client.MergeFrom(serviceAccount.DeepCopy())
+		serviceAccount.ImagePullSecrets = pullSecrets
+		if err := r.Patch(ctx, serviceAccount, patch); err != nil {
+			return nil, err
+		}
+	}
+	return conflicts, nil
+}
+
+// applyPullSecretConflictStatus reports the existing Secrets left alone instead of copying the
+// image pull Secrets of the project over them in the ImagePullSecretConflict condition
+func applyPullSecretConflictStatus(project *servingv1alpha1.KalypsoProject, conflicts []string) {
+	if len(conflicts) == 0 {
+		meta.SetStatusCondition(&project.Status.Conditions, metav1.Condition{
+			Type:               "ImagePullSecretConflict",
+			Status:             metav1.ConditionFalse,
+			Reason:             "NoConflicts",
+			Message:            "All image pull Secrets are copied into the environment namespaces",
+			LastTransitionTime: metav1.Now(),
+		})
+		return
+	}
+	meta.SetStatusCondition(&project.Status.Conditions, metav1.Condition{
+		Type:               "ImagePullSecretConflict",
+		Status:             metav1.ConditionTrue,
+		Reason:             "UnmanagedSecret",
+		Message:            fmt.Sprintf("Existing Secrets not copied by the project were left alone: %s", strings.Join(conflicts, ", ")),
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// pullSecretServiceAccounts returns the ServiceAccounts of the namespace the image pull Secrets
+// are attached to. The cache only holds the Kalypso-created ones, so the default ServiceAccount
+// is read from the API server
+func (r *KalypsoProjectReconciler) pullSecretServiceAccounts(ctx context.Context, nsName string) ([]*corev1.ServiceAccount, error) {
+	serviceAccounts := &corev1.ServiceAccountList{}
+	if err := r.List(ctx, serviceAccounts, client.InNamespace(nsName), client.MatchingLabels{ManagedByLabelKey: ManagedByLabelValue}); err != nil {
+		return nil, err
+	}
+	var result []*corev1.ServiceAccount
+	for i := range serviceAccounts.Items {
+		if serviceAccounts.Items[i].Name != "default" {
+			result = append(result, &serviceAccounts.Items[i])
+		}
+	}
+
+	reader := r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+	defaultAccount := &corev1.ServiceAccount{}
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: nsName, Name: "default"}, defaultAccount); err != nil {
+		// The default ServiceAccount is created asynchronously with the namespace
+		if client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	return append(result, defaultAccount), nil
+}
+
+// pullSecretServiceAccount reports whether the project image pull Secrets are attached to a
+// ServiceAccount: the default one, and those the operator creates for the servers
+func pullSecretServiceAccount(serviceAccount *corev1.ServiceAccount) bool {
+	return serviceAccount.Name == "default" || serviceAccount.Labels[ManagedByLabelKey] == ManagedByLabelValue
+}
+
+// attachPullSecrets appends the missing Secrets to the pull secrets of a ServiceAccount and
+// drops the removed ones, keeping the pull secrets set by others
+func attachPullSecrets(current []corev1.LocalObjectReference, names, removed []string) []corev1.LocalObjectReference {
+	result := make([]corev1.LocalObjectReference, 0, len(current)+len(names))
+	for _, ref := range current {
+		if !slices.Contains(removed, ref.Name) {
+			result = append(result, ref)
+		}
+	}
+	for _, name := range names {
+		if !slices.Contains(result, corev1.LocalObjectReference{Name: name}) {
+			result = append(result, corev1.LocalObjectReference{Name: name})
+		}
+	}
+	return result
+}
+
+// projectsForImagePullSecret maps a Secret to the projects listing it as an image pull Secret,
+// and a copy of it to the project it was copied for, so changes and edits are synced
+func (r *KalypsoProjectReconciler) projectsForImagePullSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	projects := &servingv1alpha1.KalypsoProjectList{}
+	if err := r.List(ctx, projects); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, project := range projects.Items {
+		source := project.Namespace == obj.GetNamespace() && slices.Contains(projectPullSecretNames(&project), obj.GetName())
+		replica := obj.GetLabels()[ReplicatedSecretLabelKey] == "true" && hasProjectLabels(obj.GetLabels(), &project) &&
+			slices.Contains(project.Status.CreatedNamespaces, obj.GetNamespace())
+		if source || replica {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: project.Name, Namespace: project.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
+// projectsForServiceAccount maps the ServiceAccounts receiving the image pull Secrets to the
+// projects that created their namespace, so new ServiceAccounts get them attached
+func (r *KalypsoProjectReconciler) projectsForServiceAccount(ctx context.Context, obj client.Object) []reconcile.Request {
+	serviceAccount, ok := obj.(*corev1.ServiceAccount)
+	if !ok || !pullSecretServiceAccount(serviceAccount) {
+		return nil
+	}
+	projects := &servingv1alpha1.KalypsoProjectList{}
+	if err := r.List(ctx, projects); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, project := range projects.Items {
+		if len(project.Spec.ImagePullSecrets) > 0 && slices.Contains(project.Status.CreatedNamespaces, obj.GetNamespace()) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: project.Name, Namespace: project.Namespace},
+			})
+		}
+	}
+	return requests
+}
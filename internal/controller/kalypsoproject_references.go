@@ -0,0 +1,44 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// listProjectReferrers lists the KalypsoApplications of every namespace allowed by the project
+// that reference it
+func listProjectReferrers(ctx context.Context, c client.Reader, project *servingv1alpha1.KalypsoProject) ([]servingv1alpha1.KalypsoApplication, error) {
+	apps := &servingv1alpha1.KalypsoApplicationList{}
+	if err := c.List(ctx, apps); err != nil {
+		return nil, err
+	}
+	projectKey := client.ObjectKeyFromObject(project)
+	var result []servingv1alpha1.KalypsoApplication
+	for _, app := range apps.Items {
+		if naming.Reference(app.Namespace, app.Spec.ProjectRef) == projectKey && defaults.ProjectAllowsNamespace(project, app.Namespace) {
+			result = append(result, app)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+)
+
+const (
+	// PromotionLabelKey is the label key for the KalypsoPromotion managing a promoted server
+	PromotionLabelKey = "kalypso-serving.io/promotion"
+	// PromotedFromAnnotation records the source server and generation of a promoted server
+	PromotedFromAnnotation = "serving.kalypso.io/promoted-from"
+)
+
+// KalypsoPromotionReconciler reconciles a KalypsoPromotion object
+type KalypsoPromotionReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsopromotions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsopromotions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsopromotions/finalizers,verbs=update
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsoprojects,verbs=get;list;watch
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsotritonservers,verbs=get;list;watch;create;update;patch
+
+// Reconcile copies the spec of the source KalypsoTritonServer to the target environment each
+// time it changes, once approved when approval is required, and records the promotion history
+func (r *KalypsoPromotionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	// Fetch the KalypsoPromotion instance
+	promotion := &servingv1alpha1.KalypsoPromotion{}
+	if err := r.Get(ctx, req.NamespacedName, promotion); err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("KalypsoPromotion resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get KalypsoPromotion")
+		return ctrl.Result{}, err
+	}
+
+	// Resolve the environments and the source server
+	source, missing, err := r.resolvePromotionSource(ctx, promotion)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if missing != "" {
+		log.Info("Promotion source cannot be resolved", "reason", missing)
+		setPromotionPhase(promotion, servingv1alpha1.PromotionPhaseFailed, "SourceNotFound", missing)
+		_ = r.Status().Update(ctx, promotion)
+		return ctrl.Result{RequeueAfter: 30000000000}, nil // 30 seconds
+	}
+
+	hash := promotionSpecHash(source)
+	promotion.Status.PendingGeneration = 0
+	switch {
+	case lastPromotedHash(promotion) == hash:
+		setPromotionPhase(promotion, servingv1alpha1.PromotionPhasePromoted, "UpToDate",
+			fmt.Sprintf("%s/%s runs generation %d of the %s server", promotion.Status.TargetNamespace, source.Name, lastPromotion(promotion).SourceGeneration, promotion.Spec.From))
+	case promotion.Spec.RequireApproval && promotion.Annotations[PlanApprovalAnnotation] != strconv.FormatInt(source.Generation, 10):
+		promotion.Status.PendingGeneration = source.Generation
+		setPromotionPhase(promotion, servingv1alpha1.PromotionPhaseAwaitingApproval, "AwaitingApproval",
+			fmt.Sprintf("Set the %s annotation to %d to promote generation %d of %s/%s to %s", PlanApprovalAnnotation, source.Generation, source.Generation, source.Namespace, source.Name, promotion.Spec.To))
+	default:
+		if err := r.promoteServer(ctx, promotion, source); err != nil {
+			log.Error(err, "Failed to promote KalypsoTritonServer")
+			return ctrl.Result{}, err
+		}
+		recordPromotion(promotion, servingv1alpha1.PromotionRecord{
+			SourceGeneration: source.Generation,
+			SpecHash:         hash,
+			StorageURI:       source.Spec.StorageURI,
+			Image:            tritonImage(source),
+			Approved:         promotion.Spec.RequireApproval,
+			PromotedAt:       metav1.Now(),
+		})
+		setPromotionPhase(promotion, servingv1alpha1.PromotionPhasePromoted, "Promoted",
+			fmt.Sprintf("Promoted generation %d of %s/%s to %s", source.Generation, source.Namespace, source.Name, promotion.Status.TargetNamespace))
+	}
+
+	if err := r.Status().Update(ctx, promotion); err != nil {
+		if errors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "Failed to update KalypsoPromotion status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// resolvePromotionSource resolves the environment namespaces into the status and fetches the
+// source server. It returns a message instead when one cannot be resolved
+func (r *KalypsoPromotionReconciler) resolvePromotionSource(ctx context.Context, promotion *servingv1alpha1.KalypsoPromotion) (*servingv1alpha1.KalypsoTritonServer, string, error) {
+	project, err := defaults.ReferencedProject(ctx, r.Client, promotion.Namespace, promotion.Spec.ProjectRef)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Sprintf("KalypsoProject '%s' not found", promotion.Spec.ProjectRef), nil
+		}
+		return nil, "", err
+	}
+	from, ok := project.Spec.Environments[promotion.Spec.From]
+	if !ok {
+		return nil, fmt.Sprintf("KalypsoProject '%s' has no environment '%s'", project.Name, promotion.Spec.From), nil
+	}
+	to, ok := project.Spec.Environments[promotion.Spec.To]
+	if !ok {
+		return nil, fmt.Sprintf("KalypsoProject '%s' has no environment '%s'", project.Name, promotion.Spec.To), nil
+	}
+	promotion.Status.SourceNamespace = from.Namespace
+	promotion.Status.TargetNamespace = to.Namespace
+
+	source := &servingv1alpha1.KalypsoTritonServer{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: from.Namespace, Name: promotion.Spec.ServerRef}, source); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Sprintf("KalypsoTritonServer '%s' not found in environment '%s'", promotion.Spec.ServerRef, promotion.Spec.From), nil
+		}
+		return nil, "", err
+	}
+	return source, "", nil
+}
+
+// promotionSpecHash identifies the promoted part of the source server spec; replicas are sized
+// per environment
+func promotionSpecHash(source *servingv1alpha1.KalypsoTritonServer) string {
+	spec := source.Spec.DeepCopy()
+	spec.Replicas = nil
+	encoded, _ := json.Marshal(spec)
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:8])
+}
+
+// promoteServer creates or updates the server of the target environment with the source spec,
+// retargeted at the target application. Existing target servers keep their replicas
+func (r *KalypsoPromotionReconciler) promoteServer(ctx context.Context, promotion *servingv1alpha1.KalypsoPromotion, source *servingv1alpha1.KalypsoTritonServer) error {
+	target := &servingv1alpha1.KalypsoTritonServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      source.Name,
+			Namespace: promotion.Status.TargetNamespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, target, func() error {
+		replicas := target.Spec.Replicas
+		exists := target.ResourceVersion != ""
+
+		target.Spec = *source.Spec.DeepCopy()
+		if promotion.Spec.ApplicationRef != "" {
+			target.Spec.ApplicationRef = promotion.Spec.ApplicationRef
+		}
+		if exists {
+			target.Spec.Replicas = replicas
+		}
+
+		if target.Labels == nil {
+			target.Labels = make(map[string]string)
+		}
+		target.Labels[PromotionLabelKey] = promotion.Name
+		if target.Annotations == nil {
+			target.Annotations = make(map[string]string)
+		}
+		target.Annotations[PromotedFromAnnotation] = fmt.Sprintf("%s/%s@%d", source.Namespace, source.Name, source.Generation)
+		return nil
+	})
+	return err
+}
+
+// lastPromotion returns the most recent promotion, nil before the first one
+func lastPromotion(promotion *servingv1alpha1.KalypsoPromotion) *servingv1alpha1.PromotionRecord {
+	if n := len(promotion.Status.History); n > 0 {
+		return &promotion.Status.History[n-1]
+	}
+	return nil
+}
+
+// lastPromotedHash returns the spec hash of the most recent promotion
+func lastPromotedHash(promotion *servingv1alpha1.KalypsoPromotion) string {
+	if last := lastPromotion(promotion); last != nil {
+		return last.SpecHash
+	}
+	return ""
+}
+
+// recordPromotion appends a promotion to the bounded history
+func recordPromotion(promotion *servingv1alpha1.KalypsoPromotion, record servingv1alpha1.PromotionRecord) {
+	history := append(promotion.Status.History, record)
+	if len(history) > maxRevisionHistory {
+		history = history[len(history)-maxRevisionHistory:]
+	}
+	promotion.Status.History = history
+}
+
+// setPromotionPhase records the phase of the promotion and its Promoted condition
+func setPromotionPhase(promotion *servingv1alpha1.KalypsoPromotion, phase servingv1alpha1.PromotionPhase, reason, message string) {
+	promotion.Status.Phase = phase
+	promotion.Status.Message = message
+	status := metav1.ConditionFalse
+	if phase == servingv1alpha1.PromotionPhasePromoted {
+		status = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(&promotion.Status.Conditions, metav1.Condition{
+		Type:               "Promoted",
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// promotionsForServer maps a KalypsoTritonServer to the promotions copying it, so its changes
+// are promoted or put up for approval
+func (r *KalypsoPromotionReconciler) promotionsForServer(ctx context.Context, obj client.Object) []reconcile.Request {
+	promotions := &servingv1alpha1.KalypsoPromotionList{}
+	if err := r.List(ctx, promotions); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, promotion := range promotions.Items {
+		if promotion.Status.SourceNamespace == obj.GetNamespace() && promotion.Spec.ServerRef == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&promotion)})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KalypsoPromotionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&servingv1alpha1.KalypsoPromotion{}).
+		Watches(&servingv1alpha1.KalypsoTritonServer{}, handler.EnqueueRequestsFromMapFunc(r.promotionsForServer)).
+		Named("kalypsopromotion").
+		Complete(r)
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+var _ = Describe("KalypsoPromotion Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default", // TODO(user):Modify as needed
+		}
+		kalypsopromotion := &servingv1alpha1.KalypsoPromotion{}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind KalypsoPromotion")
+			err := k8sClient.Get(ctx, typeNamespacedName, kalypsopromotion)
+			if err != nil && errors.IsNotFound(err) {
+				resource := &servingv1alpha1.KalypsoPromotion{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: "default",
+					},
+					Spec: servingv1alpha1.KalypsoPromotionSpec{
+						ProjectRef: "test-project",
+						ServerRef:  "test-server",
+						From:       "dev",
+						To:         "prod",
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			// TODO(user): Cleanup logic after each test, like removing the resource instance.
+			resource := &servingv1alpha1.KalypsoPromotion{}
+			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Cleanup the specific resource instance KalypsoPromotion")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+		It("should successfully reconcile the resource", func() {
+			By("Reconciling the created resource")
+			controllerReconciler := &KalypsoPromotionReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When promoting a server between project environments", func() {
+		var (
+			ctx        context.Context
+			reconciler *KalypsoPromotionReconciler
+			source     *servingv1alpha1.KalypsoTritonServer
+			promotion  *servingv1alpha1.KalypsoPromotion
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			project := &servingv1alpha1.KalypsoProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoProjectSpec{
+					Environments: map[string]servingv1alpha1.EnvironmentSpec{
+						"stage": {Namespace: "sample-project-stage"},
+						"prod":  {Namespace: "sample-project"},
+					},
+				},
+			}
+			source = &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-server", Namespace: "sample-project-stage", Generation: 3},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					ApplicationRef: "recommendation-stage",
+					StorageURI:     "s3://models/recommendation/v3",
+					Replicas:       ptrTo(int32(1)),
+				},
+			}
+			promotion = &servingv1alpha1.KalypsoPromotion{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-stage-to-prod", Namespace: project.Namespace},
+				Spec: servingv1alpha1.KalypsoPromotionSpec{
+					ProjectRef:      project.Name,
+					ServerRef:       source.Name,
+					From:            "stage",
+					To:              "prod",
+					ApplicationRef:  "recommendation-application",
+					RequireApproval: true,
+				},
+			}
+			reconciler = &KalypsoPromotionReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).
+					WithObjects(project, source, promotion).
+					WithStatusSubresource(&servingv1alpha1.KalypsoPromotion{}).
+					Build(),
+				Scheme: scheme,
+			}
+		})
+
+		reconcilePromotion := func() *servingv1alpha1.KalypsoPromotion {
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(promotion)})
+			Expect(err).NotTo(HaveOccurred())
+			updated := &servingv1alpha1.KalypsoPromotion{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(promotion), updated)).To(Succeed())
+			return updated
+		}
+
+		approve := func(generation string) {
+			updated := &servingv1alpha1.KalypsoPromotion{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(promotion), updated)).To(Succeed())
+			updated.Annotations = map[string]string{PlanApprovalAnnotation: generation}
+			Expect(reconciler.Update(ctx, updated)).To(Succeed())
+		}
+
+		It("should hold the promotion until the source generation is approved", func() {
+			updated := reconcilePromotion()
+			Expect(updated.Status.Phase).To(Equal(servingv1alpha1.PromotionPhaseAwaitingApproval))
+			Expect(updated.Status.PendingGeneration).To(Equal(int64(3)))
+			Expect(updated.Status.TargetNamespace).To(Equal("sample-project"))
+			target := &servingv1alpha1.KalypsoTritonServer{}
+			err := reconciler.Get(ctx, client.ObjectKey{Namespace: "sample-project", Name: source.Name}, target)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+
+			approve("3")
+			updated = reconcilePromotion()
+			Expect(updated.Status.Phase).To(Equal(servingv1alpha1.PromotionPhasePromoted))
+			Expect(updated.Status.History).To(HaveLen(1))
+			Expect(updated.Status.History[0].SourceGeneration).To(Equal(int64(3)))
+			Expect(updated.Status.History[0].Approved).To(BeTrue())
+
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: "sample-project", Name: source.Name}, target)).To(Succeed())
+			Expect(target.Spec.StorageURI).To(Equal("s3://models/recommendation/v3"))
+			Expect(target.Spec.ApplicationRef).To(Equal("recommendation-application"))
+			Expect(target.Labels).To(HaveKeyWithValue(PromotionLabelKey, promotion.Name))
+			Expect(target.Annotations).To(HaveKeyWithValue(PromotedFromAnnotation, "sample-project-stage/recommendation-server@3"))
+		})
+
+		It("should promote a new source generation and keep the target replicas", func() {
+			promotion.Spec.RequireApproval = false
+			updated := &servingv1alpha1.KalypsoPromotion{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(promotion), updated)).To(Succeed())
+			updated.Spec.RequireApproval = false
+			Expect(reconciler.Update(ctx, updated)).To(Succeed())
+			Expect(reconcilePromotion().Status.History).To(HaveLen(1))
+
+			target := &servingv1alpha1.KalypsoTritonServer{}
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: "sample-project", Name: source.Name}, target)).To(Succeed())
+			target.Spec.Replicas = ptrTo(int32(4))
+			Expect(reconciler.Update(ctx, target)).To(Succeed())
+
+			By("reconciling again without a source change")
+			Expect(reconcilePromotion().Status.History).To(HaveLen(1))
+
+			By("changing the source server")
+			changed := &servingv1alpha1.KalypsoTritonServer{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(source), changed)).To(Succeed())
+			changed.Spec.StorageURI = "s3://models/recommendation/v4"
+			changed.Generation = 4
+			Expect(reconciler.Update(ctx, changed)).To(Succeed())
+
+			updated = reconcilePromotion()
+			Expect(updated.Status.History).To(HaveLen(2))
+			Expect(updated.Status.History[1].StorageURI).To(Equal("s3://models/recommendation/v4"))
+			Expect(updated.Status.History[1].Approved).To(BeFalse())
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: "sample-project", Name: source.Name}, target)).To(Succeed())
+			Expect(target.Spec.StorageURI).To(Equal("s3://models/recommendation/v4"))
+			Expect(*target.Spec.Replicas).To(Equal(int32(4)))
+		})
+
+		It("should fail when the target environment is not defined by the project", func() {
+			updated := &servingv1alpha1.KalypsoPromotion{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(promotion), updated)).To(Succeed())
+			updated.Spec.To = "qa"
+			Expect(reconciler.Update(ctx, updated)).To(Succeed())
+
+			updated = reconcilePromotion()
+			Expect(updated.Status.Phase).To(Equal(servingv1alpha1.PromotionPhaseFailed))
+			Expect(updated.Status.Message).To(ContainSubstring("no environment 'qa'"))
+		})
+
+		It("should resolve a project of another namespace allowing the promotion namespace", func() {
+			shared := promotion.DeepCopy()
+			shared.Namespace = "sample-project-stage"
+			shared.Spec.ProjectRef = "kalypso-system/sample-project"
+			resolved, message, err := reconciler.resolvePromotionSource(ctx, shared)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(message).To(BeEmpty())
+			Expect(resolved.Name).To(Equal(source.Name))
+			Expect(shared.Status.TargetNamespace).To(Equal("sample-project"))
+
+			By("reporting the project as not found from a namespace it does not allow")
+			shared.Namespace = "other-team"
+			resolved, message, err = reconciler.resolvePromotionSource(ctx, shared)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolved).To(BeNil())
+			Expect(message).To(Equal("KalypsoProject 'kalypso-system/sample-project' not found"))
+		})
+	})
+})
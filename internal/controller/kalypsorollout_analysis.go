@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+	"github.com/kalypsoServing/KalypsoServing/internal/retraining"
+)
+
+// serverErrorRateQuery returns the ratio of 5xx responses served by a server over the window
+func serverErrorRateQuery(server *servingv1alpha1.KalypsoTritonServer, window string) string {
+	selector := serverSelector(server)
+	return fmt.Sprintf(`sum(rate(istio_requests_total{%s,response_code=~"5.."}[%s])) / sum(rate(istio_requests_total{%s}[%s]))`,
+		selector, window, selector, window)
+}
+
+// serverP99LatencyQuery returns the p99 request latency of a server in milliseconds over the window
+func serverP99LatencyQuery(server *servingv1alpha1.KalypsoTritonServer, window string) string {
+	return fmt.Sprintf(`histogram_quantile(0.99, sum(rate(istio_request_duration_milliseconds_bucket{%s}[%s])) by (le))`,
+		serverSelector(server), window)
+}
+
+// serverSelector selects the Istio metrics reported by the sidecars of a server
+func serverSelector(server *servingv1alpha1.KalypsoTritonServer) string {
+	return fmt.Sprintf(`reporter="destination",destination_service_name=%q,destination_service_namespace=%q`,
+		naming.Service(server.Name), server.Namespace)
+}
+
+// analyzeCanary queries the canary error rate and p99 latency, and counts a failure when either
+// breaches its threshold. Queries without samples, e.g. before the canary received traffic, pass
+func (r *KalypsoRolloutReconciler) analyzeCanary(ctx context.Context, rollout *servingv1alpha1.KalypsoRollout, canary *servingv1alpha1.KalypsoTritonServer, now time.Time) {
+	log := logf.FromContext(ctx)
+
+	analysis := rollout.Spec.Analysis
+	if r.MetricsQuerier == nil {
+		log.Info("Rollout analysis is configured but no metrics querier is configured")
+		return
+	}
+
+	status := rollout.Status.Analysis
+	if status == nil {
+		status = &servingv1alpha1.RolloutAnalysisStatus{}
+	}
+	evaluatedAt := metav1.NewTime(now)
+	status.LastEvaluationTime = &evaluatedAt
+
+	interval := parseDurationOrDefault(analysis.Interval, time.Minute)
+	window := fmt.Sprintf("%ds", int64(interval.Seconds()))
+
+	var breaches []string
+	checks := []struct {
+		name      string
+		query     string
+		threshold string
+		observed  *string
+	}{
+		{"error rate", serverErrorRateQuery(canary, window), analysis.MaxErrorRate, &status.ErrorRate},
+		{"p99 latency", serverP99LatencyQuery(canary, window), analysis.MaxP99LatencyMs, &status.P99LatencyMs},
+	}
+	for _, check := range checks {
+		if check.threshold == "" {
+			continue
+		}
+		threshold, err := strconv.ParseFloat(check.threshold, 64)
+		if err != nil {
+			log.Info("Invalid rollout analysis threshold", "metric", check.name, "threshold", check.threshold)
+			continue
+		}
+
+		value, err := r.MetricsQuerier.Query(ctx, analysis.PrometheusURL, check.query)
+		if err != nil {
+			if !errors.Is(err, retraining.ErrNoSamples) {
+				log.Info("Failed to evaluate rollout analysis", "metric", check.name, "error", err)
+			}
+			continue
+		}
+		*check.observed = strconv.FormatFloat(value, 'f', -1, 64)
+
+		if value > threshold {
+			breaches = append(breaches, fmt.Sprintf("%s %s above %s", check.name, *check.observed, check.threshold))
+		}
+	}
+
+	if len(breaches) > 0 {
+		status.Failures++
+		log.Info("Canary analysis failed", "canary", canary.Name, "breaches", breaches, "failures", status.Failures)
+	}
+	rollout.Status.Analysis = status
+}
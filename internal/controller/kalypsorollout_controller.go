@@ -0,0 +1,367 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+	"github.com/kalypsoServing/KalypsoServing/internal/retraining"
+)
+
+const (
+	// RolloutLabelKey is the label key for the KalypsoRollout owning a traffic splitting route
+	RolloutLabelKey = "kalypso-serving.io/rollout"
+)
+
+// KalypsoRolloutReconciler reconciles a KalypsoRollout object
+type KalypsoRolloutReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// MetricsQuerier evaluates the canary analysis queries
+	MetricsQuerier retraining.Querier
+}
+
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsorollouts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsorollouts/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsorollouts/finalizers,verbs=update
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsoapplications,verbs=get;list;watch
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsotritonservers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile shifts the application traffic from the stable to the canary KalypsoTritonServer one
+// step at a time, and promotes or aborts the rollout based on the canary analysis
+func (r *KalypsoRolloutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	// Fetch the KalypsoRollout instance
+	rollout := &servingv1alpha1.KalypsoRollout{}
+	if err := r.Get(ctx, req.NamespacedName, rollout); err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("KalypsoRollout resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get KalypsoRollout")
+		return ctrl.Result{}, err
+	}
+
+	// Set initial status
+	if rollout.Status.Phase == "" {
+		rollout.Status.Phase = servingv1alpha1.RolloutPhaseProgressing
+		startRolloutStep(rollout, 0, time.Now())
+		if err := r.Status().Update(ctx, rollout); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// Validate the application and server references
+	app, stable, canary, missing, err := r.resolveRolloutReferences(ctx, rollout)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if missing != "" {
+		log.Info("Rollout references cannot be resolved", "reason", missing)
+		meta.SetStatusCondition(&rollout.Status.Conditions, metav1.Condition{
+			Type:               "ReferencesResolved",
+			Status:             metav1.ConditionFalse,
+			Reason:             "ReferenceNotFound",
+			Message:            missing,
+			LastTransitionTime: metav1.Now(),
+		})
+		_ = r.Status().Update(ctx, rollout)
+		return ctrl.Result{RequeueAfter: 30000000000}, nil // 30 seconds
+	}
+	meta.SetStatusCondition(&rollout.Status.Conditions, metav1.Condition{
+		Type:               "ReferencesResolved",
+		Status:             metav1.ConditionTrue,
+		Reason:             "ReferencesFound",
+		Message:            "Application, stable and canary servers found",
+		LastTransitionTime: metav1.Now(),
+	})
+
+	var requeueAfter time.Duration
+	if rollout.Status.Phase == servingv1alpha1.RolloutPhaseProgressing {
+		requeueAfter = r.progressRollout(ctx, rollout, canary, time.Now())
+	}
+
+	// Apply the current weights
+	if err := r.reconcileRolloutRoute(ctx, rollout, app, stable, canary); err != nil {
+		log.Error(err, "Failed to reconcile rollout VirtualService")
+		return ctrl.Result{}, err
+	}
+
+	if err := r.Status().Update(ctx, rollout); err != nil {
+		if errors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "Failed to update KalypsoRollout status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// resolveRolloutReferences fetches the application and the stable and canary servers. It returns
+// a message instead when one is missing or the servers do not belong to the application
+func (r *KalypsoRolloutReconciler) resolveRolloutReferences(ctx context.Context, rollout *servingv1alpha1.KalypsoRollout) (*servingv1alpha1.KalypsoApplication, *servingv1alpha1.KalypsoTritonServer, *servingv1alpha1.KalypsoTritonServer, string, error) {
+	app := &servingv1alpha1.KalypsoApplication{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: rollout.Namespace, Name: rollout.Spec.ApplicationRef}, app); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil, nil, fmt.Sprintf("KalypsoApplication '%s' not found", rollout.Spec.ApplicationRef), nil
+		}
+		return nil, nil, nil, "", err
+	}
+
+	servers := make([]*servingv1alpha1.KalypsoTritonServer, 0, 2)
+	for _, name := range []string{rollout.Spec.StableRef, rollout.Spec.CanaryRef} {
+		server := &servingv1alpha1.KalypsoTritonServer{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: rollout.Namespace, Name: name}, server); err != nil {
+			if errors.IsNotFound(err) {
+				return nil, nil, nil, fmt.Sprintf("KalypsoTritonServer '%s' not found", name), nil
+			}
+			return nil, nil, nil, "", err
+		}
+		if !servesApplication(server, app) {
+			return nil, nil, nil, fmt.Sprintf("KalypsoTritonServer '%s' does not belong to KalypsoApplication '%s'", name, app.Name), nil
+		}
+		servers = append(servers, server)
+	}
+	return app, servers[0], servers[1], "", nil
+}
+
+// startRolloutStep applies the weight of a step
+func startRolloutStep(rollout *servingv1alpha1.KalypsoRollout, step int32, now time.Time) {
+	startedAt := metav1.NewTime(now)
+	rollout.Status.CurrentStep = step
+	rollout.Status.CanaryWeight = rollout.Spec.Steps[step].Weight
+	rollout.Status.StepStartedAt = &startedAt
+	rollout.Status.Message = fmt.Sprintf("Step %d/%d: %d%% of traffic to %s", step+1, len(rollout.Spec.Steps), rollout.Status.CanaryWeight, rollout.Spec.CanaryRef)
+}
+
+// progressRollout evaluates the canary analysis when due, then aborts the rollout, advances it to
+// the next step once the pause elapsed, or promotes it after the last step. It returns when the
+// rollout must be reconciled again
+func (r *KalypsoRolloutReconciler) progressRollout(ctx context.Context, rollout *servingv1alpha1.KalypsoRollout, canary *servingv1alpha1.KalypsoTritonServer, now time.Time) time.Duration {
+	analysis := rollout.Spec.Analysis
+	interval := time.Duration(0)
+	if analysis != nil {
+		interval = parseDurationOrDefault(analysis.Interval, time.Minute)
+		last := rollout.Status.Analysis
+		if last == nil || last.LastEvaluationTime == nil || now.Sub(last.LastEvaluationTime.Time) >= interval {
+			r.analyzeCanary(ctx, rollout, canary, now)
+		}
+
+		failureLimit := analysis.FailureLimit
+		if failureLimit == 0 {
+			failureLimit = 3
+		}
+		if rollout.Status.Analysis != nil && rollout.Status.Analysis.Failures >= failureLimit {
+			rollout.Status.Phase = servingv1alpha1.RolloutPhaseAborted
+			rollout.Status.CanaryWeight = 0
+			rollout.Status.Message = fmt.Sprintf("Aborted after %d failed analyses, all traffic sent back to %s", rollout.Status.Analysis.Failures, rollout.Spec.StableRef)
+			meta.SetStatusCondition(&rollout.Status.Conditions, metav1.Condition{
+				Type:               "Progressing",
+				Status:             metav1.ConditionFalse,
+				Reason:             "AnalysisFailed",
+				Message:            rollout.Status.Message,
+				LastTransitionTime: metav1.Now(),
+			})
+			return 0
+		}
+	}
+
+	step := rollout.Status.CurrentStep
+	if int(step) >= len(rollout.Spec.Steps) {
+		step = int32(len(rollout.Spec.Steps) - 1)
+	}
+	pause := parseDurationOrDefault(rollout.Spec.Steps[step].Pause, time.Minute)
+	elapsed := time.Duration(0)
+	if rollout.Status.StepStartedAt != nil {
+		elapsed = now.Sub(rollout.Status.StepStartedAt.Time)
+	} else {
+		startRolloutStep(rollout, step, now)
+	}
+
+	remaining := pause - elapsed
+	if remaining <= 0 {
+		if int(step)+1 >= len(rollout.Spec.Steps) {
+			rollout.Status.Phase = servingv1alpha1.RolloutPhasePromoted
+			rollout.Status.CanaryWeight = 100
+			rollout.Status.Message = fmt.Sprintf("Promoted, all traffic sent to %s", rollout.Spec.CanaryRef)
+			meta.SetStatusCondition(&rollout.Status.Conditions, metav1.Condition{
+				Type:               "Progressing",
+				Status:             metav1.ConditionFalse,
+				Reason:             "Promoted",
+				Message:            rollout.Status.Message,
+				LastTransitionTime: metav1.Now(),
+			})
+			return 0
+		}
+		startRolloutStep(rollout, step+1, now)
+		remaining = parseDurationOrDefault(rollout.Spec.Steps[step+1].Pause, time.Minute)
+	}
+
+	meta.SetStatusCondition(&rollout.Status.Conditions, metav1.Condition{
+		Type:               "Progressing",
+		Status:             metav1.ConditionTrue,
+		Reason:             "StepApplied",
+		Message:            rollout.Status.Message,
+		LastTransitionTime: metav1.Now(),
+	})
+
+	if interval > 0 && interval < remaining {
+		return interval
+	}
+	return remaining
+}
+
+// tritonHTTPPort returns the HTTP port of the server, taken from the server defaults of its
+// application when the server leaves it unset. app may be nil for a server already carrying them
+func tritonHTTPPort(server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) int32 {
+	server = defaults.WithApplicationDefaults(server, app)
+	if server.Spec.Networking != nil && server.Spec.Networking.HTTPPort != nil {
+		return *server.Spec.Networking.HTTPPort
+	}
+	return defaults.HTTPPort
+}
+
+// tritonGRPCPort returns the gRPC port of the server, taken from the server defaults of its
+// application when the server leaves it unset. app may be nil for a server already carrying them
+func tritonGRPCPort(server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) int32 {
+	server = defaults.WithApplicationDefaults(server, app)
+	if server.Spec.Networking != nil && server.Spec.Networking.GrpcPort != nil {
+		return *server.Spec.Networking.GrpcPort
+	}
+	return defaults.GRPCPort
+}
+
+// serviceHost returns the cluster-local hostname of a server's Service
+func serviceHost(server *servingv1alpha1.KalypsoTritonServer) string {
+	return fmt.Sprintf("%s.%s.svc.cluster.local", naming.Service(server.Name), server.Namespace)
+}
+
+// reconcileRolloutRoute ensures the VirtualService splitting the traffic between the stable and
+// canary servers
+func (r *KalypsoRolloutReconciler) reconcileRolloutRoute(ctx context.Context, rollout *servingv1alpha1.KalypsoRollout, app *servingv1alpha1.KalypsoApplication, stable, canary *servingv1alpha1.KalypsoTritonServer) error {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(virtualServiceGVK)
+	route.SetName(naming.RolloutRoute(rollout.Name))
+	route.SetNamespace(rollout.Namespace)
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, route, func() error {
+		labels := route.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[RolloutLabelKey] = rollout.Name
+		labels[ApplicationLabelKey] = app.Name
+		labels[ManagedByLabelKey] = ManagedByLabelValue
+		route.SetLabels(labels)
+
+		if err := unstructured.SetNestedMap(route.Object, buildRolloutRouteSpec(rollout, app, stable, canary), "spec"); err != nil {
+			return err
+		}
+
+		// Set owner reference
+		return controllerutil.SetControllerReference(rollout, route, r.Scheme)
+	})
+	return err
+}
+
+// buildRolloutRouteSpec builds the VirtualService spec routing mesh requests to the stable Service,
+// and the application gateway custom domains, to both servers by the current canary weight
+func buildRolloutRouteSpec(rollout *servingv1alpha1.KalypsoRollout, app *servingv1alpha1.KalypsoApplication, stable, canary *servingv1alpha1.KalypsoTritonServer) map[string]interface{} {
+	hosts := []interface{}{serviceHost(stable)}
+	gateways := []interface{}{"mesh"}
+	if app.Spec.Routing != nil && len(app.Spec.Routing.CustomDomains) > 0 {
+		for _, domain := range app.Spec.Routing.CustomDomains {
+			hosts = append(hosts, domain)
+		}
+		gateways = append(gateways, naming.Gateway(app.Name))
+	}
+
+	canaryWeight := int64(rollout.Status.CanaryWeight)
+	return map[string]interface{}{
+		"hosts":    hosts,
+		"gateways": gateways,
+		"http": []interface{}{
+			map[string]interface{}{
+				"name": "rollout",
+				"route": []interface{}{
+					map[string]interface{}{
+						"destination": map[string]interface{}{
+							"host": serviceHost(stable),
+							"port": map[string]interface{}{"number": int64(tritonHTTPPort(stable, app))},
+						},
+						"weight": 100 - canaryWeight,
+					},
+					map[string]interface{}{
+						"destination": map[string]interface{}{
+							"host": serviceHost(canary),
+							"port": map[string]interface{}{"number": int64(tritonHTTPPort(canary, app))},
+						},
+						"weight": canaryWeight,
+					},
+				},
+			},
+		},
+	}
+}
+
+// rolloutsForApplication maps a KalypsoApplication to the rollouts routing its traffic, so
+// custom domain changes reach their VirtualServices
+func (r *KalypsoRolloutReconciler) rolloutsForApplication(ctx context.Context, obj client.Object) []reconcile.Request {
+	rollouts := &servingv1alpha1.KalypsoRolloutList{}
+	if err := r.List(ctx, rollouts, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, rollout := range rollouts.Items {
+		if rollout.Spec.ApplicationRef == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&rollout)})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KalypsoRolloutReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&servingv1alpha1.KalypsoRollout{}).
+		Watches(&servingv1alpha1.KalypsoApplication{}, handler.EnqueueRequestsFromMapFunc(r.rolloutsForApplication)).
+		Named("kalypsorollout").
+		Complete(r)
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/retraining"
+)
+
+var _ = Describe("KalypsoRollout Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default", // TODO(user):Modify as needed
+		}
+		kalypsorollout := &servingv1alpha1.KalypsoRollout{}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind KalypsoRollout")
+			err := k8sClient.Get(ctx, typeNamespacedName, kalypsorollout)
+			if err != nil && errors.IsNotFound(err) {
+				resource := &servingv1alpha1.KalypsoRollout{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: "default",
+					},
+					Spec: servingv1alpha1.KalypsoRolloutSpec{
+						ApplicationRef: "test-application",
+						StableRef:      "test-stable",
+						CanaryRef:      "test-canary",
+						Steps:          []servingv1alpha1.RolloutStep{{Weight: 10}},
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			// TODO(user): Cleanup logic after each test, like removing the resource instance.
+			resource := &servingv1alpha1.KalypsoRollout{}
+			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Cleanup the specific resource instance KalypsoRollout")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+		It("should successfully reconcile the resource", func() {
+			By("Reconciling the created resource")
+			controllerReconciler := &KalypsoRolloutReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
+			// Example: If you expect a certain status condition after reconciliation, verify it here.
+		})
+	})
+
+	Context("When shifting traffic to a canary", func() {
+		var (
+			ctx        context.Context
+			reconciler *KalypsoRolloutReconciler
+			querier    *stubQuerier
+			rollout    *servingv1alpha1.KalypsoRollout
+		)
+
+		reconcileRollout := func() *servingv1alpha1.KalypsoRollout {
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(rollout)})
+			Expect(err).NotTo(HaveOccurred())
+			updated := &servingv1alpha1.KalypsoRollout{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(rollout), updated)).To(Succeed())
+			return updated
+		}
+
+		routeWeights := func() []int64 {
+			route := &unstructured.Unstructured{}
+			route.SetGroupVersionKind(virtualServiceGVK)
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: rollout.Namespace, Name: "recommendation-rollout-rollout"}, route)).To(Succeed())
+			routes, _, _ := unstructured.NestedSlice(route.Object, "spec", "http")
+			destinations := routes[0].(map[string]interface{})["route"].([]interface{})
+			weights := make([]int64, 0, len(destinations))
+			for _, destination := range destinations {
+				weights = append(weights, destination.(map[string]interface{})["weight"].(int64))
+			}
+			return weights
+		}
+
+		expireStep := func() {
+			updated := &servingv1alpha1.KalypsoRollout{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(rollout), updated)).To(Succeed())
+			startedAt := metav1.NewTime(time.Now().Add(-time.Hour))
+			updated.Status.StepStartedAt = &startedAt
+			Expect(reconciler.Status().Update(ctx, updated)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ProjectRef: "sample-project",
+					Routing:    &servingv1alpha1.RoutingSpec{CustomDomains: []string{"api.example.com"}},
+				},
+			}
+			stable := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: app.Namespace},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: app.Name},
+			}
+			canary := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v2", Namespace: app.Namespace},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: app.Name},
+			}
+			rollout = &servingv1alpha1.KalypsoRollout{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-rollout", Namespace: app.Namespace},
+				Spec: servingv1alpha1.KalypsoRolloutSpec{
+					ApplicationRef: app.Name,
+					StableRef:      stable.Name,
+					CanaryRef:      canary.Name,
+					Steps:          []servingv1alpha1.RolloutStep{{Weight: 10, Pause: "5m"}, {Weight: 50, Pause: "5m"}},
+					Analysis: &servingv1alpha1.RolloutAnalysisSpec{
+						PrometheusURL:   "http://prometheus:9090",
+						MaxErrorRate:    "0.01",
+						MaxP99LatencyMs: "250",
+						FailureLimit:    2,
+					},
+				},
+			}
+			querier = &stubQuerier{values: map[string]float64{}}
+			reconciler = &KalypsoRolloutReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).
+					WithObjects(app, stable, canary, rollout).
+					WithStatusSubresource(&servingv1alpha1.KalypsoRollout{}).
+					Build(),
+				Scheme:         scheme,
+				MetricsQuerier: querier,
+			}
+		})
+
+		It("should advance through the steps and promote a healthy canary", func() {
+			querier.values["istio_requests_total"] = 0.001
+			querier.values["istio_request_duration_milliseconds_bucket"] = 120
+
+			Expect(reconcileRollout().Status.Phase).To(Equal(servingv1alpha1.RolloutPhaseProgressing))
+			updated := reconcileRollout()
+			Expect(updated.Status.CanaryWeight).To(Equal(int32(10)))
+			Expect(updated.Status.Analysis.P99LatencyMs).To(Equal("120"))
+			Expect(routeWeights()).To(Equal([]int64{90, 10}))
+
+			route := &unstructured.Unstructured{}
+			route.SetGroupVersionKind(virtualServiceGVK)
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: rollout.Namespace, Name: "recommendation-rollout-rollout"}, route)).To(Succeed())
+			hosts, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hosts")
+			Expect(hosts).To(ConsistOf("recommendation-v1-svc.kalypso-system.svc.cluster.local", "api.example.com"))
+			gateways, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "gateways")
+			Expect(gateways).To(ConsistOf("mesh", "recommendation-application-gateway"))
+
+			expireStep()
+			updated = reconcileRollout()
+			Expect(updated.Status.CurrentStep).To(Equal(int32(1)))
+			Expect(routeWeights()).To(Equal([]int64{50, 50}))
+
+			expireStep()
+			updated = reconcileRollout()
+			Expect(updated.Status.Phase).To(Equal(servingv1alpha1.RolloutPhasePromoted))
+			Expect(routeWeights()).To(Equal([]int64{0, 100}))
+		})
+
+		It("should abort and restore the stable server when the analysis fails", func() {
+			querier.values["istio_requests_total"] = 0.2
+
+			reconcileRollout()
+			updated := reconcileRollout()
+			Expect(updated.Status.Analysis.Failures).To(Equal(int32(1)))
+			Expect(updated.Status.Phase).To(Equal(servingv1alpha1.RolloutPhaseProgressing))
+
+			analyzedAt := metav1.NewTime(time.Now().Add(-time.Hour))
+			updated.Status.Analysis.LastEvaluationTime = &analyzedAt
+			Expect(reconciler.Status().Update(ctx, updated)).To(Succeed())
+
+			updated = reconcileRollout()
+			Expect(updated.Status.Phase).To(Equal(servingv1alpha1.RolloutPhaseAborted))
+			Expect(routeWeights()).To(Equal([]int64{100, 0}))
+		})
+	})
+})
+
+// stubQuerier answers queries with the value of the first metric name they contain
+type stubQuerier struct {
+	values map[string]float64
+}
+
+func (q *stubQuerier) Query(_ context.Context, _, query string) (float64, error) {
+	for _, metric := range []string{"istio_request_duration_milliseconds_bucket", "istio_requests_total"} {
+		if value, ok := q.values[metric]; ok && strings.Contains(query, metric) {
+			return value, nil
+		}
+	}
+	return 0, retraining.ErrNoSamples
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+// serversForApplication maps a KalypsoApplication to its KalypsoTritonServers, so changed server
+// defaults roll them
+func (r *KalypsoTritonServerReconciler) serversForApplication(ctx context.Context, obj client.Object) []reconcile.Request {
+	app, ok := obj.(*servingv1alpha1.KalypsoApplication)
+	if !ok {
+		return nil
+	}
+	servers := &servingv1alpha1.KalypsoTritonServerList{}
+	if err := r.List(ctx, servers); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, server := range servers.Items {
+		if servesApplication(&server, app) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&server)})
+		}
+	}
+	return requests
+}
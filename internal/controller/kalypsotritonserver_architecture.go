@@ -0,0 +1,177 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/features"
+)
+
+// DefaultTritonImage is the Triton image used when spec.tritonConfig.image is not set
+const DefaultTritonImage = "nvcr.io/nvidia/tritonserver"
+
+// defaultTritonTags are the Triton release tags used per node architecture when
+// spec.tritonConfig.tag is not set. NGC publishes the py3 release tags for both amd64 and
+// arm64 (SBSA), which covers Graviton and Grace nodes.
+var defaultTritonTags = map[string]string{
+	"amd64": "24.12-py3",
+	"arm64": "24.12-py3",
+}
+
+// tritonImage returns the Triton container image for the server's target architecture
+func tritonImage(server *servingv1alpha1.KalypsoTritonServer) string {
+	image := DefaultTritonImage
+	if server.Spec.TritonConfig.Image != "" {
+		image = server.Spec.TritonConfig.Image
+	}
+
+	tag := defaultTritonTag(server)
+	if server.Spec.TritonConfig.Tag != "" {
+		tag = server.Spec.TritonConfig.Tag
+	}
+	// Images pinned to a manifest digest are referenced with @
+	if strings.HasPrefix(tag, "sha256:") {
+		return image + "@" + tag
+	}
+	return fmt.Sprintf("%s:%s", image, tag)
+}
+
+// resolvedTritonConfig returns a copy of the Triton configuration of the server with the image
+// and tag it is deployed with
+func resolvedTritonConfig(server *servingv1alpha1.KalypsoTritonServer) servingv1alpha1.TritonConfigSpec {
+	config := *server.Spec.TritonConfig.DeepCopy()
+	if config.Image == "" {
+		config.Image = DefaultTritonImage
+	}
+	if config.Tag == "" {
+		config.Tag = defaultTritonTag(server)
+	}
+	return config
+}
+
+// defaultTritonTag returns the Triton release tag used for the server's target architecture
+// when spec.tritonConfig.tag is not set
+func defaultTritonTag(server *servingv1alpha1.KalypsoTritonServer) string {
+	if tag, ok := defaultTritonTags[targetArchitecture(server)]; ok {
+		return tag
+	}
+	return defaultTritonTags["amd64"]
+}
+
+// targetArchitecture returns the node architecture pinned by the scheduling constraints,
+// either through the kubernetes.io/arch node selector or a required node affinity, or an
+// empty string when pods may land on any architecture
+func targetArchitecture(server *servingv1alpha1.KalypsoTritonServer) string {
+	scheduling := server.Spec.Scheduling
+	if scheduling == nil {
+		return ""
+	}
+	if arch := scheduling.NodeSelector[corev1.LabelArchStable]; arch != "" {
+		return arch
+	}
+	if scheduling.Affinity == nil || scheduling.Affinity.NodeAffinity == nil ||
+		scheduling.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return ""
+	}
+
+	// Node selector terms are ORed, so every term must pin the same architecture
+	arch := ""
+	for _, term := range scheduling.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		termArch := ""
+		for _, expr := range term.MatchExpressions {
+			if expr.Key == corev1.LabelArchStable && expr.Operator == corev1.NodeSelectorOpIn && len(expr.Values) == 1 {
+				termArch = expr.Values[0]
+			}
+		}
+		if termArch == "" || (arch != "" && termArch != arch) {
+			return ""
+		}
+		arch = termArch
+	}
+	return arch
+}
+
+// requestsGPU reports whether the GPU spec allocates whole GPUs or MIG slices
+func requestsGPU(gpu *servingv1alpha1.GPUSpec) bool {
+	return gpu != nil && (gpu.MIG != nil || (gpu.Count != nil && *gpu.Count > 0))
+}
+
+// checkArchitecture returns the ArchitectureMismatch condition for the server's target
+// architecture, or nil when the scheduling constraints do not pin an architecture
+func (r *KalypsoTritonServerReconciler) checkArchitecture(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer) *metav1.Condition {
+	log := logf.FromContext(ctx)
+
+	arch := targetArchitecture(server)
+	if arch == "" {
+		return nil
+	}
+
+	condition := &metav1.Condition{
+		Type:    "ArchitectureMismatch",
+		Status:  metav1.ConditionFalse,
+		Reason:  "ArchitectureSupported",
+		Message: fmt.Sprintf("Triton pods target %s nodes", arch),
+	}
+
+	// TensorRT engines only run on NVIDIA GPUs, which CPU-only arm64 nodes such as Graviton lack
+	if server.Spec.TritonConfig.BackendType == "tensorrt" && arch == "arm64" && !requestsGPU(server.Spec.GPU) {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "TensorRTUnsupported"
+		condition.Message = "The TensorRT backend requires NVIDIA GPUs; request spec.gpu or schedule the server to amd64 nodes"
+		return condition
+	}
+
+	if !features.Enabled(features.ImageArchitectureCheck) || r.ImageResolver == nil {
+		return condition
+	}
+
+	image := tritonImage(server)
+	architectures, err := r.ImageResolver.Architectures(ctx, image)
+	if err != nil {
+		log.Info("Failed to resolve image architectures", "image", image, "error", err)
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "ManifestUnavailable"
+		condition.Message = fmt.Sprintf("Could not read the manifest of %s: %v", image, err)
+		return condition
+	}
+	if !slices.Contains(architectures, arch) {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "ImageArchitectureUnsupported"
+		condition.Message = fmt.Sprintf("Image %s is published for %s, not %s", image, strings.Join(architectures, ", "), arch)
+	}
+	return condition
+}
+
+// applyArchitectureStatus records the architecture check on the server status
+func applyArchitectureStatus(server *servingv1alpha1.KalypsoTritonServer, condition *metav1.Condition) {
+	if condition == nil {
+		meta.RemoveStatusCondition(&server.Status.Conditions, "ArchitectureMismatch")
+		return
+	}
+	condition.LastTransitionTime = metav1.Now()
+	meta.SetStatusCondition(&server.Status.Conditions, *condition)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+// archiveDefaultImage provides curl and the busybox sha256sum, tar and unzip applets
+const archiveDefaultImage = "curlimages/curl:8.10.1"
+
+// archiveDownloadScript downloads the archive, verifies its checksum and unpacks it to the
+// repository directory. The credentials are passed on stdin so they stay out of the process
+// arguments
+const archiveDownloadScript = `set -eu
+archive="$KALYPSO_MODELS/.archive"
+repository="$KALYPSO_MODELS/repository"
+rm -rf "$archive" "$repository"
+mkdir -p "$repository"
+config=""
+if [ -n "${ARCHIVE_USERNAME:-}" ]; then
+  config="user = \"$ARCHIVE_USERNAME:$ARCHIVE_PASSWORD\""
+fi
+echo "$config" | curl --config - --fail --silent --show-error --location --retry 3 --output "$archive" "$ARCHIVE_URL"
+echo "$ARCHIVE_SHA256  $archive" | sha256sum -c -
+case "$ARCHIVE_FORMAT" in
+  zip) unzip -q "$archive" -d "$repository" ;;
+  tar.gz) tar -xzf "$archive" -C "$repository" ;;
+  *) tar -xf "$archive" -C "$repository" ;;
+esac
+rm -f "$archive"
+`
+
+// isArchiveURI reports whether the storage URI is a model repository archive served over HTTP(S)
+func isArchiveURI(uri string) bool {
+	return strings.HasPrefix(uri, "https://") || strings.HasPrefix(uri, "http://")
+}
+
+// archiveFormat returns the packaging of the archive: spec.archive.format, or else the one
+// matching the extension of the URI path
+func archiveFormat(server *servingv1alpha1.KalypsoTritonServer) servingv1alpha1.ArchiveFormat {
+	if server.Spec.Archive != nil && server.Spec.Archive.Format != "" {
+		return server.Spec.Archive.Format
+	}
+	name := server.Spec.StorageURI
+	if u, err := url.Parse(name); err == nil {
+		name = u.Path
+	}
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return servingv1alpha1.ArchiveFormatZip
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return servingv1alpha1.ArchiveFormatTarGz
+	}
+	return servingv1alpha1.ArchiveFormatTar
+}
+
+// applyArchiveDownload adds the init container downloading and unpacking a model repository
+// archive to the model download volume
+func applyArchiveDownload(podSpec *corev1.PodSpec, server *servingv1alpha1.KalypsoTritonServer) {
+	if !isArchiveURI(server.Spec.StorageURI) {
+		return
+	}
+	spec := server.Spec.Archive
+	if spec == nil {
+		spec = &servingv1alpha1.ArchiveSpec{}
+	}
+
+	image := spec.Image
+	if image == "" {
+		image = archiveDefaultImage
+	}
+	env := []corev1.EnvVar{
+		{Name: "KALYPSO_MODELS", Value: ModelDownloadMountPath},
+		{Name: "ARCHIVE_URL", Value: server.Spec.StorageURI},
+		{Name: "ARCHIVE_SHA256", Value: spec.SHA256},
+		{Name: "ARCHIVE_FORMAT", Value: string(archiveFormat(server))},
+	}
+	if spec.CredentialsSecret != "" {
+		for _, key := range []string{"username", "password"} {
+			env = append(env, corev1.EnvVar{
+				Name: "ARCHIVE_" + strings.ToUpper(key),
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: spec.CredentialsSecret},
+						Key:                  key,
+					},
+				},
+			})
+		}
+	}
+
+	applyModelDownload(podSpec, corev1.Container{
+		Name:            "archive-download",
+		Image:           image,
+		Command:         []string{"sh", "-c", archiveDownloadScript},
+		Env:             env,
+		SecurityContext: buildContainerSecurityContext(server.Spec.ContainerSecurityContext),
+	}, spec.SizeLimit)
+}
+
+// archiveRepositoryPath returns the model repository of an unpacked archive
+func archiveRepositoryPath(server *servingv1alpha1.KalypsoTritonServer) string {
+	repository := path.Join(ModelDownloadMountPath, "repository")
+	if server.Spec.Archive != nil && server.Spec.Archive.Path != "" {
+		return path.Join(repository, server.Spec.Archive.Path)
+	}
+	return repository
+}
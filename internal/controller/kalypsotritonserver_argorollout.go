@@ -0,0 +1,188 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// argoRolloutGVK is the Argo Rollouts Rollout kind
+var argoRolloutGVK = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout"}
+
+// isArgoRollout reports whether the server runs as an Argo Rollouts Rollout
+func isArgoRollout(server *servingv1alpha1.KalypsoTritonServer) bool {
+	return server.Spec.WorkloadType == servingv1alpha1.WorkloadTypeRollout
+}
+
+// newArgoRollout returns an empty Rollout object with its name set
+func newArgoRollout(name, namespace string) *unstructured.Unstructured {
+	rollout := &unstructured.Unstructured{}
+	rollout.SetGroupVersionKind(argoRolloutGVK)
+	rollout.SetName(name)
+	rollout.SetNamespace(namespace)
+	return rollout
+}
+
+// deleteArgoRollout removes the Rollout of the server. Nothing is left to remove when the Argo
+// Rollouts CRDs are not installed
+func (r *KalypsoTritonServerReconciler) deleteArgoRollout(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer) error {
+	rollout := newArgoRollout(naming.ArgoRollout(server.Name), server.Namespace)
+	if err := r.deleteOwned(ctx, server, rollout); err != nil && !meta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
+
+// reconcileArgoRollout ensures the Rollout exists with proper configuration
+func (r *KalypsoTritonServerReconciler) reconcileArgoRollout(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication, rollout *unstructured.Unstructured, surge int32) error {
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, rollout, func() error {
+		if err := r.mutateArgoRollout(rollout, server, app); err != nil {
+			return err
+		}
+		if !scaledToZero(server) {
+			replicas, _, _ := unstructured.NestedInt64(rollout.Object, "spec", "replicas")
+			return unstructured.SetNestedField(rollout.Object, replicas+int64(surge), "spec", "replicas")
+		}
+		return nil
+	})
+
+	return err
+}
+
+// mutateArgoRollout applies the desired Triton configuration to the Rollout. The pod template
+// is rendered like the Deployment one, so every workload type runs identical pods
+func (r *KalypsoTritonServerReconciler) mutateArgoRollout(rollout *unstructured.Unstructured, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) error {
+	triton := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+	if err := r.mutateDeployment(triton, server, app); err != nil {
+		return err
+	}
+
+	// Set labels
+	labels := rollout.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	for k, v := range triton.Labels {
+		labels[k] = v
+	}
+	rollout.SetLabels(labels)
+	if len(triton.Annotations) > 0 {
+		annotations := rollout.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		for k, v := range triton.Annotations {
+			annotations[k] = v
+		}
+		rollout.SetAnnotations(annotations)
+	}
+
+	// Set spec
+	selector, err := runtime.DefaultUnstructuredConverter.ToUnstructured(triton.Spec.Selector)
+	if err != nil {
+		return err
+	}
+	template, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&triton.Spec.Template)
+	if err != nil {
+		return err
+	}
+	if err := unstructured.SetNestedMap(rollout.Object, map[string]interface{}{
+		"replicas": int64(*triton.Spec.Replicas),
+		"selector": selector,
+		"template": template,
+		"strategy": map[string]interface{}{
+			"canary": buildArgoCanaryStrategy(server, triton.Spec.Strategy),
+		},
+	}, "spec"); err != nil {
+		return err
+	}
+
+	// Set owner reference
+	return controllerutil.SetControllerReference(server, rollout, r.Scheme)
+}
+
+// buildArgoCanaryStrategy builds the canary strategy of the Rollout from spec.argoRollout, with
+// the surge limits of the rendered Deployment strategy
+func buildArgoCanaryStrategy(server *servingv1alpha1.KalypsoTritonServer, strategy appsv1.DeploymentStrategy) map[string]interface{} {
+	canary := map[string]interface{}{}
+	if rolling := strategy.RollingUpdate; rolling != nil {
+		if rolling.MaxSurge != nil {
+			canary["maxSurge"] = rolling.MaxSurge.String()
+		}
+		if rolling.MaxUnavailable != nil {
+			canary["maxUnavailable"] = rolling.MaxUnavailable.String()
+		}
+	}
+
+	spec := server.Spec.ArgoRollout
+	if spec == nil {
+		return canary
+	}
+
+	if len(spec.Steps) > 0 {
+		steps := make([]interface{}, 0, 2*len(spec.Steps))
+		for _, step := range spec.Steps {
+			steps = append(steps, map[string]interface{}{"setWeight": int64(step.Weight)})
+			if step.Pause != "" {
+				steps = append(steps, map[string]interface{}{
+					"pause": map[string]interface{}{"duration": step.Pause},
+				})
+			}
+		}
+		canary["steps"] = steps
+	}
+
+	if len(spec.AnalysisTemplates) > 0 {
+		templates := make([]interface{}, 0, len(spec.AnalysisTemplates))
+		for _, name := range spec.AnalysisTemplates {
+			templates = append(templates, map[string]interface{}{"templateName": name})
+		}
+		canary["analysis"] = map[string]interface{}{
+			"templates": templates,
+			"args": []interface{}{
+				map[string]interface{}{"name": "service-name", "value": naming.Service(server.Name)},
+			},
+		}
+	}
+	return canary
+}
+
+// argoRolloutPodTemplate decodes the pod template and replica count of an existing Rollout
+func argoRolloutPodTemplate(rollout *unstructured.Unstructured) (*corev1.PodTemplateSpec, int32, error) {
+	raw, _, err := unstructured.NestedMap(rollout.Object, "spec", "template")
+	if err != nil {
+		return nil, 0, err
+	}
+	template := &corev1.PodTemplateSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, template); err != nil {
+		return nil, 0, err
+	}
+	replicas, _, _ := unstructured.NestedInt64(rollout.Object, "status", "replicas")
+	return template, int32(replicas), nil
+}
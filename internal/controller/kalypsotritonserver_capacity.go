@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+const (
+	// CapacityReservationLabelKey identifies the placeholder pods of a KalypsoTritonServer.
+	// The pods must not carry TritonServerLabelKey, or the Service would route traffic to them
+	CapacityReservationLabelKey = "kalypso-serving.io/capacity-reservation"
+	// CapacityReservationPriorityClass is the default priority class of the placeholder pods
+	CapacityReservationPriorityClass = "kalypso-capacity-reservation"
+	// CapacityReservationImage is the container image of the placeholder pods
+	CapacityReservationImage = "registry.k8s.io/pause:3.10"
+)
+
+// reconcileCapacityReservation ensures the placeholder Deployment reserving capacity for extra
+// replicas, and removes it when the reservation is disabled
+func (r *KalypsoTritonServerReconciler) reconcileCapacityReservation(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication, name string) error {
+	placeholder := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: server.Namespace,
+		},
+	}
+
+	reservation := server.Spec.CapacityReservation
+	if reservation == nil || reservation.Replicas == 0 || scaledToZero(server) {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(placeholder), placeholder); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !metav1.IsControlledBy(placeholder, server) {
+			return nil
+		}
+		return client.IgnoreNotFound(r.Delete(ctx, placeholder))
+	}
+
+	if reservation.PriorityClassName == "" {
+		if err := r.ensureCapacityReservationPriorityClass(ctx); err != nil {
+			return err
+		}
+	}
+
+	podSpec, err := r.buildCapacityReservationPodSpec(server, app)
+	if err != nil {
+		return err
+	}
+
+	labels := map[string]string{
+		CapacityReservationLabelKey: server.Name,
+		ApplicationLabelKey:         serverApplicationKey(server).Name,
+		ManagedByLabelKey:           ManagedByLabelValue,
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, placeholder, func() error {
+		// Set labels
+		if placeholder.Labels == nil {
+			placeholder.Labels = make(map[string]string)
+		}
+		for k, v := range labels {
+			placeholder.Labels[k] = v
+		}
+
+		// Set spec
+		replicas := reservation.Replicas
+		placeholder.Spec.Replicas = &replicas
+		placeholder.Spec.Selector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{CapacityReservationLabelKey: server.Name},
+		}
+		placeholder.Spec.Template = corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: labels},
+			Spec:       podSpec,
+		}
+
+		// Set owner reference
+		return controllerutil.SetControllerReference(server, placeholder, r.Scheme)
+	})
+
+	return err
+}
+
+// buildCapacityReservationPodSpec builds a pause pod requesting the same resources and
+// scheduled to the same nodes, by the same scheduler, as a Triton replica. Placeholders
+// reference the same DRA GPU claims, so claim templates reserve devices for each of them
+func (r *KalypsoTritonServerReconciler) buildCapacityReservationPodSpec(server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) (corev1.PodSpec, error) {
+	// Render the Triton pod template so the placeholder tracks its resources and placement
+	triton := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+	if err := r.mutateDeployment(triton, server, app); err != nil {
+		return corev1.PodSpec{}, err
+	}
+	tritonPod := triton.Spec.Template.Spec
+
+	priorityClassName := server.Spec.CapacityReservation.PriorityClassName
+	if priorityClassName == "" {
+		priorityClassName = CapacityReservationPriorityClass
+	}
+
+	gracePeriod := int64(0)
+	return corev1.PodSpec{
+		PriorityClassName:             priorityClassName,
+		TerminationGracePeriodSeconds: &gracePeriod,
+		NodeSelector:                  tritonPod.NodeSelector,
+		Tolerations:                   tritonPod.Tolerations,
+		Affinity:                      tritonPod.Affinity,
+		RuntimeClassName:              tritonPod.RuntimeClassName,
+		SchedulerName:                 tritonPod.SchedulerName,
+		ResourceClaims:                tritonPod.ResourceClaims,
+		Containers: []corev1.Container{
+			{
+				Name:      "reserve",
+				Image:     CapacityReservationImage,
+				Resources: tritonPod.Containers[0].Resources,
+			},
+		},
+	}, nil
+}
+
+// ensureCapacityReservationPriorityClass creates the shared negative priority class of the
+// placeholder pods, so any other pod preempts them. Existing classes are left untouched
+func (r *KalypsoTritonServerReconciler) ensureCapacityReservationPriorityClass(ctx context.Context) error {
+	priorityClass := &schedulingv1.PriorityClass{}
+	err := r.Get(ctx, client.ObjectKey{Name: CapacityReservationPriorityClass}, priorityClass)
+	if !errors.IsNotFound(err) {
+		return err
+	}
+
+	preemptNever := corev1.PreemptNever
+	priorityClass = &schedulingv1.PriorityClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   CapacityReservationPriorityClass,
+			Labels: map[string]string{ManagedByLabelKey: ManagedByLabelValue},
+		},
+		Value:            -10,
+		PreemptionPolicy: &preemptNever,
+		Description:      "Placeholder pods reserving capacity for KalypsoTritonServer scale-ups",
+	}
+	return client.IgnoreAlreadyExists(r.Create(ctx, priorityClass))
+}
@@ -19,22 +19,33 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+	"github.com/kalypsoServing/KalypsoServing/internal/imagearch"
+	"github.com/kalypsoServing/KalypsoServing/internal/imagesig"
+	"github.com/kalypsoServing/KalypsoServing/internal/mlflow"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+	"github.com/kalypsoServing/KalypsoServing/internal/retraining"
+	"github.com/kalypsoServing/KalypsoServing/internal/statusupdater"
+	"github.com/kalypsoServing/KalypsoServing/internal/triton"
 )
 
 const (
@@ -42,12 +53,51 @@ const (
 	TritonServerFinalizerName = "serving.kalypso.io/tritonserver-finalizer"
 	// TritonServerLabelKey is the label key for triton server identification
 	TritonServerLabelKey = "kalypso-serving.io/tritonserver"
+	// GPUResourceName is the extended resource name for whole NVIDIA GPUs
+	GPUResourceName = defaults.GPUResourceName
+	// MIGStrategyLabelKey is the node label set by GPU feature discovery for the MIG strategy
+	MIGStrategyLabelKey = "nvidia.com/mig.strategy"
+	// MIGConfigLabelKey is the node label selecting the MIG partitioning applied by the NVIDIA MIG manager
+	MIGConfigLabelKey = "nvidia.com/mig.config"
+	// SharedGPUResourceName is the extended resource name of shared GPUs when the sharing
+	// configuration renames them
+	SharedGPUResourceName = defaults.SharedGPUResourceName
+	// GPUSharingStrategyLabelKey is the node label set by GPU feature discovery for the sharing strategy
+	GPUSharingStrategyLabelKey = "nvidia.com/gpu.sharing-strategy"
+	// DevicePluginConfigLabelKey is the node label selecting the NVIDIA device plugin configuration
+	DevicePluginConfigLabelKey = "nvidia.com/device-plugin.config"
+	// GPUSharingAnnotation records the GPU sharing strategy on the Triton pods
+	GPUSharingAnnotation = "kalypso-serving.io/gpu-sharing"
+	// SharedMemoryVolumeName is the name of the memory-backed /dev/shm volume
+	SharedMemoryVolumeName = "dshm"
 )
 
 // KalypsoTritonServerReconciler reconciles a KalypsoTritonServer object
 type KalypsoTritonServerReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// MetricsQuerier evaluates retraining hook trigger queries
+	MetricsQuerier retraining.Querier
+	// EventSender emits retraining CloudEvents
+	EventSender retraining.Sender
+	// ImageResolver looks up the architectures the Triton image is published for
+	ImageResolver imagearch.Resolver
+	// StatusUpdater writes status asynchronously; status is written inline when nil
+	StatusUpdater *statusupdater.Updater
+	// ModelIndex lists the models loaded by the Triton servers
+	ModelIndex triton.Client
+	// ModelRegistry resolves mlflow:// storage URIs to the registered model artifacts
+	ModelRegistry mlflow.Client
+	// ArtifactResolver resolves the tags of oci:// storage URIs to manifest digests
+	ArtifactResolver imagearch.DigestResolver
+	// ImageVerifier checks the cosign signatures of the Triton images
+	ImageVerifier imagesig.Verifier
+	// ImageVerification is the operator-wide image signature policy, overridden per project
+	ImageVerification ImageVerificationPolicy
+	// APIReader reads the data of the Secrets the manager cache strips, such as storage
+	// credentials and certificates. The client is used when unset
+	APIReader client.Reader
 }
 
 // +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsotritonservers,verbs=get;list;watch;create;update;patch;delete
@@ -55,8 +105,22 @@ type KalypsoTritonServerReconciler struct {
 // +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsotritonservers/finalizers,verbs=update
 // +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsotritonservers/scale,verbs=get;update;patch
 // +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsoapplications,verbs=get;list;watch
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsoprojects,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=argoproj.io,resources=rollouts,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses;networkpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=scheduling.k8s.io,resources=priorityclasses,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=resource.k8s.io,resources=resourceclaimtemplates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.istio.io,resources=envoyfilters,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -98,13 +162,21 @@ func (r *KalypsoTritonServerReconciler) Reconcile(ctx context.Context, req ctrl.
 		return ctrl.Result{Requeue: true}, nil
 	}
 
-	// Validate applicationRef existence
-	app := &servingv1alpha1.KalypsoApplication{}
-	appKey := types.NamespacedName{
-		Name:      server.Spec.ApplicationRef,
-		Namespace: server.Namespace,
+	// Restore a previous configuration requested through the rollback annotation
+	rolledBack, err := r.reconcileRollback(ctx, server)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if rolledBack {
+		return ctrl.Result{Requeue: true}, nil
 	}
-	if err := r.Get(ctx, appKey, app); err != nil {
+
+	// Fill the fields left unset from the preset
+	server = defaults.WithPreset(server)
+
+	// Validate applicationRef existence
+	app, err := defaults.ServerApplication(ctx, r.Client, server)
+	if err != nil {
 		if errors.IsNotFound(err) {
 			log.Error(err, "Referenced KalypsoApplication not found", "applicationRef", server.Spec.ApplicationRef)
 			r.setFailedStatus(ctx, server, fmt.Sprintf("KalypsoApplication '%s' not found", server.Spec.ApplicationRef))
@@ -112,40 +184,250 @@ func (r *KalypsoTritonServerReconciler) Reconcile(ctx context.Context, req ctrl.
 		}
 		return ctrl.Result{}, err
 	}
+	// The Secrets of the application cannot be mounted from another namespace
+	if message := crossNamespaceSecretsMessage(server, app); message != "" {
+		r.setFailedStatus(ctx, server, message)
+		return ctrl.Result{RequeueAfter: 30000000000}, nil // 30 seconds
+	}
+
+	// Apply the storage overrides of the server to the application defaults, and bind the
+	// ServiceAccount to the workload identity of the storage
+	app = withServerStorage(app, server)
+	server = withWorkloadIdentity(server, app)
+
+	// Merge the server defaults of the application, then inherit the Triton configuration left
+	// unset from the project defaults
+	server = defaults.WithApplicationDefaults(server, app)
+	projectDefaults, err := projectTritonDefaults(ctx, r.Client, app)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	server = withProjectDefaults(server, projectDefaults)
+
+	// Detect derived resource name collisions with other servers in the namespace
+	collisions, err := r.findNameCollisions(ctx, server)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(collisions) > 0 {
+		message := fmt.Sprintf("Derived resource names collide with other KalypsoTritonServers: %s", strings.Join(collisions, "; "))
+		log.Info("Derived resource name collision detected", "collisions", collisions)
+		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               "NameCollision",
+			Status:             metav1.ConditionTrue,
+			Reason:             "DerivedNameCollision",
+			Message:            message,
+			LastTransitionTime: metav1.Now(),
+		})
+		r.setFailedStatus(ctx, server, message)
+		return ctrl.Result{RequeueAfter: 30000000000}, nil // 30 seconds
+	}
+
+	// Hold spec changes for approval under the Manual change policy
+	specHash := renderedSpecHash(server)
+	if planPending(server, specHash) {
+		held, err := r.reconcilePlan(ctx, server, app, specHash)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if held {
+			return ctrl.Result{}, nil
+		}
+	}
+	appliedGeneration := server.Generation
+
+	// Reconcile ServiceAccount (if auto-created) before the pods reference it
+	if err := r.reconcileServiceAccount(ctx, server, naming.ServiceAccount(server.Name)); err != nil {
+		log.Error(err, "Failed to reconcile ServiceAccount")
+		r.setFailedStatus(ctx, server, fmt.Sprintf("Failed to reconcile ServiceAccount: %v", err))
+		return ctrl.Result{}, err
+	}
+
+	// Surge replacements for pods on nodes being evacuated
+	evacuation, err := r.evaluateEvacuation(ctx, server)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Fall back to the degraded profile when the GPUs do not fit in the project quota
+	capacityProfile, err := r.evaluateCapacityProfile(ctx, server)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	deployed := withCapacityProfile(server, capacityProfile)
+
+	// Roll the pods when the certificate of their gRPC endpoint is renewed
+	certificateRevision, err := r.tlsCertificateRevision(ctx, server, app)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	deployed = withTLSCertificateRevision(deployed, certificateRevision)
+
+	// Roll the pods when the credential Secrets of the storage or model registry are rotated
+	credentialsRevision, err := r.credentialsRevision(ctx, server, app)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	deployed = withCredentialsRevision(deployed, credentialsRevision)
+
+	// Serve the artifacts of the model version registered in the stage of a mlflow:// storage URI
+	modelRegistry := r.resolveModelRegistry(ctx, server)
+	if modelRegistry != nil && modelRegistry.status == nil {
+		log.Error(modelRegistry.err, "Failed to resolve the registered model")
+		applyModelRegistryStatus(server, modelRegistry)
+		r.setFailedStatus(ctx, server, fmt.Sprintf("Failed to resolve the registered model: %v", modelRegistry.err))
+		return ctrl.Result{RequeueAfter: 30000000000}, nil // 30 seconds
+	}
+	deployed = withModelRegistryVersion(deployed, modelRegistry)
+
+	// Pull an oci:// storage URI by the manifest digest of its tag
+	modelArtifact := r.resolveModelArtifact(ctx, server)
+	if modelArtifact != nil && modelArtifact.digest == "" {
+		log.Error(modelArtifact.err, "Failed to resolve the model artifact")
+		applyModelArtifactStatus(server, modelArtifact)
+		r.setFailedStatus(ctx, server, fmt.Sprintf("Failed to resolve the model artifact: %v", modelArtifact.err))
+		return ctrl.Result{RequeueAfter: 30000000000}, nil // 30 seconds
+	}
+	deployed = withModelArtifactDigest(deployed, modelArtifact)
+
+	// Only deploy Triton images signed with the cosign key of the verification policy
+	imageSignature := r.verifyImageSignature(ctx, deployed, app)
+	if imageSignature.enforced() {
+		log.Error(imageSignature.err, "Triton image signature verification failed", "image", imageSignature.image)
+		applyImageSignatureStatus(server, imageSignature)
+		r.setFailedStatus(ctx, server, fmt.Sprintf("Image signature verification failed: %v", imageSignature.err))
+		return ctrl.Result{RequeueAfter: 30000000000}, nil // 30 seconds
+	}
+	deployed = withVerifiedImage(deployed, imageSignature)
+
+	// Only deploy model repositories the pods can verify before serving them
+	if modelVerification(app) != nil {
+		if reason := verificationUnsupported(deployed, app); reason != "" {
+			log.Info("Model repository cannot be verified", "reason", reason)
+			applyVerificationStatus(server, &verificationResult{unsupported: reason})
+			r.setFailedStatus(ctx, server, "Model repository verification: "+reason)
+			return ctrl.Result{RequeueAfter: 30000000000}, nil // 30 seconds
+		}
+	}
+
+	// Reconcile the Python tracing helper ConfigMap before the pods mount it
+	if err := r.reconcileTracingHelper(ctx, server, naming.TracingHelper(server.Name)); err != nil {
+		log.Error(err, "Failed to reconcile tracing helper ConfigMap")
+		r.setFailedStatus(ctx, server, fmt.Sprintf("Failed to reconcile tracing helper ConfigMap: %v", err))
+		return ctrl.Result{}, err
+	}
 
-	// Reconcile Deployment
-	deploymentName := fmt.Sprintf("%s-deploy", server.Name)
-	if err := r.reconcileDeployment(ctx, server, app, deploymentName); err != nil {
-		log.Error(err, "Failed to reconcile Deployment")
-		r.setFailedStatus(ctx, server, fmt.Sprintf("Failed to reconcile Deployment: %v", err))
+	// Reconcile the ResourceClaimTemplates of DRA GPU claims before the pods reference them
+	if err := r.reconcileGPUClaimTemplates(ctx, deployed); err != nil {
+		log.Error(err, "Failed to reconcile GPU ResourceClaimTemplates")
+		r.setFailedStatus(ctx, server, fmt.Sprintf("Failed to reconcile GPU ResourceClaimTemplates: %v", err))
+		return ctrl.Result{}, err
+	}
+
+	// Reconcile the Deployment, the Argo Rollout, or the StatefulSet keeping per-replica volumes
+	workload := workloadName(server)
+	if err := r.reconcileWorkload(ctx, deployed, app, evacuation.surge()); err != nil {
+		log.Error(err, "Failed to reconcile "+workloadKind(server))
+		r.setFailedStatus(ctx, server, fmt.Sprintf("Failed to reconcile %s: %v", workloadKind(server), err))
 		return ctrl.Result{}, err
 	}
 
 	// Reconcile Service
-	serviceName := fmt.Sprintf("%s-svc", server.Name)
+	serviceName := naming.Service(server.Name)
 	if err := r.reconcileService(ctx, server, serviceName); err != nil {
 		log.Error(err, "Failed to reconcile Service")
 		r.setFailedStatus(ctx, server, fmt.Sprintf("Failed to reconcile Service: %v", err))
 		return ctrl.Result{}, err
 	}
 
+	// Reconcile the dedicated metrics Service (only when enabled)
+	if err := r.reconcileMetricsService(ctx, server, naming.MetricsService(server.Name)); err != nil {
+		log.Error(err, "Failed to reconcile metrics Service")
+		r.setFailedStatus(ctx, server, fmt.Sprintf("Failed to reconcile metrics Service: %v", err))
+		return ctrl.Result{}, err
+	}
+
+	// Reconcile the Ingress of the HTTP endpoint (only when configured)
+	if err := r.reconcileIngress(ctx, server, naming.Ingress(server.Name)); err != nil {
+		log.Error(err, "Failed to reconcile Ingress")
+		r.setFailedStatus(ctx, server, fmt.Sprintf("Failed to reconcile Ingress: %v", err))
+		return ctrl.Result{}, err
+	}
+
+	// Reconcile the NetworkPolicy of the Triton pods (only when enabled)
+	if err := r.reconcileNetworkPolicy(ctx, server, app, naming.NetworkPolicy(server.Name)); err != nil {
+		log.Error(err, "Failed to reconcile NetworkPolicy")
+		r.setFailedStatus(ctx, server, fmt.Sprintf("Failed to reconcile NetworkPolicy: %v", err))
+		return ctrl.Result{}, err
+	}
+
+	// Reconcile PodDisruptionBudget (only for multi-replica or evacuating servers)
+	if err := r.reconcilePodDisruptionBudget(ctx, server, naming.PodDisruptionBudget(server.Name), evacuation != nil); err != nil {
+		log.Error(err, "Failed to reconcile PodDisruptionBudget")
+		r.setFailedStatus(ctx, server, fmt.Sprintf("Failed to reconcile PodDisruptionBudget: %v", err))
+		return ctrl.Result{}, err
+	}
+
+	// Reconcile placeholder pods reserving capacity for scale-ups
+	if err := r.reconcileCapacityReservation(ctx, deployed, app, naming.CapacityReservation(server.Name)); err != nil {
+		log.Error(err, "Failed to reconcile capacity reservation")
+		r.setFailedStatus(ctx, server, fmt.Sprintf("Failed to reconcile capacity reservation: %v", err))
+		return ctrl.Result{}, err
+	}
+
+	// Reconcile the 410 Gone response of archived servers (requires Istio)
+	if err := r.reconcileArchivedRoute(ctx, server, naming.ArchivedRoute(server.Name)); err != nil {
+		// VirtualService failure is not fatal - just log warning
+		log.Info("Failed to reconcile archived VirtualService (Istio may not be installed)", "error", err)
+	}
+
 	// Reconcile ServiceMonitor (if observability metrics are enabled)
 	if server.Spec.Observability != nil &&
 		server.Spec.Observability.Enabled &&
 		server.Spec.Observability.Metrics != nil &&
 		server.Spec.Observability.Metrics.EnableServiceMonitor {
-		serviceMonitorName := fmt.Sprintf("%s-monitor", server.Name)
+		serviceMonitorName := naming.ServiceMonitor(server.Name)
 		if err := r.reconcileServiceMonitor(ctx, server, serviceMonitorName); err != nil {
 			// ServiceMonitor creation failure is not fatal - just log warning
 			log.Info("Failed to reconcile ServiceMonitor (Prometheus Operator may not be installed)", "error", err)
 		}
 	}
 
-	// Get Deployment status
-	deployment := &appsv1.Deployment{}
-	if err := r.Get(ctx, types.NamespacedName{Name: deploymentName, Namespace: server.Namespace}, deployment); err != nil {
+	// Reconcile provenance response headers (requires the Istio sidecar)
+	if err := r.reconcileProvenanceFilter(ctx, server, naming.ProvenanceFilter(server.Name)); err != nil {
+		// EnvoyFilter failure is not fatal - just log warning
+		log.Info("Failed to reconcile provenance EnvoyFilter (Istio may not be installed)", "error", err)
+	}
+
+	// Check the image and backend support the targeted node architecture
+	architectureCondition := r.checkArchitecture(ctx, server)
+
+	// Evaluate retraining triggers (if the retraining hook is enabled)
+	retrainingResult := r.evaluateRetrainingHook(ctx, server, app)
+
+	// Get Deployment or StatefulSet status
+	availableReplicas, err := r.workloadAvailableReplicas(ctx, server)
+	if err != nil {
 		return ctrl.Result{}, err
 	}
+	template, _, err := r.currentPodTemplate(ctx, server)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// List the models ready on the server for model-name routing
+	modelIndex := r.indexModels(ctx, server, availableReplicas)
+
+	// Reload the models of new replicas with the overridden model configurations
+	modelConfig := r.applyModelConfig(ctx, server)
+
+	// Report the model repository verification of the pods
+	verification := r.evaluateVerification(ctx, server, app, availableReplicas)
+
+	// Revisions record the Triton configuration inherited from the defaults, and the endpoint
+	// uses the ports inherited from the application
+	revisionConfig := resolvedTritonConfig(server)
+	serviceEndpoint := tritonServiceEndpoint(server, app)
 
 	// Re-fetch the server to get the latest version before updating status
 	if err := r.Get(ctx, req.NamespacedName, server); err != nil {
@@ -153,23 +435,57 @@ func (r *KalypsoTritonServerReconciler) Reconcile(ctx context.Context, req ctrl.
 	}
 
 	// Update status
-	httpPort := int32(8000)
-	if server.Spec.Networking != nil && server.Spec.Networking.HTTPPort != nil {
-		httpPort = *server.Spec.Networking.HTTPPort
+	meta.RemoveStatusCondition(&server.Status.Conditions, "NameCollision")
+	meta.RemoveStatusCondition(&server.Status.Conditions, "PlanPending")
+	server.Status.AppliedGeneration = appliedGeneration
+	server.Status.AppliedSpecHash = specHash
+	server.Status.PendingPlan = nil
+	// Revisions describe the spec generation rendered above; a newer one is recorded next time
+	if template != nil && server.Generation == appliedGeneration {
+		recordRevision(server, buildRevision(server, revisionConfig, template))
 	}
+	server.Status.DeploymentName = workload
+	server.Status.AvailableReplicas = availableReplicas
+	server.Status.ServiceEndpoint = serviceEndpoint
 
-	server.Status.DeploymentName = deploymentName
-	server.Status.AvailableReplicas = deployment.Status.AvailableReplicas
-	server.Status.ServiceEndpoint = fmt.Sprintf("http://%s.%s.svc:%d", serviceName, server.Namespace, httpPort)
-
-	if deployment.Status.AvailableReplicas > 0 {
+	if isArchived(server) {
+		server.Status.Phase = servingv1alpha1.TritonServerPhaseArchived
+		server.Status.Message = "Triton Server is archived; requests are answered with 410 Gone."
+		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			Reason:             "Archived",
+			Message:            workloadKind(server) + " is scaled to zero by spec.state Archived",
+			LastTransitionTime: metav1.Now(),
+		})
+	} else if server.Spec.Suspend {
+		server.Status.Phase = servingv1alpha1.TritonServerPhaseSuspended
+		server.Status.Message = "Triton Server is suspended."
+		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			Reason:             "Suspended",
+			Message:            workloadKind(server) + " is scaled to zero by spec.suspend",
+			LastTransitionTime: metav1.Now(),
+		})
+	} else if availableReplicas > 0 && modelIndex.modelsPending() {
+		server.Status.Phase = servingv1alpha1.TritonServerPhasePending
+		server.Status.Message = "Waiting for models to become ready."
+		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               "Available",
+			Status:             metav1.ConditionFalse,
+			Reason:             "ModelsNotReady",
+			Message:            "Models not ready: " + strings.Join(modelIndex.unready, ", "),
+			LastTransitionTime: metav1.Now(),
+		})
+	} else if availableReplicas > 0 {
 		server.Status.Phase = servingv1alpha1.TritonServerPhaseRunning
 		server.Status.Message = "Triton Server is ready to serve inference."
 		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
 			Type:               "Available",
 			Status:             metav1.ConditionTrue,
 			Reason:             "DeploymentReady",
-			Message:            fmt.Sprintf("Deployment has %d available replicas", deployment.Status.AvailableReplicas),
+			Message:            fmt.Sprintf("%s has %d available replicas", workloadKind(server), availableReplicas),
 			LastTransitionTime: metav1.Now(),
 		})
 	} else {
@@ -179,12 +495,25 @@ func (r *KalypsoTritonServerReconciler) Reconcile(ctx context.Context, req ctrl.
 			Type:               "Available",
 			Status:             metav1.ConditionFalse,
 			Reason:             "DeploymentNotReady",
-			Message:            "Deployment has no available replicas",
+			Message:            workloadKind(server) + " has no available replicas",
 			LastTransitionTime: metav1.Now(),
 		})
 	}
 
-	if err := r.Status().Update(ctx, server); err != nil {
+	applyArchitectureStatus(server, architectureCondition)
+	applyEvacuationStatus(server, evacuation)
+	applyCapacityProfileStatus(server, capacityProfile)
+	applyRetrainingStatus(server, retrainingResult)
+	applyModelIndexStatus(server, modelIndex)
+	applyModelConfigStatus(server, modelConfig)
+	applyModelRegistryStatus(server, modelRegistry)
+	applyModelArtifactStatus(server, modelArtifact)
+	applyImageSignatureStatus(server, imageSignature)
+	applyVerificationStatus(server, verification)
+
+	// Retraining evaluation and event times are written inline so the evaluation interval and
+	// the cooldown hold for the next reconcile
+	if err := r.updateStatus(ctx, server, retrainingResult.triggersEvaluated()); err != nil {
 		if errors.IsConflict(err) {
 			// Conflict error - requeue to retry
 			return ctrl.Result{Requeue: true}, nil
@@ -194,9 +523,41 @@ func (r *KalypsoTritonServerReconciler) Reconcile(ctx context.Context, req ctrl.
 
 	log.Info("Successfully reconciled KalypsoTritonServer",
 		"server", server.Name,
-		"deployment", deploymentName,
-		"availableReplicas", deployment.Status.AvailableReplicas)
+		"workload", workload,
+		"availableReplicas", availableReplicas)
 
+	if evacuation.surge() > 0 {
+		// Follow evictions from the evacuated nodes
+		return ctrl.Result{RequeueAfter: 15000000000}, nil // 15 seconds
+	}
+	if modelIndex.modelsPending() {
+		// Follow the models until they are loaded
+		return ctrl.Result{RequeueAfter: modelIndexRetryInterval}, nil
+	}
+	if modelConfig != nil && (modelConfig.pending > 0 || len(modelConfig.failures) > 0) {
+		// Reload the models once the pods are ready, or retry failed reloads
+		return ctrl.Result{RequeueAfter: 30000000000}, nil // 30 seconds
+	}
+	if capacityProfile != nil && capacityProfile.degraded {
+		// Quota changes are not watched, so re-check whether the GPUs fit again
+		return ctrl.Result{RequeueAfter: 60000000000}, nil // 60 seconds
+	}
+	if retrainingResult != nil {
+		// Re-evaluate retraining triggers periodically
+		return ctrl.Result{RequeueAfter: retrainingResult.requeueAfter}, nil
+	}
+	if (modelRegistry != nil && modelRegistry.err != nil) || (modelArtifact != nil && modelArtifact.err != nil) {
+		// Retry resolving the registered model or the artifact tag
+		return ctrl.Result{RequeueAfter: 30000000000}, nil // 30 seconds
+	}
+	if modelRegistry != nil {
+		// Follow stage transitions of the registered model
+		return ctrl.Result{RequeueAfter: modelRegistry.interval}, nil
+	}
+	if modelIndex != nil && availableReplicas > 0 {
+		// Follow models loaded or unloaded after startup
+		return ctrl.Result{RequeueAfter: modelIndexInterval}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -219,27 +580,72 @@ func (r *KalypsoTritonServerReconciler) reconcileDelete(ctx context.Context, ser
 	return ctrl.Result{}, nil
 }
 
+// findNameCollisions returns the derived resource names this server shares with servers
+// created before it in the same namespace; the oldest server keeps ownership of the names
+func (r *KalypsoTritonServerReconciler) findNameCollisions(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer) ([]string, error) {
+	servers := &servingv1alpha1.KalypsoTritonServerList{}
+	if err := r.List(ctx, servers, client.InNamespace(server.Namespace)); err != nil {
+		return nil, err
+	}
+
+	serverNames := []string{server.Name}
+	for _, item := range servers.Items {
+		if item.Name == server.Name {
+			continue
+		}
+		createdBefore := item.CreationTimestamp.Before(&server.CreationTimestamp) ||
+			(item.CreationTimestamp.Equal(&server.CreationTimestamp) && item.Name < server.Name)
+		if createdBefore {
+			serverNames = append(serverNames, item.Name)
+		}
+	}
+
+	allCollisions := naming.FindCollisions(serverNames)
+	var collisions []string
+	for _, childName := range naming.TritonServerChildNames(server.Name) {
+		if owners, ok := allCollisions[childName]; ok {
+			collisions = append(collisions, fmt.Sprintf("%s (%s)", childName, strings.Join(owners, ", ")))
+		}
+	}
+	return collisions, nil
+}
+
 // reconcileDeployment ensures the Deployment exists with proper configuration
-func (r *KalypsoTritonServerReconciler) reconcileDeployment(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication, deploymentName string) error {
+func (r *KalypsoTritonServerReconciler) reconcileDeployment(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication, deploymentName string, surge int32) error {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deploymentName,
+			Namespace: server.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		if err := r.mutateDeployment(deployment, server, app); err != nil {
+			return err
+		}
+		if !scaledToZero(server) {
+			*deployment.Spec.Replicas += surge
+		}
+		return nil
+	})
+
+	return err
+}
+
+// mutateDeployment applies the desired Triton configuration to the Deployment
+func (r *KalypsoTritonServerReconciler) mutateDeployment(deployment *appsv1.Deployment, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) error {
 	replicas := int32(1)
 	if server.Spec.Replicas != nil {
 		replicas = *server.Spec.Replicas
 	}
-
-	image := "nvcr.io/nvidia/tritonserver"
-	if server.Spec.TritonConfig.Image != "" {
-		image = server.Spec.TritonConfig.Image
-	}
-
-	tag := "24.12-py3"
-	if server.Spec.TritonConfig.Tag != "" {
-		tag = server.Spec.TritonConfig.Tag
+	if scaledToZero(server) {
+		replicas = 0
 	}
 
 	// Build container args
 	args := []string{
 		"tritonserver",
-		fmt.Sprintf("--model-repository=%s", server.Spec.StorageURI),
+		fmt.Sprintf("--model-repository=%s", modelRepositoryPath(server, app)),
 	}
 
 	for _, param := range server.Spec.TritonConfig.Parameters {
@@ -249,10 +655,26 @@ func (r *KalypsoTritonServerReconciler) reconcileDeployment(ctx context.Context,
 	// Add observability args
 	args = r.buildObservabilityArgs(server, args)
 
+	// Add graceful shutdown args
+	args = buildShutdownArgs(server, args)
+
+	// Add gRPC keepalive args
+	args = buildKeepaliveArgs(server, args)
+
+	// Add model control args
+	args = buildModelControlArgs(server, args)
+
+	// Poll a git repository kept in sync
+	args = buildGitSyncArgs(server, args)
+
+	// Add gRPC TLS args
+	tlsSecret := tlsSecretName(server, app)
+	args = buildTLSArgs(server, tlsSecret, args)
+
 	// Build ports
-	httpPort := int32(8000)
-	grpcPort := int32(8001)
-	metricsPort := int32(8002)
+	httpPort := defaults.HTTPPort
+	grpcPort := defaults.GRPCPort
+	metricsPort := defaults.MetricsPort
 
 	if server.Spec.Networking != nil {
 		if server.Spec.Networking.HTTPPort != nil {
@@ -268,7 +690,7 @@ func (r *KalypsoTritonServerReconciler) reconcileDeployment(ctx context.Context,
 
 	labels := map[string]string{
 		TritonServerLabelKey: server.Name,
-		ApplicationLabelKey:  server.Spec.ApplicationRef,
+		ApplicationLabelKey:  serverApplicationKey(server).Name,
 		ManagedByLabelKey:    ManagedByLabelValue,
 	}
 
@@ -277,8 +699,8 @@ func (r *KalypsoTritonServerReconciler) reconcileDeployment(ctx context.Context,
 	var envFrom []corev1.EnvFromSource
 
 	if app.Spec.Storage != nil {
-		// Add secret reference for S3 credentials
-		if app.Spec.Storage.SecretName != "" {
+		// Add secret reference for S3 credentials, unless the pods use a workload identity
+		if app.Spec.Storage.SecretName != "" && storageWorkloadIdentity(app.Spec.Storage) == nil {
 			envFrom = append(envFrom, corev1.EnvFromSource{
 				SecretRef: &corev1.SecretEnvSource{
 					LocalObjectReference: corev1.LocalObjectReference{
@@ -301,99 +723,352 @@ func (r *KalypsoTritonServerReconciler) reconcileDeployment(ctx context.Context,
 			})
 		}
 
-		// Add region if specified
-		if app.Spec.Storage.Region != "" {
+		// Add region if specified, or implied by the SSE-KMS key
+		if region := s3Region(app.Spec.Storage); region != "" {
 			envVars = append(envVars, corev1.EnvVar{
 				Name:  "AWS_DEFAULT_REGION",
-				Value: app.Spec.Storage.Region,
+				Value: region,
 			})
 		}
+
+		// Add the SSE-KMS key, CA bundle and proxy of the S3 client
+		envVars = append(envVars, buildS3OptionsEnv(app.Spec.Storage)...)
+	}
+
+	// Point Triton at the service account key of gs:// repositories
+	envVars = append(envVars, buildGCSCredentialsEnv(server, app)...)
+
+	// Add the storage account credentials of as:// repositories
+	azureEnv, azureEnvFrom := buildAzureStorageEnv(server, app)
+	envVars = append(envVars, azureEnv...)
+	envFrom = append(envFrom, azureEnvFrom...)
+
+	// Configure the OpenTelemetry SDK of Python backend models
+	envVars = append(envVars, buildPythonTracingEnv(server)...)
+
+	// Add user-defined environment, overriding injected variables of the same name
+	envVars = mergeEnvVars(envVars, server.Spec.Env)
+	for i := range server.Spec.EnvFrom {
+		envFrom = append(envFrom, *server.Spec.EnvFrom[i].DeepCopy())
 	}
 
 	// Build profiling annotations
-	podAnnotations := r.buildProfilingAnnotations(server)
+	podAnnotations := defaults.MergeStringMaps(server.Spec.PodAnnotations, r.buildProfilingAnnotations(server))
+	podAnnotations = defaults.MergeStringMaps(podAnnotations, buildGPUSharingAnnotations(server.Spec.GPU))
+	podAnnotations = applyMetricsPortExclusion(podAnnotations, server)
 
-	deployment := &appsv1.Deployment{
+	// Set user-defined labels and annotations; the labels below take precedence
+	applyCustomMetadata(deployment, server)
+
+	// Set labels
+	if deployment.Labels == nil {
+		deployment.Labels = make(map[string]string)
+	}
+	for k, v := range labels {
+		deployment.Labels[k] = v
+	}
+
+	// Set spec
+	deployment.Spec.Replicas = &replicas
+	deployment.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: labels,
+	}
+	deployment.Spec.Template = corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      deploymentName,
-			Namespace: server.Namespace,
+			Labels:      defaults.MergeStringMaps(buildPodLabels(labels, server.Spec.PodLabels, server.Spec.Metadata), azureWorkloadIdentityLabels(server, app)),
+			Annotations: podAnnotations,
+		},
+		Spec: corev1.PodSpec{
+			NodeSelector: buildNodeSelector(server),
+			Containers: []corev1.Container{
+				{
+					Name:    "tritonserver",
+					Image:   tritonImage(server),
+					Args:    args,
+					Env:     envVars,
+					EnvFrom: envFrom,
+					Ports: []corev1.ContainerPort{
+						{Name: "http", ContainerPort: httpPort, Protocol: corev1.ProtocolTCP},
+						{Name: "grpc", ContainerPort: grpcPort, Protocol: corev1.ProtocolTCP},
+						{Name: "metrics", ContainerPort: metricsPort, Protocol: corev1.ProtocolTCP},
+					},
+					ReadinessProbe: buildReadinessProbe(server, httpPort),
+					LivenessProbe:  buildLivenessProbe(server, httpPort),
+					StartupProbe:   buildStartupProbe(server, httpPort),
+				},
+			},
 		},
 	}
 
-	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
-		// Set labels
-		if deployment.Labels == nil {
-			deployment.Labels = make(map[string]string)
-		}
-		for k, v := range labels {
-			deployment.Labels[k] = v
+	// Set the update strategy
+	applyDeploymentStrategy(deployment, server.Spec.DeploymentStrategy)
+
+	// Set resources if specified
+	if server.Spec.Resources != nil {
+		deployment.Spec.Template.Spec.Containers[0].Resources = *server.Spec.Resources.DeepCopy()
+	}
+
+	// Set scheduling constraints if specified
+	if server.Spec.Scheduling != nil {
+		deployment.Spec.Template.Spec.Tolerations = server.Spec.Scheduling.DeepCopy().Tolerations
+		deployment.Spec.Template.Spec.Affinity = server.Spec.Scheduling.Affinity.DeepCopy()
+		deployment.Spec.Template.Spec.TopologySpreadConstraints = buildTopologySpreadConstraints(server.Spec.Scheduling.TopologySpreadConstraints, labels)
+		deployment.Spec.Template.Spec.SchedulerName = server.Spec.Scheduling.SchedulerName
+		deployment.Spec.Template.Spec.SchedulingGates = server.Spec.Scheduling.DeepCopy().SchedulingGates
+	}
+
+	// Set custom volumes and mounts if specified
+	for i := range server.Spec.Volumes {
+		deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, *server.Spec.Volumes[i].DeepCopy())
+	}
+	for i := range server.Spec.VolumeMounts {
+		deployment.Spec.Template.Spec.Containers[0].VolumeMounts = append(deployment.Spec.Template.Spec.Containers[0].VolumeMounts, *server.Spec.VolumeMounts[i].DeepCopy())
+	}
+
+	// Mount the Python tracing helper module if trace propagation is enabled
+	applyPythonTracing(&deployment.Spec.Template.Spec, server, naming.TracingHelper(server.Name))
+
+	// Mount the gRPC certificate if TLS is enabled
+	applyTritonTLS(&deployment.Spec.Template.Spec, tlsSecret)
+
+	// Mount the service account key of gs:// repositories
+	applyGCSCredentials(&deployment.Spec.Template.Spec, server, app)
+
+	// Mount the CA bundle of the S3 endpoint
+	applyS3CABundle(&deployment.Spec.Template.Spec, app.Spec.Storage, &deployment.Spec.Template.Spec.Containers[0])
+
+	// Copy an s3:// repository locally, download a Hugging Face Hub repository or an archive,
+	// pull an OCI artifact, or clone a git repository before the user init containers
+	applyLocalDownload(&deployment.Spec.Template.Spec, server, app)
+	applyHuggingFaceDownload(&deployment.Spec.Template.Spec, server)
+	applyOCIArtifactPull(&deployment.Spec.Template.Spec, server)
+	applyGitSync(&deployment.Spec.Template.Spec, server)
+	applyArchiveDownload(&deployment.Spec.Template.Spec, server)
+
+	// Verify the downloaded repository before Triton serves it
+	applyModelVerification(&deployment.Spec.Template.Spec, server, app)
+
+	// Set init containers if specified
+	for i := range server.Spec.InitContainers {
+		deployment.Spec.Template.Spec.InitContainers = append(deployment.Spec.Template.Spec.InitContainers, *server.Spec.InitContainers[i].DeepCopy())
+	}
+
+	// Append sidecar containers after the Triton container
+	for i := range server.Spec.Sidecars {
+		deployment.Spec.Template.Spec.Containers = append(deployment.Spec.Template.Spec.Containers, *server.Spec.Sidecars[i].DeepCopy())
+	}
+
+	// Mount a memory-backed /dev/shm if specified (the container runtime default is 64Mi)
+	if server.Spec.SharedMemory != nil {
+		applySharedMemory(&deployment.Spec.Template.Spec, server.Spec.SharedMemory)
+	}
+
+	// Drain in-flight requests before the pod terminates
+	applyGracefulShutdown(&deployment.Spec.Template.Spec, server)
+
+	// Set security contexts, defaulting to the restricted Pod Security Standard
+	deployment.Spec.Template.Spec.SecurityContext = buildPodSecurityContext(server.Spec.PodSecurityContext)
+	deployment.Spec.Template.Spec.Containers[0].SecurityContext = buildContainerSecurityContext(server.Spec.ContainerSecurityContext)
+
+	// Set the pod identity if specified
+	deployment.Spec.Template.Spec.ServiceAccountName = serviceAccountName(server)
+
+	// Set image pull secrets, falling back to the application default
+	if len(server.Spec.ImagePullSecrets) > 0 {
+		deployment.Spec.Template.Spec.ImagePullSecrets = append([]corev1.LocalObjectReference{}, server.Spec.ImagePullSecrets...)
+	} else if len(app.Spec.ImagePullSecrets) > 0 {
+		deployment.Spec.Template.Spec.ImagePullSecrets = append([]corev1.LocalObjectReference{}, app.Spec.ImagePullSecrets...)
+	}
+
+	// Set runtime class if specified (e.g. the NVIDIA container runtime)
+	if server.Spec.RuntimeClassName != nil {
+		runtimeClassName := *server.Spec.RuntimeClassName
+		deployment.Spec.Template.Spec.RuntimeClassName = &runtimeClassName
+	}
+
+	// Add GPU limits (whole GPUs or MIG slices)
+	defaults.ApplyGPUResources(&deployment.Spec.Template.Spec.Containers[0].Resources, server.Spec.GPU)
+	applyGPUResourceClaims(&deployment.Spec.Template.Spec, server)
+
+	// Set owner reference
+	return controllerutil.SetControllerReference(server, deployment, r.Scheme)
+}
+
+// applyDeploymentStrategy sets the update strategy of the Triton Deployment. Without a
+// strategy, a Recreate strategy set earlier is reverted to the RollingUpdate default
+func applyDeploymentStrategy(deployment *appsv1.Deployment, strategy *servingv1alpha1.DeploymentStrategySpec) {
+	if strategy == nil {
+		if deployment.Spec.Strategy.Type == appsv1.RecreateDeploymentStrategyType {
+			deployment.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType}
 		}
+		return
+	}
 
-		// Set spec
-		deployment.Spec.Replicas = &replicas
-		deployment.Spec.Selector = &metav1.LabelSelector{
-			MatchLabels: labels,
-		}
-		deployment.Spec.Template = corev1.PodTemplateSpec{
-			ObjectMeta: metav1.ObjectMeta{
-				Labels:      labels,
-				Annotations: podAnnotations,
-			},
-			Spec: corev1.PodSpec{
-				Containers: []corev1.Container{
-					{
-						Name:    "tritonserver",
-						Image:   fmt.Sprintf("%s:%s", image, tag),
-						Args:    args,
-						Env:     envVars,
-						EnvFrom: envFrom,
-						Ports: []corev1.ContainerPort{
-							{Name: "http", ContainerPort: httpPort, Protocol: corev1.ProtocolTCP},
-							{Name: "grpc", ContainerPort: grpcPort, Protocol: corev1.ProtocolTCP},
-							{Name: "metrics", ContainerPort: metricsPort, Protocol: corev1.ProtocolTCP},
-						},
-						ReadinessProbe: &corev1.Probe{
-							ProbeHandler: corev1.ProbeHandler{
-								HTTPGet: &corev1.HTTPGetAction{
-									Path: "/v2/health/ready",
-									Port: intstr.FromInt(int(httpPort)),
-								},
-							},
-							InitialDelaySeconds: 10,
-							PeriodSeconds:       5,
-						},
-						LivenessProbe: &corev1.Probe{
-							ProbeHandler: corev1.ProbeHandler{
-								HTTPGet: &corev1.HTTPGetAction{
-									Path: "/v2/health/live",
-									Port: intstr.FromInt(int(httpPort)),
-								},
-							},
-							InitialDelaySeconds: 15,
-							PeriodSeconds:       10,
-						},
-					},
-				},
+	if strategy.Type == string(appsv1.RecreateDeploymentStrategyType) {
+		deployment.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+		return
+	}
+
+	// Set the API server defaults explicitly, so unset fields do not differ from the live object
+	maxSurge := intstr.FromString("25%")
+	if strategy.MaxSurge != nil {
+		maxSurge = *strategy.MaxSurge
+	}
+	maxUnavailable := intstr.FromString("25%")
+	if strategy.MaxUnavailable != nil {
+		maxUnavailable = *strategy.MaxUnavailable
+	}
+	deployment.Spec.Strategy = appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDeployment{
+			MaxSurge:       &maxSurge,
+			MaxUnavailable: &maxUnavailable,
+		},
+	}
+}
+
+// applySharedMemory mounts an emptyDir with medium Memory at /dev/shm in the Triton container
+func applySharedMemory(podSpec *corev1.PodSpec, sharedMemory *servingv1alpha1.SharedMemorySpec) {
+	sizeLimit := sharedMemory.Size.DeepCopy()
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: SharedMemoryVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{
+				Medium:    corev1.StorageMediumMemory,
+				SizeLimit: &sizeLimit,
 			},
+		},
+	})
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      SharedMemoryVolumeName,
+		MountPath: "/dev/shm",
+	})
+}
+
+// buildGPUNodeSelector builds the node selector matching the MIG or sharing strategy labels set
+// by GPU feature discovery
+func buildGPUNodeSelector(gpu *servingv1alpha1.GPUSpec) map[string]string {
+	if gpu == nil {
+		return nil
+	}
+	if gpu.Sharing != nil {
+		nodeSelector := map[string]string{GPUSharingStrategyLabelKey: gpu.Sharing.Strategy}
+		if gpu.Sharing.Config != "" {
+			nodeSelector[DevicePluginConfigLabelKey] = gpu.Sharing.Config
 		}
+		return nodeSelector
+	}
+	if gpu.MIG == nil {
+		return nil
+	}
 
-		// Set resources if specified
-		if server.Spec.Resources != nil {
-			deployment.Spec.Template.Spec.Containers[0].Resources = *server.Spec.Resources
+	strategy := "mixed"
+	if gpu.MIG.Strategy != "" {
+		strategy = gpu.MIG.Strategy
+	}
+	nodeSelector := map[string]string{
+		MIGStrategyLabelKey: strategy,
+	}
+	// With the single strategy every slice is advertised as nvidia.com/gpu, so only the node
+	// partitioning tells the profiles apart
+	if strategy == "single" {
+		nodeSelector[MIGConfigLabelKey] = "all-" + gpu.MIG.Profile
+	}
+	return nodeSelector
+}
+
+// buildGPUSharingAnnotations records the GPU sharing strategy, so shared GPU pods can be told apart
+func buildGPUSharingAnnotations(gpu *servingv1alpha1.GPUSpec) map[string]string {
+	if gpu == nil || gpu.Sharing == nil {
+		return nil
+	}
+	return map[string]string{GPUSharingAnnotation: gpu.Sharing.Strategy}
+}
+
+// buildNodeSelector merges the user node selector with the GPU node selector
+func buildNodeSelector(server *servingv1alpha1.KalypsoTritonServer) map[string]string {
+	nodeSelector := buildGPUNodeSelector(server.Spec.GPU)
+	if server.Spec.Scheduling == nil || len(server.Spec.Scheduling.NodeSelector) == 0 {
+		return nodeSelector
+	}
+
+	if nodeSelector == nil {
+		nodeSelector = make(map[string]string)
+	}
+	// User-provided labels take precedence
+	for k, v := range server.Spec.Scheduling.NodeSelector {
+		nodeSelector[k] = v
+	}
+	// GPU feature discovery reports the product of single-strategy MIG nodes as <product>-MIG-<profile>
+	if gpu := server.Spec.GPU; gpu != nil && gpu.MIG != nil && gpu.MIG.Strategy == "single" {
+		if product, ok := nodeSelector[defaults.GPUProductLabelKey]; ok && !strings.Contains(product, "-MIG-") {
+			nodeSelector[defaults.GPUProductLabelKey] = product + "-MIG-" + gpu.MIG.Profile
 		}
+	}
+	return nodeSelector
+}
 
-		// Set owner reference
-		return controllerutil.SetControllerReference(server, deployment, r.Scheme)
-	})
+// mergeEnvVars appends the override variables to base, replacing variables with the same name
+func mergeEnvVars(base, overrides []corev1.EnvVar) []corev1.EnvVar {
+	for _, override := range overrides {
+		override = *override.DeepCopy()
+		replaced := false
+		for i := range base {
+			if base[i].Name == override.Name {
+				base[i] = override
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			base = append(base, override)
+		}
+	}
+	return base
+}
 
-	return err
+// buildTopologySpreadConstraints copies the spread constraints, selecting the server's pods when no labelSelector is set
+func buildTopologySpreadConstraints(constraints []corev1.TopologySpreadConstraint, labels map[string]string) []corev1.TopologySpreadConstraint {
+	if len(constraints) == 0 {
+		return nil
+	}
+
+	result := make([]corev1.TopologySpreadConstraint, 0, len(constraints))
+	for _, constraint := range constraints {
+		c := *constraint.DeepCopy()
+		if c.LabelSelector == nil {
+			matchLabels := make(map[string]string, len(labels))
+			for k, v := range labels {
+				matchLabels[k] = v
+			}
+			c.LabelSelector = &metav1.LabelSelector{MatchLabels: matchLabels}
+		}
+		result = append(result, c)
+	}
+	return result
 }
 
 // reconcileService ensures the Service exists with proper configuration
 func (r *KalypsoTritonServerReconciler) reconcileService(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, serviceName string) error {
-	httpPort := int32(8000)
-	grpcPort := int32(8001)
-	metricsPort := int32(8002)
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName,
+			Namespace: server.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+		return r.mutateService(service, server)
+	})
+
+	return err
+}
+
+// mutateService applies the desired port configuration to the Service
+func (r *KalypsoTritonServerReconciler) mutateService(service *corev1.Service, server *servingv1alpha1.KalypsoTritonServer) error {
+	httpPort := defaults.HTTPPort
+	grpcPort := defaults.GRPCPort
+	metricsPort := defaults.MetricsPort
 
 	if server.Spec.Networking != nil {
 		if server.Spec.Networking.HTTPPort != nil {
@@ -409,59 +1084,165 @@ func (r *KalypsoTritonServerReconciler) reconcileService(ctx context.Context, se
 
 	labels := map[string]string{
 		TritonServerLabelKey: server.Name,
-		ApplicationLabelKey:  server.Spec.ApplicationRef,
+		ApplicationLabelKey:  serverApplicationKey(server).Name,
 		ManagedByLabelKey:    ManagedByLabelValue,
 	}
 
-	service := &corev1.Service{
+	// Set user-defined labels and annotations; the labels below take precedence
+	applyCustomMetadata(service, server)
+
+	// Set labels
+	if service.Labels == nil {
+		service.Labels = make(map[string]string)
+	}
+	for k, v := range labels {
+		service.Labels[k] = v
+	}
+
+	// Set the user-defined Service annotations, type and load balancer class
+	serviceType := corev1.ServiceTypeClusterIP
+	var loadBalancerClass *string
+	if server.Spec.Networking != nil {
+		if len(server.Spec.Networking.ServiceAnnotations) > 0 {
+			service.Annotations = defaults.MergeStringMaps(service.Annotations, server.Spec.Networking.ServiceAnnotations)
+		}
+		if server.Spec.Networking.ServiceType != "" {
+			serviceType = server.Spec.Networking.ServiceType
+		}
+		if serviceType == corev1.ServiceTypeLoadBalancer {
+			loadBalancerClass = server.Spec.Networking.LoadBalancerClass
+		}
+	}
+
+	// Keep the allocated node ports, so updates do not move clients to new ones
+	nodePorts := make(map[string]int32)
+	for _, port := range service.Spec.Ports {
+		nodePorts[port.Name] = port.NodePort
+	}
+
+	// Set spec (preserve ClusterIP if already set)
+	service.Spec.Selector = map[string]string{
+		TritonServerLabelKey: server.Name,
+	}
+	service.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       "http",
+			Port:       httpPort,
+			TargetPort: intstr.FromString("http"),
+			Protocol:   corev1.ProtocolTCP,
+		},
+		{
+			Name:       "grpc",
+			Port:       grpcPort,
+			TargetPort: intstr.FromString("grpc"),
+			Protocol:   corev1.ProtocolTCP,
+		},
+	}
+	// The metrics port moves to the dedicated metrics Service when it is enabled
+	if !metricsServiceEnabled(server) {
+		service.Spec.Ports = append(service.Spec.Ports, corev1.ServicePort{
+			Name:       "metrics",
+			Port:       metricsPort,
+			TargetPort: intstr.FromString("metrics"),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
+	if serviceType != corev1.ServiceTypeClusterIP {
+		for i := range service.Spec.Ports {
+			service.Spec.Ports[i].NodePort = nodePorts[service.Spec.Ports[i].Name]
+		}
+	}
+	service.Spec.Type = serviceType
+	service.Spec.LoadBalancerClass = loadBalancerClass
+	applySessionAffinity(service, server)
+
+	// Set owner reference
+	return controllerutil.SetControllerReference(server, service, r.Scheme)
+}
+
+// reconcilePodDisruptionBudget ensures a PodDisruptionBudget exists while the server runs
+// more than one replica, and removes it when the server is scaled down to a single replica
+func (r *KalypsoTritonServerReconciler) reconcilePodDisruptionBudget(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, pdbName string, evacuating bool) error {
+	pdb := &policyv1.PodDisruptionBudget{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      serviceName,
+			Name:      pdbName,
 			Namespace: server.Namespace,
 		},
 	}
 
-	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
-		// Set labels
-		if service.Labels == nil {
-			service.Labels = make(map[string]string)
+	if !evacuating && (server.Spec.Replicas == nil || *server.Spec.Replicas <= 1) {
+		// A budget on a single replica would block node drains entirely
+		if err := r.Get(ctx, client.ObjectKeyFromObject(pdb), pdb); err != nil {
+			return client.IgnoreNotFound(err)
 		}
-		for k, v := range labels {
-			service.Labels[k] = v
+		if !metav1.IsControlledBy(pdb, server) {
+			return nil
 		}
+		return client.IgnoreNotFound(r.Delete(ctx, pdb))
+	}
 
-		// Set spec (preserve ClusterIP if already set)
-		service.Spec.Selector = map[string]string{
-			TritonServerLabelKey: server.Name,
+	labels := map[string]string{
+		TritonServerLabelKey: server.Name,
+		ApplicationLabelKey:  serverApplicationKey(server).Name,
+		ManagedByLabelKey:    ManagedByLabelValue,
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, pdb, func() error {
+		// Set user-defined labels and annotations; the labels below take precedence
+		applyCustomMetadata(pdb, server)
+
+		// Set labels
+		if pdb.Labels == nil {
+			pdb.Labels = make(map[string]string)
 		}
-		service.Spec.Ports = []corev1.ServicePort{
-			{
-				Name:       "http",
-				Port:       httpPort,
-				TargetPort: intstr.FromString("http"),
-				Protocol:   corev1.ProtocolTCP,
-			},
-			{
-				Name:       "grpc",
-				Port:       grpcPort,
-				TargetPort: intstr.FromString("grpc"),
-				Protocol:   corev1.ProtocolTCP,
-			},
-			{
-				Name:       "metrics",
-				Port:       metricsPort,
-				TargetPort: intstr.FromString("metrics"),
-				Protocol:   corev1.ProtocolTCP,
-			},
+		for k, v := range labels {
+			pdb.Labels[k] = v
 		}
-		service.Spec.Type = corev1.ServiceTypeClusterIP
+
+		// Set spec
+		pdb.Spec = buildPodDisruptionBudgetSpec(server, evacuating)
 
 		// Set owner reference
-		return controllerutil.SetControllerReference(server, service, r.Scheme)
+		return controllerutil.SetControllerReference(server, pdb, r.Scheme)
 	})
 
 	return err
 }
 
+// buildPodDisruptionBudgetSpec builds the budget from spec.availability, defaulting to maxUnavailable: 1.
+// While evacuating, the budget keeps every desired replica available so pods on the drained
+// nodes are only evicted once their surged replacements are ready
+func buildPodDisruptionBudgetSpec(server *servingv1alpha1.KalypsoTritonServer, evacuating bool) policyv1.PodDisruptionBudgetSpec {
+	spec := policyv1.PodDisruptionBudgetSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				TritonServerLabelKey: server.Name,
+			},
+		},
+	}
+
+	availability := server.Spec.Availability
+	switch {
+	case evacuating:
+		replicas := int32(1)
+		if server.Spec.Replicas != nil {
+			replicas = *server.Spec.Replicas
+		}
+		minAvailable := intstr.FromInt32(replicas)
+		spec.MinAvailable = &minAvailable
+	case availability != nil && availability.MinAvailable != nil:
+		minAvailable := *availability.MinAvailable
+		spec.MinAvailable = &minAvailable
+	case availability != nil && availability.MaxUnavailable != nil:
+		maxUnavailable := *availability.MaxUnavailable
+		spec.MaxUnavailable = &maxUnavailable
+	default:
+		maxUnavailable := intstr.FromInt32(1)
+		spec.MaxUnavailable = &maxUnavailable
+	}
+	return spec
+}
+
 // setFailedStatus updates the server status to Failed
 func (r *KalypsoTritonServerReconciler) setFailedStatus(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, message string) {
 	server.Status.Phase = servingv1alpha1.TritonServerPhaseFailed
@@ -473,7 +1254,25 @@ func (r *KalypsoTritonServerReconciler) setFailedStatus(ctx context.Context, ser
 		Message:            message,
 		LastTransitionTime: metav1.Now(),
 	})
-	_ = r.Status().Update(ctx, server)
+	_ = r.updateStatus(ctx, server, false)
+}
+
+// updateStatus writes the server status, coalescing through the StatusUpdater when configured
+func (r *KalypsoTritonServerReconciler) updateStatus(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, inline bool) error {
+	if r.StatusUpdater == nil {
+		return r.Status().Update(ctx, server)
+	}
+	if inline {
+		// Drop the pending update so it cannot overwrite this newer status
+		r.StatusUpdater.Discard(server)
+		return r.Status().Update(ctx, server)
+	}
+
+	desired := server.Status.DeepCopy()
+	r.StatusUpdater.Enqueue(server, func(obj client.Object) {
+		obj.(*servingv1alpha1.KalypsoTritonServer).Status = *desired
+	})
+	return nil
 }
 
 // buildObservabilityArgs builds Triton server arguments for observability features
@@ -505,12 +1304,9 @@ func (r *KalypsoTritonServerReconciler) buildObservabilityArgs(server *servingv1
 	// Tracing configuration (#29)
 	// Configures Triton to push traces to OTLP collector
 	if obs.Tracing != nil && obs.Tracing.Enabled && obs.CollectorEndpoint != "" {
-		samplingRate := "0.1"
-		if obs.Tracing.SamplingRate != "" {
-			samplingRate = obs.Tracing.SamplingRate
-		}
-		traceConfig := fmt.Sprintf("mode=opentelemetry,url=%s,rate=%s", obs.CollectorEndpoint, samplingRate)
+		traceConfig := fmt.Sprintf("mode=opentelemetry,url=%s,rate=%s", obs.CollectorEndpoint, tracingSamplingRate(obs.Tracing))
 		args = append(args, fmt.Sprintf("--trace-config=%s", traceConfig))
+		args = append(args, buildLineageTraceArgs(server.Spec.Metadata)...)
 	}
 
 	return args
@@ -579,12 +1375,15 @@ func (r *KalypsoTritonServerReconciler) reconcileServiceMonitor(ctx context.Cont
 	}
 
 	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, serviceMonitor, func() error {
+		// Set user-defined labels and annotations; the labels below take precedence
+		applyCustomMetadata(serviceMonitor, server)
+
 		// Set labels for Prometheus Operator discovery
 		if serviceMonitor.Labels == nil {
 			serviceMonitor.Labels = make(map[string]string)
 		}
 		serviceMonitor.Labels[TritonServerLabelKey] = server.Name
-		serviceMonitor.Labels[ApplicationLabelKey] = server.Spec.ApplicationRef
+		serviceMonitor.Labels[ApplicationLabelKey] = serverApplicationKey(server).Name
 		serviceMonitor.Labels[ManagedByLabelKey] = ManagedByLabelValue
 		// Common label for Prometheus Operator selector
 		serviceMonitor.Labels["release"] = "prometheus"
@@ -596,6 +1395,7 @@ func (r *KalypsoTritonServerReconciler) reconcileServiceMonitor(ctx context.Cont
 					TritonServerLabelKey: server.Name,
 				},
 			},
+			PodTargetLabels: lineageLabelKeys(server.Spec.Metadata),
 			Endpoints: []monitoringv1.Endpoint{
 				{
 					Port:     metricsPort,
@@ -616,7 +1416,16 @@ func (r *KalypsoTritonServerReconciler) SetupWithManager(mgr ctrl.Manager) error
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&servingv1alpha1.KalypsoTritonServer{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.Service{}).
+		Owns(&corev1.ServiceAccount{}).
+		Owns(&networkingv1.Ingress{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.serversForTLSSecret)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.serversForCredentialSecret)).
+		Watches(&servingv1alpha1.KalypsoApplication{}, handler.EnqueueRequestsFromMapFunc(r.serversForApplication)).
+		Watches(&servingv1alpha1.KalypsoProject{}, handler.EnqueueRequestsFromMapFunc(r.serversForProject)).
 		Named("kalypsotritonserver").
 		Complete(r)
 }
@@ -18,16 +18,41 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	resourcev1 "k8s.io/api/resource/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+	"github.com/kalypsoServing/KalypsoServing/internal/features"
+	"github.com/kalypsoServing/KalypsoServing/internal/imagesig"
+	"github.com/kalypsoServing/KalypsoServing/internal/mlflow"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+	"github.com/kalypsoServing/KalypsoServing/internal/retraining"
+	"github.com/kalypsoServing/KalypsoServing/internal/triton"
 )
 
 var _ = Describe("KalypsoTritonServer Controller", func() {
@@ -81,4 +106,2906 @@ var _ = Describe("KalypsoTritonServer Controller", func() {
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
 	})
+
+	Context("When building GPU resources", func() {
+		It("should request MIG slices with the mixed strategy by default", func() {
+			resources := corev1.ResourceRequirements{}
+			gpu := &servingv1alpha1.GPUSpec{
+				MIG: &servingv1alpha1.MIGSpec{Profile: "1g.10gb"},
+			}
+
+			defaults.ApplyGPUResources(&resources, gpu)
+
+			Expect(resources.Limits).To(HaveKey(corev1.ResourceName("nvidia.com/mig-1g.10gb")))
+			Expect(resources.Limits.Name("nvidia.com/mig-1g.10gb", resource.DecimalSI).Value()).To(Equal(int64(1)))
+			Expect(buildGPUNodeSelector(gpu)).To(Equal(map[string]string{MIGStrategyLabelKey: "mixed"}))
+		})
+
+		It("should request whole GPUs with the single strategy", func() {
+			resources := corev1.ResourceRequirements{}
+			count := int32(2)
+			gpu := &servingv1alpha1.GPUSpec{
+				MIG: &servingv1alpha1.MIGSpec{Profile: "1g.10gb", Count: &count, Strategy: "single"},
+			}
+
+			defaults.ApplyGPUResources(&resources, gpu)
+
+			Expect(resources.Limits.Name(GPUResourceName, resource.DecimalSI).Value()).To(Equal(int64(2)))
+			Expect(buildGPUNodeSelector(gpu)).To(Equal(map[string]string{
+				MIGStrategyLabelKey: "single",
+				MIGConfigLabelKey:   "all-1g.10gb",
+			}))
+		})
+
+		It("should select the MIG product of the requested profile with the single strategy", func() {
+			server := &servingv1alpha1.KalypsoTritonServer{
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					GPU: &servingv1alpha1.GPUSpec{
+						MIG: &servingv1alpha1.MIGSpec{Profile: "1g.10gb", Strategy: "single"},
+					},
+					Scheduling: &servingv1alpha1.SchedulingSpec{
+						NodeSelector: map[string]string{defaults.GPUProductLabelKey: "NVIDIA-A100-SXM4-80GB"},
+					},
+				},
+			}
+
+			Expect(buildNodeSelector(server)).To(Equal(map[string]string{
+				MIGStrategyLabelKey:         "single",
+				MIGConfigLabelKey:           "all-1g.10gb",
+				defaults.GPUProductLabelKey: "NVIDIA-A100-SXM4-80GB-MIG-1g.10gb",
+			}))
+
+			server.Spec.GPU.MIG.Strategy = "mixed"
+			Expect(buildNodeSelector(server)).To(HaveKeyWithValue(defaults.GPUProductLabelKey, "NVIDIA-A100-SXM4-80GB"))
+			Expect(buildNodeSelector(server)).NotTo(HaveKey(MIGConfigLabelKey))
+		})
+
+		It("should request shared GPUs on nodes with the sharing strategy", func() {
+			resources := corev1.ResourceRequirements{}
+			gpu := &servingv1alpha1.GPUSpec{
+				Count:   ptrTo(int32(1)),
+				Sharing: &servingv1alpha1.GPUSharingSpec{Strategy: "mps", RenameByDefault: true, Config: "a100-mps"},
+			}
+
+			defaults.ApplyGPUResources(&resources, gpu)
+
+			Expect(resources.Limits).NotTo(HaveKey(corev1.ResourceName(GPUResourceName)))
+			Expect(resources.Limits.Name(SharedGPUResourceName, resource.DecimalSI).Value()).To(Equal(int64(1)))
+			Expect(buildGPUNodeSelector(gpu)).To(Equal(map[string]string{
+				GPUSharingStrategyLabelKey: "mps",
+				DevicePluginConfigLabelKey: "a100-mps",
+			}))
+			Expect(buildGPUSharingAnnotations(gpu)).To(HaveKeyWithValue(GPUSharingAnnotation, "mps"))
+			Expect(gpuLimits(nil, gpu)).To(HaveKey(corev1.ResourceName(SharedGPUResourceName)), "shared GPUs count against the quota")
+		})
+
+		It("should reference DRA claims instead of GPU limits", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system", UID: "server-uid"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					GPU: &servingv1alpha1.GPUSpec{
+						ResourceClaims: []servingv1alpha1.GPUResourceClaim{
+							{Name: "gpus", DeviceClassName: ptrTo("gpu.nvidia.com"), Count: ptrTo(int32(2))},
+							{Name: "shared", ResourceClaimName: ptrTo("shared-a100")},
+						},
+					},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build(),
+				Scheme: scheme,
+			}
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, &servingv1alpha1.KalypsoApplication{})).To(Succeed())
+			podSpec := deployment.Spec.Template.Spec
+			Expect(podSpec.Containers[0].Resources.Limits).NotTo(HaveKey(corev1.ResourceName(GPUResourceName)))
+			Expect(podSpec.Containers[0].Resources.Claims).To(Equal([]corev1.ResourceClaim{{Name: "gpus"}, {Name: "shared"}}))
+			Expect(podSpec.ResourceClaims).To(Equal([]corev1.PodResourceClaim{
+				{Name: "gpus", ResourceClaimTemplateName: ptrTo("recommendation-v1-gpus-gpu")},
+				{Name: "shared", ResourceClaimName: ptrTo("shared-a100")},
+			}))
+
+			Expect(reconciler.reconcileGPUClaimTemplates(ctx, server)).To(Succeed())
+			template := &resourcev1.ResourceClaimTemplate{}
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: server.Namespace, Name: "recommendation-v1-gpus-gpu"}, template)).To(Succeed())
+			Expect(template.Spec.Spec.Devices.Requests).To(HaveLen(1))
+			Expect(template.Spec.Spec.Devices.Requests[0].Exactly.DeviceClassName).To(Equal("gpu.nvidia.com"))
+			Expect(template.Spec.Spec.Devices.Requests[0].Exactly.Count).To(Equal(int64(2)))
+
+			server.Spec.GPU = nil
+			Expect(reconciler.reconcileGPUClaimTemplates(ctx, server)).To(Succeed())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(template), template))).To(BeTrue())
+		})
+	})
+
+	Context("When running as a StatefulSet", func() {
+		It("should keep per-replica volumes and replace the Deployment", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "llm-70b", Namespace: "kalypso-system", UID: "server-uid"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					ApplicationRef: "llm-application",
+					StorageURI:     "s3://models/llm-70b",
+					Replicas:       ptrTo(int32(2)),
+					VolumeMounts:   []corev1.VolumeMount{{Name: "model-cache", MountPath: "/cache"}},
+					VolumeClaimTemplates: []servingv1alpha1.VolumeClaimTemplate{{
+						Name: "model-cache",
+						Spec: corev1.PersistentVolumeClaimSpec{
+							AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+							Resources: corev1.VolumeResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("500Gi")},
+							},
+						},
+					}},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build(),
+				Scheme: scheme,
+			}
+			app := &servingv1alpha1.KalypsoApplication{}
+
+			Expect(reconciler.reconcileWorkload(ctx, server, app, 0)).To(Succeed())
+			deployment := &appsv1.Deployment{}
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: server.Namespace, Name: naming.Deployment(server.Name)}, deployment)).To(Succeed())
+
+			server.Spec.WorkloadType = servingv1alpha1.WorkloadTypeStatefulSet
+			Expect(reconciler.reconcileWorkload(ctx, server, app, 0)).To(Succeed())
+
+			statefulSet := &appsv1.StatefulSet{}
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: server.Namespace, Name: "llm-70b-sts"}, statefulSet)).To(Succeed())
+			Expect(*statefulSet.Spec.Replicas).To(Equal(int32(2)))
+			Expect(statefulSet.Spec.ServiceName).To(Equal("llm-70b-headless"))
+			Expect(statefulSet.Spec.VolumeClaimTemplates).To(HaveLen(1))
+			Expect(statefulSet.Spec.VolumeClaimTemplates[0].Name).To(Equal("model-cache"))
+			Expect(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "model-cache", MountPath: "/cache"}))
+
+			headless := &corev1.Service{}
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: server.Namespace, Name: statefulSet.Spec.ServiceName}, headless)).To(Succeed())
+			Expect(headless.Spec.ClusterIP).To(Equal(corev1.ClusterIPNone))
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(deployment), deployment))).To(BeTrue())
+
+			statefulSet.Status.AvailableReplicas = 1
+			Expect(reconciler.Status().Update(ctx, statefulSet)).To(Succeed())
+			Expect(reconciler.workloadAvailableReplicas(ctx, server)).To(Equal(int32(1)))
+		})
+	})
+
+	Context("When running as an Argo Rollout", func() {
+		It("should render the canary strategy and replace the Deployment", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "ranking-v3", Namespace: "kalypso-system", UID: "server-uid"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					ApplicationRef: "ranking-application",
+					StorageURI:     "s3://models/ranking",
+					Replicas:       ptrTo(int32(4)),
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build(),
+				Scheme: scheme,
+			}
+			app := &servingv1alpha1.KalypsoApplication{}
+
+			Expect(reconciler.reconcileWorkload(ctx, server, app, 0)).To(Succeed())
+			deployment := &appsv1.Deployment{}
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: server.Namespace, Name: naming.Deployment(server.Name)}, deployment)).To(Succeed())
+
+			server.Spec.WorkloadType = servingv1alpha1.WorkloadTypeRollout
+			server.Spec.ArgoRollout = &servingv1alpha1.ArgoRolloutSpec{
+				Steps:             []servingv1alpha1.RolloutStep{{Weight: 25, Pause: "10m"}, {Weight: 50}},
+				AnalysisTemplates: []string{"triton-error-rate"},
+			}
+			Expect(reconciler.reconcileWorkload(ctx, server, app, 1)).To(Succeed())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(deployment), deployment))).To(BeTrue())
+
+			rollout := newArgoRollout("ranking-v3-rollout", server.Namespace)
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(rollout), rollout)).To(Succeed())
+			replicas, _, _ := unstructured.NestedInt64(rollout.Object, "spec", "replicas")
+			Expect(replicas).To(Equal(int64(5)), "evacuation surge is added")
+			steps, _, _ := unstructured.NestedSlice(rollout.Object, "spec", "strategy", "canary", "steps")
+			Expect(steps).To(Equal([]interface{}{
+				map[string]interface{}{"setWeight": int64(25)},
+				map[string]interface{}{"pause": map[string]interface{}{"duration": "10m"}},
+				map[string]interface{}{"setWeight": int64(50)},
+			}))
+			args, _, _ := unstructured.NestedSlice(rollout.Object, "spec", "strategy", "canary", "analysis", "args")
+			Expect(args).To(ConsistOf(map[string]interface{}{"name": "service-name", "value": "ranking-v3-svc"}))
+
+			template, _, err := reconciler.currentPodTemplate(ctx, server)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(template.Labels).To(HaveKeyWithValue(TritonServerLabelKey, server.Name))
+			Expect(template.Spec.Containers[0].Name).To(Equal(deployment.Spec.Template.Spec.Containers[0].Name))
+
+			Expect(unstructured.SetNestedField(rollout.Object, int64(3), "status", "availableReplicas")).To(Succeed())
+			Expect(reconciler.Update(ctx, rollout)).To(Succeed())
+			Expect(reconciler.workloadAvailableReplicas(ctx, server)).To(Equal(int32(3)))
+		})
+	})
+
+	Context("When selecting a preset", func() {
+		It("should fill unset fields and keep explicit ones", func() {
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "llm-7b", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					Preset:       servingv1alpha1.PresetLLM7BA10G,
+					Scheduling:   &servingv1alpha1.SchedulingSpec{NodeSelector: map[string]string{"pool": "inference"}},
+					SharedMemory: &servingv1alpha1.SharedMemorySpec{Size: resource.MustParse("2Gi")},
+					TritonConfig: servingv1alpha1.TritonConfigSpec{
+						Parameters: []servingv1alpha1.TritonParameter{{Name: "model-load-thread-count", Value: "1"}},
+					},
+				},
+			}
+
+			expanded := defaults.WithPreset(server)
+
+			Expect(server.Spec.GPU).To(BeNil(), "the stored spec is not modified")
+			Expect(*expanded.Spec.GPU.Count).To(Equal(int32(1)))
+			Expect(expanded.Spec.Scheduling.NodeSelector).To(Equal(map[string]string{
+				defaults.GPUProductLabelKey: "NVIDIA-A10G",
+				"pool":                      "inference",
+			}))
+			Expect(expanded.Spec.Resources.Limits.Memory().String()).To(Equal("32Gi"))
+			Expect(expanded.Spec.SharedMemory.Size.String()).To(Equal("2Gi"))
+			Expect(expanded.Spec.TritonConfig.Parameters).To(Equal([]servingv1alpha1.TritonParameter{
+				{Name: "pinned-memory-pool-byte-size", Value: "268435456"},
+				{Name: "model-load-thread-count", Value: "1"},
+			}))
+			Expect(*expanded.Spec.Probes.Startup.FailureThreshold).To(Equal(int32(60)))
+			Expect(expanded.Spec.DeploymentStrategy.MaxSurge.IntValue()).To(Equal(1))
+			Expect(expanded.Spec.DeploymentStrategy.MaxUnavailable.IntValue()).To(Equal(0))
+
+			server.Spec.Preset = ""
+			Expect(defaults.WithPreset(server)).To(BeIdenticalTo(server))
+		})
+	})
+
+	Context("When merging the application server defaults", func() {
+		It("should fill the fields left unset and roll the servers of the application", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			httpPort := int32(9000)
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ProjectRef: "sample-project",
+					ServerDefaults: &servingv1alpha1.ServerDefaultsSpec{
+						Resources: &corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("8Gi")},
+						},
+						Networking: &servingv1alpha1.NetworkingSpec{
+							HTTPPort:           &httpPort,
+							ServiceAnnotations: map[string]string{"team": "recommendation"},
+						},
+						Observability: &servingv1alpha1.ObservabilitySpec{Enabled: true},
+						Scheduling:    &servingv1alpha1.SchedulingSpec{NodeSelector: map[string]string{"pool": "inference"}},
+					},
+				},
+			}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: app.Namespace},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					ApplicationRef: app.Name,
+					StorageURI:     "s3://models/recommendation",
+					Resources: &corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Gi")},
+					},
+					Networking: &servingv1alpha1.NetworkingSpec{ServiceType: corev1.ServiceTypeNodePort},
+					Scheduling: &servingv1alpha1.SchedulingSpec{NodeSelector: map[string]string{"zone": "a"}},
+				},
+			}
+			unrelated := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "search-v1", Namespace: app.Namespace},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: "search-application", StorageURI: "s3://models/search"},
+			}
+
+			merged := defaults.WithApplicationDefaults(server, app)
+
+			Expect(server.Spec.Observability).To(BeNil(), "the stored spec is not modified")
+			Expect(merged.Spec.Resources.Requests.Cpu().String()).To(Equal("2"))
+			Expect(merged.Spec.Resources.Requests.Memory().String()).To(Equal("16Gi"))
+			Expect(*merged.Spec.Networking.HTTPPort).To(Equal(int32(9000)))
+			Expect(merged.Spec.Networking.ServiceType).To(Equal(corev1.ServiceTypeNodePort))
+			Expect(merged.Spec.Networking.ServiceAnnotations).To(HaveKeyWithValue("team", "recommendation"))
+			Expect(merged.Spec.Observability.Enabled).To(BeTrue())
+			Expect(merged.Spec.Scheduling.NodeSelector).To(Equal(map[string]string{"pool": "inference", "zone": "a"}))
+			usage := defaults.QuotaUsage(server, app)
+			Expect(usage.Name("requests.cpu", resource.DecimalSI).String()).To(Equal("2"), "the quota counts the defaulted requests")
+
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(app, server, unrelated).Build(),
+				Scheme: scheme,
+			}
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: server.Namespace, Name: server.Name}}
+			Expect(reconciler.serversForApplication(ctx, app)).To(ConsistOf(request))
+
+			app.Spec.ServerDefaults = nil
+			Expect(defaults.WithApplicationDefaults(server, app)).To(BeIdenticalTo(server))
+		})
+	})
+
+	Context("When inheriting the project defaults", func() {
+		It("should fill unset Triton fields and roll the servers of the project", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			project := &servingv1alpha1.KalypsoProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoProjectSpec{
+					Defaults: &servingv1alpha1.ProjectDefaultsSpec{TritonConfig: &servingv1alpha1.ProjectTritonDefaults{
+						Image: "registry.example.com/tritonserver",
+						Tag:   "25.01-py3",
+						Parameters: []servingv1alpha1.TritonParameter{
+							{Name: "model-load-thread-count", Value: "2"},
+							{Name: "exit-timeout-secs", Value: "60"},
+						},
+						Observability: &servingv1alpha1.ObservabilitySpec{Enabled: true},
+					}},
+				},
+			}
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: project.Namespace},
+				Spec:       servingv1alpha1.KalypsoApplicationSpec{ProjectRef: project.Name},
+			}
+			other := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "search-application", Namespace: project.Namespace},
+				Spec:       servingv1alpha1.KalypsoApplicationSpec{ProjectRef: "other-project"},
+			}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: project.Namespace},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					ApplicationRef: app.Name,
+					StorageURI:     "s3://models/recommendation",
+					TritonConfig: servingv1alpha1.TritonConfigSpec{
+						Tag:        "24.12-py3",
+						Parameters: []servingv1alpha1.TritonParameter{{Name: "model-load-thread-count", Value: "4"}},
+					},
+				},
+			}
+			unrelated := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "search-v1", Namespace: project.Namespace},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: other.Name, StorageURI: "s3://models/search"},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(project, app, other, server, unrelated).Build(),
+				Scheme: scheme,
+			}
+
+			projectDefaults, err := projectTritonDefaults(ctx, reconciler.Client, app)
+			Expect(err).NotTo(HaveOccurred())
+			inherited := withProjectDefaults(server, projectDefaults)
+
+			Expect(server.Spec.TritonConfig.Image).To(BeEmpty(), "the stored spec is not modified")
+			Expect(tritonImage(inherited)).To(Equal("registry.example.com/tritonserver:24.12-py3"))
+			Expect(inherited.Spec.TritonConfig.Parameters).To(Equal([]servingv1alpha1.TritonParameter{
+				{Name: "exit-timeout-secs", Value: "60"},
+				{Name: "model-load-thread-count", Value: "4"},
+			}))
+			Expect(inherited.Spec.Observability.Enabled).To(BeTrue())
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: server.Namespace, Name: server.Name}}
+			Expect(reconciler.serversForProject(ctx, project)).To(ConsistOf(request))
+
+			By("keeping the built-in defaults without project defaults")
+			projectDefaults, err = projectTritonDefaults(ctx, reconciler.Client, other)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(withProjectDefaults(unrelated, projectDefaults)).To(BeIdenticalTo(unrelated))
+			Expect(tritonImage(unrelated)).To(Equal(DefaultTritonImage + ":24.12-py3"))
+		})
+	})
+
+	Context("When rolling back a revision", func() {
+		It("should record rendered revisions and restore a previous one", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system", Generation: 1},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					ApplicationRef: "recommendation-application",
+					StorageURI:     "s3://models/recommendation/v1",
+					TritonConfig:   servingv1alpha1.TritonConfigSpec{Image: "nvcr.io/nvidia/tritonserver", Tag: "24.12-py3"},
+				},
+			}
+			template := func(image string, args ...string) *corev1.PodTemplateSpec {
+				return &corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Image: image, Args: args}}}}
+			}
+
+			recordRevision(server, buildRevision(server, resolvedTritonConfig(server), template("nvcr.io/nvidia/tritonserver:24.12-py3", "--model-repository=s3://models/recommendation/v1")))
+			recordRevision(server, buildRevision(server, resolvedTritonConfig(server), template("nvcr.io/nvidia/tritonserver:24.12-py3", "--model-repository=s3://models/recommendation/v1")))
+			Expect(server.Status.Revisions).To(HaveLen(1), "unchanged containers do not add revisions")
+
+			server.Generation = 2
+			server.Spec.StorageURI = "s3://models/recommendation/v2"
+			server.Spec.TritonConfig.Tag = "25.01-py3"
+			recordRevision(server, buildRevision(server, resolvedTritonConfig(server), template("nvcr.io/nvidia/tritonserver:25.01-py3", "--model-repository=s3://models/recommendation/v2")))
+			Expect(server.Status.Revisions).To(HaveLen(2))
+			Expect(server.Status.Revisions[1].Revision).To(Equal(int64(2)))
+			Expect(server.Status.Revisions[1].ArgsHash).NotTo(Equal(server.Status.Revisions[0].ArgsHash))
+
+			server.Annotations = map[string]string{RollbackAnnotation: "1"}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).WithStatusSubresource(server).Build(),
+				Scheme: scheme,
+			}
+
+			Expect(reconciler.reconcileRollback(ctx, server)).To(BeTrue())
+			updated := &servingv1alpha1.KalypsoTritonServer{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(server), updated)).To(Succeed())
+			Expect(updated.Annotations).NotTo(HaveKey(RollbackAnnotation))
+			Expect(updated.Spec.StorageURI).To(Equal("s3://models/recommendation/v1"))
+			Expect(updated.Spec.TritonConfig.Tag).To(Equal("24.12-py3"))
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, "RolledBack")).To(BeTrue())
+
+			updated.Annotations = map[string]string{RollbackAnnotation: "7"}
+			Expect(reconciler.reconcileRollback(ctx, updated)).To(BeTrue())
+			Expect(updated.Spec.StorageURI).To(Equal("s3://models/recommendation/v1"))
+			Expect(meta.FindStatusCondition(updated.Status.Conditions, "RolledBack").Reason).To(Equal("RevisionNotFound"))
+		})
+
+		It("should restore the inherited image of a revision", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system", Generation: 1},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					ApplicationRef: "recommendation-application",
+					StorageURI:     "s3://models/recommendation/v1",
+				},
+			}
+			template := func(image string) *corev1.PodTemplateSpec {
+				return &corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Image: image}}}}
+			}
+
+			inherited := withProjectDefaults(server, &servingv1alpha1.ProjectTritonDefaults{Image: "registry.example.com/tritonserver", Tag: "24.10-py3"})
+			recordRevision(server, buildRevision(server, resolvedTritonConfig(inherited), template(tritonImage(inherited))))
+			Expect(server.Status.Revisions[0].TritonConfig).To(Equal(servingv1alpha1.TritonConfigSpec{
+				Image: "registry.example.com/tritonserver", Tag: "24.10-py3",
+			}))
+
+			By("restoring the rendered image of revisions recorded without it")
+			server.Status.Revisions = append(server.Status.Revisions, servingv1alpha1.ServerRevision{
+				Revision:   2,
+				Image:      "registry.example.com/tritonserver@sha256:" + strings.Repeat("ab", 32),
+				StorageURI: "s3://models/recommendation/v1",
+			})
+			server.Annotations = map[string]string{RollbackAnnotation: "2"}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).WithStatusSubresource(server).Build(),
+				Scheme: scheme,
+			}
+
+			Expect(reconciler.reconcileRollback(ctx, server)).To(BeTrue())
+			Expect(server.Spec.TritonConfig.Image).To(Equal("registry.example.com/tritonserver"))
+			Expect(server.Spec.TritonConfig.Tag).To(Equal("sha256:" + strings.Repeat("ab", 32)))
+
+			server.Annotations = map[string]string{RollbackAnnotation: "1"}
+			Expect(reconciler.reconcileRollback(ctx, server)).To(BeTrue())
+			Expect(tritonImage(server)).To(Equal("registry.example.com/tritonserver:24.10-py3"))
+		})
+	})
+
+	Context("When archiving a server", func() {
+		It("should scale to zero and answer requests with 410 Gone", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system", UID: "server-uid"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					ApplicationRef: "recommendation-application",
+					StorageURI:     "s3://models/recommendation/v1",
+					Replicas:       ptrTo(int32(3)),
+					State:          servingv1alpha1.ServerStateArchived,
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build(),
+				Scheme: scheme,
+			}
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, &servingv1alpha1.KalypsoApplication{})).To(Succeed())
+			Expect(*deployment.Spec.Replicas).To(BeZero())
+
+			Expect(reconciler.reconcileArchivedRoute(ctx, server, "recommendation-v1-archived")).To(Succeed())
+			route := &unstructured.Unstructured{}
+			route.SetGroupVersionKind(virtualServiceGVK)
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: server.Namespace, Name: "recommendation-v1-archived"}, route)).To(Succeed())
+			hosts, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hosts")
+			Expect(hosts).To(ConsistOf("recommendation-v1-svc.kalypso-system.svc.cluster.local"))
+			routes, _, _ := unstructured.NestedSlice(route.Object, "spec", "http")
+			status, _, _ := unstructured.NestedInt64(routes[0].(map[string]interface{}), "directResponse", "status")
+			Expect(status).To(Equal(int64(410)))
+
+			server.Spec.State = servingv1alpha1.ServerStateActive
+			Expect(reconciler.reconcileArchivedRoute(ctx, server, "recommendation-v1-archived")).To(Succeed())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(route), route))).To(BeTrue())
+		})
+	})
+
+	Context("When configuring the deployment strategy", func() {
+		It("should recreate pods or tune the rolling update", func() {
+			deployment := &appsv1.Deployment{}
+
+			applyDeploymentStrategy(deployment, &servingv1alpha1.DeploymentStrategySpec{Type: "Recreate"})
+			Expect(deployment.Spec.Strategy).To(Equal(appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}))
+
+			maxSurge := intstr.FromInt32(0)
+			applyDeploymentStrategy(deployment, &servingv1alpha1.DeploymentStrategySpec{Type: "RollingUpdate", MaxSurge: &maxSurge})
+			Expect(deployment.Spec.Strategy.RollingUpdate.MaxSurge).To(Equal(ptrTo(intstr.FromInt32(0))))
+			Expect(deployment.Spec.Strategy.RollingUpdate.MaxUnavailable).To(Equal(ptrTo(intstr.FromString("25%"))))
+
+			applyDeploymentStrategy(deployment, &servingv1alpha1.DeploymentStrategySpec{Type: "Recreate"})
+			applyDeploymentStrategy(deployment, nil)
+			Expect(deployment.Spec.Strategy.Type).To(Equal(appsv1.RollingUpdateDeploymentStrategyType), "removing the strategy reverts Recreate")
+		})
+	})
+
+	Context("When configuring shared memory", func() {
+		It("should mount a memory-backed emptyDir at /dev/shm", func() {
+			podSpec := corev1.PodSpec{Containers: []corev1.Container{{Name: "tritonserver"}}}
+
+			applySharedMemory(&podSpec, &servingv1alpha1.SharedMemorySpec{Size: resource.MustParse("2Gi")})
+
+			Expect(podSpec.Volumes).To(HaveLen(1))
+			Expect(podSpec.Volumes[0].EmptyDir.Medium).To(Equal(corev1.StorageMediumMemory))
+			Expect(podSpec.Volumes[0].EmptyDir.SizeLimit.String()).To(Equal("2Gi"))
+			Expect(podSpec.Containers[0].VolumeMounts).To(ConsistOf(corev1.VolumeMount{Name: SharedMemoryVolumeName, MountPath: "/dev/shm"}))
+		})
+	})
+
+	Context("When selecting a runtime class", func() {
+		It("should set the runtime class of the pods and clear it once unset", func() {
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					StorageURI:       "s3://models/recommendation",
+					RuntimeClassName: ptrTo("nvidia"),
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Scheme: scheme,
+			}
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, &servingv1alpha1.KalypsoApplication{})).To(Succeed())
+			Expect(deployment.Spec.Template.Spec.RuntimeClassName).To(Equal(ptrTo("nvidia")))
+
+			*server.Spec.RuntimeClassName = "kata"
+			Expect(deployment.Spec.Template.Spec.RuntimeClassName).To(Equal(ptrTo("nvidia")), "the pod spec does not alias the server spec")
+
+			server.Spec.RuntimeClassName = nil
+			Expect(reconciler.mutateDeployment(deployment, server, &servingv1alpha1.KalypsoApplication{})).To(Succeed())
+			Expect(deployment.Spec.Template.Spec.RuntimeClassName).To(BeNil())
+		})
+	})
+
+	Context("When adding sidecar containers", func() {
+		It("should append the sidecars after the Triton container in a stable order", func() {
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					StorageURI: "s3://models/recommendation",
+					Sidecars: []corev1.Container{
+						{Name: "auth-proxy", Image: "quay.io/oauth2-proxy/oauth2-proxy:v7.6.0"},
+						{Name: "model-sync", Image: "kalypso/model-sync:v1"},
+					},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Scheme: scheme,
+			}
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, &servingv1alpha1.KalypsoApplication{})).To(Succeed())
+			containers := deployment.Spec.Template.Spec.Containers
+			Expect(containers).To(HaveLen(3))
+			Expect(containers[0].Name).To(Equal("tritonserver"))
+			Expect(containers[1:]).To(Equal(server.Spec.Sidecars))
+
+			By("mutating the Deployment again")
+			rendered := deployment.Spec.Template.DeepCopy()
+			Expect(reconciler.mutateDeployment(deployment, server, &servingv1alpha1.KalypsoApplication{})).To(Succeed())
+			Expect(deployment.Spec.Template).To(Equal(*rendered), "the sidecars are not appended twice")
+
+			By("removing the sidecars")
+			server.Spec.Sidecars = nil
+			Expect(reconciler.mutateDeployment(deployment, server, &servingv1alpha1.KalypsoApplication{})).To(Succeed())
+			Expect(deployment.Spec.Template.Spec.Containers).To(HaveLen(1))
+		})
+	})
+
+	Context("When adding init containers", func() {
+		It("should run the init containers after the model download", func() {
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "llm-v1", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					StorageURI:  "hf://meta-llama/Llama-3.1-8B-Instruct@0e9e39f",
+					HuggingFace: &servingv1alpha1.HuggingFaceSpec{Backend: "vllm"},
+					InitContainers: []corev1.Container{
+						{Name: "fetch-tokenizer", Image: "busybox:1.36", Command: []string{"sh", "-c", "wget -O /models/tokenizer.json $TOKENIZER_URL"}},
+						{Name: "warm-cache", Image: "kalypso/warm-cache:v1"},
+					},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Scheme: scheme,
+			}
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, &servingv1alpha1.KalypsoApplication{})).To(Succeed())
+			initContainers := deployment.Spec.Template.Spec.InitContainers
+			Expect(initContainers).To(HaveLen(3))
+			Expect(initContainers[0].Env).To(ContainElement(corev1.EnvVar{Name: "HF_REPO", Value: "meta-llama/Llama-3.1-8B-Instruct"}), "the download runs first")
+			Expect(initContainers[1:]).To(Equal(server.Spec.InitContainers))
+			Expect(deployment.Spec.Template.Spec.Containers).To(HaveLen(1))
+
+			By("removing the init containers")
+			server.Spec.InitContainers = nil
+			Expect(reconciler.mutateDeployment(deployment, server, &servingv1alpha1.KalypsoApplication{})).To(Succeed())
+			Expect(deployment.Spec.Template.Spec.InitContainers).To(HaveLen(1))
+		})
+	})
+
+	Context("When adding custom volumes", func() {
+		It("should add the volumes to the pod and mount them in the Triton container only", func() {
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			configVolume := corev1.Volume{Name: "model-config", VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "recommendation-config"}},
+			}}
+			cacheVolume := corev1.Volume{Name: "model-cache", VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "recommendation-cache"},
+			}}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					StorageURI:   "s3://models/recommendation",
+					Volumes:      []corev1.Volume{configVolume, cacheVolume},
+					VolumeMounts: []corev1.VolumeMount{{Name: "model-config", MountPath: "/config", ReadOnly: true}, {Name: "model-cache", MountPath: "/cache"}},
+					SharedMemory: &servingv1alpha1.SharedMemorySpec{Size: resource.MustParse("1Gi")},
+					Sidecars:     []corev1.Container{{Name: "model-sync", Image: "kalypso/model-sync:v1"}},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Scheme: scheme,
+			}
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, &servingv1alpha1.KalypsoApplication{})).To(Succeed())
+			podSpec := deployment.Spec.Template.Spec
+			Expect(podSpec.Volumes).To(ContainElements(configVolume, cacheVolume))
+			Expect(podSpec.Volumes).To(ContainElement(HaveField("Name", SharedMemoryVolumeName)), "the operator volumes are kept")
+			Expect(podSpec.Containers[0].VolumeMounts).To(ContainElements(server.Spec.VolumeMounts))
+			Expect(podSpec.Containers[1].VolumeMounts).To(BeEmpty())
+
+			By("removing the volumes")
+			server.Spec.Volumes = nil
+			server.Spec.VolumeMounts = nil
+			Expect(reconciler.mutateDeployment(deployment, server, &servingv1alpha1.KalypsoApplication{})).To(Succeed())
+			Expect(deployment.Spec.Template.Spec.Volumes).NotTo(ContainElement(HaveField("Name", "model-config")))
+			Expect(deployment.Spec.Template.Spec.Containers[0].VolumeMounts).NotTo(ContainElement(HaveField("Name", "model-config")))
+		})
+	})
+
+	Context("When setting image pull Secrets", func() {
+		It("should fall back to the application default unless the server sets its own", func() {
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system"},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{StorageURI: "s3://models/recommendation"},
+			}
+			app := &servingv1alpha1.KalypsoApplication{
+				Spec: servingv1alpha1.KalypsoApplicationSpec{ImagePullSecrets: []corev1.LocalObjectReference{{Name: "nvcr-mirror"}}},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Scheme: scheme,
+			}
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, app)).To(Succeed())
+			Expect(deployment.Spec.Template.Spec.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{{Name: "nvcr-mirror"}}))
+
+			deployment.Spec.Template.Spec.ImagePullSecrets[0].Name = "changed"
+			Expect(app.Spec.ImagePullSecrets[0].Name).To(Equal("nvcr-mirror"), "the pod spec does not alias the application spec")
+
+			By("setting the pull Secrets of the server")
+			server.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "team-registry"}}
+			Expect(reconciler.mutateDeployment(deployment, server, app)).To(Succeed())
+			Expect(deployment.Spec.Template.Spec.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{{Name: "team-registry"}}))
+
+			By("removing every pull Secret")
+			server.Spec.ImagePullSecrets = nil
+			app.Spec.ImagePullSecrets = nil
+			Expect(reconciler.mutateDeployment(deployment, server, app)).To(Succeed())
+			Expect(deployment.Spec.Template.Spec.ImagePullSecrets).To(BeEmpty())
+		})
+	})
+
+	Context("When building health probes", func() {
+		It("should give large models a long startup window by default", func() {
+			server := &servingv1alpha1.KalypsoTritonServer{}
+
+			startup := buildStartupProbe(server, 8000)
+
+			Expect(startup.HTTPGet.Path).To(Equal("/v2/health/live"))
+			Expect(startup.PeriodSeconds * startup.FailureThreshold).To(BeNumerically(">=", 600))
+			Expect(buildLivenessProbe(server, 8000).InitialDelaySeconds).To(Equal(int32(15)))
+		})
+
+		It("should override only the configured fields", func() {
+			server := &servingv1alpha1.KalypsoTritonServer{
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					Probes: &servingv1alpha1.ProbesSpec{
+						Readiness: &servingv1alpha1.ProbeSpec{FailureThreshold: ptrTo(int32(6))},
+					},
+				},
+			}
+
+			readiness := buildReadinessProbe(server, 8000)
+
+			Expect(readiness.FailureThreshold).To(Equal(int32(6)))
+			Expect(readiness.PeriodSeconds).To(Equal(int32(5)))
+			Expect(readiness.HTTPGet.Path).To(Equal("/v2/health/ready"))
+		})
+	})
+
+	Context("When configuring graceful shutdown", func() {
+		It("should sleep before SIGTERM and size the grace period to the drain", func() {
+			server := &servingv1alpha1.KalypsoTritonServer{}
+			podSpec := corev1.PodSpec{Containers: []corev1.Container{{Name: "tritonserver"}}}
+
+			applyGracefulShutdown(&podSpec, server)
+
+			Expect(podSpec.Containers[0].Lifecycle.PreStop.Exec.Command).To(Equal([]string{"sleep", "10"}))
+			Expect(*podSpec.TerminationGracePeriodSeconds).To(Equal(int64(45)))
+			Expect(buildShutdownArgs(server, nil)).To(ConsistOf("--exit-timeout-secs=30"))
+		})
+
+		It("should fit the exit timeout in a configured grace period", func() {
+			server := &servingv1alpha1.KalypsoTritonServer{
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{TerminationGracePeriodSeconds: ptrTo(int64(300))},
+			}
+			podSpec := corev1.PodSpec{Containers: []corev1.Container{{Name: "tritonserver"}}}
+
+			applyGracefulShutdown(&podSpec, server)
+
+			Expect(*podSpec.TerminationGracePeriodSeconds).To(Equal(int64(300)))
+			Expect(buildShutdownArgs(server, nil)).To(ConsistOf("--exit-timeout-secs=285"))
+		})
+
+		It("should respect an exit timeout passed as a Triton parameter", func() {
+			server := &servingv1alpha1.KalypsoTritonServer{
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					TritonConfig: servingv1alpha1.TritonConfigSpec{
+						Parameters: []servingv1alpha1.TritonParameter{{Name: "exit-timeout-secs", Value: "120"}},
+					},
+					Lifecycle: &servingv1alpha1.LifecycleSpec{PreStopSleepSeconds: ptrTo(int32(0))},
+				},
+			}
+			podSpec := corev1.PodSpec{Containers: []corev1.Container{{Name: "tritonserver"}}}
+
+			applyGracefulShutdown(&podSpec, server)
+
+			Expect(podSpec.Containers[0].Lifecycle).To(BeNil())
+			Expect(*podSpec.TerminationGracePeriodSeconds).To(Equal(int64(125)))
+			Expect(buildShutdownArgs(server, nil)).To(BeEmpty())
+		})
+	})
+
+	Context("When tuning long-lived gRPC clients", func() {
+		It("should pass keepalive flags in milliseconds unless set as Triton parameters", func() {
+			server := &servingv1alpha1.KalypsoTritonServer{
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					TritonConfig: servingv1alpha1.TritonConfigSpec{
+						Parameters: []servingv1alpha1.TritonParameter{{Name: "grpc-keepalive-timeout", Value: "5000"}},
+					},
+					Networking: &servingv1alpha1.NetworkingSpec{
+						GRPCKeepalive: &servingv1alpha1.GRPCKeepaliveSpec{
+							TimeSeconds:        ptrTo(int32(30)),
+							TimeoutSeconds:     ptrTo(int32(10)),
+							PermitWithoutCalls: ptrTo(true),
+							MaxPingStrikes:     ptrTo(int32(0)),
+						},
+					},
+				},
+			}
+
+			Expect(buildKeepaliveArgs(server, nil)).To(Equal([]string{
+				"--grpc-keepalive-time=30000",
+				"--grpc-keepalive-permit-without-calls=true",
+				"--grpc-http2-max-ping-strikes=0",
+			}))
+		})
+
+		It("should pin clients to a replica with ClientIP affinity", func() {
+			server := &servingv1alpha1.KalypsoTritonServer{
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					Networking: &servingv1alpha1.NetworkingSpec{SessionAffinity: corev1.ServiceAffinityClientIP},
+				},
+			}
+			service := &corev1.Service{}
+
+			applySessionAffinity(service, server)
+			Expect(service.Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityClientIP))
+			Expect(*service.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds).To(Equal(corev1.DefaultClientIPServiceAffinitySeconds))
+
+			server.Spec.Networking = nil
+			applySessionAffinity(service, server)
+			Expect(service.Spec.SessionAffinity).To(Equal(corev1.ServiceAffinityNone))
+			Expect(service.Spec.SessionAffinityConfig).To(BeNil())
+		})
+	})
+
+	Context("When serving gRPC over TLS", func() {
+		It("should mount the application certificate and roll the pods on renewal", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					Certificate: &servingv1alpha1.ApplicationCertificateSpec{
+						IssuerRef: servingv1alpha1.IssuerReference{Name: "internal-ca"},
+						ServerTLS: true,
+					},
+				},
+			}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: app.Namespace},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: app.Name, StorageURI: "s3://models/recommendation"},
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "recommendation-application-cert",
+					Namespace: app.Namespace,
+					Labels:    map[string]string{ApplicationLabelKey: app.Name},
+				},
+				Data: map[string][]byte{corev1.TLSCertKey: []byte("certificate"), corev1.TLSPrivateKeyKey: []byte("key")},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(app, server, secret).Build(),
+				Scheme: scheme,
+			}
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, app)).To(Succeed())
+			container := deployment.Spec.Template.Spec.Containers[0]
+			Expect(container.Args).To(ContainElements(
+				"--grpc-use-ssl=true",
+				"--grpc-server-cert=/etc/kalypso/tls/tls.crt",
+				"--grpc-server-key=/etc/kalypso/tls/tls.key",
+			))
+			Expect(container.VolumeMounts).To(ContainElement(HaveField("MountPath", "/etc/kalypso/tls")))
+			Expect(deployment.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("Secret.SecretName", "recommendation-application-cert")))
+
+			revision, err := reconciler.tlsCertificateRevision(ctx, server, app)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revision).NotTo(BeEmpty())
+			Expect(withTLSCertificateRevision(server, revision).Spec.PodAnnotations).To(HaveKeyWithValue(TLSCertificateRevisionAnnotation, revision))
+
+			secret.Data[corev1.TLSCertKey] = []byte("renewed certificate")
+			Expect(reconciler.Update(ctx, secret)).To(Succeed())
+			renewed, err := reconciler.tlsCertificateRevision(ctx, server, app)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(renewed).NotTo(Equal(revision))
+
+			Expect(reconciler.serversForTLSSecret(ctx, secret)).To(ConsistOf(
+				reconcile.Request{NamespacedName: types.NamespacedName{Namespace: server.Namespace, Name: server.Name}},
+			))
+		})
+
+		It("should prefer the server secret and verify client certificates when requested", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					Certificate: &servingv1alpha1.ApplicationCertificateSpec{ServerTLS: true},
+				},
+			}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v2", Namespace: app.Namespace},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					ApplicationRef: app.Name,
+					StorageURI:     "s3://models/recommendation",
+					Networking: &servingv1alpha1.NetworkingSpec{
+						TLS: &servingv1alpha1.TLSSpec{SecretName: "recommendation-v2-tls", ClientAuth: true},
+					},
+				},
+			}
+			Expect(tlsSecretName(server, app)).To(Equal("recommendation-v2-tls"))
+			Expect(buildTLSArgs(server, tlsSecretName(server, app), nil)).To(ContainElements(
+				"--grpc-use-ssl=true",
+				"--grpc-use-ssl-mutual=true",
+				"--grpc-root-cert=/etc/kalypso/tls/ca.crt",
+			))
+
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(app, server).Build(),
+				Scheme: scheme,
+			}
+			serverSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v2-tls", Namespace: app.Namespace}}
+			Expect(reconciler.serversForTLSSecret(ctx, serverSecret)).To(HaveLen(1))
+			applicationSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:      "recommendation-application-cert",
+				Namespace: app.Namespace,
+				Labels:    map[string]string{ApplicationLabelKey: app.Name},
+			}}
+			Expect(reconciler.serversForTLSSecret(ctx, applicationSecret)).To(BeEmpty())
+		})
+	})
+
+	Context("When credential Secrets are rotated", func() {
+		It("should roll the servers of the applications and projects referencing them", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			project := &servingv1alpha1.KalypsoProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoProjectSpec{
+					ModelRegistry: &servingv1alpha1.ModelRegistrySpec{URL: "s3://kalypso-models/sample-project", SecretRef: "registry-credentials"},
+				},
+			}
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: project.Namespace},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ProjectRef: project.Name,
+					Storage:    &servingv1alpha1.StorageSpec{SecretName: "s3-credentials"},
+				},
+			}
+			other := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "search-application", Namespace: project.Namespace},
+				Spec:       servingv1alpha1.KalypsoApplicationSpec{ProjectRef: "other-project"},
+			}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: project.Namespace},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: app.Name, StorageURI: "s3://models/recommendation"},
+			}
+			unrelated := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "search-v1", Namespace: project.Namespace},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: other.Name, StorageURI: "s3://models/search"},
+			}
+			storageSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "s3-credentials", Namespace: project.Namespace},
+				Data:       map[string][]byte{"AWS_ACCESS_KEY_ID": []byte("AKIA1"), "AWS_SECRET_ACCESS_KEY": []byte("secret1")},
+			}
+			registrySecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "registry-credentials", Namespace: project.Namespace},
+				Data:       map[string][]byte{"token": []byte("token1")},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).
+					WithObjects(project, app, other, server, unrelated, storageSecret, registrySecret).
+					Build(),
+				Scheme: scheme,
+			}
+
+			revision, err := reconciler.credentialsRevision(ctx, server, app)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revision).NotTo(BeEmpty())
+			Expect(withCredentialsRevision(server, revision).Spec.PodAnnotations).To(HaveKeyWithValue(CredentialsRevisionAnnotation, revision))
+
+			storageSecret.Data["AWS_SECRET_ACCESS_KEY"] = []byte("secret2")
+			Expect(reconciler.Update(ctx, storageSecret)).To(Succeed())
+			rotated, err := reconciler.credentialsRevision(ctx, server, app)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rotated).NotTo(Equal(revision))
+
+			registrySecret.Data["token"] = []byte("token2")
+			Expect(reconciler.Update(ctx, registrySecret)).To(Succeed())
+			Expect(reconciler.credentialsRevision(ctx, server, app)).NotTo(Equal(rotated))
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: server.Namespace, Name: server.Name}}
+			Expect(reconciler.serversForCredentialSecret(ctx, storageSecret)).To(ConsistOf(request))
+			Expect(reconciler.serversForCredentialSecret(ctx, registrySecret)).To(ConsistOf(request))
+			Expect(reconciler.serversForCredentialSecret(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "unused", Namespace: project.Namespace}})).To(BeEmpty())
+
+			By("leaving servers without credential Secrets unannotated")
+			Expect(reconciler.credentialsRevision(ctx, unrelated, other)).To(BeEmpty())
+		})
+	})
+
+	Context("When verifying the Triton image signature", func() {
+		It("should pin verified images and honour the project override", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			digest := "sha256:" + strings.Repeat("ab", 32)
+			project := &servingv1alpha1.KalypsoProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "kalypso-system"},
+			}
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: project.Namespace},
+				Spec:       servingv1alpha1.KalypsoApplicationSpec{ProjectRef: project.Name},
+			}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: project.Namespace},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					ApplicationRef: app.Name,
+					TritonConfig:   servingv1alpha1.TritonConfigSpec{Image: "registry.example.com/triton", Tag: "24.12-py3"},
+				},
+			}
+			keySecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-cosign", Namespace: project.Namespace},
+				Data:       map[string][]byte{"cosign.pub": []byte("team key")},
+			}
+			verifier := &staticImageVerifier{digests: map[string]string{"registry.example.com/triton:24.12-py3": digest}}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(project, app, server, keySecret).Build(),
+				Scheme: scheme,
+				ImageVerification: ImageVerificationPolicy{
+					Mode:      servingv1alpha1.ImageVerificationEnforce,
+					PublicKey: []byte("operator key"),
+				},
+				ImageVerifier: verifier,
+			}
+
+			result := reconciler.verifyImageSignature(ctx, server, app)
+			Expect(result.err).NotTo(HaveOccurred())
+			Expect(verifier.policy.PublicKey).To(Equal([]byte("operator key")))
+			Expect(tritonImage(withVerifiedImage(server, result))).To(Equal("registry.example.com/triton@" + digest))
+			applyImageSignatureStatus(server, result)
+			Expect(meta.IsStatusConditionTrue(server.Status.Conditions, "ImageVerified")).To(BeTrue())
+
+			By("failing enforced verification of an unsigned image")
+			server.Spec.TritonConfig.Tag = "25.01-py3"
+			result = reconciler.verifyImageSignature(ctx, server, app)
+			Expect(result.enforced()).To(BeTrue())
+			Expect(withVerifiedImage(server, result)).To(BeIdenticalTo(server))
+			applyImageSignatureStatus(server, result)
+			condition := meta.FindStatusCondition(server.Status.Conditions, "ImageVerified")
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("SignatureNotFound"))
+
+			By("verifying with the project key in Audit mode")
+			project.Spec.ImageVerification = &servingv1alpha1.ImageVerificationSpec{
+				Mode:            servingv1alpha1.ImageVerificationAudit,
+				PublicKeySecret: keySecret.Name,
+			}
+			Expect(reconciler.Update(ctx, project)).To(Succeed())
+			result = reconciler.verifyImageSignature(ctx, server, app)
+			Expect(verifier.policy.PublicKey).To(Equal([]byte("team key")))
+			Expect(result.err).To(HaveOccurred())
+			Expect(result.enforced()).To(BeFalse(), "Audit mode deploys unsigned images")
+
+			By("requiring a Rekor key for the transparency log")
+			project.Spec.ImageVerification.TransparencyLog = ptrTo(true)
+			Expect(reconciler.Update(ctx, project)).To(Succeed())
+			result = reconciler.verifyImageSignature(ctx, server, app)
+			Expect(result.reason).To(Equal("PolicyInvalid"))
+
+			By("skipping projects opting out")
+			project.Spec.ImageVerification = &servingv1alpha1.ImageVerificationSpec{Mode: servingv1alpha1.ImageVerificationDisabled}
+			Expect(reconciler.Update(ctx, project)).To(Succeed())
+			Expect(reconciler.verifyImageSignature(ctx, server, app)).To(BeNil())
+			applyImageSignatureStatus(server, nil)
+			Expect(meta.FindStatusCondition(server.Status.Conditions, "ImageVerified")).To(BeNil())
+		})
+	})
+
+	Context("When indexing the loaded models", func() {
+		It("should record the ready models of running servers and gate readiness on them", func() {
+			ctx := context.Background()
+			index := &staticModelIndex{models: []triton.Model{
+				{Name: "resnet50", Version: "1", State: triton.ModelStateReady},
+				{Name: "bert", Version: "1", State: "UNAVAILABLE"},
+			}}
+			reconciler := &KalypsoTritonServerReconciler{ModelIndex: index}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					Networking: &servingv1alpha1.NetworkingSpec{HTTPPort: ptrTo(int32(9000))},
+				},
+			}
+
+			result := reconciler.indexModels(ctx, server, 1)
+			applyModelIndexStatus(server, result)
+			Expect(index.endpoint).To(Equal("http://recommendation-v1-svc.kalypso-system.svc:9000"))
+			Expect(server.Status.Models).To(Equal([]string{"resnet50"}))
+			Expect(result.modelsPending()).To(BeTrue(), "a model failed to load")
+			Expect(meta.FindStatusCondition(server.Status.Conditions, "ModelsReady").Message).To(Equal("Models not ready: bert (UNAVAILABLE)"))
+
+			server.Spec.RequiredModels = []string{"resnet50"}
+			result = reconciler.indexModels(ctx, server, 1)
+			applyModelIndexStatus(server, result)
+			Expect(result.modelsPending()).To(BeFalse())
+			Expect(meta.IsStatusConditionTrue(server.Status.Conditions, "ModelsReady")).To(BeTrue())
+
+			index.err = errors.NewServiceUnavailable("connection refused")
+			applyModelIndexStatus(server, reconciler.indexModels(ctx, server, 1))
+			Expect(server.Status.Models).To(Equal([]string{"resnet50"}), "a failed query keeps the last known models")
+
+			applyModelIndexStatus(server, reconciler.indexModels(ctx, server, 0))
+			Expect(server.Status.Models).To(BeEmpty())
+		})
+	})
+
+	Context("When overriding the model version policy", func() {
+		It("should load models explicitly and reload each ready pod once", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					TritonConfig: servingv1alpha1.TritonConfigSpec{
+						VersionPolicy: &servingv1alpha1.ModelVersionPolicy{Latest: ptrTo(int32(2))},
+					},
+				},
+			}
+			Expect(buildModelControlArgs(server, nil)).To(Equal([]string{"--model-control-mode=explicit", "--load-model=*"}))
+
+			readyPod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1-ready", Namespace: server.Namespace, Labels: map[string]string{TritonServerLabelKey: server.Name}},
+				Status: corev1.PodStatus{
+					PodIP:      "10.0.0.12",
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+				},
+			}
+			startingPod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1-starting", Namespace: server.Namespace, Labels: map[string]string{TritonServerLabelKey: server.Name}},
+			}
+			index := &staticModelIndex{models: []triton.Model{{Name: "resnet50", Version: "3", State: triton.ModelStateReady}}}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client:     fake.NewClientBuilder().WithScheme(scheme).WithObjects(server, readyPod, startingPod).Build(),
+				Scheme:     scheme,
+				ModelIndex: index,
+			}
+
+			result := reconciler.applyModelConfig(ctx, server)
+			Expect(result.failures).To(BeEmpty())
+			Expect(result.pending).To(Equal(1))
+			Expect(index.endpoint).To(Equal("http://10.0.0.12:8000"))
+			Expect(index.loaded["resnet50"]).To(HaveKeyWithValue("version_policy", map[string]interface{}{
+				"latest": map[string]interface{}{"num_versions": int32(2)},
+			}))
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(readyPod), readyPod)).To(Succeed())
+			Expect(readyPod.Annotations).To(HaveKeyWithValue(ModelConfigRevisionAnnotation, result.revision))
+
+			applyModelConfigStatus(server, result)
+			Expect(meta.FindStatusCondition(server.Status.Conditions, "ModelConfigApplied").Reason).To(Equal("ReloadPending"))
+
+			index.loaded = nil
+			reconciler.applyModelConfig(ctx, server)
+			Expect(index.loaded).To(BeEmpty(), "reloaded pods are skipped")
+		})
+	})
+
+	Context("When warming up models", func() {
+		It("should warm up unlisted model inputs with zeros shaped from the configuration", func() {
+			server := &servingv1alpha1.KalypsoTritonServer{
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					Warmup: &servingv1alpha1.WarmupSpec{Models: []servingv1alpha1.ModelWarmup{
+						{Name: "*"},
+						{Name: "bert", BatchSize: 4, Count: 2, Inputs: []servingv1alpha1.WarmupInput{
+							{Name: "input_ids", Data: "Random"},
+						}},
+					}},
+				},
+			}
+			config := map[string]interface{}{
+				"name": "resnet50",
+				"input": []interface{}{
+					map[string]interface{}{"name": "image", "data_type": "TYPE_FP32", "dims": []interface{}{"-1", "224", float64(224)}},
+				},
+			}
+
+			overrideModelConfig(server, "resnet50", config)
+			Expect(config["model_warmup"]).To(Equal([]interface{}{
+				map[string]interface{}{
+					"name":       "kalypso_warmup",
+					"batch_size": int64(1),
+					"count":      int64(1),
+					"inputs": map[string]interface{}{
+						"image": map[string]interface{}{"data_type": "TYPE_FP32", "dims": []interface{}{int64(1), int64(224), int64(224)}, "zero_data": true},
+					},
+				},
+			}))
+
+			config = map[string]interface{}{
+				"name": "bert",
+				"input": []interface{}{
+					map[string]interface{}{"name": "input_ids", "data_type": "TYPE_INT64", "dims": []interface{}{"128"}},
+					map[string]interface{}{"name": "attention_mask", "data_type": "TYPE_INT64", "dims": []interface{}{"128"}},
+				},
+			}
+			overrideModelConfig(server, "bert", config)
+			warmup := config["model_warmup"].([]interface{})[0].(map[string]interface{})
+			Expect(warmup).To(HaveKeyWithValue("batch_size", int64(4)))
+			Expect(warmup).To(HaveKeyWithValue("count", int64(2)))
+			Expect(warmup["inputs"]).To(Equal(map[string]interface{}{
+				"input_ids":      map[string]interface{}{"data_type": "TYPE_INT64", "dims": []interface{}{int64(128)}, "random_data": true},
+				"attention_mask": map[string]interface{}{"data_type": "TYPE_INT64", "dims": []interface{}{int64(128)}, "zero_data": true},
+			}))
+		})
+	})
+
+	Context("When overriding the dynamic batcher", func() {
+		It("should configure batching models only", func() {
+			server := &servingv1alpha1.KalypsoTritonServer{
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					TritonConfig: servingv1alpha1.TritonConfigSpec{
+						DynamicBatching: []servingv1alpha1.ModelDynamicBatching{
+							{Name: "*", PreferredBatchSizes: []int32{4, 8}, MaxQueueDelayMicroseconds: 100},
+						},
+					},
+				},
+			}
+			Expect(modelConfigRevision(server)).NotTo(BeEmpty())
+
+			config := map[string]interface{}{"name": "resnet50", "max_batch_size": float64(8)}
+			overrideModelConfig(server, "resnet50", config)
+			Expect(config["dynamic_batching"]).To(Equal(map[string]interface{}{
+				"preferred_batch_size":         []interface{}{int64(4), int64(8)},
+				"max_queue_delay_microseconds": int64(100),
+				"preserve_ordering":            false,
+			}))
+
+			config = map[string]interface{}{"name": "tokenizer", "max_batch_size": float64(0)}
+			overrideModelConfig(server, "tokenizer", config)
+			Expect(config).NotTo(HaveKey("dynamic_batching"))
+		})
+	})
+
+	Context("When overriding model configurations", func() {
+		It("should replace the instance groups and batch size and merge the optimizations", func() {
+			server := &servingv1alpha1.KalypsoTritonServer{
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					ModelConfigOverrides: []servingv1alpha1.ModelConfigOverride{{
+						Name:           "resnet50",
+						InstanceGroups: []servingv1alpha1.ModelInstanceGroup{{Count: 2, Kind: "GPU", GPUs: []int32{0}}},
+						MaxBatchSize:   ptrTo(int32(16)),
+						Optimization:   &servingv1alpha1.ModelOptimization{InputPinnedMemory: ptrTo(true), TensorRTPrecision: "FP16"},
+					}},
+					TritonConfig: servingv1alpha1.TritonConfigSpec{
+						DynamicBatching: []servingv1alpha1.ModelDynamicBatching{{Name: "resnet50", PreferredBatchSizes: []int32{16}}},
+					},
+				},
+			}
+			config := map[string]interface{}{
+				"name":           "resnet50",
+				"max_batch_size": float64(0),
+				"instance_group": []interface{}{map[string]interface{}{"count": float64(1), "kind": "KIND_CPU"}},
+				"optimization":   map[string]interface{}{"priority": "PRIORITY_DEFAULT"},
+			}
+
+			overrideModelConfig(server, "resnet50", config)
+			Expect(config["instance_group"]).To(Equal([]interface{}{
+				map[string]interface{}{"count": int64(2), "kind": "KIND_GPU", "gpus": []interface{}{int64(0)}},
+			}))
+			Expect(config["max_batch_size"]).To(Equal(int64(16)))
+			Expect(config).To(HaveKey("dynamic_batching"), "the batcher follows the overridden batch size")
+			optimization := config["optimization"].(map[string]interface{})
+			Expect(optimization).To(HaveKeyWithValue("priority", "PRIORITY_DEFAULT"))
+			Expect(optimization).To(HaveKeyWithValue("input_pinned_memory", map[string]interface{}{"enable": true}))
+			Expect(optimization).To(HaveKey("execution_accelerators"))
+		})
+	})
+
+	Context("When serving a registered MLflow model", func() {
+		It("should serve the artifacts of the version in the stage and follow transitions", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					StorageURI: "mlflow://mlflow.mlops:5000/recommender/Production",
+					MLflow:     &servingv1alpha1.MLflowSpec{CredentialsSecret: "mlflow-credentials", Insecure: true, Interval: "5m"},
+				},
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "mlflow-credentials", Namespace: server.Namespace},
+				Data:       map[string][]byte{"token": []byte("secret")},
+			}
+			registry := &staticModelRegistry{version: &mlflow.ModelVersion{Version: "7", ArtifactURI: "s3://mlflow/artifacts/7/model"}}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client:        fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build(),
+				Scheme:        scheme,
+				ModelRegistry: registry,
+			}
+
+			result := reconciler.resolveModelRegistry(ctx, server)
+			Expect(result.err).NotTo(HaveOccurred())
+			Expect(registry.baseURL).To(Equal("http://mlflow.mlops:5000"))
+			Expect(registry.credentials.Token).To(Equal("secret"))
+			Expect(withModelRegistryVersion(server, result).Spec.StorageURI).To(Equal("s3://mlflow/artifacts/7/model"))
+			applyModelRegistryStatus(server, result)
+			Expect(server.Status.ModelRegistry.Version).To(Equal("7"))
+
+			By("reusing the resolved version within the interval")
+			registry.version = &mlflow.ModelVersion{Version: "8", ArtifactURI: "s3://mlflow/artifacts/8/model"}
+			Expect(reconciler.resolveModelRegistry(ctx, server).status.Version).To(Equal("7"))
+
+			By("following the stage to a new version")
+			server.Status.ModelRegistry.LastCheckTime = &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}
+			result = reconciler.resolveModelRegistry(ctx, server)
+			Expect(withModelRegistryVersion(server, result).Spec.StorageURI).To(Equal("s3://mlflow/artifacts/8/model"))
+			applyModelRegistryStatus(server, result)
+
+			By("serving the previous version when the registry is unreachable")
+			server.Status.ModelRegistry.LastCheckTime = &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}
+			registry.err = fmt.Errorf("connection refused")
+			result = reconciler.resolveModelRegistry(ctx, server)
+			Expect(withModelRegistryVersion(server, result).Spec.StorageURI).To(Equal("s3://mlflow/artifacts/8/model"))
+			applyModelRegistryStatus(server, result)
+			condition := meta.FindStatusCondition(server.Status.Conditions, "ModelRegistryResolved")
+			Expect(condition.Reason).To(Equal("ResolveFailed"))
+			Expect(condition.Message).To(ContainSubstring("still serving version 8"))
+		})
+	})
+
+	Context("When serving a Hugging Face Hub repository", func() {
+		It("should download the repository in an init container and serve it locally", func() {
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "llm-v1", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					StorageURI:  "hf://meta-llama/Llama-3.1-8B-Instruct@0e9e39f",
+					HuggingFace: &servingv1alpha1.HuggingFaceSpec{TokenSecret: "hf-token", Backend: "vllm"},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Scheme: scheme,
+			}
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, &servingv1alpha1.KalypsoApplication{})).To(Succeed())
+			podSpec := deployment.Spec.Template.Spec
+			Expect(podSpec.Containers[0].Args).To(ContainElement("--model-repository=/kalypso/models/repository"))
+			Expect(podSpec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name: ModelDownloadVolumeName, MountPath: ModelDownloadMountPath, ReadOnly: true,
+			}))
+			Expect(podSpec.InitContainers).To(HaveLen(1))
+			Expect(podSpec.InitContainers[0].Env).To(ContainElements(
+				corev1.EnvVar{Name: "HF_REPO", Value: "meta-llama/Llama-3.1-8B-Instruct"},
+				corev1.EnvVar{Name: "HF_REVISION", Value: "0e9e39f"},
+				corev1.EnvVar{Name: "MODEL_NAME", Value: "Llama-3.1-8B-Instruct"},
+				corev1.EnvVar{Name: "TRITON_BACKEND", Value: "vllm"},
+			))
+			Expect(podSpec.InitContainers[0].Env).To(ContainElement(HaveField("ValueFrom.SecretKeyRef.Name", "hf-token")))
+
+			server.Spec.StorageURI = "s3://models/llm"
+			deployment = &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, &servingv1alpha1.KalypsoApplication{})).To(Succeed())
+			Expect(deployment.Spec.Template.Spec.InitContainers).To(BeEmpty())
+		})
+	})
+
+	Context("When serving an OCI model artifact", func() {
+		It("should pull the artifact by the digest of its tag", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			digest := "sha256:" + strings.Repeat("ab", 32)
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					StorageURI: "oci://registry.example.com/models/recommender:v3",
+					OCI:        &servingv1alpha1.OCIArtifactSpec{PullSecret: "registry-credentials", Path: "model_repository"},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client:           fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Scheme:           scheme,
+				ArtifactResolver: staticDigests{"registry.example.com/models/recommender:v3": digest},
+			}
+
+			result := reconciler.resolveModelArtifact(ctx, server)
+			Expect(result.err).NotTo(HaveOccurred())
+			Expect(result.reason).To(Equal("TagResolved"))
+			deployed := withModelArtifactDigest(server, result)
+			Expect(deployed.Spec.StorageURI).To(Equal("oci://registry.example.com/models/recommender@" + digest))
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, deployed, &servingv1alpha1.KalypsoApplication{})).To(Succeed())
+			podSpec := deployment.Spec.Template.Spec
+			Expect(podSpec.Containers[0].Args).To(ContainElement("--model-repository=/kalypso/models/repository/model_repository"))
+			Expect(podSpec.InitContainers).To(HaveLen(1))
+			Expect(podSpec.InitContainers[0].Args).To(Equal([]string{
+				"pull", "registry.example.com/models/recommender@" + digest, "--output", "/kalypso/models/repository",
+			}))
+			Expect(podSpec.InitContainers[0].Env).To(ContainElement(corev1.EnvVar{Name: "DOCKER_CONFIG", Value: OCICredentialsMountPath}))
+
+			By("keeping the resolved digest when the registry is unreachable")
+			applyModelArtifactStatus(server, result)
+			reconciler.ArtifactResolver = staticDigests{}
+			result = reconciler.resolveModelArtifact(ctx, server)
+			Expect(result.err).To(HaveOccurred())
+			Expect(result.digest).To(Equal(digest))
+
+			By("rejecting a storage URI digest differing from the pinned digest")
+			server.Spec.StorageURI = "oci://registry.example.com/models/recommender@sha256:" + strings.Repeat("cd", 32)
+			server.Spec.OCI.Digest = digest
+			result = reconciler.resolveModelArtifact(ctx, server)
+			Expect(result.digest).To(BeEmpty())
+			Expect(result.err).To(MatchError(ContainSubstring("does not match spec.oci.digest")))
+		})
+	})
+
+	Context("When serving a gs:// model repository", func() {
+		It("should mount the service account key of the application storage", func() {
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "llama-v1", Namespace: "kalypso-system"},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{StorageURI: "gs://models/llama"},
+			}
+			app := &servingv1alpha1.KalypsoApplication{
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					Storage: &servingv1alpha1.StorageSpec{GCS: &servingv1alpha1.GCSStorageSpec{CredentialsSecret: "gcs-key"}},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Scheme: scheme,
+			}
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, app)).To(Succeed())
+			container := deployment.Spec.Template.Spec.Containers[0]
+			Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: "/kalypso/gcs/key.json"}))
+			Expect(container.EnvFrom).To(BeEmpty())
+			Expect(container.VolumeMounts).To(ContainElement(HaveField("Name", GCSCredentialsVolumeName)))
+
+			By("relying on workload identity without a key")
+			app.Spec.Storage.GCS = nil
+			deployment = &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, app)).To(Succeed())
+			Expect(deployment.Spec.Template.Spec.Volumes).NotTo(ContainElement(HaveField("Name", GCSCredentialsVolumeName)))
+		})
+	})
+
+	Context("When serving an as:// model repository", func() {
+		It("should inject the storage account key or the managed identity", func() {
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "ranking-v1", Namespace: "kalypso-system"},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{StorageURI: "as://kalypsomodels/models/ranking"},
+			}
+			app := &servingv1alpha1.KalypsoApplication{
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					Storage: &servingv1alpha1.StorageSpec{Azure: &servingv1alpha1.AzureStorageSpec{CredentialsSecret: "azure-storage"}},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Scheme: scheme,
+			}
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, app)).To(Succeed())
+			container := deployment.Spec.Template.Spec.Containers[0]
+			Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "AZURE_STORAGE_ACCOUNT", Value: "kalypsomodels"}))
+			Expect(container.EnvFrom).To(ContainElement(HaveField("SecretRef.Name", "azure-storage")))
+			Expect(deployment.Spec.Template.Labels).NotTo(HaveKey(AzureWorkloadIdentityLabelKey))
+
+			By("authenticating with the managed identity")
+			app.Spec.Storage.Azure = &servingv1alpha1.AzureStorageSpec{
+				ManagedIdentity: &servingv1alpha1.AzureManagedIdentitySpec{ClientID: "00000000-0000-0000-0000-000000000001"},
+			}
+			deployment = &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, app)).To(Succeed())
+			container = deployment.Spec.Template.Spec.Containers[0]
+			Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "AZURE_CLIENT_ID", Value: "00000000-0000-0000-0000-000000000001"}))
+			Expect(container.EnvFrom).To(BeEmpty())
+			Expect(deployment.Spec.Template.Labels).To(HaveKeyWithValue(AzureWorkloadIdentityLabelKey, "true"))
+		})
+	})
+
+	Context("When serving a git model repository", func() {
+		It("should clone the ref and keep polling it when requested", func() {
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "tokenizer-v1", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					StorageURI: "git+ssh://git@github.com/kalypso/tokenizers.git@v1.2",
+					Git:        &servingv1alpha1.GitSyncSpec{SSHKeySecret: "deploy-key", Path: "models", Poll: true, Period: "30s"},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Scheme: scheme,
+			}
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, &servingv1alpha1.KalypsoApplication{})).To(Succeed())
+			podSpec := deployment.Spec.Template.Spec
+			Expect(podSpec.Containers[0].Args).To(ContainElements(
+				"--model-repository=/kalypso/models/repository/models",
+				"--model-control-mode=poll",
+				"--repository-poll-secs=30",
+			))
+			Expect(podSpec.InitContainers).To(HaveLen(1))
+			Expect(podSpec.InitContainers[0].Args).To(ContainElements(
+				"--repo=ssh://git@github.com/kalypso/tokenizers.git",
+				"--ref=v1.2",
+				"--one-time",
+				"--ssh-key-file=/etc/git-secret/ssh-privatekey",
+			))
+			Expect(podSpec.Containers).To(HaveLen(2))
+			Expect(podSpec.Containers[1].Name).To(Equal("git-sync"))
+			Expect(podSpec.Containers[1].Args).To(ContainElement("--period=30s"))
+			Expect(podSpec.Containers[1].Args).NotTo(ContainElement("--one-time"))
+
+			By("loading models explicitly when their configurations are overridden")
+			server.Spec.Warmup = &servingv1alpha1.WarmupSpec{Models: []servingv1alpha1.ModelWarmup{{Name: "*"}}}
+			Expect(buildGitSyncArgs(server, nil)).To(BeEmpty())
+		})
+	})
+
+	Context("When serving a model repository archive", func() {
+		It("should download, verify and unpack the archive before Triton starts", func() {
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			checksum := strings.Repeat("ab", 32)
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					StorageURI: "https://nexus.internal/repository/models/recommendation-1.4.tgz?download=true",
+					Archive:    &servingv1alpha1.ArchiveSpec{SHA256: checksum, CredentialsSecret: "nexus", Path: "models"},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Scheme: scheme,
+			}
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, &servingv1alpha1.KalypsoApplication{})).To(Succeed())
+			podSpec := deployment.Spec.Template.Spec
+			Expect(podSpec.Containers[0].Args).To(ContainElement("--model-repository=/kalypso/models/repository/models"))
+			Expect(podSpec.InitContainers).To(HaveLen(1))
+			download := podSpec.InitContainers[0]
+			Expect(download.Name).To(Equal("archive-download"))
+			Expect(download.Env).To(ContainElements(
+				corev1.EnvVar{Name: "ARCHIVE_URL", Value: server.Spec.StorageURI},
+				corev1.EnvVar{Name: "ARCHIVE_SHA256", Value: checksum},
+				corev1.EnvVar{Name: "ARCHIVE_FORMAT", Value: "tar.gz"},
+			))
+			Expect(download.Env).To(ContainElement(HaveField("ValueFrom.SecretKeyRef.Key", "password")))
+			Expect(podSpec.Containers[0].VolumeMounts).To(ContainElement(
+				corev1.VolumeMount{Name: ModelDownloadVolumeName, MountPath: ModelDownloadMountPath, ReadOnly: true}))
+
+			By("honouring an explicit format")
+			server.Spec.Archive.Format = servingv1alpha1.ArchiveFormatZip
+			Expect(archiveFormat(server)).To(Equal(servingv1alpha1.ArchiveFormatZip))
+		})
+	})
+
+	Context("When copying an s3:// model repository locally", func() {
+		It("should copy the repository with s5cmd and point Triton at local disk", func() {
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "llm-v1", Namespace: "kalypso-system"},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{StorageURI: "s3://models/llm/"},
+			}
+			sizeLimit := resource.MustParse("200Gi")
+			app := &servingv1alpha1.KalypsoApplication{
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					Storage: &servingv1alpha1.StorageSpec{
+						SecretName:   "s3-credentials",
+						Endpoint:     "http://minio.storage:9000",
+						DownloadMode: servingv1alpha1.StorageDownloadModeLocal,
+						LocalDownload: &servingv1alpha1.LocalDownloadSpec{
+							Workers:          ptrTo(int32(64)),
+							SizeLimit:        &sizeLimit,
+							StorageClassName: ptrTo("local-nvme"),
+						},
+					},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Scheme: scheme,
+			}
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, app)).To(Succeed())
+			podSpec := deployment.Spec.Template.Spec
+			Expect(podSpec.Containers[0].Args).To(ContainElement("--model-repository=/kalypso/models/repository"))
+			Expect(podSpec.InitContainers).To(HaveLen(1))
+			Expect(podSpec.InitContainers[0].Args).To(Equal([]string{
+				"--numworkers", "64", "--endpoint-url", "http://minio.storage:9000",
+				"cp", "s3://models/llm/*", "/kalypso/models/repository/",
+			}))
+			Expect(podSpec.InitContainers[0].EnvFrom).To(HaveLen(1))
+			Expect(podSpec.Volumes).To(ContainElement(HaveField("Name", ModelDownloadVolumeName)))
+			for _, volume := range podSpec.Volumes {
+				if volume.Name == ModelDownloadVolumeName {
+					Expect(volume.Ephemeral).NotTo(BeNil())
+					Expect(volume.Ephemeral.VolumeClaimTemplate.Spec.StorageClassName).To(Equal(ptrTo("local-nvme")))
+				}
+			}
+
+			By("loading the repository from the node model cache when it holds it")
+			server.Spec.ModelCache = "gpu-nodes"
+			deployment = &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, app)).To(Succeed())
+			podSpec = deployment.Spec.Template.Spec
+			Expect(podSpec.InitContainers[0].Command).To(HaveLen(4))
+			Expect(podSpec.InitContainers[0].Env).To(ContainElement(
+				corev1.EnvVar{Name: "MODEL_CACHE_KEY", Value: modelCacheKey("s3://models/llm")}))
+			Expect(podSpec.Containers[0].VolumeMounts).To(ContainElement(
+				corev1.VolumeMount{Name: ModelCacheVolumeName, MountPath: ModelCacheMountPath, ReadOnly: true}))
+			Expect(podSpec.Volumes).To(ContainElement(HaveField("HostPath.Path", "/var/lib/kalypso/model-cache/kalypso-system/gpu-nodes")))
+			server.Spec.ModelCache = ""
+
+			By("streaming the repository in the default mode")
+			app.Spec.Storage.DownloadMode = ""
+			Expect(modelRepositoryPath(server, app)).To(Equal("s3://models/llm/"))
+		})
+	})
+
+	Context("When overriding the application storage on a server", func() {
+		It("should replace the overridden fields and keep the other application settings", func() {
+			app := &servingv1alpha1.KalypsoApplication{
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					Storage: &servingv1alpha1.StorageSpec{
+						SecretName:   "s3-credentials",
+						Endpoint:     "http://minio.storage:9000",
+						Region:       "us-east-1",
+						DownloadMode: servingv1alpha1.StorageDownloadModeLocal,
+					},
+				},
+			}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{StorageURI: "s3://vision-models/resnet/"},
+			}
+			Expect(withServerStorage(app, server)).To(BeIdenticalTo(app))
+
+			server.Spec.Storage = &servingv1alpha1.ServerStorageSpec{SecretName: "vision-credentials", Region: "eu-west-1"}
+			merged := withServerStorage(app, server)
+			Expect(merged.Spec.Storage.SecretName).To(Equal("vision-credentials"))
+			Expect(merged.Spec.Storage.Region).To(Equal("eu-west-1"))
+			Expect(merged.Spec.Storage.Endpoint).To(Equal("http://minio.storage:9000"))
+			Expect(merged.Spec.Storage.DownloadMode).To(Equal(servingv1alpha1.StorageDownloadModeLocal))
+			Expect(app.Spec.Storage.SecretName).To(Equal("s3-credentials"))
+
+			By("overriding an application without storage configuration")
+			app.Spec.Storage = nil
+			Expect(withServerStorage(app, server).Spec.Storage.SecretName).To(Equal("vision-credentials"))
+		})
+	})
+
+	Context("When configuring the S3 client options", func() {
+		It("should address the bucket path-style and pass the KMS key, CA bundle and proxy", func() {
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "llm-v1", Namespace: "kalypso-system"},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{StorageURI: "s3://models/llm/"},
+			}
+			app := &servingv1alpha1.KalypsoApplication{
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					Storage: &servingv1alpha1.StorageSpec{
+						SecretName: "s3-credentials",
+						Endpoint:   "https://minio.storage",
+						S3: &servingv1alpha1.S3OptionsSpec{
+							ForcePathStyle: true,
+							KMSKeyID:       "arn:aws:kms:eu-central-1:123456789012:key/0c1f5e2a",
+							CABundleSecret: "minio-ca",
+							ProxyURL:       "http://proxy.corp:3128",
+							NoProxy:        []string{".svc", ".cluster.local"},
+						},
+					},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Scheme: scheme,
+			}
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, app)).To(Succeed())
+			podSpec := deployment.Spec.Template.Spec
+			triton := podSpec.Containers[0]
+			Expect(triton.Args).To(ContainElement("--model-repository=s3://https://minio.storage:443/models/llm/"))
+			Expect(triton.Env).To(ContainElements(
+				corev1.EnvVar{Name: "AWS_DEFAULT_REGION", Value: "eu-central-1"},
+				corev1.EnvVar{Name: "AWS_S3_SSE_KMS_KEY_ID", Value: "arn:aws:kms:eu-central-1:123456789012:key/0c1f5e2a"},
+				corev1.EnvVar{Name: "AWS_CA_BUNDLE", Value: "/kalypso/s3-ca/ca.crt"},
+				corev1.EnvVar{Name: "https_proxy", Value: "http://proxy.corp:3128"},
+				corev1.EnvVar{Name: "no_proxy", Value: ".svc,.cluster.local"},
+			))
+			Expect(triton.VolumeMounts).To(ContainElement(
+				corev1.VolumeMount{Name: S3CABundleVolumeName, MountPath: S3CABundleMountPath, ReadOnly: true}))
+
+			By("mounting the CA bundle once in the local download mode")
+			app.Spec.Storage.DownloadMode = servingv1alpha1.StorageDownloadModeLocal
+			deployment = &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, app)).To(Succeed())
+			podSpec = deployment.Spec.Template.Spec
+			Expect(podSpec.InitContainers[0].Env).To(ContainElements(
+				corev1.EnvVar{Name: "AWS_REGION", Value: "eu-central-1"},
+				corev1.EnvVar{Name: "AWS_CA_BUNDLE", Value: "/kalypso/s3-ca/ca.crt"},
+			))
+			Expect(podSpec.InitContainers[0].VolumeMounts).To(ContainElement(HaveField("Name", S3CABundleVolumeName)))
+			caVolumes := 0
+			for _, volume := range podSpec.Volumes {
+				if volume.Name == S3CABundleVolumeName {
+					caVolumes++
+				}
+			}
+			Expect(caVolumes).To(Equal(1))
+
+			By("keeping URIs that name their endpoint")
+			Expect(s3PathStyleURI("s3://http://minio.storage:9000/models/llm", app.Spec.Storage)).To(Equal("s3://http://minio.storage:9000/models/llm"))
+		})
+	})
+
+	Context("When authenticating to the storage with a workload identity", func() {
+		It("should bind an auto-created ServiceAccount instead of injecting the credentials", func() {
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "llm-v1", Namespace: "kalypso-system"},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{StorageURI: "s3://models/llm/"},
+			}
+			app := &servingv1alpha1.KalypsoApplication{
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					Storage: &servingv1alpha1.StorageSpec{
+						SecretName: "s3-credentials",
+						Region:     "us-east-1",
+						Auth:       servingv1alpha1.StorageAuthWorkloadIdentity,
+						WorkloadIdentity: &servingv1alpha1.WorkloadIdentitySpec{
+							AWSRoleARN:    "arn:aws:iam::123456789012:role/model-reader",
+							AzureClientID: "00000000-0000-0000-0000-000000000001",
+						},
+					},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Scheme: scheme,
+			}
+
+			bound := withWorkloadIdentity(server, app)
+			Expect(server.Spec.ServiceAccount).To(BeNil())
+			Expect(bound.Spec.ServiceAccount.AutoCreate).To(BeTrue())
+			Expect(bound.Spec.ServiceAccount.Annotations).To(Equal(map[string]string{
+				AWSRoleARNAnnotation:    "arn:aws:iam::123456789012:role/model-reader",
+				AzureClientIDAnnotation: "00000000-0000-0000-0000-000000000001",
+			}))
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, bound, app)).To(Succeed())
+			podSpec := deployment.Spec.Template.Spec
+			Expect(podSpec.ServiceAccountName).To(Equal("llm-v1-sa"))
+			Expect(podSpec.Containers[0].EnvFrom).To(BeEmpty())
+			Expect(podSpec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "AWS_DEFAULT_REGION", Value: "us-east-1"}))
+			Expect(deployment.Spec.Template.Labels).To(HaveKeyWithValue(AzureWorkloadIdentityLabelKey, "true"))
+
+			Expect(reconciler.reconcileServiceAccount(ctx, bound, "llm-v1-sa")).To(Succeed())
+			serviceAccount := &corev1.ServiceAccount{}
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: server.Namespace, Name: "llm-v1-sa"}, serviceAccount)).To(Succeed())
+			Expect(serviceAccount.Annotations).To(HaveKeyWithValue(AWSRoleARNAnnotation, "arn:aws:iam::123456789012:role/model-reader"))
+
+			By("keeping a ServiceAccount named by the server")
+			server.Spec.ServiceAccount = &servingv1alpha1.ServiceAccountSpec{Name: "model-reader"}
+			Expect(withWorkloadIdentity(server, app)).To(BeIdenticalTo(server))
+		})
+	})
+
+	Context("When verifying the model repository", func() {
+		It("should check the manifest signature and checksums before Triton starts", func() {
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system"},
+				Spec:       servingv1alpha1.KalypsoTritonServerSpec{StorageURI: "s3://models/recommendation"},
+			}
+			app := &servingv1alpha1.KalypsoApplication{
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					Storage: &servingv1alpha1.StorageSpec{
+						Verification: &servingv1alpha1.ModelVerificationSpec{
+							Cosign: &servingv1alpha1.CosignVerificationSpec{PublicKeySecret: "model-signer"},
+						},
+					},
+				},
+			}
+			failed := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "recommendation-v1-7d9f",
+					Namespace: server.Namespace,
+					Labels:    map[string]string{TritonServerLabelKey: server.Name},
+				},
+				Status: corev1.PodStatus{
+					InitContainerStatuses: []corev1.ContainerStatus{{
+						Name: "model-checksums",
+						State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 1,
+							Message:  "model.onnx: FAILED\n",
+						}},
+					}},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(failed).Build(),
+				Scheme: scheme,
+			}
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, app)).To(Succeed())
+			podSpec := deployment.Spec.Template.Spec
+			Expect(podSpec.Containers[0].Args).To(ContainElement("--model-repository=/kalypso/models/repository"))
+			Expect(podSpec.InitContainers).To(HaveLen(3))
+			Expect(podSpec.InitContainers[0].Name).To(Equal("s3-download"))
+			Expect(podSpec.InitContainers[1].Name).To(Equal("model-signature"))
+			Expect(podSpec.InitContainers[1].Args).To(Equal([]string{
+				"verify-blob",
+				"--key=/kalypso/cosign/cosign.pub",
+				"--signature=/kalypso/models/repository/SHA256SUMS.sig",
+				"--insecure-ignore-tlog=true",
+				"/kalypso/models/repository/SHA256SUMS",
+			}))
+			Expect(podSpec.InitContainers[2].Name).To(Equal("model-checksums"))
+			Expect(podSpec.InitContainers[2].VolumeMounts).To(ConsistOf(
+				corev1.VolumeMount{Name: ModelDownloadVolumeName, MountPath: ModelDownloadMountPath, ReadOnly: true}))
+
+			By("reporting the pods failing the verification")
+			result := reconciler.evaluateVerification(context.Background(), server, app, 0)
+			applyVerificationStatus(server, result)
+			condition := meta.FindStatusCondition(server.Status.Conditions, "Verified")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal("VerificationFailed"))
+			Expect(condition.Message).To(Equal("recommendation-v1-7d9f: model.onnx: FAILED"))
+
+			By("refusing repositories Triton reads remotely")
+			server.Spec.StorageURI = "gs://models/recommendation"
+			Expect(verificationUnsupported(server, app)).NotTo(BeEmpty())
+		})
+	})
+
+	Context("When building the provenance EnvoyFilter", func() {
+		It("should add the provenance headers to inbound responses", func() {
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Generation: 3},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					ProvenanceHeaders: &servingv1alpha1.ProvenanceHeadersSpec{Enabled: true},
+				},
+			}
+
+			spec := &unstructured.Unstructured{Object: buildProvenanceFilterSpec(server)}
+
+			patches, _, _ := unstructured.NestedSlice(spec.Object, "configPatches")
+			Expect(patches).To(HaveLen(1))
+			headers, _, _ := unstructured.NestedSlice(patches[0].(map[string]interface{}), "patch", "value", "response_headers_to_add")
+			Expect(headers).To(ContainElements(
+				HaveKeyWithValue("header", map[string]interface{}{"key": ModelHeader, "value": "recommendation-v1"}),
+				HaveKeyWithValue("header", map[string]interface{}{"key": ModelVersionHeader, "value": "3"}),
+				HaveKeyWithValue("header", map[string]interface{}{"key": ServedByHeader, "value": "%HOSTNAME%"}),
+			))
+		})
+	})
+
+	Context("When propagating model lineage", func() {
+		It("should add the set lineage fields to pod labels, target labels, and trace attributes", func() {
+			metadata := &servingv1alpha1.ModelMetadataSpec{TrainingRunID: "run-42", GitCommit: "3f9c2ab"}
+			selector := map[string]string{TritonServerLabelKey: "recommendation-v1"}
+
+			podLabels := buildPodLabels(selector, map[string]string{TritonServerLabelKey: "spoofed", "team": "search"}, metadata)
+
+			Expect(podLabels).To(HaveKeyWithValue(TritonServerLabelKey, "recommendation-v1"))
+			Expect(podLabels).To(HaveKeyWithValue(TrainingRunLabelKey, "run-42"))
+			Expect(podLabels).To(HaveKeyWithValue(GitCommitLabelKey, "3f9c2ab"))
+			Expect(podLabels).NotTo(HaveKey(DatasetVersionLabelKey))
+			Expect(podLabels).To(HaveKeyWithValue("team", "search"))
+			Expect(selector).To(HaveLen(1), "the selector labels are not modified")
+			Expect(lineageLabelKeys(metadata)).To(ConsistOf(TrainingRunLabelKey, GitCommitLabelKey))
+			Expect(buildLineageTraceArgs(metadata)).To(ConsistOf(
+				"--trace-config=opentelemetry,resource=kalypso.training_run_id=run-42",
+				"--trace-config=opentelemetry,resource=kalypso.git_commit=3f9c2ab",
+			))
+		})
+	})
+
+	Context("When propagating traces into Python backends", func() {
+		It("should configure the OpenTelemetry SDK and mount the helper module", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system", UID: "server-uid"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					Metadata: &servingv1alpha1.ModelMetadataSpec{GitCommit: "4f2c1a9"},
+					Observability: &servingv1alpha1.ObservabilitySpec{
+						Enabled:           true,
+						CollectorEndpoint: "http://otel-collector:4318/v1/traces",
+						Tracing:           &servingv1alpha1.TracingSpec{Enabled: true, SamplingRate: "0.5", PythonBackendPropagation: true},
+					},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build(),
+				Scheme: scheme,
+			}
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, &servingv1alpha1.KalypsoApplication{})).To(Succeed())
+			container := deployment.Spec.Template.Spec.Containers[0]
+			Expect(container.Env).To(ContainElements(
+				corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", Value: "http://otel-collector:4318/v1/traces"},
+				corev1.EnvVar{Name: "OTEL_TRACES_SAMPLER_ARG", Value: "0.5"},
+				corev1.EnvVar{Name: "OTEL_RESOURCE_ATTRIBUTES", Value: "kalypso.git_commit=4f2c1a9"},
+				corev1.EnvVar{Name: "PYTHONPATH", Value: TracingHelperMountPath},
+			))
+			Expect(container.VolumeMounts).To(ContainElement(HaveField("MountPath", TracingHelperMountPath)))
+
+			name := naming.TracingHelper(server.Name)
+			Expect(reconciler.reconcileTracingHelper(ctx, server, name)).To(Succeed())
+			configMap := &corev1.ConfigMap{}
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: server.Namespace, Name: name}, configMap)).To(Succeed())
+			Expect(configMap.Data[TracingHelperModuleFile]).To(ContainSubstring("def start_span(request, name"))
+
+			server.Spec.Observability.Tracing.PythonBackendPropagation = false
+			Expect(reconciler.reconcileTracingHelper(ctx, server, name)).To(Succeed())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(configMap), configMap))).To(BeTrue())
+		})
+	})
+
+	Context("When merging environment variables", func() {
+		It("should let user variables override injected ones", func() {
+			injected := []corev1.EnvVar{{Name: "AWS_DEFAULT_REGION", Value: "ap-northeast-2"}}
+
+			env := mergeEnvVars(injected, []corev1.EnvVar{
+				{Name: "AWS_DEFAULT_REGION", Value: "us-east-1"},
+				{Name: "HTTPS_PROXY", Value: "http://proxy:3128"},
+			})
+
+			Expect(env).To(Equal([]corev1.EnvVar{
+				{Name: "AWS_DEFAULT_REGION", Value: "us-east-1"},
+				{Name: "HTTPS_PROXY", Value: "http://proxy:3128"},
+			}))
+		})
+	})
+
+	Context("When building security contexts", func() {
+		It("should default to the restricted Pod Security Standard", func() {
+			pod := buildPodSecurityContext(nil)
+			Expect(*pod.RunAsNonRoot).To(BeTrue())
+			Expect(*pod.RunAsUser).To(Equal(TritonUserID))
+			Expect(pod.SeccompProfile.Type).To(Equal(corev1.SeccompProfileTypeRuntimeDefault))
+
+			container := buildContainerSecurityContext(nil)
+			Expect(*container.AllowPrivilegeEscalation).To(BeFalse())
+			Expect(container.Capabilities.Drop).To(ConsistOf(corev1.Capability("ALL")))
+		})
+
+		It("should use the configured contexts as is", func() {
+			configured := &corev1.PodSecurityContext{RunAsUser: ptrTo(int64(2000))}
+
+			pod := buildPodSecurityContext(configured)
+
+			Expect(pod).To(Equal(configured))
+			Expect(pod).NotTo(BeIdenticalTo(configured))
+		})
+	})
+
+	Context("When scheduling Triton pods", func() {
+		It("should merge the user node selector over the GPU node selector", func() {
+			server := &servingv1alpha1.KalypsoTritonServer{}
+			Expect(buildNodeSelector(server)).To(BeNil())
+
+			server.Spec.GPU = &servingv1alpha1.GPUSpec{
+				MIG: &servingv1alpha1.MIGSpec{Profile: "1g.10gb"},
+			}
+			server.Spec.Scheduling = &servingv1alpha1.SchedulingSpec{
+				NodeSelector: map[string]string{
+					"cloud.google.com/gke-nodepool": "gpu-pool",
+					MIGStrategyLabelKey:             "single",
+				},
+			}
+
+			Expect(buildNodeSelector(server)).To(Equal(map[string]string{
+				"cloud.google.com/gke-nodepool": "gpu-pool",
+				MIGStrategyLabelKey:             "single",
+			}), "user labels take precedence")
+			Expect(server.Spec.Scheduling.NodeSelector).To(HaveLen(2))
+		})
+
+		It("should apply tolerations, affinity and topology spread constraints to the pods", func() {
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			affinity := &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						TopologyKey:   "kubernetes.io/hostname",
+						LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{TritonServerLabelKey: "recommendation-v1"}},
+					},
+				}},
+			}}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					StorageURI: "s3://models/recommendation",
+					Scheduling: &servingv1alpha1.SchedulingSpec{
+						NodeSelector: map[string]string{"cloud.google.com/gke-nodepool": "gpu-pool"},
+						Tolerations: []corev1.Toleration{
+							{Key: GPUResourceName, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+						},
+						Affinity: affinity,
+						TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+							{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.ScheduleAnyway},
+						},
+					},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Scheme: scheme,
+			}
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, &servingv1alpha1.KalypsoApplication{})).To(Succeed())
+			podSpec := deployment.Spec.Template.Spec
+			Expect(podSpec.NodeSelector).To(Equal(map[string]string{"cloud.google.com/gke-nodepool": "gpu-pool"}))
+			Expect(podSpec.Tolerations).To(Equal(server.Spec.Scheduling.Tolerations))
+			Expect(podSpec.Affinity).To(Equal(affinity))
+			Expect(podSpec.Affinity).NotTo(BeIdenticalTo(affinity))
+			Expect(podSpec.TopologySpreadConstraints).To(HaveLen(1))
+			Expect(podSpec.TopologySpreadConstraints[0].LabelSelector.MatchLabels).To(Equal(deployment.Spec.Selector.MatchLabels))
+
+			server.Spec.Scheduling = nil
+			deployment = &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateDeployment(deployment, server, &servingv1alpha1.KalypsoApplication{})).To(Succeed())
+			podSpec = deployment.Spec.Template.Spec
+			Expect(podSpec.NodeSelector).To(BeEmpty())
+			Expect(podSpec.Tolerations).To(BeEmpty())
+			Expect(podSpec.Affinity).To(BeNil())
+			Expect(podSpec.TopologySpreadConstraints).To(BeEmpty())
+		})
+	})
+
+	Context("When building topology spread constraints", func() {
+		It("should default the label selector to the server pods", func() {
+			labels := map[string]string{TritonServerLabelKey: "recommendation-v1"}
+			constraints := []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.ScheduleAnyway},
+				{
+					MaxSkew:           1,
+					TopologyKey:       "kubernetes.io/hostname",
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+					LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "custom"}},
+				},
+			}
+
+			result := buildTopologySpreadConstraints(constraints, labels)
+
+			Expect(result).To(HaveLen(2))
+			Expect(result[0].LabelSelector.MatchLabels).To(Equal(labels))
+			Expect(result[1].LabelSelector.MatchLabels).To(HaveKeyWithValue("app", "custom"))
+			Expect(constraints[0].LabelSelector).To(BeNil())
+		})
+	})
+
+	Context("When building the PodDisruptionBudget", func() {
+		It("should default to one unavailable replica", func() {
+			server := &servingv1alpha1.KalypsoTritonServer{ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1"}}
+
+			spec := buildPodDisruptionBudgetSpec(server, false)
+
+			Expect(spec.MinAvailable).To(BeNil())
+			Expect(spec.MaxUnavailable).To(Equal(ptrTo(intstr.FromInt32(1))))
+			Expect(spec.Selector.MatchLabels).To(HaveKeyWithValue(TritonServerLabelKey, "recommendation-v1"))
+		})
+
+		It("should use the configured minAvailable", func() {
+			minAvailable := intstr.FromString("50%")
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					Availability: &servingv1alpha1.AvailabilitySpec{MinAvailable: &minAvailable},
+				},
+			}
+
+			spec := buildPodDisruptionBudgetSpec(server, false)
+
+			Expect(spec.MinAvailable).To(Equal(&minAvailable))
+			Expect(spec.MaxUnavailable).To(BeNil())
+		})
+	})
+
+	Context("When holding spec changes under the Manual change policy", func() {
+		It("should record a pending plan instead of updating the Deployment", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system"},
+			}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system", Generation: 2},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					ApplicationRef: app.Name,
+					Replicas:       ptrTo(int32(3)),
+					ChangePolicy:   servingv1alpha1.ChangePolicyManual,
+				},
+				Status: servingv1alpha1.KalypsoTritonServerStatus{AppliedGeneration: 1},
+			}
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: naming.Deployment(server.Name), Namespace: server.Namespace},
+				Spec:       appsv1.DeploymentSpec{Replicas: ptrTo(int32(1))},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).
+					WithObjects(app, server, deployment).
+					WithStatusSubresource(server).
+					Build(),
+				Scheme: scheme,
+			}
+			specHash := renderedSpecHash(server)
+			Expect(planPending(server, specHash)).To(BeTrue())
+
+			held, err := reconciler.reconcilePlan(ctx, server, app, specHash)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(held).To(BeTrue())
+			Expect(server.Status.PendingPlan.Generation).To(Equal(int64(2)))
+			Expect(server.Status.PendingPlan.Diff).To(ContainSubstring("Deployment/recommendation-v1"))
+			Expect(server.Status.PendingPlan.Diff).To(ContainSubstring(`"replicas": 3`))
+
+			current := &appsv1.Deployment{}
+			Expect(reconciler.Get(ctx, client.ObjectKeyFromObject(deployment), current)).To(Succeed())
+			Expect(*current.Spec.Replicas).To(Equal(int32(1)))
+
+			server.Annotations = map[string]string{PlanApprovalAnnotation: "2"}
+			Expect(planPending(server, specHash)).To(BeFalse())
+		})
+
+		It("should hold a change that leaves the workload and Service as they are", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system"},
+			}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system", Generation: 2},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					ApplicationRef: app.Name,
+					ChangePolicy:   servingv1alpha1.ChangePolicyManual,
+					Networking: &servingv1alpha1.NetworkingSpec{
+						Ingress: &servingv1alpha1.IngressSpec{Host: "recommendation.example.com"},
+					},
+				},
+				Status: servingv1alpha1.KalypsoTritonServerStatus{AppliedGeneration: 1},
+			}
+			reconciler := &KalypsoTritonServerReconciler{Scheme: scheme}
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: naming.Deployment(server.Name), Namespace: server.Namespace},
+			}
+			Expect(reconciler.mutateDeployment(deployment, server, app)).To(Succeed())
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: naming.Service(server.Name), Namespace: server.Namespace},
+			}
+			Expect(reconciler.mutateService(service, server)).To(Succeed())
+			reconciler.Client = fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(app, server, deployment, service).
+				WithStatusSubresource(server).
+				Build()
+
+			specHash := renderedSpecHash(server)
+			held, err := reconciler.reconcilePlan(ctx, server, app, specHash)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(held).To(BeTrue(), "only the Ingress changes, which the diff does not render")
+			Expect(server.Status.PendingPlan.Diff).To(Equal(unrenderedPlanDiff))
+		})
+
+		It("should hold changed application defaults of an applied generation", func() {
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ServerDefaults: &servingv1alpha1.ServerDefaultsSpec{
+						Scheduling: &servingv1alpha1.SchedulingSpec{NodeSelector: map[string]string{"pool": "gpu-a"}},
+					},
+				},
+			}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system", Generation: 2},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					ApplicationRef: app.Name,
+					ChangePolicy:   servingv1alpha1.ChangePolicyManual,
+				},
+			}
+			applied := renderedSpecHash(defaults.WithApplicationDefaults(server, app))
+			server.Status = servingv1alpha1.KalypsoTritonServerStatus{AppliedGeneration: 2, AppliedSpecHash: applied}
+			Expect(planPending(server, applied)).To(BeFalse())
+
+			app.Spec.ServerDefaults.Scheduling.NodeSelector["pool"] = "gpu-b"
+			changed := renderedSpecHash(defaults.WithApplicationDefaults(server, app))
+			Expect(changed).NotTo(Equal(applied))
+			Expect(planPending(server, changed)).To(BeTrue(), "the generation is unchanged")
+
+			server.Annotations = map[string]string{PlanApprovalAnnotation: "2"}
+			Expect(planPending(server, changed)).To(BeTrue(), "the applied generation does not approve new defaults")
+			server.Annotations = map[string]string{PlanApprovalAnnotation: changed}
+			Expect(planPending(server, changed)).To(BeFalse())
+
+			server.Status.AppliedSpecHash = ""
+			server.Annotations = nil
+			Expect(planPending(server, changed)).To(BeFalse(), "servers applied before the hash was recorded are not held")
+		})
+	})
+
+	Context("When checking the target architecture", func() {
+		arm64Affinity := &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: corev1.LabelArchStable, Operator: corev1.NodeSelectorOpIn, Values: []string{"arm64"}},
+				}}},
+			},
+		}}
+
+		It("should flag a TensorRT backend scheduled to arm64 nodes without GPUs", func() {
+			server := &servingv1alpha1.KalypsoTritonServer{
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					TritonConfig: servingv1alpha1.TritonConfigSpec{BackendType: "tensorrt"},
+					Scheduling:   &servingv1alpha1.SchedulingSpec{Affinity: arm64Affinity},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{}
+
+			Expect(targetArchitecture(server)).To(Equal("arm64"))
+			condition := reconciler.checkArchitecture(context.Background(), server)
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("TensorRTUnsupported"))
+		})
+
+		It("should flag images not published for the target architecture", func() {
+			Expect(features.Gate.Set("ImageArchitectureCheck=true")).To(Succeed())
+			DeferCleanup(features.Gate.Set, "ImageArchitectureCheck=false")
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					TritonConfig: servingv1alpha1.TritonConfigSpec{Image: "registry.example.com/triton-custom"},
+					Scheduling: &servingv1alpha1.SchedulingSpec{
+						NodeSelector: map[string]string{corev1.LabelArchStable: "arm64"},
+					},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				ImageResolver: staticResolver{"registry.example.com/triton-custom:24.12-py3": {"amd64"}},
+			}
+
+			condition := reconciler.checkArchitecture(context.Background(), server)
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("ImageArchitectureUnsupported"))
+		})
+	})
+
+	Context("When auto-creating the ServiceAccount", func() {
+		It("should create an owned ServiceAccount with the workload identity annotations", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system", UID: "server-uid"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					ServiceAccount: &servingv1alpha1.ServiceAccountSpec{
+						AutoCreate:  true,
+						Annotations: map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/triton"},
+					},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build(),
+				Scheme: scheme,
+			}
+
+			Expect(reconciler.reconcileServiceAccount(ctx, server, naming.ServiceAccount(server.Name))).To(Succeed())
+
+			serviceAccount := &corev1.ServiceAccount{}
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: server.Namespace, Name: serviceAccountName(server)}, serviceAccount)).To(Succeed())
+			Expect(serviceAccount.Name).To(Equal("recommendation-v1-sa"))
+			Expect(serviceAccount.Annotations).To(HaveKeyWithValue("eks.amazonaws.com/role-arn", "arn:aws:iam::123456789012:role/triton"))
+			Expect(metav1.IsControlledBy(serviceAccount, server)).To(BeTrue())
+
+			server.Spec.ServiceAccount = nil
+			Expect(reconciler.reconcileServiceAccount(ctx, server, naming.ServiceAccount(server.Name))).To(Succeed())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(serviceAccount), serviceAccount))).To(BeTrue())
+		})
+	})
+
+	Context("When separating the metrics Service", func() {
+		It("should move the metrics port to a headless Service excluded from the mesh", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system", UID: "server-uid"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					Networking: &servingv1alpha1.NetworkingSpec{
+						MetricsService: &servingv1alpha1.MetricsServiceSpec{Enabled: true},
+					},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build(),
+				Scheme: scheme,
+			}
+
+			main := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+			Expect(reconciler.mutateService(main, server)).To(Succeed())
+			Expect(main.Spec.Ports).To(HaveLen(2))
+
+			Expect(reconciler.reconcileMetricsService(ctx, server, naming.MetricsService(server.Name))).To(Succeed())
+			metrics := &corev1.Service{}
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: server.Namespace, Name: "recommendation-v1-metrics"}, metrics)).To(Succeed())
+			Expect(metrics.Spec.ClusterIP).To(Equal(corev1.ClusterIPNone))
+			Expect(metrics.Spec.Ports).To(ConsistOf(HaveField("Port", int32(8002))))
+
+			annotations := applyMetricsPortExclusion(map[string]string{IstioExcludeInboundPortsAnnotation: "9090"}, server)
+			Expect(annotations).To(HaveKeyWithValue(IstioExcludeInboundPortsAnnotation, "9090,8002"))
+
+			server.Spec.Networking.MetricsService = nil
+			Expect(reconciler.reconcileMetricsService(ctx, server, naming.MetricsService(server.Name))).To(Succeed())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(metrics), metrics))).To(BeTrue())
+		})
+	})
+
+	Context("When exposing the Service outside the cluster", func() {
+		It("should apply the Service type, load balancer class and annotations", func() {
+			className := "metallb"
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					Networking: &servingv1alpha1.NetworkingSpec{
+						ServiceType:        corev1.ServiceTypeLoadBalancer,
+						LoadBalancerClass:  &className,
+						ServiceAnnotations: map[string]string{"metallb.universe.tf/address-pool": "inference"},
+					},
+				},
+			}
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+			reconciler := &KalypsoTritonServerReconciler{Scheme: scheme}
+
+			service := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Name: "grpc", Port: 8001, NodePort: 31001}},
+				},
+			}
+			Expect(reconciler.mutateService(service, server)).To(Succeed())
+			Expect(service.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer))
+			Expect(service.Spec.LoadBalancerClass).To(Equal(&className))
+			Expect(service.Annotations).To(HaveKeyWithValue("metallb.universe.tf/address-pool", "inference"))
+			Expect(service.Spec.Ports).To(ContainElement(And(HaveField("Name", "grpc"), HaveField("NodePort", int32(31001)))))
+
+			server.Spec.Networking.ServiceType = corev1.ServiceTypeClusterIP
+			Expect(reconciler.mutateService(service, server)).To(Succeed())
+			Expect(service.Spec.Type).To(Equal(corev1.ServiceTypeClusterIP))
+			Expect(service.Spec.LoadBalancerClass).To(BeNil())
+			Expect(service.Spec.Ports).To(HaveEach(HaveField("NodePort", int32(0))))
+		})
+	})
+
+	Context("When isolating the Triton pods", func() {
+		It("should only admit the gateway to the inference ports and Prometheus to the metrics port", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					Routing: &servingv1alpha1.RoutingSpec{IngressNamespace: "istio-ingress"},
+				},
+			}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system", UID: "server-uid"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					ApplicationRef: app.Name,
+					Networking: &servingv1alpha1.NetworkingSpec{
+						NetworkPolicy: &servingv1alpha1.NetworkPolicySpec{Enabled: true, AllowedNamespaces: []string{"batch-jobs"}},
+					},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build(),
+				Scheme: scheme,
+			}
+
+			Expect(reconciler.reconcileNetworkPolicy(ctx, server, app, naming.NetworkPolicy(server.Name))).To(Succeed())
+			policy := &networkingv1.NetworkPolicy{}
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: server.Namespace, Name: "recommendation-v1-netpol"}, policy)).To(Succeed())
+			Expect(policy.Spec.PodSelector.MatchLabels).To(HaveKeyWithValue(TritonServerLabelKey, "recommendation-v1"))
+			Expect(policy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress))
+			Expect(policy.Spec.Ingress).To(HaveLen(2))
+
+			namespaces := func(rule networkingv1.NetworkPolicyIngressRule) []string {
+				var names []string
+				for _, peer := range rule.From {
+					names = append(names, peer.NamespaceSelector.MatchLabels[corev1.LabelMetadataName])
+				}
+				return names
+			}
+			ports := func(rule networkingv1.NetworkPolicyIngressRule) []int32 {
+				var numbers []int32
+				for _, port := range rule.Ports {
+					numbers = append(numbers, port.Port.IntVal)
+				}
+				return numbers
+			}
+			Expect(namespaces(policy.Spec.Ingress[0])).To(Equal([]string{"batch-jobs", "istio-ingress"}))
+			Expect(ports(policy.Spec.Ingress[0])).To(Equal([]int32{8000, 8001}))
+			Expect(namespaces(policy.Spec.Ingress[1])).To(Equal([]string{"monitoring"}))
+			Expect(ports(policy.Spec.Ingress[1])).To(Equal([]int32{8002}))
+
+			server.Spec.Networking.NetworkPolicy.Enabled = false
+			Expect(reconciler.reconcileNetworkPolicy(ctx, server, app, naming.NetworkPolicy(server.Name))).To(Succeed())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(policy), policy))).To(BeTrue())
+		})
+	})
+
+	Context("When exposing the HTTP endpoint through an Ingress", func() {
+		It("should route the host to the HTTP port and remove the Ingress once disabled", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			className := "nginx"
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system", UID: "server-uid"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					Networking: &servingv1alpha1.NetworkingSpec{
+						Ingress: &servingv1alpha1.IngressSpec{
+							Host:             "recommend.example.com",
+							Path:             "/v1",
+							IngressClassName: &className,
+							TLSSecretName:    "recommend-tls",
+						},
+					},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(server).Build(),
+				Scheme: scheme,
+			}
+
+			Expect(reconciler.reconcileIngress(ctx, server, naming.Ingress(server.Name))).To(Succeed())
+			ingress := &networkingv1.Ingress{}
+			Expect(reconciler.Get(ctx, client.ObjectKey{Namespace: server.Namespace, Name: "recommendation-v1-ingress"}, ingress)).To(Succeed())
+			Expect(ingress.Spec.IngressClassName).To(Equal(&className))
+			Expect(ingress.Spec.TLS).To(ConsistOf(networkingv1.IngressTLS{Hosts: []string{"recommend.example.com"}, SecretName: "recommend-tls"}))
+			Expect(ingress.Spec.Rules).To(HaveLen(1))
+			Expect(ingress.Spec.Rules[0].Host).To(Equal("recommend.example.com"))
+			path := ingress.Spec.Rules[0].HTTP.Paths[0]
+			Expect(path.Path).To(Equal("/v1"))
+			Expect(path.Backend.Service.Name).To(Equal("recommendation-v1-svc"))
+			Expect(path.Backend.Service.Port.Name).To(Equal("http"))
+
+			server.Spec.Networking.Ingress = nil
+			Expect(reconciler.reconcileIngress(ctx, server, naming.Ingress(server.Name))).To(Succeed())
+			Expect(errors.IsNotFound(reconciler.Get(ctx, client.ObjectKeyFromObject(ingress), ingress))).To(BeTrue())
+		})
+	})
+
+	Context("When reserving capacity for scale-ups", func() {
+		It("should size the placeholder pods like a Triton replica", func() {
+			scheme := runtime.NewScheme()
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					GPU: &servingv1alpha1.GPUSpec{Count: ptrTo(int32(1))},
+					Scheduling: &servingv1alpha1.SchedulingSpec{
+						NodeSelector:    map[string]string{"pool": "gpu"},
+						SchedulerName:   "volcano",
+						SchedulingGates: []corev1.PodSchedulingGate{{Name: "example.com/gang"}},
+					},
+					CapacityReservation: &servingv1alpha1.CapacityReservationSpec{Replicas: 2},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{Scheme: scheme}
+
+			podSpec, err := reconciler.buildCapacityReservationPodSpec(server, &servingv1alpha1.KalypsoApplication{})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podSpec.PriorityClassName).To(Equal(CapacityReservationPriorityClass))
+			Expect(podSpec.NodeSelector).To(HaveKeyWithValue("pool", "gpu"))
+			Expect(podSpec.SchedulerName).To(Equal("volcano"), "the Triton pods' scheduler preempts the placeholders")
+			Expect(podSpec.SchedulingGates).To(BeEmpty(), "placeholders are not admitted by the batch queue")
+			Expect(podSpec.Containers).To(HaveLen(1))
+			Expect(podSpec.Containers[0].Image).To(Equal(CapacityReservationImage))
+			Expect(podSpec.Containers[0].Resources.Limits.Name(GPUResourceName, resource.DecimalSI).Value()).To(Equal(int64(1)))
+		})
+	})
+
+	Context("When the GPUs exceed the project quota", func() {
+		It("should deploy the degraded profile until the GPUs fit again", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			quota := &corev1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project-quota", Namespace: "kalypso-system"},
+				Spec:       corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{"requests.nvidia.com/gpu": resource.MustParse("4")}},
+				Status:     corev1.ResourceQuotaStatus{Used: corev1.ResourceList{"requests.nvidia.com/gpu": resource.MustParse("3")}},
+			}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "kalypso-system"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					Replicas: ptrTo(int32(2)),
+					GPU:      &servingv1alpha1.GPUSpec{Count: ptrTo(int32(1))},
+					DegradedProfile: &servingv1alpha1.DegradedProfileSpec{
+						Resources:    &corev1.ResourceRequirements{Limits: corev1.ResourceList{"nvidia.com/gpu.shared": resource.MustParse("1")}},
+						NodeSelector: map[string]string{"nvidia.com/gpu.sharing-strategy": "time-slicing"},
+					},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(quota).Build(),
+				Scheme: scheme,
+			}
+
+			profile, err := reconciler.evaluateCapacityProfile(ctx, server)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profile.degraded).To(BeTrue())
+			Expect(profile.message).To(ContainSubstring("leaves 1"))
+
+			deployed := withCapacityProfile(server, profile)
+			Expect(deployed.Spec.GPU).To(BeNil())
+			Expect(deployed.Spec.Scheduling.NodeSelector).To(HaveKeyWithValue("nvidia.com/gpu.sharing-strategy", "time-slicing"))
+			Expect(server.Spec.GPU).NotTo(BeNil(), "the server itself is not modified")
+
+			applyCapacityProfileStatus(server, profile)
+			Expect(meta.IsStatusConditionTrue(server.Status.Conditions, "RunningDegradedCapacity")).To(BeTrue())
+
+			// A replica already holding a whole GPU releases it on the next rollout
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: naming.Deployment(server.Name), Namespace: server.Namespace},
+				Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+					Name:      "tritonserver",
+					Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{GPUResourceName: resource.MustParse("1")}},
+				}}}}},
+				Status: appsv1.DeploymentStatus{Replicas: 1},
+			}
+			Expect(reconciler.Create(ctx, deployment)).To(Succeed())
+
+			profile, err = reconciler.evaluateCapacityProfile(ctx, server)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profile.degraded).To(BeFalse())
+			Expect(withCapacityProfile(server, profile)).To(BeIdenticalTo(server))
+		})
+	})
+
+	Context("When evacuating nodes", func() {
+		It("should surge replacements and keep every desired replica available", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "recommendation-v1",
+					Namespace:   "kalypso-system",
+					Annotations: map[string]string{EvacuateNodesAnnotation: "node-a, node-b"},
+				},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{Replicas: ptrTo(int32(3))},
+			}
+			pod := func(name, node string) *corev1.Pod {
+				return &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: server.Namespace, Labels: map[string]string{TritonServerLabelKey: server.Name}},
+					Spec:       corev1.PodSpec{NodeName: node},
+				}
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).
+					WithObjects(pod("pod-1", "node-a"), pod("pod-2", "node-b"), pod("pod-3", "node-c")).
+					Build(),
+			}
+
+			evacuation, err := reconciler.evaluateEvacuation(ctx, server)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(evacuation.nodes).To(Equal([]string{"node-a", "node-b"}))
+			Expect(evacuation.surge()).To(Equal(int32(2)))
+
+			spec := buildPodDisruptionBudgetSpec(server, true)
+			Expect(spec.MinAvailable).To(Equal(ptrTo(intstr.FromInt32(3))))
+			Expect(spec.MaxUnavailable).To(BeNil())
+		})
+	})
+
+	Context("When evaluating the retraining hook", func() {
+		It("should not query Prometheus again within the evaluation interval", func() {
+			ctx := context.Background()
+			Expect(features.Gate.Set("RetrainingHook=true")).To(Succeed())
+			DeferCleanup(features.Gate.Set, "RetrainingHook=false")
+
+			var queries, events atomic.Int32
+			prometheus := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				queries.Add(1)
+				_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"scalar","result":[0,"0.4"]}}`))
+			}))
+			DeferCleanup(prometheus.Close)
+			sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				events.Add(1)
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			DeferCleanup(sink.Close)
+
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation", Namespace: "default"},
+				Spec:       servingv1alpha1.KalypsoApplicationSpec{ProjectRef: "sample-project"},
+			}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "default"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					ApplicationRef: app.Name,
+					RetrainingHook: &servingv1alpha1.RetrainingHookSpec{
+						Enabled:            true,
+						PrometheusURL:      prometheus.URL,
+						SinkURI:            sink.URL,
+						EvaluationInterval: "5m",
+						Triggers: []servingv1alpha1.RetrainingTrigger{
+							{Name: "feature-drift", Type: "Drift", Query: "max(feature_psi)", Threshold: "0.2"},
+						},
+					},
+				},
+			}
+			reconciler := &KalypsoTritonServerReconciler{
+				MetricsQuerier: retraining.NewPrometheusQuerier(),
+				EventSender:    retraining.NewHTTPSender(),
+			}
+
+			result := reconciler.evaluateRetrainingHook(ctx, server, app)
+			Expect(result.triggersEvaluated()).To(BeTrue())
+			Expect(result.firing).To(ConsistOf("feature-drift"))
+			Expect(queries.Load()).To(Equal(int32(1)))
+			Expect(events.Load()).To(Equal(int32(1)))
+			applyRetrainingStatus(server, result)
+
+			By("reconciling again within the interval")
+			result = reconciler.evaluateRetrainingHook(ctx, server, app)
+			Expect(result.triggersEvaluated()).To(BeFalse())
+			Expect(result.firing).To(ConsistOf("feature-drift"))
+			Expect(result.statuses).To(Equal(server.Status.RetrainingTriggers))
+			Expect(result.requeueAfter).To(BeNumerically("<=", 5*time.Minute))
+			Expect(queries.Load()).To(Equal(int32(1)))
+
+			By("reconciling once the interval has passed")
+			evaluated := metav1.NewTime(time.Now().Add(-5 * time.Minute))
+			server.Status.RetrainingTriggers[0].LastEvaluationTime = &evaluated
+			result = reconciler.evaluateRetrainingHook(ctx, server, app)
+			Expect(result.triggersEvaluated()).To(BeTrue())
+			Expect(queries.Load()).To(Equal(int32(2)))
+			// The cooldown still holds back a second event
+			Expect(events.Load()).To(Equal(int32(1)))
+		})
+
+		It("should report a retraining hook left unevaluated by the disabled feature gate", func() {
+			ctx := context.Background()
+			querier := &stubQuerier{values: map[string]float64{"istio_requests_total": 1}}
+			reconciler := &KalypsoTritonServerReconciler{MetricsQuerier: querier, EventSender: retraining.NewHTTPSender()}
+			server := &servingv1alpha1.KalypsoTritonServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "default"},
+				Spec: servingv1alpha1.KalypsoTritonServerSpec{
+					RetrainingHook: &servingv1alpha1.RetrainingHookSpec{
+						Enabled: true,
+						Triggers: []servingv1alpha1.RetrainingTrigger{
+							{Name: "error-budget", Type: "SLOBurn", Query: "sum(rate(istio_requests_total[5m]))", Threshold: "0.5"},
+						},
+					},
+				},
+			}
+
+			result := reconciler.evaluateRetrainingHook(ctx, server, &servingv1alpha1.KalypsoApplication{})
+			Expect(result).To(BeNil())
+			applyRetrainingStatus(server, result)
+			condition := meta.FindStatusCondition(server.Status.Conditions, "RetrainingHook")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("FeatureGateDisabled"))
+
+			By("dropping the condition once the hook is disabled")
+			server.Spec.RetrainingHook.Enabled = false
+			applyRetrainingStatus(server, nil)
+			Expect(meta.FindStatusCondition(server.Status.Conditions, "RetrainingHook")).To(BeNil())
+		})
+	})
+
+	Context("When configuring the manager cache", func() {
+		It("should only cache managed child resources", func() {
+			opts := NewCacheOptions()
+
+			Expect(opts.DefaultTransform).NotTo(BeNil())
+			for obj, byObject := range opts.ByObject {
+				if _, ok := obj.(*corev1.Secret); ok {
+					Expect(byObject.Label).To(BeNil())
+					Expect(byObject.Transform).NotTo(BeNil())
+					continue
+				}
+				Expect(byObject.Label.String()).To(Equal(ManagedByLabelKey+"="+ManagedByLabelValue), "%T", obj)
+			}
+		})
+	})
 })
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
+
+// staticResolver returns fixed image architectures keyed by image reference
+type staticResolver map[string][]string
+
+func (s staticResolver) Architectures(_ context.Context, image string) ([]string, error) {
+	return s[image], nil
+}
+
+// staticModelRegistry returns a fixed model version and records the request
+type staticModelRegistry struct {
+	version     *mlflow.ModelVersion
+	err         error
+	baseURL     string
+	credentials mlflow.Credentials
+}
+
+func (s *staticModelRegistry) LatestVersion(_ context.Context, baseURL string, credentials mlflow.Credentials, _, _ string) (*mlflow.ModelVersion, error) {
+	s.baseURL = baseURL
+	s.credentials = credentials
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.version, nil
+}
+
+// staticDigests maps artifact references to their manifest digests
+type staticDigests map[string]string
+
+func (s staticDigests) Digest(_ context.Context, reference string) (string, error) {
+	digest, ok := s[reference]
+	if !ok {
+		return "", fmt.Errorf("manifest unknown")
+	}
+	return digest, nil
+}
+
+// staticImageVerifier returns fixed verified digests keyed by image and records the policy
+type staticImageVerifier struct {
+	digests map[string]string
+	policy  imagesig.Policy
+}
+
+func (s *staticImageVerifier) Verify(_ context.Context, image string, policy imagesig.Policy) (string, error) {
+	s.policy = policy
+	digest, ok := s.digests[image]
+	if !ok {
+		return "", fmt.Errorf("%w for %s", imagesig.ErrSignatureNotFound, image)
+	}
+	return digest, nil
+}
+
+// staticModelIndex returns a fixed repository index, records the queried endpoint and the
+// configurations models are loaded with
+type staticModelIndex struct {
+	models   []triton.Model
+	err      error
+	endpoint string
+	loaded   map[string]map[string]interface{}
+}
+
+func (s *staticModelIndex) RepositoryIndex(_ context.Context, endpoint string) ([]triton.Model, error) {
+	s.endpoint = endpoint
+	return s.models, s.err
+}
+
+func (s *staticModelIndex) ModelConfig(_ context.Context, _, model string) (map[string]interface{}, error) {
+	return map[string]interface{}{"name": model}, s.err
+}
+
+func (s *staticModelIndex) LoadModel(_ context.Context, endpoint, model string, config map[string]interface{}) error {
+	if s.loaded == nil {
+		s.loaded = make(map[string]map[string]interface{})
+	}
+	s.endpoint = endpoint
+	s.loaded[model] = config
+	return s.err
+}
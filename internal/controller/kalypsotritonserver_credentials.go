@@ -0,0 +1,172 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+)
+
+const (
+	// CredentialsRevisionAnnotation identifies the data of the credential Secrets of the Triton
+	// pods, so rotated credentials roll them: environment variables are only read at startup
+	CredentialsRevisionAnnotation = "serving.kalypso.io/credentials-revision"
+)
+
+// applicationCredentialSecrets returns the Secrets referenced by the storage of the application
+func applicationCredentialSecrets(app *servingv1alpha1.KalypsoApplication) []string {
+	storage := app.Spec.Storage
+	if storage == nil {
+		return nil
+	}
+
+	var names []string
+	if storageWorkloadIdentity(storage) == nil {
+		if storage.SecretName != "" {
+			names = append(names, storage.SecretName)
+		}
+		if storage.GCS != nil && storage.GCS.CredentialsSecret != "" {
+			names = append(names, storage.GCS.CredentialsSecret)
+		}
+		if storage.Azure != nil && storage.Azure.CredentialsSecret != "" {
+			names = append(names, storage.Azure.CredentialsSecret)
+		}
+	}
+	if options := s3Options(storage); options != nil && options.CABundleSecret != "" {
+		names = append(names, options.CABundleSecret)
+	}
+	return names
+}
+
+// projectCredentialSecrets returns the Secrets referenced by the model registry of the project
+func projectCredentialSecrets(project *servingv1alpha1.KalypsoProject) []string {
+	if project.Spec.ModelRegistry == nil || project.Spec.ModelRegistry.SecretRef == "" {
+		return nil
+	}
+	return []string{project.Spec.ModelRegistry.SecretRef}
+}
+
+// credentialsRevision returns a short hash of the credential Secrets of the server: the
+// Secrets of the application storage and of the project model registry. Missing Secrets are
+// left out, and the revision is empty when the server references none
+func (r *KalypsoTritonServerReconciler) credentialsRevision(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) (string, error) {
+	names := applicationCredentialSecrets(app)
+	if project, err := defaults.ApplicationProject(ctx, r.Client, app); err == nil {
+		names = append(names, projectCredentialSecrets(project)...)
+	} else if client.IgnoreNotFound(err) != nil {
+		return "", err
+	}
+	if len(names) == 0 {
+		return "", nil
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	for i, name := range names {
+		if i > 0 && name == names[i-1] {
+			continue
+		}
+		secret := &corev1.Secret{}
+		if err := r.secretReader().Get(ctx, client.ObjectKey{Namespace: server.Namespace, Name: name}, secret); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return "", err
+			}
+			continue
+		}
+		keys := make([]string, 0, len(secret.Data))
+		for key := range secret.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		hash.Write([]byte(name))
+		for _, key := range keys {
+			hash.Write([]byte(key))
+			hash.Write(secret.Data[key])
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)[:8]), nil
+}
+
+// secretReader returns the reader of Secret data, which the manager cache only holds for the
+// Secrets the operator manages
+func (r *KalypsoTritonServerReconciler) secretReader() client.Reader {
+	if r.APIReader == nil {
+		return r.Client
+	}
+	return r.APIReader
+}
+
+// withCredentialsRevision returns a copy of the server annotating its pods with the
+// credentials revision
+func withCredentialsRevision(server *servingv1alpha1.KalypsoTritonServer, revision string) *servingv1alpha1.KalypsoTritonServer {
+	if revision == "" {
+		return server
+	}
+	annotated := server.DeepCopy()
+	annotated.Spec.PodAnnotations = defaults.MergeStringMaps(annotated.Spec.PodAnnotations, map[string]string{
+		CredentialsRevisionAnnotation: revision,
+	})
+	return annotated
+}
+
+// serversForCredentialSecret maps a Secret to the servers of the applications referencing it
+// in their storage, or belonging to a project referencing it for its model registry
+func (r *KalypsoTritonServerReconciler) serversForCredentialSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	apps := &servingv1alpha1.KalypsoApplicationList{}
+	if err := r.List(ctx, apps); err != nil {
+		return nil
+	}
+
+	// The credential Secrets of an application or project, possibly of another namespace, are
+	// read from the namespace of each server
+	referencingApps := make(map[client.ObjectKey]bool)
+	for i := range apps.Items {
+		app := &apps.Items[i]
+		names := applicationCredentialSecrets(app)
+		if project, err := defaults.ApplicationProject(ctx, r.Client, app); err == nil {
+			names = append(names, projectCredentialSecrets(project)...)
+		}
+		if slices.Contains(names, obj.GetName()) {
+			referencingApps[client.ObjectKeyFromObject(app)] = true
+		}
+	}
+	if len(referencingApps) == 0 {
+		return nil
+	}
+
+	servers := &servingv1alpha1.KalypsoTritonServerList{}
+	if err := r.List(ctx, servers, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, server := range servers.Items {
+		if referencingApps[serverApplicationKey(&server)] {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&server)})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+)
+
+// capacityProfile is the outcome of the GPU quota check
+type capacityProfile struct {
+	degraded bool
+	message  string
+}
+
+// evaluateCapacityProfile reports whether the server must run its degraded profile because the
+// GPUs of all its replicas exceed what is left of the project ResourceQuotas. GPUs held by the
+// current Deployment or StatefulSet count as available, so the server returns to full capacity
+// once they fit
+func (r *KalypsoTritonServerReconciler) evaluateCapacityProfile(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer) (*capacityProfile, error) {
+	if server.Spec.DegradedProfile == nil || scaledToZero(server) {
+		return nil, nil
+	}
+
+	requested := gpuLimits(server.Spec.Resources, server.Spec.GPU)
+	if len(requested) == 0 {
+		return nil, nil
+	}
+	replicas := int64(1)
+	if server.Spec.Replicas != nil {
+		replicas = int64(*server.Spec.Replicas)
+	}
+
+	// GPUs held by the current pods are released when the workload is updated
+	held := corev1.ResourceList{}
+	template, currentReplicas, err := r.currentPodTemplate(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+	if template != nil && len(template.Spec.Containers) > 0 {
+		for name, quantity := range gpuLimitsOf(template.Spec.Containers[0].Resources) {
+			held[name] = *resource.NewQuantity(quantity.Value()*int64(currentReplicas), resource.DecimalSI)
+		}
+	}
+
+	quotas := &corev1.ResourceQuotaList{}
+	if err := r.List(ctx, quotas, client.InNamespace(server.Namespace)); err != nil {
+		return nil, err
+	}
+
+	for _, quota := range quotas.Items {
+		for name, perPod := range requested {
+			for _, key := range []corev1.ResourceName{"requests." + name, name} {
+				hard, ok := quota.Spec.Hard[key]
+				if !ok {
+					continue
+				}
+				used := quota.Status.Used[key]
+				heldQuantity := held[name]
+				available := hard.Value() - used.Value() + heldQuantity.Value()
+				if needed := perPod.Value() * replicas; needed > available {
+					return &capacityProfile{
+						degraded: true,
+						message: fmt.Sprintf("%d replicas need %d %s but ResourceQuota %s leaves %d",
+							replicas, needed, name, quota.Name, max(available, 0)),
+					}, nil
+				}
+			}
+		}
+	}
+	return &capacityProfile{}, nil
+}
+
+// gpuLimits returns the GPU limits of a Triton container with the given resources and GPU spec
+func gpuLimits(resources *corev1.ResourceRequirements, gpu *servingv1alpha1.GPUSpec) corev1.ResourceList {
+	container := corev1.ResourceRequirements{}
+	if resources != nil {
+		container = *resources.DeepCopy()
+	}
+	defaults.ApplyGPUResources(&container, gpu)
+	return gpuLimitsOf(container)
+}
+
+// gpuLimitsOf returns the NVIDIA extended resource limits: whole GPUs, MIG slices, and shared GPUs
+func gpuLimitsOf(resources corev1.ResourceRequirements) corev1.ResourceList {
+	limits := corev1.ResourceList{}
+	for name, quantity := range resources.Limits {
+		if strings.HasPrefix(string(name), "nvidia.com/") && !quantity.IsZero() {
+			limits[name] = quantity
+		}
+	}
+	return limits
+}
+
+// withCapacityProfile returns the server to deploy: the server itself, or a copy running the
+// degraded profile
+func withCapacityProfile(server *servingv1alpha1.KalypsoTritonServer, profile *capacityProfile) *servingv1alpha1.KalypsoTritonServer {
+	if profile == nil || !profile.degraded {
+		return server
+	}
+
+	degraded := server.DeepCopy()
+	spec := degraded.Spec.DegradedProfile
+	degraded.Spec.GPU = spec.GPU
+	if spec.Resources != nil {
+		degraded.Spec.Resources = spec.Resources
+	}
+	if len(spec.NodeSelector) > 0 {
+		if degraded.Spec.Scheduling == nil {
+			degraded.Spec.Scheduling = &servingv1alpha1.SchedulingSpec{}
+		}
+		degraded.Spec.Scheduling.NodeSelector = defaults.MergeStringMaps(degraded.Spec.Scheduling.NodeSelector, spec.NodeSelector)
+	}
+	return degraded
+}
+
+// applyCapacityProfileStatus reports whether the server runs its degraded profile
+func applyCapacityProfileStatus(server *servingv1alpha1.KalypsoTritonServer, profile *capacityProfile) {
+	switch {
+	case profile == nil:
+		meta.RemoveStatusCondition(&server.Status.Conditions, "RunningDegradedCapacity")
+	case profile.degraded:
+		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               "RunningDegradedCapacity",
+			Status:             metav1.ConditionTrue,
+			Reason:             "GPUQuotaExhausted",
+			Message:            fmt.Sprintf("Running the degraded profile: %s", profile.message),
+			LastTransitionTime: metav1.Now(),
+		})
+	default:
+		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               "RunningDegradedCapacity",
+			Status:             metav1.ConditionFalse,
+			Reason:             "FullCapacity",
+			Message:            "The GPUs of all replicas fit in the project quota",
+			LastTransitionTime: metav1.Now(),
+		})
+	}
+}
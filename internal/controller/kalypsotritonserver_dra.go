@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	resourcev1 "k8s.io/api/resource/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// gpuResourceClaims returns the Dynamic Resource Allocation claims of the GPU spec
+func gpuResourceClaims(gpu *servingv1alpha1.GPUSpec) []servingv1alpha1.GPUResourceClaim {
+	if gpu == nil {
+		return nil
+	}
+	return gpu.ResourceClaims
+}
+
+// applyGPUResourceClaims adds the GPU resource claims to the pod and the Triton container.
+// Claims requesting a DeviceClass use the ResourceClaimTemplate managed by the operator
+func applyGPUResourceClaims(podSpec *corev1.PodSpec, server *servingv1alpha1.KalypsoTritonServer) {
+	for i := range gpuResourceClaims(server.Spec.GPU) {
+		claim := server.Spec.GPU.ResourceClaims[i].DeepCopy()
+		podClaim := corev1.PodResourceClaim{
+			Name:                      claim.Name,
+			ResourceClaimName:         claim.ResourceClaimName,
+			ResourceClaimTemplateName: claim.ResourceClaimTemplateName,
+		}
+		if claim.DeviceClassName != nil {
+			templateName := naming.GPUClaimTemplate(server.Name, claim.Name)
+			podClaim.ResourceClaimTemplateName = &templateName
+		}
+		podSpec.ResourceClaims = append(podSpec.ResourceClaims, podClaim)
+		podSpec.Containers[0].Resources.Claims = append(podSpec.Containers[0].Resources.Claims, corev1.ResourceClaim{Name: claim.Name})
+	}
+}
+
+// reconcileGPUClaimTemplates ensures a ResourceClaimTemplate for every GPU resource claim
+// requesting a DeviceClass, and removes the templates of claims no longer declared
+func (r *KalypsoTritonServerReconciler) reconcileGPUClaimTemplates(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer) error {
+	labels := map[string]string{
+		TritonServerLabelKey: server.Name,
+		ManagedByLabelKey:    ManagedByLabelValue,
+	}
+
+	desired := map[string]bool{}
+	for _, claim := range gpuResourceClaims(server.Spec.GPU) {
+		if claim.DeviceClassName == nil {
+			continue
+		}
+		template := &resourcev1.ResourceClaimTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      naming.GPUClaimTemplate(server.Name, claim.Name),
+				Namespace: server.Namespace,
+			},
+		}
+		desired[template.Name] = true
+
+		_, err := controllerutil.CreateOrUpdate(ctx, r.Client, template, func() error {
+			// Set labels
+			if template.Labels == nil {
+				template.Labels = make(map[string]string)
+			}
+			for k, v := range labels {
+				template.Labels[k] = v
+			}
+
+			// Set spec (immutable once created, so only set on creation)
+			if template.CreationTimestamp.IsZero() {
+				template.Spec = buildGPUClaimTemplateSpec(claim)
+			}
+
+			// Set owner reference
+			return controllerutil.SetControllerReference(server, template, r.Scheme)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Remove templates of claims no longer declared
+	templates := &resourcev1.ResourceClaimTemplateList{}
+	if err := r.List(ctx, templates, client.InNamespace(server.Namespace), client.MatchingLabels(labels)); err != nil {
+		// The DRA API may not be served by this cluster
+		if meta.IsNoMatchError(err) && len(desired) == 0 {
+			return nil
+		}
+		return err
+	}
+	for i := range templates.Items {
+		template := &templates.Items[i]
+		if desired[template.Name] || !metav1.IsControlledBy(template, server) {
+			continue
+		}
+		if err := client.IgnoreNotFound(r.Delete(ctx, template)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildGPUClaimTemplateSpec builds a claim requesting count devices of the claim DeviceClass
+func buildGPUClaimTemplateSpec(claim servingv1alpha1.GPUResourceClaim) resourcev1.ResourceClaimTemplateSpec {
+	count := int64(1)
+	if claim.Count != nil {
+		count = int64(*claim.Count)
+	}
+	return resourcev1.ResourceClaimTemplateSpec{
+		Spec: resourcev1.ResourceClaimSpec{
+			Devices: resourcev1.DeviceClaim{
+				Requests: []resourcev1.DeviceRequest{
+					{
+						Name: "gpu",
+						Exactly: &resourcev1.ExactDeviceRequest{
+							DeviceClassName: *claim.DeviceClassName,
+							AllocationMode:  resourcev1.DeviceAllocationModeExactCount,
+							Count:           count,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+// EvacuateNodesAnnotation lists the comma-separated nodes a KalypsoTritonServer is moved off
+// ahead of a planned drain. The nodes are expected to be cordoned, so replacements are
+// scheduled elsewhere
+const EvacuateNodesAnnotation = "serving.kalypso.io/evacuate-nodes"
+
+// evacuationResult is the state of a requested node evacuation
+type evacuationResult struct {
+	nodes       []string
+	podsOnNodes int32
+}
+
+// surge returns the number of replacement replicas to run on top of spec.replicas
+func (e *evacuationResult) surge() int32 {
+	if e == nil {
+		return 0
+	}
+	return e.podsOnNodes
+}
+
+// evacuatingNodes returns the nodes listed in the evacuation annotation
+func evacuatingNodes(server *servingv1alpha1.KalypsoTritonServer) []string {
+	var nodes []string
+	for _, node := range strings.Split(server.Annotations[EvacuateNodesAnnotation], ",") {
+		if node = strings.TrimSpace(node); node != "" && !slices.Contains(nodes, node) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// evaluateEvacuation counts the server's pods still running on the evacuated nodes, or
+// returns nil when no evacuation is requested
+func (r *KalypsoTritonServerReconciler) evaluateEvacuation(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer) (*evacuationResult, error) {
+	nodes := evacuatingNodes(server)
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(server.Namespace), client.MatchingLabels{TritonServerLabelKey: server.Name}); err != nil {
+		return nil, err
+	}
+
+	result := &evacuationResult{nodes: nodes}
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp.IsZero() && slices.Contains(nodes, pod.Spec.NodeName) {
+			result.podsOnNodes++
+		}
+	}
+	return result, nil
+}
+
+// applyEvacuationStatus records the evacuation progress on the server status
+func applyEvacuationStatus(server *servingv1alpha1.KalypsoTritonServer, result *evacuationResult) {
+	if result == nil {
+		server.Status.Evacuation = nil
+		meta.RemoveStatusCondition(&server.Status.Conditions, "Evacuating")
+		return
+	}
+
+	server.Status.Evacuation = &servingv1alpha1.EvacuationStatus{
+		Nodes:       result.nodes,
+		PodsOnNodes: result.podsOnNodes,
+	}
+	condition := metav1.Condition{
+		Type:               "Evacuating",
+		Status:             metav1.ConditionTrue,
+		Reason:             "ReplacingPods",
+		Message:            fmt.Sprintf("%d pods remain on %s; the replacements are surged and evictions wait for them to become available", result.podsOnNodes, strings.Join(result.nodes, ", ")),
+		LastTransitionTime: metav1.Now(),
+	}
+	if result.podsOnNodes == 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "EvacuationComplete"
+		condition.Message = fmt.Sprintf("No pods remain on %s; remove the %s annotation once the drain is done", strings.Join(result.nodes, ", "), EvacuateNodesAnnotation)
+	}
+	meta.SetStatusCondition(&server.Status.Conditions, condition)
+}
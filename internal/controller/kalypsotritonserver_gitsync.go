@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+const (
+	// GitSyncSecretVolumeName is the volume holding the deploy key of git+ssh:// repositories
+	GitSyncSecretVolumeName = "kalypso-git-secret"
+	// GitSyncSecretMountPath is where the deploy key is mounted in the git-sync containers
+	GitSyncSecretMountPath = "/etc/git-secret"
+	// gitSyncDefaultImage clones and pulls git repositories
+	gitSyncDefaultImage = "registry.k8s.io/git-sync/git-sync:v4.4.0"
+	// gitSyncDefaultPeriod is how often the ref is pulled when polling
+	gitSyncDefaultPeriod = 60 * time.Second
+)
+
+// parseGitURI splits a git+https:// or git+ssh:// storage URI into the repository URL and the
+// ref following the last @ of the path (default: HEAD); ok is false for other schemes
+func parseGitURI(uri string) (repo, ref string, ok bool) {
+	if !strings.HasPrefix(uri, "git+https://") && !strings.HasPrefix(uri, "git+ssh://") {
+		return "", "", false
+	}
+	repo = strings.TrimPrefix(uri, "git+")
+	ref = "HEAD"
+	if i := strings.LastIndex(repo, "@"); i > strings.LastIndex(repo, "/") {
+		repo, ref = repo[:i], repo[i+1:]
+	}
+	return repo, ref, true
+}
+
+// gitSyncPolling reports whether the repository is kept in sync after startup
+func gitSyncPolling(server *servingv1alpha1.KalypsoTritonServer) bool {
+	_, _, ok := parseGitURI(server.Spec.StorageURI)
+	return ok && server.Spec.Git != nil && server.Spec.Git.Poll
+}
+
+// gitSyncRepositoryPath returns the model repository of a git clone: git-sync swaps the link
+// to each new worktree atomically
+func gitSyncRepositoryPath(server *servingv1alpha1.KalypsoTritonServer) string {
+	repository := path.Join(ModelDownloadMountPath, "repository")
+	if server.Spec.Git != nil && server.Spec.Git.Path != "" {
+		return path.Join(repository, server.Spec.Git.Path)
+	}
+	return repository
+}
+
+// buildGitSyncArgs switches Triton to the poll model control mode when the repository is kept
+// in sync, unless the models are loaded explicitly
+func buildGitSyncArgs(server *servingv1alpha1.KalypsoTritonServer, args []string) []string {
+	if !gitSyncPolling(server) || modelConfigRevision(server) != "" || tritonParameterSet(server, "model-control-mode") {
+		return args
+	}
+	period := parseDurationOrDefault(server.Spec.Git.Period, gitSyncDefaultPeriod)
+	return append(args,
+		"--model-control-mode=poll",
+		fmt.Sprintf("--repository-poll-secs=%d", int(period.Seconds())),
+	)
+}
+
+// applyGitSync adds the git-sync init container cloning the repository to the model download
+// volume, and the git-sync sidecar pulling the ref when polling
+func applyGitSync(podSpec *corev1.PodSpec, server *servingv1alpha1.KalypsoTritonServer) {
+	repo, ref, ok := parseGitURI(server.Spec.StorageURI)
+	if !ok {
+		return
+	}
+	spec := server.Spec.Git
+	if spec == nil {
+		spec = &servingv1alpha1.GitSyncSpec{}
+	}
+
+	image := spec.Image
+	if image == "" {
+		image = gitSyncDefaultImage
+	}
+	args := []string{
+		"--repo=" + repo,
+		"--ref=" + ref,
+		"--root=" + path.Join(ModelDownloadMountPath, ".git-sync"),
+		"--link=" + path.Join(ModelDownloadMountPath, "repository"),
+		"--depth=1",
+	}
+	var env []corev1.EnvVar
+	var mounts []corev1.VolumeMount
+	if spec.CredentialsSecret != "" {
+		for _, credential := range []struct{ name, key string }{
+			{"GITSYNC_USERNAME", "username"},
+			{"GITSYNC_PASSWORD", "password"},
+		} {
+			env = append(env, corev1.EnvVar{
+				Name: credential.name,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: spec.CredentialsSecret},
+						Key:                  credential.key,
+					},
+				},
+			})
+		}
+	}
+	if spec.SSHKeySecret != "" {
+		// git-sync requires the key to be readable by its user only
+		mode := int32(0400)
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: GitSyncSecretVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: spec.SSHKeySecret, DefaultMode: &mode},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{Name: GitSyncSecretVolumeName, MountPath: GitSyncSecretMountPath, ReadOnly: true})
+		args = append(args,
+			"--ssh-key-file="+path.Join(GitSyncSecretMountPath, corev1.SSHAuthPrivateKey),
+			"--ssh-known-hosts-file="+path.Join(GitSyncSecretMountPath, "known_hosts"),
+		)
+	}
+
+	clone := corev1.Container{
+		Name:            "git-clone",
+		Image:           image,
+		Args:            append(append([]string{}, args...), "--one-time"),
+		Env:             env,
+		VolumeMounts:    mounts,
+		SecurityContext: buildContainerSecurityContext(server.Spec.ContainerSecurityContext),
+	}
+	applyModelDownload(podSpec, clone, nil)
+
+	if !spec.Poll {
+		return
+	}
+	period := parseDurationOrDefault(spec.Period, gitSyncDefaultPeriod)
+	podSpec.Containers = append(podSpec.Containers, corev1.Container{
+		Name:  "git-sync",
+		Image: image,
+		Args:  append(args, "--period="+period.String()),
+		Env:   env,
+		VolumeMounts: append(mounts, corev1.VolumeMount{
+			Name:      ModelDownloadVolumeName,
+			MountPath: ModelDownloadMountPath,
+		}),
+		SecurityContext: buildContainerSecurityContext(server.Spec.ContainerSecurityContext),
+	})
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+const (
+	// HuggingFaceScheme is the storage URI scheme of Hugging Face Hub repositories
+	HuggingFaceScheme = "hf://"
+	// huggingFaceDefaultImage runs the download script, installing huggingface_hub when missing
+	huggingFaceDefaultImage = "python:3.12-slim"
+)
+
+// huggingFaceDownloadScript downloads a Hub repository and places it in the Triton model
+// repository layout: repositories of models with a config.pbtxt are kept as is, a model with
+// a config.pbtxt at the root becomes the single model, and plain model files become version 1
+// of a model, with a config.pbtxt selecting the backend when set
+const huggingFaceDownloadScript = `import os
+import pathlib
+import shutil
+import subprocess
+import sys
+
+root = pathlib.Path(os.environ["KALYPSO_MODELS"])
+cache = root / ".cache"
+try:
+    from huggingface_hub import snapshot_download
+except ImportError:
+    target = cache / "site-packages"
+    subprocess.check_call([sys.executable, "-m", "pip", "install", "--quiet", "--target", str(target), "huggingface_hub"])
+    sys.path.insert(0, str(target))
+    from huggingface_hub import snapshot_download
+
+staging = root / ".staging"
+repository = root / "repository"
+shutil.rmtree(staging, ignore_errors=True)
+shutil.rmtree(repository, ignore_errors=True)
+snapshot_download(
+    os.environ["HF_REPO"],
+    revision=os.environ["HF_REVISION"],
+    local_dir=staging,
+    token=os.environ.get("HF_TOKEN") or None,
+)
+shutil.rmtree(staging / ".cache", ignore_errors=True)
+
+model_name = os.environ["MODEL_NAME"]
+if any(staging.glob("*/config.pbtxt")):
+    staging.rename(repository)
+elif (staging / "config.pbtxt").exists():
+    repository.mkdir()
+    staging.rename(repository / model_name)
+else:
+    model = repository / model_name
+    model.mkdir(parents=True)
+    staging.rename(model / "1")
+    backend = os.environ.get("TRITON_BACKEND")
+    if backend:
+        (model / "config.pbtxt").write_text('backend: "%s"\n' % backend)
+shutil.rmtree(cache, ignore_errors=True)
+`
+
+// parseHuggingFaceURI splits a hf://<org>/<repo>[@<revision>] storage URI; ok is false for
+// other schemes
+func parseHuggingFaceURI(uri string) (repo, revision string, ok bool) {
+	if !strings.HasPrefix(uri, HuggingFaceScheme) {
+		return "", "", false
+	}
+	repo, revision, _ = strings.Cut(strings.TrimPrefix(uri, HuggingFaceScheme), "@")
+	if revision == "" {
+		revision = "main"
+	}
+	return strings.Trim(repo, "/"), revision, true
+}
+
+// applyHuggingFaceDownload adds the init container downloading a Hugging Face Hub repository
+// to the model download volume
+func applyHuggingFaceDownload(podSpec *corev1.PodSpec, server *servingv1alpha1.KalypsoTritonServer) {
+	repo, revision, ok := parseHuggingFaceURI(server.Spec.StorageURI)
+	if !ok {
+		return
+	}
+	spec := server.Spec.HuggingFace
+	if spec == nil {
+		spec = &servingv1alpha1.HuggingFaceSpec{}
+	}
+
+	modelName := spec.ModelName
+	if modelName == "" {
+		modelName = path.Base(repo)
+	}
+	image := spec.Image
+	if image == "" {
+		image = huggingFaceDefaultImage
+	}
+	env := []corev1.EnvVar{
+		{Name: "KALYPSO_MODELS", Value: ModelDownloadMountPath},
+		{Name: "HF_REPO", Value: repo},
+		{Name: "HF_REVISION", Value: revision},
+		{Name: "MODEL_NAME", Value: modelName},
+		// The Triton user has no writable home directory
+		{Name: "HOME", Value: path.Join(ModelDownloadMountPath, ".cache")},
+		{Name: "HF_HUB_DISABLE_TELEMETRY", Value: "1"},
+	}
+	if spec.Backend != "" {
+		env = append(env, corev1.EnvVar{Name: "TRITON_BACKEND", Value: spec.Backend})
+	}
+	if spec.TokenSecret != "" {
+		env = append(env, corev1.EnvVar{
+			Name: "HF_TOKEN",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: spec.TokenSecret},
+					Key:                  "token",
+				},
+			},
+		})
+	}
+
+	applyModelDownload(podSpec, corev1.Container{
+		Name:            "huggingface-download",
+		Image:           image,
+		Command:         []string{"python", "-c", huggingFaceDownloadScript},
+		Env:             env,
+		SecurityContext: buildContainerSecurityContext(server.Spec.ContainerSecurityContext),
+	}, spec.SizeLimit)
+}
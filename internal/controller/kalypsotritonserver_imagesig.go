@@ -0,0 +1,176 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+	"github.com/kalypsoServing/KalypsoServing/internal/imagesig"
+)
+
+// ImageVerificationPolicy is the operator-wide cosign verification of the Triton images, which
+// projects override through spec.imageVerification
+type ImageVerificationPolicy struct {
+	// Mode is Enforce, Audit or Disabled; empty disables the verification
+	Mode servingv1alpha1.ImageVerificationMode
+	// PublicKey is the PEM encoded cosign public key the images must be signed with
+	PublicKey []byte
+	// RekorPublicKey is the PEM encoded public key of the Rekor log; when set, signatures
+	// must be recorded in the transparency log unless a project opts out
+	RekorPublicKey []byte
+}
+
+// imageSignatureResult is the outcome of verifying the signature of the Triton image
+type imageSignatureResult struct {
+	mode  servingv1alpha1.ImageVerificationMode
+	image string
+	// digest is the verified manifest digest the pods pull, empty when verification failed
+	digest string
+	// reason is the ImageVerified condition reason of a failure
+	reason string
+	err    error
+}
+
+// enforced reports whether a failed verification must block the rollout
+func (result *imageSignatureResult) enforced() bool {
+	return result != nil && result.err != nil && result.mode == servingv1alpha1.ImageVerificationEnforce
+}
+
+// imageVerificationPolicy merges the image verification override of the server's project into
+// the operator policy
+func (r *KalypsoTritonServerReconciler) imageVerificationPolicy(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) (servingv1alpha1.ImageVerificationMode, imagesig.Policy, error) {
+	mode := r.ImageVerification.Mode
+	policy := imagesig.Policy{PublicKey: r.ImageVerification.PublicKey, RekorPublicKey: r.ImageVerification.RekorPublicKey}
+
+	project, err := defaults.ApplicationProject(ctx, r.Client, app)
+	if err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return mode, policy, err
+		}
+		project = &servingv1alpha1.KalypsoProject{}
+	}
+	override := project.Spec.ImageVerification
+	if override == nil {
+		return mode, policy, nil
+	}
+	if override.Mode != "" {
+		mode = override.Mode
+	}
+	if mode == "" || mode == servingv1alpha1.ImageVerificationDisabled {
+		return servingv1alpha1.ImageVerificationDisabled, policy, nil
+	}
+
+	if override.PublicKeySecret != "" {
+		secret := &corev1.Secret{}
+		if err := r.secretReader().Get(ctx, client.ObjectKey{Namespace: server.Namespace, Name: override.PublicKeySecret}, secret); err != nil {
+			return mode, policy, fmt.Errorf("failed to read public key Secret %s: %w", override.PublicKeySecret, err)
+		}
+		if len(secret.Data["cosign.pub"]) == 0 {
+			return mode, policy, fmt.Errorf("public key Secret %s has no cosign.pub key", override.PublicKeySecret)
+		}
+		policy.PublicKey = secret.Data["cosign.pub"]
+	}
+	if override.TransparencyLog != nil {
+		if !*override.TransparencyLog {
+			policy.RekorPublicKey = nil
+		} else if len(policy.RekorPublicKey) == 0 {
+			return mode, policy, fmt.Errorf("the transparency log is required but the operator has no Rekor public key")
+		}
+	}
+	return mode, policy, nil
+}
+
+// verifyImageSignature checks that the Triton image of the server is signed with the cosign
+// key of the verification policy. Returns nil when the verification is disabled
+func (r *KalypsoTritonServerReconciler) verifyImageSignature(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) *imageSignatureResult {
+	mode, policy, err := r.imageVerificationPolicy(ctx, server, app)
+	if mode == "" || mode == servingv1alpha1.ImageVerificationDisabled {
+		return nil
+	}
+	result := &imageSignatureResult{mode: mode, image: tritonImage(server)}
+	switch {
+	case err != nil:
+		result.reason = "PolicyInvalid"
+		result.err = err
+	case len(policy.PublicKey) == 0:
+		result.reason = "PolicyInvalid"
+		result.err = fmt.Errorf("no cosign public key is configured")
+	case r.ImageVerifier == nil:
+		result.reason = "VerificationUnavailable"
+		result.err = fmt.Errorf("no image signature verifier is configured")
+	}
+	if result.err != nil {
+		return result
+	}
+
+	digest, err := r.ImageVerifier.Verify(ctx, result.image, policy)
+	switch {
+	case errors.Is(err, imagesig.ErrSignatureNotFound):
+		result.reason = "SignatureNotFound"
+	case errors.Is(err, imagesig.ErrSignatureInvalid):
+		result.reason = "SignatureInvalid"
+	case err != nil:
+		result.reason = "VerificationUnavailable"
+	}
+	result.digest = digest
+	result.err = err
+	return result
+}
+
+// withVerifiedImage returns a copy of the server pulling its Triton image by the verified
+// digest, so a tag moved after the verification is not deployed
+func withVerifiedImage(server *servingv1alpha1.KalypsoTritonServer, result *imageSignatureResult) *servingv1alpha1.KalypsoTritonServer {
+	if result == nil || result.digest == "" {
+		return server
+	}
+	pinned := server.DeepCopy()
+	pinned.Spec.TritonConfig.Tag = result.digest
+	return pinned
+}
+
+// applyImageSignatureStatus records the image signature verification on the server status
+func applyImageSignatureStatus(server *servingv1alpha1.KalypsoTritonServer, result *imageSignatureResult) {
+	if result == nil {
+		meta.RemoveStatusCondition(&server.Status.Conditions, "ImageVerified")
+		return
+	}
+	condition := metav1.Condition{
+		Type:               "ImageVerified",
+		Status:             metav1.ConditionTrue,
+		Reason:             "SignatureVerified",
+		Message:            fmt.Sprintf("Image %s is signed; pods pull %s", result.image, result.digest),
+		LastTransitionTime: metav1.Now(),
+	}
+	if result.err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = result.reason
+		condition.Message = fmt.Sprintf("Signature verification of %s failed: %v", result.image, result.err)
+		if result.mode == servingv1alpha1.ImageVerificationAudit {
+			condition.Message += "; deployed anyway in Audit mode"
+		}
+	}
+	meta.SetStatusCondition(&server.Status.Conditions, condition)
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// ingressEnabled reports whether the server's HTTP endpoint is exposed through an Ingress
+func ingressEnabled(server *servingv1alpha1.KalypsoTritonServer) bool {
+	return server.Spec.Networking != nil && server.Spec.Networking.Ingress != nil
+}
+
+// reconcileIngress ensures the Ingress of the server's HTTP endpoint, and removes it when
+// the Ingress is disabled
+func (r *KalypsoTritonServerReconciler) reconcileIngress(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, name string) error {
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: server.Namespace,
+		},
+	}
+
+	if !ingressEnabled(server) {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(ingress), ingress); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !metav1.IsControlledBy(ingress, server) {
+			return nil
+		}
+		return client.IgnoreNotFound(r.Delete(ctx, ingress))
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, ingress, func() error {
+		return r.mutateIngress(ingress, server)
+	})
+	return err
+}
+
+// mutateIngress applies the desired configuration to the Ingress, routing the host and path
+// prefix to the HTTP port of the server's Service
+func (r *KalypsoTritonServerReconciler) mutateIngress(ingress *networkingv1.Ingress, server *servingv1alpha1.KalypsoTritonServer) error {
+	spec := server.Spec.Networking.Ingress
+
+	// Set user-defined labels and annotations; the labels below take precedence
+	applyCustomMetadata(ingress, server)
+
+	// Set labels
+	if ingress.Labels == nil {
+		ingress.Labels = make(map[string]string)
+	}
+	ingress.Labels[TritonServerLabelKey] = server.Name
+	ingress.Labels[ApplicationLabelKey] = serverApplicationKey(server).Name
+	ingress.Labels[ManagedByLabelKey] = ManagedByLabelValue
+
+	// Set spec
+	path := spec.Path
+	if path == "" {
+		path = "/"
+	}
+	pathType := networkingv1.PathTypePrefix
+	ingress.Spec.IngressClassName = spec.IngressClassName
+	ingress.Spec.Rules = []networkingv1.IngressRule{
+		{
+			Host: spec.Host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{
+							Path:     path,
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: naming.Service(server.Name),
+									Port: networkingv1.ServiceBackendPort{Name: "http"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	ingress.Spec.TLS = nil
+	if spec.TLSSecretName != "" {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{
+			{Hosts: []string{spec.Host}, SecretName: spec.TLSSecretName},
+		}
+	}
+
+	// Set owner reference
+	return controllerutil.SetControllerReference(server, ingress, r.Scheme)
+}
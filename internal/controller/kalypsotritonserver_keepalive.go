@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+// buildKeepaliveArgs adds the gRPC keepalive flags of spec.networking.grpcKeepalive. Flags passed
+// in tritonConfig.parameters take precedence
+func buildKeepaliveArgs(server *servingv1alpha1.KalypsoTritonServer, args []string) []string {
+	if server.Spec.Networking == nil || server.Spec.Networking.GRPCKeepalive == nil {
+		return args
+	}
+	keepalive := server.Spec.Networking.GRPCKeepalive
+
+	add := func(name, value string) {
+		if !tritonParameterSet(server, name) {
+			args = append(args, fmt.Sprintf("--%s=%s", name, value))
+		}
+	}
+	// Triton takes the intervals in milliseconds
+	if keepalive.TimeSeconds != nil {
+		add("grpc-keepalive-time", strconv.FormatInt(int64(*keepalive.TimeSeconds)*1000, 10))
+	}
+	if keepalive.TimeoutSeconds != nil {
+		add("grpc-keepalive-timeout", strconv.FormatInt(int64(*keepalive.TimeoutSeconds)*1000, 10))
+	}
+	if keepalive.PermitWithoutCalls != nil {
+		add("grpc-keepalive-permit-without-calls", strconv.FormatBool(*keepalive.PermitWithoutCalls))
+	}
+	if keepalive.MaxPingsWithoutData != nil {
+		add("grpc-http2-max-pings-without-data", strconv.Itoa(int(*keepalive.MaxPingsWithoutData)))
+	}
+	if keepalive.MinRecvPingIntervalSeconds != nil {
+		add("grpc-http2-min-recv-ping-interval-without-data", strconv.FormatInt(int64(*keepalive.MinRecvPingIntervalSeconds)*1000, 10))
+	}
+	if keepalive.MaxPingStrikes != nil {
+		add("grpc-http2-max-ping-strikes", strconv.Itoa(int(*keepalive.MaxPingStrikes)))
+	}
+	return args
+}
+
+// tritonParameterSet reports whether a Triton flag is passed in tritonConfig.parameters
+func tritonParameterSet(server *servingv1alpha1.KalypsoTritonServer, name string) bool {
+	for _, param := range server.Spec.TritonConfig.Parameters {
+		if strings.TrimPrefix(param.Name, "--") == name {
+			return true
+		}
+	}
+	return false
+}
+
+// applySessionAffinity sets the client affinity of the main Service
+func applySessionAffinity(service *corev1.Service, server *servingv1alpha1.KalypsoTritonServer) {
+	service.Spec.SessionAffinity = corev1.ServiceAffinityNone
+	service.Spec.SessionAffinityConfig = nil
+	if server.Spec.Networking == nil || server.Spec.Networking.SessionAffinity != corev1.ServiceAffinityClientIP {
+		return
+	}
+
+	service.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+	timeout := corev1.DefaultClientIPServiceAffinitySeconds
+	if server.Spec.Networking.SessionAffinityTimeoutSeconds != nil {
+		timeout = *server.Spec.Networking.SessionAffinityTimeoutSeconds
+	}
+	service.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+		ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: &timeout},
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+const (
+	// DefaultPreStopSleepSeconds is long enough for endpoint removal to reach kube-proxy and the mesh
+	DefaultPreStopSleepSeconds = int32(10)
+	// DefaultExitTimeoutSeconds matches the Triton default for --exit-timeout-secs
+	DefaultExitTimeoutSeconds = int32(30)
+	// terminationGraceBufferSeconds leaves time for Triton to exit after the exit timeout
+	terminationGraceBufferSeconds = int64(5)
+)
+
+// exitTimeoutParameter returns the --exit-timeout-secs value passed in tritonConfig.parameters
+func exitTimeoutParameter(server *servingv1alpha1.KalypsoTritonServer) (string, bool) {
+	for _, param := range server.Spec.TritonConfig.Parameters {
+		if param.Name == "exit-timeout-secs" {
+			return param.Value, true
+		}
+	}
+	return "", false
+}
+
+// shutdownTimings returns the preStop sleep, the Triton exit timeout, and the termination grace
+// period. On SIGTERM Triton stops accepting requests, then unloads its models once their queued
+// and in-flight requests finish, for at most the exit timeout. An exit timeout passed in
+// tritonConfig.parameters takes precedence over spec.lifecycle
+func shutdownTimings(server *servingv1alpha1.KalypsoTritonServer) (preStopSleep, exitTimeout int32, gracePeriod int64) {
+	preStopSleep, exitTimeout = DefaultPreStopSleepSeconds, DefaultExitTimeoutSeconds
+	exitTimeoutSet := false
+	if lifecycle := server.Spec.Lifecycle; lifecycle != nil {
+		if lifecycle.PreStopSleepSeconds != nil {
+			preStopSleep = *lifecycle.PreStopSleepSeconds
+		}
+		if lifecycle.ExitTimeoutSeconds != nil {
+			exitTimeout = *lifecycle.ExitTimeoutSeconds
+			exitTimeoutSet = true
+		}
+	}
+	if value, ok := exitTimeoutParameter(server); ok {
+		if parsed, err := strconv.ParseInt(value, 10, 32); err == nil {
+			exitTimeout = int32(parsed)
+			exitTimeoutSet = true
+		}
+	}
+
+	if server.Spec.TerminationGracePeriodSeconds == nil {
+		return preStopSleep, exitTimeout, int64(preStopSleep) + int64(exitTimeout) + terminationGraceBufferSeconds
+	}
+
+	// Fit the exit timeout in the remaining grace period so Triton exits before SIGKILL
+	gracePeriod = *server.Spec.TerminationGracePeriodSeconds
+	if !exitTimeoutSet {
+		exitTimeout = int32(max(gracePeriod-int64(preStopSleep)-terminationGraceBufferSeconds, 0))
+	}
+	return preStopSleep, exitTimeout, gracePeriod
+}
+
+// buildShutdownArgs sets the Triton exit timeout unless it is passed in tritonConfig.parameters
+func buildShutdownArgs(server *servingv1alpha1.KalypsoTritonServer, args []string) []string {
+	if _, ok := exitTimeoutParameter(server); ok {
+		return args
+	}
+	_, exitTimeout, _ := shutdownTimings(server)
+	return append(args, fmt.Sprintf("--exit-timeout-secs=%d", exitTimeout))
+}
+
+// applyGracefulShutdown adds the preStop hook to the Triton container and sets the termination
+// grace period
+func applyGracefulShutdown(podSpec *corev1.PodSpec, server *servingv1alpha1.KalypsoTritonServer) {
+	preStopSleep, _, gracePeriod := shutdownTimings(server)
+	if preStopSleep > 0 {
+		podSpec.Containers[0].Lifecycle = &corev1.Lifecycle{
+			PreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{
+					Command: []string{"sleep", strconv.Itoa(int(preStopSleep))},
+				},
+			},
+		}
+	}
+
+	podSpec.TerminationGracePeriodSeconds = &gracePeriod
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+const (
+	// TrainingRunLabelKey is the pod label carrying spec.metadata.trainingRunId
+	TrainingRunLabelKey = "lineage.kalypso-serving.io/training-run"
+	// DatasetVersionLabelKey is the pod label carrying spec.metadata.datasetVersion
+	DatasetVersionLabelKey = "lineage.kalypso-serving.io/dataset-version"
+	// GitCommitLabelKey is the pod label carrying spec.metadata.gitCommit
+	GitCommitLabelKey = "lineage.kalypso-serving.io/git-commit"
+	// ModelOwnerLabelKey is the pod label carrying spec.metadata.owner
+	ModelOwnerLabelKey = "lineage.kalypso-serving.io/owner"
+)
+
+// lineageField pairs a lineage label key with its trace resource attribute
+type lineageField struct {
+	labelKey  string
+	attribute string
+	value     string
+}
+
+// lineageFields returns the lineage values that are set
+func lineageFields(metadata *servingv1alpha1.ModelMetadataSpec) []lineageField {
+	if metadata == nil {
+		return nil
+	}
+
+	var fields []lineageField
+	for _, field := range []lineageField{
+		{TrainingRunLabelKey, "kalypso.training_run_id", metadata.TrainingRunID},
+		{DatasetVersionLabelKey, "kalypso.dataset_version", metadata.DatasetVersion},
+		{GitCommitLabelKey, "kalypso.git_commit", metadata.GitCommit},
+		{ModelOwnerLabelKey, "kalypso.owner", metadata.Owner},
+	} {
+		if field.value != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// buildPodLabels merges the user pod labels, the lineage labels, and the selector labels, in
+// increasing precedence. Only the selector labels are part of the Deployment selector, which is immutable
+func buildPodLabels(labels, userLabels map[string]string, metadata *servingv1alpha1.ModelMetadataSpec) map[string]string {
+	podLabels := make(map[string]string, len(labels)+len(userLabels))
+	for k, v := range userLabels {
+		podLabels[k] = v
+	}
+	for _, field := range lineageFields(metadata) {
+		podLabels[field.labelKey] = field.value
+	}
+	for k, v := range labels {
+		podLabels[k] = v
+	}
+	return podLabels
+}
+
+// lineageLabelKeys returns the lineage pod labels Prometheus copies onto the scraped series
+func lineageLabelKeys(metadata *servingv1alpha1.ModelMetadataSpec) []string {
+	var keys []string
+	for _, field := range lineageFields(metadata) {
+		keys = append(keys, field.labelKey)
+	}
+	return keys
+}
+
+// buildLineageTraceArgs returns the Triton arguments adding the lineage as OpenTelemetry
+// resource attributes of the exported traces
+func buildLineageTraceArgs(metadata *servingv1alpha1.ModelMetadataSpec) []string {
+	var args []string
+	for _, field := range lineageFields(metadata) {
+		args = append(args, fmt.Sprintf("--trace-config=opentelemetry,resource=%s=%s", field.attribute, field.value))
+	}
+	return args
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+const (
+	// localDownloadDefaultImage copies s3:// repositories with parallel transfers
+	localDownloadDefaultImage = "peakcom/s5cmd:v2.3.0"
+	// localDownloadDefaultWorkers is the s5cmd default number of parallel transfers
+	localDownloadDefaultWorkers = 256
+)
+
+// localDownload returns the copy settings of an s3:// model repository in the local download
+// mode, loaded from a model cache or verified, nil when Triton streams the repository from the bucket
+func localDownload(server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) *servingv1alpha1.LocalDownloadSpec {
+	if !strings.HasPrefix(server.Spec.StorageURI, "s3://") {
+		return nil
+	}
+	if server.Spec.ModelCache == "" && modelVerification(app) == nil &&
+		(app.Spec.Storage == nil || app.Spec.Storage.DownloadMode != servingv1alpha1.StorageDownloadModeLocal) {
+		return nil
+	}
+	if app.Spec.Storage == nil || app.Spec.Storage.LocalDownload == nil {
+		return &servingv1alpha1.LocalDownloadSpec{}
+	}
+	return app.Spec.Storage.LocalDownload
+}
+
+// s5cmdSource returns the s5cmd source of every object of an s3:// model repository, and the
+// endpoint URL: Triton names custom endpoints in the URI, s3://[http[s]://]<host>:<port>/<bucket>/<path>,
+// while s5cmd takes them as a flag
+func s5cmdSource(uri string, storage *servingv1alpha1.StorageSpec) (string, string) {
+	endpoint := ""
+	if storage != nil {
+		endpoint = storage.Endpoint
+	}
+	location := strings.TrimPrefix(uri, "s3://")
+	scheme := "http://"
+	for _, prefix := range []string{"http://", "https://"} {
+		if strings.HasPrefix(location, prefix) {
+			scheme, location = prefix, strings.TrimPrefix(location, prefix)
+		}
+	}
+	if host, rest, _ := strings.Cut(location, "/"); strings.Contains(host, ":") {
+		endpoint, location = scheme+host, rest
+	}
+	return "s3://" + strings.TrimSuffix(location, "/") + "/*", endpoint
+}
+
+// buildS5cmdFlags builds the global s5cmd flags of the transfers
+func buildS5cmdFlags(workers *int32, endpoint string) []string {
+	numWorkers := int32(localDownloadDefaultWorkers)
+	if workers != nil {
+		numWorkers = *workers
+	}
+	flags := []string{"--numworkers", strconv.Itoa(int(numWorkers))}
+	if endpoint != "" {
+		flags = append(flags, "--endpoint-url", endpoint)
+	}
+	return flags
+}
+
+// buildLocalDownloadArgs builds the s5cmd copy of every object under the storage URI prefix to
+// the repository directory of the download volume
+func buildLocalDownloadArgs(server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication, spec *servingv1alpha1.LocalDownloadSpec) []string {
+	source, endpoint := s5cmdSource(server.Spec.StorageURI, app.Spec.Storage)
+	return append(buildS5cmdFlags(spec.Workers, endpoint), "cp", source, path.Join(ModelDownloadMountPath, "repository")+"/")
+}
+
+// buildS3Env passes the S3 credentials, region and client options of the application to s5cmd
+func buildS3Env(storage *servingv1alpha1.StorageSpec) ([]corev1.EnvVar, []corev1.EnvFromSource) {
+	if storage == nil {
+		return nil, nil
+	}
+	var env []corev1.EnvVar
+	var envFrom []corev1.EnvFromSource
+	if storage.SecretName != "" && storageWorkloadIdentity(storage) == nil {
+		envFrom = append(envFrom, corev1.EnvFromSource{
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: storage.SecretName},
+			},
+		})
+	}
+	if region := s3Region(storage); region != "" {
+		env = append(env, corev1.EnvVar{Name: "AWS_REGION", Value: region})
+	}
+	env = append(env, buildS3OptionsEnv(storage)...)
+	return env, envFrom
+}
+
+// applyLocalDownload adds the s5cmd init container copying an s3:// model repository to the
+// download volume, an emptyDir or a generic ephemeral volume of the storage class, unless the
+// model cache of the node holds it
+func applyLocalDownload(podSpec *corev1.PodSpec, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) {
+	spec := localDownload(server, app)
+	if spec == nil {
+		return
+	}
+
+	image := spec.Image
+	if image == "" {
+		image = localDownloadDefaultImage
+	}
+	download := corev1.Container{
+		Name:            "s3-download",
+		Image:           image,
+		Args:            buildLocalDownloadArgs(server, app, spec),
+		SecurityContext: buildContainerSecurityContext(server.Spec.ContainerSecurityContext),
+	}
+	download.Env, download.EnvFrom = buildS3Env(app.Spec.Storage)
+	applyS3CABundle(podSpec, app.Spec.Storage, &download)
+	if server.Spec.ModelCache != "" {
+		applyModelCache(podSpec, &download, server)
+	}
+
+	source := corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: spec.SizeLimit}}
+	if spec.StorageClassName != nil && spec.SizeLimit != nil {
+		source = corev1.VolumeSource{
+			Ephemeral: &corev1.EphemeralVolumeSource{
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						StorageClassName: spec.StorageClassName,
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: *spec.SizeLimit},
+						},
+					},
+				},
+			},
+		}
+	}
+	applyModelDownloadVolume(podSpec, download, source)
+}
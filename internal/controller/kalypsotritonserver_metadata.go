@@ -0,0 +1,35 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+)
+
+// applyCustomMetadata merges spec.labels and spec.annotations onto a generated resource.
+// Labels and annotations removed from the spec are left on the resource
+func applyCustomMetadata(obj metav1.Object, server *servingv1alpha1.KalypsoTritonServer) {
+	if len(server.Spec.Labels) > 0 {
+		obj.SetLabels(defaults.MergeStringMaps(obj.GetLabels(), server.Spec.Labels))
+	}
+	if len(server.Spec.Annotations) > 0 {
+		obj.SetAnnotations(defaults.MergeStringMaps(obj.GetAnnotations(), server.Spec.Annotations))
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+)
+
+// IstioExcludeInboundPortsAnnotation keeps the Istio sidecar from intercepting the listed ports
+const IstioExcludeInboundPortsAnnotation = "traffic.sidecar.istio.io/excludeInboundPorts"
+
+// metricsServiceEnabled reports whether the metrics port is exposed through a dedicated Service
+func metricsServiceEnabled(server *servingv1alpha1.KalypsoTritonServer) bool {
+	networking := server.Spec.Networking
+	return networking != nil && networking.MetricsService != nil && networking.MetricsService.Enabled
+}
+
+// tritonMetricsPort returns the configured metrics port
+func tritonMetricsPort(server *servingv1alpha1.KalypsoTritonServer) int32 {
+	if server.Spec.Networking != nil && server.Spec.Networking.MetricsPort != nil {
+		return *server.Spec.Networking.MetricsPort
+	}
+	return defaults.MetricsPort
+}
+
+// reconcileMetricsService ensures the headless metrics Service exists while it is enabled, and
+// removes it otherwise
+func (r *KalypsoTritonServerReconciler) reconcileMetricsService(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, name string) error {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: server.Namespace,
+		},
+	}
+
+	if !metricsServiceEnabled(server) {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(service), service); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !metav1.IsControlledBy(service, server) {
+			return nil
+		}
+		return client.IgnoreNotFound(r.Delete(ctx, service))
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+		return r.mutateMetricsService(service, server)
+	})
+	return err
+}
+
+// mutateMetricsService applies the desired configuration to the metrics Service. The Service is
+// headless so scrapes go straight to each pod instead of through a load-balanced virtual IP
+func (r *KalypsoTritonServerReconciler) mutateMetricsService(service *corev1.Service, server *servingv1alpha1.KalypsoTritonServer) error {
+	// Set user-defined labels and annotations; the labels below take precedence
+	applyCustomMetadata(service, server)
+
+	// Set labels
+	if service.Labels == nil {
+		service.Labels = make(map[string]string)
+	}
+	service.Labels[TritonServerLabelKey] = server.Name
+	service.Labels[ApplicationLabelKey] = serverApplicationKey(server).Name
+	service.Labels[ManagedByLabelKey] = ManagedByLabelValue
+
+	// Set spec; ClusterIP is immutable and only set on creation
+	if service.CreationTimestamp.IsZero() {
+		service.Spec.ClusterIP = corev1.ClusterIPNone
+	}
+	service.Spec.Type = corev1.ServiceTypeClusterIP
+	service.Spec.Selector = map[string]string{
+		TritonServerLabelKey: server.Name,
+	}
+	service.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       "metrics",
+			Port:       tritonMetricsPort(server),
+			TargetPort: intstr.FromString("metrics"),
+			Protocol:   corev1.ProtocolTCP,
+		},
+	}
+
+	// Set owner reference
+	return controllerutil.SetControllerReference(server, service, r.Scheme)
+}
+
+// applyMetricsPortExclusion excludes the metrics port from sidecar interception so Prometheus can
+// scrape it without mesh mTLS
+func applyMetricsPortExclusion(annotations map[string]string, server *servingv1alpha1.KalypsoTritonServer) map[string]string {
+	if !metricsServiceEnabled(server) {
+		return annotations
+	}
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	port := strconv.Itoa(int(tritonMetricsPort(server)))
+	if excluded := annotations[IstioExcludeInboundPortsAnnotation]; excluded != "" {
+		port = excluded + "," + port
+	}
+	annotations[IstioExcludeInboundPortsAnnotation] = port
+	return annotations
+}
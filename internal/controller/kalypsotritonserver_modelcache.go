@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+const (
+	// ModelCacheVolumeName is the hostPath volume of the node model cache
+	ModelCacheVolumeName = "kalypso-model-cache"
+	// ModelCacheMountPath is where the node model cache is mounted in the cache and Triton containers
+	ModelCacheMountPath = "/kalypso/cache"
+	// modelCacheReadyDir holds a marker per repository the node cache has fully pulled
+	modelCacheReadyDir = ".ready"
+)
+
+// modelCacheLoadScript links the model repository to the node cache when it holds the
+// repository, and copies it from the bucket with s5cmd, whose flags are the arguments, otherwise
+const modelCacheLoadScript = `set -eu
+repository="$KALYPSO_MODELS/repository"
+rm -rf "$repository"
+if [ -f "$MODEL_CACHE/` + modelCacheReadyDir + `/$MODEL_CACHE_KEY" ]; then
+  echo "loading the model repository from the node cache"
+  ln -s "$MODEL_CACHE/$MODEL_CACHE_KEY" "$repository"
+else
+  echo "the node cache does not hold the model repository; copying it from the bucket"
+  /s5cmd "$@"
+fi
+`
+
+// modelCacheKey returns the node cache directory of an s3:// model repository
+func modelCacheKey(uri string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSuffix(uri, "/")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// modelCacheHostPath returns the node directory of a KalypsoModelCache
+func modelCacheHostPath(namespace, name string) string {
+	return path.Join(servingv1alpha1.ModelCacheHostPath, namespace, name)
+}
+
+// modelCacheVolume returns the hostPath volume of a KalypsoModelCache
+func modelCacheVolume(namespace, name string) corev1.Volume {
+	hostPathType := corev1.HostPathDirectoryOrCreate
+	return corev1.Volume{
+		Name: ModelCacheVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{
+				Path: modelCacheHostPath(namespace, name),
+				Type: &hostPathType,
+			},
+		},
+	}
+}
+
+// applyModelCache turns the s5cmd copy into a load from the node model cache, falling back to
+// the copy on a miss. The cache is mounted read-only at the same path in the Triton container,
+// so the linked repository resolves there
+func applyModelCache(podSpec *corev1.PodSpec, download *corev1.Container, server *servingv1alpha1.KalypsoTritonServer) {
+	download.Command = []string{"sh", "-c", modelCacheLoadScript, "sh"}
+	download.Env = append(download.Env,
+		corev1.EnvVar{Name: "KALYPSO_MODELS", Value: ModelDownloadMountPath},
+		corev1.EnvVar{Name: "MODEL_CACHE", Value: ModelCacheMountPath},
+		corev1.EnvVar{Name: "MODEL_CACHE_KEY", Value: modelCacheKey(server.Spec.StorageURI)},
+	)
+
+	podSpec.Volumes = append(podSpec.Volumes, modelCacheVolume(server.Namespace, server.Spec.ModelCache))
+	mount := corev1.VolumeMount{Name: ModelCacheVolumeName, MountPath: ModelCacheMountPath, ReadOnly: true}
+	download.VolumeMounts = append(download.VolumeMounts, mount)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, mount)
+}
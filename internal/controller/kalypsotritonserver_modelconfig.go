@@ -0,0 +1,457 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/triton"
+)
+
+// ModelConfigRevisionAnnotation records on a Triton pod the model configuration overrides it
+// was reloaded with, so each replica is reloaded once per change
+const ModelConfigRevisionAnnotation = "serving.kalypso.io/model-config-revision"
+
+// modelConfigSpec gathers the spec fields overriding the model configurations
+type modelConfigSpec struct {
+	VersionPolicy   *servingv1alpha1.ModelVersionPolicy    `json:"versionPolicy,omitempty"`
+	Warmup          *servingv1alpha1.WarmupSpec            `json:"warmup,omitempty"`
+	DynamicBatching []servingv1alpha1.ModelDynamicBatching `json:"dynamicBatching,omitempty"`
+	Overrides       []servingv1alpha1.ModelConfigOverride  `json:"overrides,omitempty"`
+}
+
+// modelConfigRevision returns a short hash of the model configuration overrides, empty when the
+// models are served with their own configuration
+func modelConfigRevision(server *servingv1alpha1.KalypsoTritonServer) string {
+	spec := modelConfigSpec{
+		VersionPolicy:   server.Spec.TritonConfig.VersionPolicy,
+		Warmup:          server.Spec.Warmup,
+		DynamicBatching: server.Spec.TritonConfig.DynamicBatching,
+		Overrides:       server.Spec.ModelConfigOverrides,
+	}
+	if spec.VersionPolicy == nil && spec.Warmup == nil && len(spec.DynamicBatching) == 0 && len(spec.Overrides) == 0 {
+		return ""
+	}
+	encoded, _ := json.Marshal(spec)
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:8])
+}
+
+// buildModelControlArgs switches Triton to the explicit model control mode, loading every
+// model at startup, so the operator can reload them with overridden configurations such as
+// the version policy, the warmup requests, the dynamic batcher and the instance groups
+func buildModelControlArgs(server *servingv1alpha1.KalypsoTritonServer, args []string) []string {
+	if modelConfigRevision(server) == "" || tritonParameterSet(server, "model-control-mode") {
+		return args
+	}
+	return append(args, "--model-control-mode=explicit", "--load-model=*")
+}
+
+// overrideModelConfig replaces the overridden fields of a model configuration, in the JSON
+// form Triton completed it to
+func overrideModelConfig(server *servingv1alpha1.KalypsoTritonServer, model string, config map[string]interface{}) {
+	// Replace the batch size first, so the dynamic batcher follows it
+	if override := modelConfigOverride(server, model); override != nil {
+		applyModelConfigOverride(override, config)
+	}
+	if policy := server.Spec.TritonConfig.VersionPolicy; policy != nil {
+		config["version_policy"] = buildVersionPolicy(policy)
+	}
+	if warmup := modelWarmup(server, model); warmup != nil {
+		config["model_warmup"] = buildModelWarmup(warmup, config)
+	}
+	if batching := modelDynamicBatching(server, model); batching != nil && configInt(config["max_batch_size"]) > 0 {
+		config["dynamic_batching"] = buildDynamicBatching(batching)
+	}
+}
+
+// buildVersionPolicy builds the version_policy of a model configuration
+func buildVersionPolicy(policy *servingv1alpha1.ModelVersionPolicy) map[string]interface{} {
+	switch {
+	case policy.Latest != nil:
+		return map[string]interface{}{"latest": map[string]interface{}{"num_versions": *policy.Latest}}
+	case len(policy.Specific) > 0:
+		return map[string]interface{}{"specific": map[string]interface{}{"versions": policy.Specific}}
+	default:
+		return map[string]interface{}{"all": map[string]interface{}{}}
+	}
+}
+
+// modelWarmup returns the warmup settings of a model, falling back to the "*" entry
+func modelWarmup(server *servingv1alpha1.KalypsoTritonServer, model string) *servingv1alpha1.ModelWarmup {
+	if server.Spec.Warmup == nil {
+		return nil
+	}
+	var fallback *servingv1alpha1.ModelWarmup
+	for i := range server.Spec.Warmup.Models {
+		warmup := &server.Spec.Warmup.Models[i]
+		switch warmup.Name {
+		case model:
+			return warmup
+		case "*":
+			fallback = warmup
+		}
+	}
+	return fallback
+}
+
+// modelConfigOverride returns the configuration override of a model, falling back to the "*"
+// entry
+func modelConfigOverride(server *servingv1alpha1.KalypsoTritonServer, model string) *servingv1alpha1.ModelConfigOverride {
+	var fallback *servingv1alpha1.ModelConfigOverride
+	for i := range server.Spec.ModelConfigOverrides {
+		override := &server.Spec.ModelConfigOverrides[i]
+		switch override.Name {
+		case model:
+			return override
+		case "*":
+			fallback = override
+		}
+	}
+	return fallback
+}
+
+// applyModelConfigOverride replaces the instance groups and batch size of a model
+// configuration and merges the optimization settings
+func applyModelConfigOverride(override *servingv1alpha1.ModelConfigOverride, config map[string]interface{}) {
+	if len(override.InstanceGroups) > 0 {
+		groups := make([]interface{}, 0, len(override.InstanceGroups))
+		for _, group := range override.InstanceGroups {
+			count := group.Count
+			if count == 0 {
+				count = 1
+			}
+			instanceGroup := map[string]interface{}{
+				"count": int64(count),
+				"kind":  instanceGroupKind(group.Kind),
+			}
+			if len(group.GPUs) > 0 {
+				gpus := make([]interface{}, 0, len(group.GPUs))
+				for _, gpu := range group.GPUs {
+					gpus = append(gpus, int64(gpu))
+				}
+				instanceGroup["gpus"] = gpus
+			}
+			groups = append(groups, instanceGroup)
+		}
+		config["instance_group"] = groups
+	}
+	if override.MaxBatchSize != nil {
+		config["max_batch_size"] = int64(*override.MaxBatchSize)
+	}
+	if override.Optimization != nil {
+		optimization, _ := config["optimization"].(map[string]interface{})
+		if optimization == nil {
+			optimization = make(map[string]interface{})
+		}
+		mergeModelOptimization(override.Optimization, optimization)
+		config["optimization"] = optimization
+	}
+}
+
+// instanceGroupKind returns the Triton instance group kind
+func instanceGroupKind(kind string) string {
+	switch kind {
+	case "GPU":
+		return "KIND_GPU"
+	case "CPU":
+		return "KIND_CPU"
+	default:
+		return "KIND_AUTO"
+	}
+}
+
+// mergeModelOptimization sets the optimization settings of a model configuration
+func mergeModelOptimization(spec *servingv1alpha1.ModelOptimization, optimization map[string]interface{}) {
+	if spec.CUDAGraphs != nil {
+		cuda, _ := optimization["cuda"].(map[string]interface{})
+		if cuda == nil {
+			cuda = make(map[string]interface{})
+		}
+		cuda["graphs"] = *spec.CUDAGraphs
+		optimization["cuda"] = cuda
+	}
+	if spec.InputPinnedMemory != nil {
+		optimization["input_pinned_memory"] = map[string]interface{}{"enable": *spec.InputPinnedMemory}
+	}
+	if spec.OutputPinnedMemory != nil {
+		optimization["output_pinned_memory"] = map[string]interface{}{"enable": *spec.OutputPinnedMemory}
+	}
+	if spec.TensorRTPrecision != "" {
+		accelerators, _ := optimization["execution_accelerators"].(map[string]interface{})
+		if accelerators == nil {
+			accelerators = make(map[string]interface{})
+		}
+		accelerators["gpu_execution_accelerator"] = []interface{}{
+			map[string]interface{}{
+				"name":       "tensorrt",
+				"parameters": map[string]interface{}{"precision_mode": spec.TensorRTPrecision},
+			},
+		}
+		optimization["execution_accelerators"] = accelerators
+	}
+}
+
+// modelDynamicBatching returns the dynamic batcher settings of a model, falling back to the
+// "*" entry
+func modelDynamicBatching(server *servingv1alpha1.KalypsoTritonServer, model string) *servingv1alpha1.ModelDynamicBatching {
+	var fallback *servingv1alpha1.ModelDynamicBatching
+	for i := range server.Spec.TritonConfig.DynamicBatching {
+		batching := &server.Spec.TritonConfig.DynamicBatching[i]
+		switch batching.Name {
+		case model:
+			return batching
+		case "*":
+			fallback = batching
+		}
+	}
+	return fallback
+}
+
+// buildDynamicBatching builds the dynamic_batching of a model configuration
+func buildDynamicBatching(batching *servingv1alpha1.ModelDynamicBatching) map[string]interface{} {
+	preferred := make([]interface{}, 0, len(batching.PreferredBatchSizes))
+	for _, size := range batching.PreferredBatchSizes {
+		preferred = append(preferred, int64(size))
+	}
+	return map[string]interface{}{
+		"preferred_batch_size":         preferred,
+		"max_queue_delay_microseconds": batching.MaxQueueDelayMicroseconds,
+		"preserve_ordering":            batching.PreserveOrdering,
+	}
+}
+
+// buildModelWarmup builds the model_warmup of a model configuration. Input types and shapes
+// left unset are taken from the configuration inputs, and unlisted inputs are zeros
+func buildModelWarmup(warmup *servingv1alpha1.ModelWarmup, config map[string]interface{}) []interface{} {
+	configInputs := make(map[string]map[string]interface{})
+	var names []string
+	inputs, _ := config["input"].([]interface{})
+	for _, i := range inputs {
+		input, ok := i.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := input["name"].(string)
+		configInputs[name] = input
+		names = append(names, name)
+	}
+
+	specs := append([]servingv1alpha1.WarmupInput(nil), warmup.Inputs...)
+	for _, name := range names {
+		listed := false
+		for _, spec := range warmup.Inputs {
+			listed = listed || spec.Name == name
+		}
+		if !listed {
+			specs = append(specs, servingv1alpha1.WarmupInput{Name: name})
+		}
+	}
+
+	tensors := make(map[string]interface{}, len(specs))
+	for _, spec := range specs {
+		configInput := configInputs[spec.Name]
+		dataType := spec.DataType
+		if dataType == "" {
+			dataType, _ = configInput["data_type"].(string)
+		}
+		dims := spec.Dims
+		if len(dims) == 0 {
+			dims = warmupDims(configInput["dims"])
+		}
+		dimValues := make([]interface{}, 0, len(dims))
+		for _, dim := range dims {
+			dimValues = append(dimValues, dim)
+		}
+
+		tensor := map[string]interface{}{"data_type": dataType, "dims": dimValues}
+		switch spec.Data {
+		case "Random":
+			tensor["random_data"] = true
+		case "File":
+			tensor["input_data_file"] = spec.File
+		default:
+			tensor["zero_data"] = true
+		}
+		tensors[spec.Name] = tensor
+	}
+
+	batchSize := warmup.BatchSize
+	if batchSize == 0 {
+		batchSize = 1
+	}
+	count := warmup.Count
+	if count == 0 {
+		count = 1
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"name":       "kalypso_warmup",
+			"batch_size": int64(batchSize),
+			"count":      int64(count),
+			"inputs":     tensors,
+		},
+	}
+}
+
+// warmupDims converts the dims of a configuration input, numbers or strings in the Triton JSON
+// form, to a warmup shape with variable dimensions set to 1
+func warmupDims(value interface{}) []int64 {
+	values, _ := value.([]interface{})
+	dims := make([]int64, 0, len(values))
+	for _, v := range values {
+		dim := configInt(v)
+		if dim < 1 {
+			dim = 1
+		}
+		dims = append(dims, dim)
+	}
+	return dims
+}
+
+// configInt converts an integer of a model configuration, a number or a string in the Triton
+// JSON form
+func configInt(value interface{}) int64 {
+	switch v := value.(type) {
+	case float64:
+		return int64(v)
+	case int64:
+		return v
+	case string:
+		i, _ := strconv.ParseInt(v, 10, 64)
+		return i
+	}
+	return 0
+}
+
+// modelConfigResult is the outcome of reloading the models of the server's pods
+type modelConfigResult struct {
+	revision string
+	pending  int
+	failures []string
+}
+
+// applyModelConfig reloads the models of every ready pod not yet reloaded with the current
+// overrides, and records the revision on the pod. Models serve their own configuration
+// between the pod becoming ready and the reload
+func (r *KalypsoTritonServerReconciler) applyModelConfig(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer) *modelConfigResult {
+	revision := modelConfigRevision(server)
+	if r.ModelIndex == nil || revision == "" {
+		return nil
+	}
+	result := &modelConfigResult{revision: revision}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(server.Namespace), client.MatchingLabels{TritonServerLabelKey: server.Name}); err != nil {
+		result.failures = append(result.failures, err.Error())
+		return result
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Annotations[ModelConfigRevisionAnnotation] == revision || !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if !podReady(pod) {
+			result.pending++
+			continue
+		}
+		if err := r.reloadPodModels(ctx, server, pod); err != nil {
+			result.failures = append(result.failures, fmt.Sprintf("%s: %v", pod.Name, err))
+			continue
+		}
+
+		patch := client.MergeFrom(pod.DeepCopy())
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[ModelConfigRevisionAnnotation] = revision
+		if err := r.Patch(ctx, pod, patch); err != nil {
+			result.failures = append(result.failures, fmt.Sprintf("%s: %v", pod.Name, err))
+		}
+	}
+	return result
+}
+
+// reloadPodModels reloads every model ready on the pod with the configuration Triton completed
+// for it, merged with the overrides
+func (r *KalypsoTritonServerReconciler) reloadPodModels(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, pod *corev1.Pod) error {
+	endpoint := fmt.Sprintf("http://%s:%d", pod.Status.PodIP, tritonHTTPPort(server, nil))
+	models, err := r.ModelIndex.RepositoryIndex(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	for _, model := range triton.ReadyModels(models) {
+		config, err := r.ModelIndex.ModelConfig(ctx, endpoint, model)
+		if err != nil {
+			return err
+		}
+		overrideModelConfig(server, model, config)
+		if err := r.ModelIndex.LoadModel(ctx, endpoint, model, config); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// podReady reports whether the pod passes its readiness probe
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// applyModelConfigStatus records whether every replica serves the overridden model configurations
+func applyModelConfigStatus(server *servingv1alpha1.KalypsoTritonServer, result *modelConfigResult) {
+	if result == nil {
+		meta.RemoveStatusCondition(&server.Status.Conditions, "ModelConfigApplied")
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               "ModelConfigApplied",
+		Status:             metav1.ConditionTrue,
+		Reason:             "ModelsReloaded",
+		Message:            fmt.Sprintf("Models are reloaded with configuration revision %s", result.revision),
+		LastTransitionTime: metav1.Now(),
+	}
+	switch {
+	case len(result.failures) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ReloadFailed"
+		condition.Message = strings.Join(result.failures, "; ")
+	case result.pending > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ReloadPending"
+		condition.Message = fmt.Sprintf("%d pods wait to become ready before their models are reloaded", result.pending)
+	}
+	meta.SetStatusCondition(&server.Status.Conditions, condition)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+const (
+	// ModelDownloadVolumeName is the emptyDir volume downloaded model repositories are written to
+	ModelDownloadVolumeName = "kalypso-models"
+	// ModelDownloadMountPath is where the download volume is mounted in the init and Triton containers
+	ModelDownloadMountPath = "/kalypso/models"
+)
+
+// modelRepositoryPath returns the model repository passed to Triton: a directory of the
+// download volume for repositories downloaded at startup, the storage URI otherwise
+func modelRepositoryPath(server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) string {
+	repository := path.Join(ModelDownloadMountPath, "repository")
+	if localDownload(server, app) != nil {
+		return repository
+	}
+	if _, _, ok := parseHuggingFaceURI(server.Spec.StorageURI); ok {
+		return repository
+	}
+	if _, _, ok := parseGitURI(server.Spec.StorageURI); ok {
+		return gitSyncRepositoryPath(server)
+	}
+	if isArchiveURI(server.Spec.StorageURI) {
+		return archiveRepositoryPath(server)
+	}
+	if _, ok := parseOCIArtifactURI(server.Spec.StorageURI); ok {
+		if server.Spec.OCI != nil && server.Spec.OCI.Path != "" {
+			return path.Join(repository, server.Spec.OCI.Path)
+		}
+		return repository
+	}
+	return s3PathStyleURI(server.Spec.StorageURI, app.Spec.Storage)
+}
+
+// applyModelDownload adds an init container writing the model repository to an emptyDir
+// download volume, mounted read-only in the Triton container
+func applyModelDownload(podSpec *corev1.PodSpec, download corev1.Container, sizeLimit *resource.Quantity) {
+	applyModelDownloadVolume(podSpec, download, corev1.VolumeSource{
+		EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: sizeLimit},
+	})
+}
+
+// applyModelDownloadVolume adds an init container writing the model repository to the download
+// volume of the source, mounted read-only in the Triton container
+func applyModelDownloadVolume(podSpec *corev1.PodSpec, download corev1.Container, source corev1.VolumeSource) {
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name:         ModelDownloadVolumeName,
+		VolumeSource: source,
+	})
+	mount := corev1.VolumeMount{Name: ModelDownloadVolumeName, MountPath: ModelDownloadMountPath}
+	download.VolumeMounts = append(download.VolumeMounts, mount)
+	podSpec.InitContainers = append(podSpec.InitContainers, download)
+
+	mount.ReadOnly = true
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, mount)
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/mlflow"
+)
+
+// modelRegistryInterval is how often the stage of a registered model is checked by default
+const modelRegistryInterval = 5 * time.Minute
+
+// modelRegistryResult is the outcome of resolving a mlflow:// storage URI
+type modelRegistryResult struct {
+	// status is the version served, nil when the storage URI was never resolved
+	status *servingv1alpha1.ModelRegistryStatus
+	// err is the last resolution failure; the previous version keeps being served
+	err error
+	// interval is how often the stage is checked
+	interval time.Duration
+}
+
+// resolveModelRegistry resolves a mlflow:// storage URI to the artifacts of the latest version
+// in the stage. The stage is checked once per interval, the version resolved last is reused
+// in between. Returns nil for other storage URIs
+func (r *KalypsoTritonServerReconciler) resolveModelRegistry(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer) *modelRegistryResult {
+	log := logf.FromContext(ctx)
+
+	uri, ok, err := mlflow.ParseURI(server.Spec.StorageURI)
+	if !ok {
+		return nil
+	}
+	result := &modelRegistryResult{interval: modelRegistryInterval}
+	if err != nil {
+		result.err = err
+		return result
+	}
+	if server.Spec.MLflow != nil {
+		result.interval = parseDurationOrDefault(server.Spec.MLflow.Interval, modelRegistryInterval)
+	}
+
+	current := server.Status.ModelRegistry
+	if current != nil && current.StorageURI == server.Spec.StorageURI {
+		result.status = current.DeepCopy()
+		if current.LastCheckTime != nil && time.Since(current.LastCheckTime.Time) < result.interval {
+			return result
+		}
+	}
+
+	if r.ModelRegistry == nil {
+		result.err = fmt.Errorf("no MLflow client is configured")
+		return result
+	}
+	credentials, err := r.modelRegistryCredentials(ctx, server)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	scheme := "https"
+	if server.Spec.MLflow != nil && server.Spec.MLflow.Insecure {
+		scheme = "http"
+	}
+	version, err := r.ModelRegistry.LatestVersion(ctx, scheme+"://"+uri.Registry, credentials, uri.Model, uri.Stage)
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	if result.status != nil && result.status.Version != version.Version {
+		log.Info("Registered model stage moved to a new version",
+			"model", uri.Model,
+			"stage", uri.Stage,
+			"previousVersion", result.status.Version,
+			"version", version.Version)
+	}
+	now := metav1.Now()
+	result.status = &servingv1alpha1.ModelRegistryStatus{
+		StorageURI:    server.Spec.StorageURI,
+		Version:       version.Version,
+		ArtifactURI:   version.ArtifactURI,
+		LastCheckTime: &now,
+	}
+	return result
+}
+
+// modelRegistryCredentials reads the tracking server credentials from the configured Secret
+func (r *KalypsoTritonServerReconciler) modelRegistryCredentials(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer) (mlflow.Credentials, error) {
+	if server.Spec.MLflow == nil || server.Spec.MLflow.CredentialsSecret == "" {
+		return mlflow.Credentials{}, nil
+	}
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: server.Namespace, Name: server.Spec.MLflow.CredentialsSecret}
+	if err := r.secretReader().Get(ctx, key, secret); err != nil {
+		return mlflow.Credentials{}, fmt.Errorf("failed to get model registry credentials: %w", err)
+	}
+	return mlflow.Credentials{
+		Token:    string(secret.Data["token"]),
+		Username: string(secret.Data["username"]),
+		Password: string(secret.Data["password"]),
+	}, nil
+}
+
+// withModelRegistryVersion returns a copy of the server serving the artifacts of the resolved
+// model version as its model repository
+func withModelRegistryVersion(server *servingv1alpha1.KalypsoTritonServer, result *modelRegistryResult) *servingv1alpha1.KalypsoTritonServer {
+	if result == nil || result.status == nil {
+		return server
+	}
+	resolved := server.DeepCopy()
+	resolved.Spec.StorageURI = result.status.ArtifactURI
+	return resolved
+}
+
+// applyModelRegistryStatus records the registered model version served by the server
+func applyModelRegistryStatus(server *servingv1alpha1.KalypsoTritonServer, result *modelRegistryResult) {
+	if result == nil {
+		server.Status.ModelRegistry = nil
+		meta.RemoveStatusCondition(&server.Status.Conditions, "ModelRegistryResolved")
+		return
+	}
+	server.Status.ModelRegistry = result.status
+
+	if result.err != nil {
+		message := result.err.Error()
+		if result.status != nil {
+			message = fmt.Sprintf("%s; still serving version %s", message, result.status.Version)
+		}
+		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               "ModelRegistryResolved",
+			Status:             metav1.ConditionFalse,
+			Reason:             "ResolveFailed",
+			Message:            message,
+			LastTransitionTime: metav1.Now(),
+		})
+		return
+	}
+	meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               "ModelRegistryResolved",
+		Status:             metav1.ConditionTrue,
+		Reason:             "VersionResolved",
+		Message:            fmt.Sprintf("Serving version %s from %s", result.status.Version, result.status.ArtifactURI),
+		LastTransitionTime: metav1.Now(),
+	})
+}
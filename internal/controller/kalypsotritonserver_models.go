@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+	"github.com/kalypsoServing/KalypsoServing/internal/triton"
+)
+
+// modelIndexInterval is how often the repository index of a running server is refreshed, to
+// follow models loaded or unloaded through the Triton model control API
+const modelIndexInterval = 5 * time.Minute
+
+// modelIndexRetryInterval is how often the repository index is re-read while models load
+const modelIndexRetryInterval = 15 * time.Second
+
+// modelIndexResult is the outcome of the repository index query of a server
+type modelIndexResult struct {
+	models  []string
+	unready []string
+	indexed bool
+}
+
+// modelsPending reports whether the server waits for models to become ready
+func (m *modelIndexResult) modelsPending() bool {
+	return m != nil && len(m.unready) > 0
+}
+
+// indexModels lists the models ready on the server and the models blocking its readiness.
+// Servers without available replicas serve no model; a failed query keeps the last known models
+func (r *KalypsoTritonServerReconciler) indexModels(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, availableReplicas int32) *modelIndexResult {
+	if r.ModelIndex == nil {
+		return nil
+	}
+	if availableReplicas == 0 || isArchived(server) || server.Spec.Suspend {
+		return &modelIndexResult{indexed: true}
+	}
+
+	models, err := r.ModelIndex.RepositoryIndex(ctx, tritonServiceEndpoint(server, nil))
+	if err != nil {
+		logf.FromContext(ctx).Info("Failed to read the Triton repository index", "server", server.Name, "error", err)
+		return &modelIndexResult{}
+	}
+	return &modelIndexResult{
+		models:  triton.ReadyModels(models),
+		unready: triton.UnreadyModels(models, server.Spec.RequiredModels),
+		indexed: true,
+	}
+}
+
+// applyModelIndexStatus records the models ready on the server, and whether the models it
+// must serve are ready. The condition is left unchanged when the index could not be read
+func applyModelIndexStatus(server *servingv1alpha1.KalypsoTritonServer, result *modelIndexResult) {
+	if result == nil {
+		meta.RemoveStatusCondition(&server.Status.Conditions, "ModelsReady")
+		return
+	}
+	if !result.indexed {
+		return
+	}
+	server.Status.Models = result.models
+
+	condition := metav1.Condition{
+		Type:               "ModelsReady",
+		Status:             metav1.ConditionTrue,
+		Reason:             "ModelsLoaded",
+		Message:            fmt.Sprintf("%d models ready for inference", len(result.models)),
+		LastTransitionTime: metav1.Now(),
+	}
+	if result.modelsPending() {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ModelsNotReady"
+		condition.Message = "Models not ready: " + strings.Join(result.unready, ", ")
+	}
+	meta.SetStatusCondition(&server.Status.Conditions, condition)
+}
+
+// tritonServiceEndpoint returns the HTTP endpoint of the server's Service; app is passed to tritonHTTPPort
+func tritonServiceEndpoint(server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) string {
+	return fmt.Sprintf("http://%s.%s.svc:%d", naming.Service(server.Name), server.Namespace, tritonHTTPPort(server, app))
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+// networkPolicyEnabled reports whether the Triton pods are isolated by a NetworkPolicy
+func networkPolicyEnabled(server *servingv1alpha1.KalypsoTritonServer) bool {
+	networking := server.Spec.Networking
+	return networking != nil && networking.NetworkPolicy != nil && networking.NetworkPolicy.Enabled
+}
+
+// reconcileNetworkPolicy ensures the NetworkPolicy of the Triton pods, and removes it when
+// it is disabled
+func (r *KalypsoTritonServerReconciler) reconcileNetworkPolicy(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication, name string) error {
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: server.Namespace,
+		},
+	}
+
+	if !networkPolicyEnabled(server) {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(policy), policy); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !metav1.IsControlledBy(policy, server) {
+			return nil
+		}
+		return client.IgnoreNotFound(r.Delete(ctx, policy))
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, policy, func() error {
+		// Set labels
+		if policy.Labels == nil {
+			policy.Labels = make(map[string]string)
+		}
+		policy.Labels[TritonServerLabelKey] = server.Name
+		policy.Labels[ApplicationLabelKey] = serverApplicationKey(server).Name
+		policy.Labels[ManagedByLabelKey] = ManagedByLabelValue
+
+		// Set spec
+		policy.Spec = buildNetworkPolicySpec(server, app)
+
+		// Set owner reference
+		return controllerutil.SetControllerReference(server, policy, r.Scheme)
+	})
+	return err
+}
+
+// buildNetworkPolicySpec admits the application gateway and the allowed namespaces to the HTTP
+// and gRPC ports, and Prometheus to the metrics port. Any other ingress traffic is denied
+func buildNetworkPolicySpec(server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) networkingv1.NetworkPolicySpec {
+	settings := server.Spec.Networking.NetworkPolicy
+
+	inferenceNamespaces := map[string]bool{gatewayNamespace(app): true}
+	for _, namespace := range settings.AllowedNamespaces {
+		inferenceNamespaces[namespace] = true
+	}
+	prometheusNamespace := settings.PrometheusNamespace
+	if prometheusNamespace == "" {
+		prometheusNamespace = "monitoring"
+	}
+
+	return networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{TritonServerLabelKey: server.Name},
+		},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
+			{
+				From: namespacePeers(inferenceNamespaces),
+				Ports: []networkingv1.NetworkPolicyPort{
+					networkPolicyPort(tritonHTTPPort(server, app)),
+					networkPolicyPort(tritonGRPCPort(server, app)),
+				},
+			},
+			{
+				From:  namespacePeers(map[string]bool{prometheusNamespace: true}),
+				Ports: []networkingv1.NetworkPolicyPort{networkPolicyPort(tritonMetricsPort(server))},
+			},
+		},
+	}
+}
+
+// gatewayNamespace returns the namespace of the gateway routing traffic to the application
+func gatewayNamespace(app *servingv1alpha1.KalypsoApplication) string {
+	if usesGatewayAPI(app) {
+		if namespace := app.Spec.Routing.GatewayAPI.GatewayRef.Namespace; namespace != "" {
+			return namespace
+		}
+		return app.Namespace
+	}
+	return ingressNamespace(app)
+}
+
+// namespacePeers selects every pod of the namespaces, in a stable order
+func namespacePeers(namespaces map[string]bool) []networkingv1.NetworkPolicyPeer {
+	names := make([]string, 0, len(namespaces))
+	for namespace := range namespaces {
+		names = append(names, namespace)
+	}
+	sort.Strings(names)
+
+	peers := make([]networkingv1.NetworkPolicyPeer, 0, len(names))
+	for _, namespace := range names {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{corev1.LabelMetadataName: namespace},
+			},
+		})
+	}
+	return peers
+}
+
+// networkPolicyPort returns a TCP port of a NetworkPolicy rule
+func networkPolicyPort(port int32) networkingv1.NetworkPolicyPort {
+	protocol := corev1.ProtocolTCP
+	target := intstr.FromInt32(port)
+	return networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &target}
+}
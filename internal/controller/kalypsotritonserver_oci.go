@@ -0,0 +1,209 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/imagearch"
+)
+
+const (
+	// OCIArtifactScheme is the storage URI scheme of model repositories packaged as OCI artifacts
+	OCIArtifactScheme = "oci://"
+	// OCICredentialsVolumeName is the volume holding the registry credentials of the ORAS pull
+	OCICredentialsVolumeName = "kalypso-registry-credentials"
+	// OCICredentialsMountPath is the DOCKER_CONFIG directory of the ORAS pull
+	OCICredentialsMountPath = "/kalypso/registry"
+	// ociDefaultImage pulls the artifact files
+	ociDefaultImage = "ghcr.io/oras-project/oras:v1.2.2"
+)
+
+// parseOCIArtifactURI returns the artifact reference of an oci:// storage URI; ok is false for
+// other schemes
+func parseOCIArtifactURI(uri string) (string, bool) {
+	if !strings.HasPrefix(uri, OCIArtifactScheme) {
+		return "", false
+	}
+	return strings.TrimPrefix(uri, OCIArtifactScheme), true
+}
+
+// modelArtifactResult is the outcome of resolving an oci:// storage URI to a manifest digest
+type modelArtifactResult struct {
+	// digest is the manifest digest pulled, empty when the storage URI was never resolved
+	digest string
+	// reason tells whether the digest was pinned or resolved from the tag
+	reason string
+	// err is the last resolution failure; the previous digest keeps being pulled
+	err error
+}
+
+// resolveModelArtifact pins an oci:// storage URI to a manifest digest: the digest of the
+// reference or spec.oci.digest, or else the current digest of the tag. Pulling by digest makes
+// ORAS verify the manifest and every file against it. Returns nil for other storage URIs
+func (r *KalypsoTritonServerReconciler) resolveModelArtifact(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer) *modelArtifactResult {
+	reference, ok := parseOCIArtifactURI(server.Spec.StorageURI)
+	if !ok {
+		return nil
+	}
+	result := &modelArtifactResult{}
+	ref, err := imagearch.ParseReference(reference)
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	pinned := ""
+	if strings.HasPrefix(ref.Reference, "sha256:") {
+		pinned = ref.Reference
+	}
+	if server.Spec.OCI != nil && server.Spec.OCI.Digest != "" {
+		if pinned != "" && pinned != server.Spec.OCI.Digest {
+			result.err = fmt.Errorf("storage URI digest %s does not match spec.oci.digest %s", pinned, server.Spec.OCI.Digest)
+			return result
+		}
+		pinned = server.Spec.OCI.Digest
+	}
+	if pinned != "" {
+		result.digest = pinned
+		result.reason = "DigestPinned"
+		return result
+	}
+
+	if current := server.Status.ModelArtifact; current != nil && current.StorageURI == server.Spec.StorageURI {
+		result.digest = current.Digest
+	}
+	if r.ArtifactResolver == nil {
+		result.err = fmt.Errorf("no registry client is configured to resolve tag %s", ref.Reference)
+		return result
+	}
+	digest, err := r.ArtifactResolver.Digest(ctx, reference)
+	if err != nil {
+		result.err = fmt.Errorf("failed to resolve %s: %w", reference, err)
+		return result
+	}
+	result.digest = digest
+	result.reason = "TagResolved"
+	return result
+}
+
+// withModelArtifactDigest returns a copy of the server pulling its OCI artifact by digest
+func withModelArtifactDigest(server *servingv1alpha1.KalypsoTritonServer, result *modelArtifactResult) *servingv1alpha1.KalypsoTritonServer {
+	if result == nil || result.digest == "" {
+		return server
+	}
+	reference, _ := parseOCIArtifactURI(server.Spec.StorageURI)
+	ref, err := imagearch.ParseReference(reference)
+	if err != nil {
+		return server
+	}
+	pinned := server.DeepCopy()
+	pinned.Spec.StorageURI = fmt.Sprintf("%s%s/%s@%s", OCIArtifactScheme, ref.Registry, ref.Repository, result.digest)
+	return pinned
+}
+
+// applyOCIArtifactPull adds the ORAS init container pulling an OCI artifact to the model
+// download volume
+func applyOCIArtifactPull(podSpec *corev1.PodSpec, server *servingv1alpha1.KalypsoTritonServer) {
+	reference, ok := parseOCIArtifactURI(server.Spec.StorageURI)
+	if !ok {
+		return
+	}
+	spec := server.Spec.OCI
+	if spec == nil {
+		spec = &servingv1alpha1.OCIArtifactSpec{}
+	}
+
+	image := spec.Image
+	if image == "" {
+		image = ociDefaultImage
+	}
+	args := []string{"pull", reference, "--output", path.Join(ModelDownloadMountPath, "repository")}
+	if spec.PlainHTTP {
+		args = append(args, "--plain-http")
+	}
+	pull := corev1.Container{
+		Name:            "oci-pull",
+		Image:           image,
+		Args:            args,
+		SecurityContext: buildContainerSecurityContext(server.Spec.ContainerSecurityContext),
+	}
+	if spec.PullSecret != "" {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: OCICredentialsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: spec.PullSecret,
+					Items:      []corev1.KeyToPath{{Key: corev1.DockerConfigJsonKey, Path: "config.json"}},
+				},
+			},
+		})
+		pull.Env = append(pull.Env, corev1.EnvVar{Name: "DOCKER_CONFIG", Value: OCICredentialsMountPath})
+		pull.VolumeMounts = append(pull.VolumeMounts, corev1.VolumeMount{
+			Name:      OCICredentialsVolumeName,
+			MountPath: OCICredentialsMountPath,
+			ReadOnly:  true,
+		})
+	}
+	applyModelDownload(podSpec, pull, spec.SizeLimit)
+}
+
+// applyModelArtifactStatus records the digest the OCI artifact is pulled at
+func applyModelArtifactStatus(server *servingv1alpha1.KalypsoTritonServer, result *modelArtifactResult) {
+	if result == nil {
+		server.Status.ModelArtifact = nil
+		meta.RemoveStatusCondition(&server.Status.Conditions, "ModelArtifactResolved")
+		return
+	}
+	server.Status.ModelArtifact = nil
+	if result.digest != "" {
+		server.Status.ModelArtifact = &servingv1alpha1.ModelArtifactStatus{
+			StorageURI: server.Spec.StorageURI,
+			Digest:     result.digest,
+		}
+	}
+
+	if result.err != nil {
+		message := result.err.Error()
+		if result.digest != "" {
+			message = fmt.Sprintf("%s; still pulling %s", message, result.digest)
+		}
+		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               "ModelArtifactResolved",
+			Status:             metav1.ConditionFalse,
+			Reason:             "ResolveFailed",
+			Message:            message,
+			LastTransitionTime: metav1.Now(),
+		})
+		return
+	}
+	meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               "ModelArtifactResolved",
+		Status:             metav1.ConditionTrue,
+		Reason:             result.reason,
+		Message:            "Pulling the model artifact at " + result.digest,
+		LastTransitionTime: metav1.Now(),
+	})
+}
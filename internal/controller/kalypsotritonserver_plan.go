@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+const (
+	// PlanApprovalAnnotation approves the pending plan rendered for the annotated spec generation
+	PlanApprovalAnnotation = "serving.kalypso.io/approved-generation"
+	// maxPlanDiffBytes bounds the rendered diff kept in status
+	maxPlanDiffBytes = 16 * 1024
+	// unrenderedPlanDiff is the plan of a change leaving the workload and Service as they are
+	unrenderedPlanDiff = "No change to the workload or Service; the change applies to other resources of the server, such as its Ingress, PodDisruptionBudget, NetworkPolicy, ServiceAccount or ServiceMonitor\n"
+)
+
+// renderedSpecHash identifies the spec of the server with the defaults it inherits from the
+// preset, the application and the project, so changed defaults are held like spec changes
+func renderedSpecHash(server *servingv1alpha1.KalypsoTritonServer) string {
+	encoded, _ := json.Marshal(server.Spec)
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:8])
+}
+
+// planPending reports whether the server's latest spec generation, or the defaults it inherits,
+// are held for approval. specHash is the renderedSpecHash of the server
+func planPending(server *servingv1alpha1.KalypsoTritonServer, specHash string) bool {
+	if server.Spec.ChangePolicy != servingv1alpha1.ChangePolicyManual {
+		return false
+	}
+	// The first generation is applied directly since there is nothing to review it against
+	if server.Status.AppliedGeneration == 0 {
+		return false
+	}
+	approved := server.Annotations[PlanApprovalAnnotation]
+	if server.Status.AppliedGeneration != server.Generation {
+		return approved != strconv.FormatInt(server.Generation, 10) && approved != specHash
+	}
+	// Changed defaults leave the generation as is, so they are approved by the spec hash
+	return server.Status.AppliedSpecHash != "" && server.Status.AppliedSpecHash != specHash && approved != specHash
+}
+
+// reconcilePlan records the pending plan for the server's spec generation in status and reports
+// whether the change is held. Every pending change is held: the diff only covers the workload and
+// Service, so it informs the review rather than deciding it
+func (r *KalypsoTritonServerReconciler) reconcilePlan(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication, specHash string) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	if plan := server.Status.PendingPlan; plan != nil && plan.Generation == server.Generation && plan.SpecHash == specHash {
+		return true, nil
+	}
+
+	planDiff, err := r.renderPlan(ctx, server, app)
+	if err != nil {
+		return false, err
+	}
+	if planDiff == "" {
+		planDiff = unrenderedPlanDiff
+	}
+
+	server.Status.PendingPlan = &servingv1alpha1.ChangePlan{
+		Generation: server.Generation,
+		SpecHash:   specHash,
+		Diff:       planDiff,
+		RenderedAt: metav1.Now(),
+	}
+	message := fmt.Sprintf("Spec generation %d is awaiting approval; set the %s annotation to %d to apply it",
+		server.Generation, PlanApprovalAnnotation, server.Generation)
+	if server.Status.AppliedGeneration == server.Generation {
+		message = fmt.Sprintf("Changed defaults of the application or project are awaiting approval; set the %s annotation to %s to apply them",
+			PlanApprovalAnnotation, specHash)
+	}
+	meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               "PlanPending",
+		Status:             metav1.ConditionTrue,
+		Reason:             "AwaitingApproval",
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+
+	log.Info("Holding spec change for approval", "server", server.Name, "generation", server.Generation)
+	return true, r.updateStatus(ctx, server, true)
+}
+
+// renderPlan renders a diff between the current and desired workload and
+// Service specs, using server-side dry-run so API defaults do not show up as changes
+func (r *KalypsoTritonServerReconciler) renderPlan(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) (string, error) {
+	workloadMeta := metav1.ObjectMeta{Name: workloadName(server), Namespace: server.Namespace}
+	var workloadDiff string
+	var err error
+	switch {
+	case isArgoRollout(server):
+		workloadDiff, err = dryRunDiff(ctx, r.Client, newArgoRollout(workloadMeta.Name, workloadMeta.Namespace),
+			func(u *unstructured.Unstructured) error { return r.mutateArgoRollout(u, server, app) },
+			func(u *unstructured.Unstructured) any { return u.Object["spec"] })
+	case isStatefulSet(server):
+		workloadDiff, err = dryRunDiff(ctx, r.Client, &appsv1.StatefulSet{ObjectMeta: workloadMeta},
+			func(s *appsv1.StatefulSet) error { return r.mutateStatefulSet(s, server, app) },
+			func(s *appsv1.StatefulSet) any { return s.Spec })
+	default:
+		workloadDiff, err = dryRunDiff(ctx, r.Client, &appsv1.Deployment{ObjectMeta: workloadMeta},
+			func(d *appsv1.Deployment) error { return r.mutateDeployment(d, server, app) },
+			func(d *appsv1.Deployment) any { return d.Spec })
+	}
+	if err != nil {
+		return "", fmt.Errorf("rendering %s plan: %w", workloadKind(server), err)
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: naming.Service(server.Name), Namespace: server.Namespace},
+	}
+	serviceDiff, err := dryRunDiff(ctx, r.Client, service,
+		func(s *corev1.Service) error { return r.mutateService(s, server) },
+		func(s *corev1.Service) any { return s.Spec })
+	if err != nil {
+		return "", fmt.Errorf("rendering Service plan: %w", err)
+	}
+
+	var b strings.Builder
+	if workloadDiff != "" {
+		fmt.Fprintf(&b, "%s/%s\n%s", workloadKind(server), workloadMeta.Name, workloadDiff)
+	}
+	if serviceDiff != "" {
+		fmt.Fprintf(&b, "Service/%s\n%s", service.Name, serviceDiff)
+	}
+
+	planDiff := b.String()
+	if len(planDiff) > maxPlanDiffBytes {
+		planDiff = planDiff[:maxPlanDiffBytes] + "\n... (truncated)"
+	}
+	return planDiff, nil
+}
+
+// dryRunDiff applies mutate to a copy of the current object, submits it as a dry-run and returns
+// the diff of the resulting spec, or an empty string when the spec is unchanged
+func dryRunDiff[T client.Object](ctx context.Context, c client.Client, obj T, mutate func(T) error, spec func(T) any) (string, error) {
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		if !errors.IsNotFound(err) {
+			return "", err
+		}
+		if err := mutate(obj); err != nil {
+			return "", err
+		}
+		if err := c.Create(ctx, obj, client.DryRunAll); err != nil {
+			return "", err
+		}
+		return diff.Diff(nil, spec(obj)), nil
+	}
+
+	desired := obj.DeepCopyObject().(T)
+	if err := mutate(desired); err != nil {
+		return "", err
+	}
+	if err := c.Update(ctx, desired, client.DryRunAll); err != nil {
+		return "", err
+	}
+	if equality.Semantic.DeepEqual(spec(obj), spec(desired)) {
+		return "", nil
+	}
+	return diff.Diff(spec(obj), spec(desired)), nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+const (
+	tritonReadyPath = "/v2/health/ready"
+	tritonLivePath  = "/v2/health/live"
+)
+
+// buildReadinessProbe gates traffic on every model being loaded
+func buildReadinessProbe(server *servingv1alpha1.KalypsoTritonServer, port int32) *corev1.Probe {
+	var override *servingv1alpha1.ProbeSpec
+	if server.Spec.Probes != nil {
+		override = server.Spec.Probes.Readiness
+	}
+	return buildHealthProbe(tritonReadyPath, port, corev1.Probe{
+		InitialDelaySeconds: 10,
+		PeriodSeconds:       5,
+	}, override)
+}
+
+// buildLivenessProbe restarts a container whose server stopped responding. It only starts once
+// the startup probe has succeeded
+func buildLivenessProbe(server *servingv1alpha1.KalypsoTritonServer, port int32) *corev1.Probe {
+	var override *servingv1alpha1.ProbeSpec
+	if server.Spec.Probes != nil {
+		override = server.Spec.Probes.Liveness
+	}
+	return buildHealthProbe(tritonLivePath, port, corev1.Probe{
+		InitialDelaySeconds: 15,
+		PeriodSeconds:       10,
+	}, override)
+}
+
+// buildStartupProbe gives large models time to load before the liveness probe can kill the pod
+func buildStartupProbe(server *servingv1alpha1.KalypsoTritonServer, port int32) *corev1.Probe {
+	var override *servingv1alpha1.ProbeSpec
+	if server.Spec.Probes != nil {
+		override = server.Spec.Probes.Startup
+	}
+	return buildHealthProbe(tritonLivePath, port, corev1.Probe{
+		PeriodSeconds:    10,
+		FailureThreshold: 60, // 10 minutes
+	}, override)
+}
+
+// buildHealthProbe builds an HTTP probe on a Triton health endpoint from the defaults and the
+// fields set in the override
+func buildHealthProbe(path string, port int32, defaults corev1.Probe, override *servingv1alpha1.ProbeSpec) *corev1.Probe {
+	probe := defaults
+	probe.ProbeHandler = corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{
+			Path: path,
+			Port: intstr.FromInt(int(port)),
+		},
+	}
+	if override == nil {
+		return &probe
+	}
+	if override.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *override.InitialDelaySeconds
+	}
+	if override.PeriodSeconds != nil {
+		probe.PeriodSeconds = *override.PeriodSeconds
+	}
+	if override.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *override.TimeoutSeconds
+	}
+	if override.FailureThreshold != nil {
+		probe.FailureThreshold = *override.FailureThreshold
+	}
+	return &probe
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+)
+
+// projectTritonDefaults returns the Triton defaults of the project of the application, which may
+// be nil, or nil when the project is missing or sets none
+func projectTritonDefaults(ctx context.Context, c client.Reader, app *servingv1alpha1.KalypsoApplication) (*servingv1alpha1.ProjectTritonDefaults, error) {
+	if app == nil || app.Spec.ProjectRef == "" {
+		return nil, nil
+	}
+	project, err := defaults.ApplicationProject(ctx, c, app)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if project.Spec.Defaults == nil {
+		return nil, nil
+	}
+	return project.Spec.Defaults.TritonConfig, nil
+}
+
+// withProjectDefaults returns the server with the Triton fields left unset filled from the
+// project defaults. The server itself is returned when the project sets no defaults
+func withProjectDefaults(server *servingv1alpha1.KalypsoTritonServer, projectDefaults *servingv1alpha1.ProjectTritonDefaults) *servingv1alpha1.KalypsoTritonServer {
+	if projectDefaults == nil {
+		return server
+	}
+
+	inherited := server.DeepCopy()
+	spec := &inherited.Spec
+	if spec.TritonConfig.Image == "" {
+		spec.TritonConfig.Image = projectDefaults.Image
+	}
+	if spec.TritonConfig.Tag == "" {
+		spec.TritonConfig.Tag = projectDefaults.Tag
+	}
+	if len(projectDefaults.Parameters) > 0 {
+		spec.TritonConfig.Parameters = defaults.MergeTritonParameters(projectDefaults.Parameters, spec.TritonConfig.Parameters)
+	}
+	if spec.Observability == nil && projectDefaults.Observability != nil {
+		spec.Observability = projectDefaults.Observability.DeepCopy()
+	}
+	return inherited
+}
+
+// serversForProject maps a KalypsoProject to the KalypsoTritonServers of its applications, so
+// changed project defaults roll the servers
+func (r *KalypsoTritonServerReconciler) serversForProject(ctx context.Context, obj client.Object) []reconcile.Request {
+	project, ok := obj.(*servingv1alpha1.KalypsoProject)
+	if !ok {
+		return nil
+	}
+	apps, err := listProjectReferrers(ctx, r.Client, project)
+	if err != nil || len(apps) == 0 {
+		return nil
+	}
+	projectApps := make(map[client.ObjectKey]bool)
+	for _, app := range apps {
+		projectApps[client.ObjectKeyFromObject(&app)] = true
+	}
+
+	servers := &servingv1alpha1.KalypsoTritonServerList{}
+	if err := r.List(ctx, servers); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, server := range servers.Items {
+		if projectApps[serverApplicationKey(&server)] {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&server)})
+		}
+	}
+	return requests
+}
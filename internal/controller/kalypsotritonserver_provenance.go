@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+const (
+	// ModelHeader identifies the KalypsoTritonServer that produced a response
+	ModelHeader = "x-kalypso-model"
+	// ModelVersionHeader identifies the serving revision that produced a response
+	ModelVersionHeader = "x-model-version"
+	// ServedByHeader identifies the replica that produced a response
+	ServedByHeader = "x-served-by"
+)
+
+// envoyFilterGVK is the Istio EnvoyFilter kind
+var envoyFilterGVK = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1alpha3", Kind: "EnvoyFilter"}
+
+// reconcileProvenanceFilter ensures the EnvoyFilter adding provenance response headers on the
+// inbound listener of the Triton pods' sidecars, and removes it when the headers are disabled
+func (r *KalypsoTritonServerReconciler) reconcileProvenanceFilter(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, filterName string) error {
+	filter := &unstructured.Unstructured{}
+	filter.SetGroupVersionKind(envoyFilterGVK)
+	filter.SetName(filterName)
+	filter.SetNamespace(server.Namespace)
+
+	if server.Spec.ProvenanceHeaders == nil || !server.Spec.ProvenanceHeaders.Enabled {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(filter), filter); err != nil {
+			if meta.IsNoMatchError(err) {
+				// Istio is not installed, so there is nothing to clean up
+				return nil
+			}
+			return client.IgnoreNotFound(err)
+		}
+		if !metav1.IsControlledBy(filter, server) {
+			return nil
+		}
+		return client.IgnoreNotFound(r.Delete(ctx, filter))
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, filter, func() error {
+		labels := filter.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[TritonServerLabelKey] = server.Name
+		labels[ApplicationLabelKey] = serverApplicationKey(server).Name
+		labels[ManagedByLabelKey] = ManagedByLabelValue
+		filter.SetLabels(labels)
+
+		if err := unstructured.SetNestedMap(filter.Object, buildProvenanceFilterSpec(server), "spec"); err != nil {
+			return err
+		}
+
+		// Set owner reference
+		return controllerutil.SetControllerReference(server, filter, r.Scheme)
+	})
+	return err
+}
+
+// buildProvenanceFilterSpec builds the EnvoyFilter spec merging the provenance headers into the
+// inbound virtual host; Envoy resolves %HOSTNAME% to the pod name
+func buildProvenanceFilterSpec(server *servingv1alpha1.KalypsoTritonServer) map[string]interface{} {
+	modelVersion := server.Spec.ProvenanceHeaders.ModelVersion
+	if modelVersion == "" {
+		modelVersion = strconv.FormatInt(server.Generation, 10)
+	}
+
+	header := func(key, value string) interface{} {
+		return map[string]interface{}{
+			"header":        map[string]interface{}{"key": key, "value": value},
+			"append_action": "OVERWRITE_IF_EXISTS_OR_ADD",
+		}
+	}
+
+	return map[string]interface{}{
+		"workloadSelector": map[string]interface{}{
+			"labels": map[string]interface{}{
+				TritonServerLabelKey: server.Name,
+			},
+		},
+		"configPatches": []interface{}{
+			map[string]interface{}{
+				"applyTo": "VIRTUAL_HOST",
+				"match": map[string]interface{}{
+					"context": "SIDECAR_INBOUND",
+				},
+				"patch": map[string]interface{}{
+					"operation": "MERGE",
+					"value": map[string]interface{}{
+						"response_headers_to_add": []interface{}{
+							header(ModelHeader, server.Name),
+							header(ModelVersionHeader, modelVersion),
+							header(ServedByHeader, "%HOSTNAME%"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
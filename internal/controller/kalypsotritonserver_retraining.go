@@ -0,0 +1,211 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/features"
+	"github.com/kalypsoServing/KalypsoServing/internal/retraining"
+)
+
+// retrainingResult is the outcome of a retraining hook evaluation
+type retrainingResult struct {
+	statuses     []servingv1alpha1.RetrainingTriggerStatus
+	firing       []string
+	evaluated    bool
+	requeueAfter time.Duration
+}
+
+// triggersEvaluated reports whether trigger queries were run, so their evaluation and event
+// times must be persisted immediately
+func (r *retrainingResult) triggersEvaluated() bool {
+	return r != nil && r.evaluated
+}
+
+// evaluateRetrainingHook evaluates the retraining triggers and emits a CloudEvent
+// for each trigger that crossed its threshold outside of the cooldown window. Triggers
+// evaluated less than the evaluation interval ago keep their last status without a query
+func (r *KalypsoTritonServerReconciler) evaluateRetrainingHook(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) *retrainingResult {
+	log := logf.FromContext(ctx)
+
+	hook := server.Spec.RetrainingHook
+	if hook == nil || !hook.Enabled {
+		return nil
+	}
+	if !features.Enabled(features.RetrainingHook) {
+		log.V(1).Info("Retraining hook is configured but the RetrainingHook feature gate is disabled")
+		return nil
+	}
+	if r.MetricsQuerier == nil || r.EventSender == nil {
+		log.Info("Retraining hook is enabled but no metrics querier or event sender is configured")
+		return nil
+	}
+
+	interval := parseDurationOrDefault(hook.EvaluationInterval, time.Minute)
+	cooldown := parseDurationOrDefault(hook.Cooldown, time.Hour)
+
+	previous := make(map[string]servingv1alpha1.RetrainingTriggerStatus)
+	for _, status := range server.Status.RetrainingTriggers {
+		previous[status.Name] = status
+	}
+
+	result := &retrainingResult{requeueAfter: interval}
+	now := time.Now()
+
+	for _, trigger := range hook.Triggers {
+		status := servingv1alpha1.RetrainingTriggerStatus{Name: trigger.Name}
+		prev, ok := previous[trigger.Name]
+		if ok {
+			status.LastEventTime = prev.LastEventTime
+		}
+
+		threshold, err := strconv.ParseFloat(trigger.Threshold, 64)
+		if err != nil {
+			log.Info("Invalid retraining trigger threshold", "trigger", trigger.Name, "threshold", trigger.Threshold)
+			result.statuses = append(result.statuses, status)
+			continue
+		}
+
+		if ok && prev.LastEvaluationTime != nil {
+			if wait := interval - now.Sub(prev.LastEvaluationTime.Time); wait > 0 {
+				result.requeueAfter = min(result.requeueAfter, wait)
+				if value, err := strconv.ParseFloat(prev.LastValue, 64); err == nil && value > threshold {
+					result.firing = append(result.firing, trigger.Name)
+				}
+				result.statuses = append(result.statuses, prev)
+				continue
+			}
+		}
+
+		evaluationTime := metav1.NewTime(now)
+		status.LastEvaluationTime = &evaluationTime
+		result.evaluated = true
+		value, err := r.MetricsQuerier.Query(ctx, hook.PrometheusURL, trigger.Query)
+		if err != nil {
+			log.Info("Failed to evaluate retraining trigger", "trigger", trigger.Name, "error", err)
+			result.statuses = append(result.statuses, status)
+			continue
+		}
+		status.LastValue = strconv.FormatFloat(value, 'f', -1, 64)
+
+		if value > threshold {
+			result.firing = append(result.firing, trigger.Name)
+
+			if status.LastEventTime == nil || now.Sub(status.LastEventTime.Time) >= cooldown {
+				event := retraining.Event{
+					ID:      fmt.Sprintf("%s-%s-%d", server.UID, trigger.Name, now.Unix()),
+					Type:    retraining.EventTypeFor(trigger.Type),
+					Source:  fmt.Sprintf("/apis/%s/namespaces/%s/kalypsotritonservers/%s", servingv1alpha1.GroupVersion.String(), server.Namespace, server.Name),
+					Subject: trigger.Name,
+					Time:    now,
+					Data: retraining.EventData{
+						Project:     app.Spec.ProjectRef,
+						Application: app.Name,
+						Server:      server.Name,
+						Namespace:   server.Namespace,
+						StorageURI:  server.Spec.StorageURI,
+						Trigger:     trigger.Name,
+						Value:       value,
+						Threshold:   threshold,
+					},
+				}
+				if err := r.EventSender.Send(ctx, hook.SinkURI, event); err != nil {
+					log.Info("Failed to emit retraining event", "trigger", trigger.Name, "sink", hook.SinkURI, "error", err)
+				} else {
+					log.Info("Emitted retraining event", "trigger", trigger.Name, "value", value, "threshold", threshold)
+					eventTime := metav1.NewTime(now)
+					status.LastEventTime = &eventTime
+				}
+			}
+		}
+
+		result.statuses = append(result.statuses, status)
+	}
+
+	return result
+}
+
+// applyRetrainingStatus records the retraining evaluation in the server status. An enabled
+// retraining hook left unevaluated because the RetrainingHook feature gate is disabled is
+// reported in the RetrainingHook condition
+func applyRetrainingStatus(server *servingv1alpha1.KalypsoTritonServer, result *retrainingResult) {
+	if result == nil {
+		server.Status.RetrainingTriggers = nil
+		meta.RemoveStatusCondition(&server.Status.Conditions, "RetrainingTriggered")
+		if hook := server.Spec.RetrainingHook; hook != nil && hook.Enabled && !features.Enabled(features.RetrainingHook) {
+			meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+				Type:               "RetrainingHook",
+				Status:             metav1.ConditionFalse,
+				Reason:             "FeatureGateDisabled",
+				Message:            "spec.retrainingHook is not evaluated until the operator runs with --feature-gates=RetrainingHook=true",
+				LastTransitionTime: metav1.Now(),
+			})
+		} else {
+			meta.RemoveStatusCondition(&server.Status.Conditions, "RetrainingHook")
+		}
+		return
+	}
+
+	meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               "RetrainingHook",
+		Status:             metav1.ConditionTrue,
+		Reason:             "Evaluating",
+		Message:            "The retraining triggers are evaluated",
+		LastTransitionTime: metav1.Now(),
+	})
+
+	server.Status.RetrainingTriggers = result.statuses
+	if len(result.firing) > 0 {
+		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               "RetrainingTriggered",
+			Status:             metav1.ConditionTrue,
+			Reason:             "ThresholdExceeded",
+			Message:            fmt.Sprintf("Retraining triggers above threshold: %s", strings.Join(result.firing, ", ")),
+			LastTransitionTime: metav1.Now(),
+		})
+	} else {
+		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+			Type:               "RetrainingTriggered",
+			Status:             metav1.ConditionFalse,
+			Reason:             "WithinThreshold",
+			Message:            "All retraining triggers are within threshold",
+			LastTransitionTime: metav1.Now(),
+		})
+	}
+}
+
+// parseDurationOrDefault parses a duration string, falling back to the default on empty or invalid input
+func parseDurationOrDefault(value string, fallback time.Duration) time.Duration {
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return fallback
+	}
+	return d
+}
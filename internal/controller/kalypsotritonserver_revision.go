@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+const (
+	// RollbackAnnotation requests restoring the configuration of a revision in status.revisions
+	RollbackAnnotation = "serving.kalypso.io/rollback-to"
+	// maxRevisionHistory bounds the revisions kept in status
+	maxRevisionHistory = 10
+)
+
+// reconcileRollback restores the storage URI and Triton configuration of the revision named by
+// the rollback annotation, removes the annotation, and reports whether the spec was updated
+func (r *KalypsoTritonServerReconciler) reconcileRollback(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	value, ok := server.Annotations[RollbackAnnotation]
+	if !ok {
+		return false, nil
+	}
+
+	var target *servingv1alpha1.ServerRevision
+	if revision, err := strconv.ParseInt(value, 10, 64); err == nil {
+		for i := range server.Status.Revisions {
+			if server.Status.Revisions[i].Revision == revision {
+				target = &server.Status.Revisions[i]
+				break
+			}
+		}
+	}
+
+	// ReadOnly and Archived servers reject spec changes, so only the annotation is removed
+	active := server.Spec.State == "" || server.Spec.State == servingv1alpha1.ServerStateActive
+
+	delete(server.Annotations, RollbackAnnotation)
+	if target != nil && active {
+		server.Spec.StorageURI = target.StorageURI
+		server.Spec.TritonConfig = *target.TritonConfig.DeepCopy()
+		// Revisions recorded without the inherited image are restored to the image they rendered
+		if server.Spec.TritonConfig.Image == "" || server.Spec.TritonConfig.Tag == "" {
+			server.Spec.TritonConfig.Image, server.Spec.TritonConfig.Tag = splitImage(target.Image)
+		}
+	}
+	if err := r.Update(ctx, server); err != nil {
+		return false, err
+	}
+
+	condition := metav1.Condition{
+		Type:               "RolledBack",
+		Status:             metav1.ConditionTrue,
+		Reason:             "RevisionRestored",
+		Message:            fmt.Sprintf("Restored the configuration of revision %s", value),
+		LastTransitionTime: metav1.Now(),
+	}
+	switch {
+	case target == nil:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "RevisionNotFound"
+		condition.Message = fmt.Sprintf("Revision %q is not in status.revisions", value)
+	case !active:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ServerNotActive"
+		condition.Message = fmt.Sprintf("A %s server cannot be rolled back; set spec.state to Active first", server.Spec.State)
+	}
+	meta.SetStatusCondition(&server.Status.Conditions, condition)
+
+	log.Info("Processed rollback request", "server", server.Name, "revision", value, "reason", condition.Reason)
+	return true, r.updateStatus(ctx, server, true)
+}
+
+// buildRevision describes the Triton container rendered into the pod template, with the spec
+// fields a rollback restores. tritonConfig is the Triton configuration the template was rendered
+// from, with the inherited fields resolved so a rollback does not pick up changed defaults
+func buildRevision(server *servingv1alpha1.KalypsoTritonServer, tritonConfig servingv1alpha1.TritonConfigSpec, template *corev1.PodTemplateSpec) servingv1alpha1.ServerRevision {
+	container := template.Spec.Containers[0]
+	return servingv1alpha1.ServerRevision{
+		Generation:   server.Generation,
+		Image:        container.Image,
+		StorageURI:   server.Spec.StorageURI,
+		ArgsHash:     argsHash(container.Args),
+		TritonConfig: tritonConfig,
+		CreatedAt:    metav1.Now(),
+	}
+}
+
+// splitImage splits an image reference into the image and the tag or sha256 digest
+func splitImage(reference string) (string, string) {
+	if image, digest, ok := strings.Cut(reference, "@"); ok {
+		return image, digest
+	}
+	if i := strings.LastIndex(reference, ":"); i > strings.LastIndex(reference, "/") {
+		return reference[:i], reference[i+1:]
+	}
+	return reference, ""
+}
+
+// recordRevision appends the revision to the history unless it renders the same container as
+// the latest one, dropping the oldest revisions beyond the history limit
+func recordRevision(server *servingv1alpha1.KalypsoTritonServer, revision servingv1alpha1.ServerRevision) {
+	revisions := server.Status.Revisions
+	if n := len(revisions); n > 0 {
+		latest := revisions[n-1]
+		if latest.Image == revision.Image && latest.StorageURI == revision.StorageURI && latest.ArgsHash == revision.ArgsHash {
+			return
+		}
+		revision.Revision = latest.Revision + 1
+	} else {
+		revision.Revision = 1
+	}
+
+	revisions = append(revisions, revision)
+	if len(revisions) > maxRevisionHistory {
+		revisions = revisions[len(revisions)-maxRevisionHistory:]
+	}
+	server.Status.Revisions = revisions
+}
+
+// argsHash returns a short hash identifying the container args
+func argsHash(args []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(args, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
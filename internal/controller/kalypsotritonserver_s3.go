@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net"
+	"net/url"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+const (
+	// S3CABundleVolumeName is the volume holding the CA bundle of the S3 endpoint
+	S3CABundleVolumeName = "kalypso-s3-ca"
+	// S3CABundleMountPath is where the CA bundle is mounted in the containers reading the bucket
+	S3CABundleMountPath = "/kalypso/s3-ca"
+)
+
+// s3Options returns the S3 client options of the storage, nil when none are configured
+func s3Options(storage *servingv1alpha1.StorageSpec) *servingv1alpha1.S3OptionsSpec {
+	if storage == nil {
+		return nil
+	}
+	return storage.S3
+}
+
+// s3Region returns the region of the storage, or the region of its SSE-KMS key ARN,
+// arn:<partition>:kms:<region>:<account>:key/<id>, so the requests are signed for the key region
+func s3Region(storage *servingv1alpha1.StorageSpec) string {
+	if storage == nil {
+		return ""
+	}
+	if storage.Region != "" {
+		return storage.Region
+	}
+	if options := s3Options(storage); options != nil {
+		if fields := strings.Split(options.KMSKeyID, ":"); len(fields) >= 6 && fields[0] == "arn" && fields[2] == "kms" {
+			return fields[3]
+		}
+	}
+	return ""
+}
+
+// s3CABundleKey returns the key of the CA bundle in its Secret
+func s3CABundleKey(options *servingv1alpha1.S3OptionsSpec) string {
+	if options.CABundleKey == "" {
+		return "ca.crt"
+	}
+	return options.CABundleKey
+}
+
+// buildS3OptionsEnv passes the SSE-KMS key, the CA bundle and the proxy to the S3 clients. The
+// proxy variables are lowercase, the only form both curl and Go read for http:// endpoints
+func buildS3OptionsEnv(storage *servingv1alpha1.StorageSpec) []corev1.EnvVar {
+	options := s3Options(storage)
+	if options == nil {
+		return nil
+	}
+
+	var env []corev1.EnvVar
+	if options.KMSKeyID != "" {
+		env = append(env, corev1.EnvVar{Name: "AWS_S3_SSE_KMS_KEY_ID", Value: options.KMSKeyID})
+	}
+	if options.CABundleSecret != "" {
+		env = append(env, corev1.EnvVar{Name: "AWS_CA_BUNDLE", Value: path.Join(S3CABundleMountPath, s3CABundleKey(options))})
+	}
+	if options.ProxyURL != "" {
+		env = append(env,
+			corev1.EnvVar{Name: "http_proxy", Value: options.ProxyURL},
+			corev1.EnvVar{Name: "https_proxy", Value: options.ProxyURL},
+		)
+		if len(options.NoProxy) > 0 {
+			env = append(env, corev1.EnvVar{Name: "no_proxy", Value: strings.Join(options.NoProxy, ",")})
+		}
+	}
+	return env
+}
+
+// applyS3CABundle mounts the CA bundle of the S3 endpoint in the containers
+func applyS3CABundle(podSpec *corev1.PodSpec, storage *servingv1alpha1.StorageSpec, containers ...*corev1.Container) {
+	options := s3Options(storage)
+	if options == nil || options.CABundleSecret == "" {
+		return
+	}
+
+	mounted := false
+	for _, volume := range podSpec.Volumes {
+		mounted = mounted || volume.Name == S3CABundleVolumeName
+	}
+	if !mounted {
+		key := s3CABundleKey(options)
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: S3CABundleVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: options.CABundleSecret,
+					Items:      []corev1.KeyToPath{{Key: key, Path: key}},
+				},
+			},
+		})
+	}
+	for _, container := range containers {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      S3CABundleVolumeName,
+			MountPath: S3CABundleMountPath,
+			ReadOnly:  true,
+		})
+	}
+}
+
+// s3PathStyleURI names the endpoint of the storage in an s3://<bucket>/<path> URI, which makes
+// Triton address the bucket path-style: s3://<scheme>://<host>:<port>/<bucket>/<path>. URIs
+// naming an endpoint already, or storage without an endpoint, are returned as is
+func s3PathStyleURI(uri string, storage *servingv1alpha1.StorageSpec) string {
+	options := s3Options(storage)
+	if options == nil || !options.ForcePathStyle || storage.Endpoint == "" || !strings.HasPrefix(uri, "s3://") {
+		return uri
+	}
+	location := strings.TrimPrefix(uri, "s3://")
+	if host, _, _ := strings.Cut(location, "/"); strings.Contains(host, ":") {
+		return uri
+	}
+
+	endpoint, err := url.Parse(storage.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return uri
+	}
+	host := endpoint.Host
+	if endpoint.Port() == "" {
+		port := "443"
+		if endpoint.Scheme == "http" {
+			port = "80"
+		}
+		host = net.JoinHostPort(endpoint.Hostname(), port)
+	}
+	return "s3://" + endpoint.Scheme + "://" + host + "/" + location
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TritonUserID is the UID of the triton-server user shipped in the NGC Triton images
+const TritonUserID = int64(1000)
+
+// buildPodSecurityContext returns the configured pod security context, or a default that
+// satisfies the restricted Pod Security Standard
+func buildPodSecurityContext(configured *corev1.PodSecurityContext) *corev1.PodSecurityContext {
+	if configured != nil {
+		return configured.DeepCopy()
+	}
+
+	runAsNonRoot := true
+	userID := TritonUserID
+	return &corev1.PodSecurityContext{
+		RunAsNonRoot: &runAsNonRoot,
+		// The NGC images default to root, so the non-root user must be set explicitly
+		RunAsUser:  &userID,
+		RunAsGroup: &userID,
+		FSGroup:    &userID,
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+}
+
+// buildContainerSecurityContext returns the configured Triton container security context, or
+// a default that satisfies the restricted Pod Security Standard
+func buildContainerSecurityContext(configured *corev1.SecurityContext) *corev1.SecurityContext {
+	if configured != nil {
+		return configured.DeepCopy()
+	}
+
+	allowPrivilegeEscalation := false
+	return &corev1.SecurityContext{
+		AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+	}
+}
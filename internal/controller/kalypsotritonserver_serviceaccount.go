@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// serviceAccountName returns the ServiceAccount the Triton pods run as, or an empty string
+// for the namespace default
+func serviceAccountName(server *servingv1alpha1.KalypsoTritonServer) string {
+	sa := server.Spec.ServiceAccount
+	if sa == nil {
+		return ""
+	}
+	if sa.Name != "" {
+		return sa.Name
+	}
+	if sa.AutoCreate {
+		return naming.ServiceAccount(server.Name)
+	}
+	return ""
+}
+
+// reconcileServiceAccount ensures the auto-created ServiceAccount exists with the configured
+// annotations, and removes the owned ServiceAccount when auto-creation is turned off
+func (r *KalypsoTritonServerReconciler) reconcileServiceAccount(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, defaultName string) error {
+	spec := server.Spec.ServiceAccount
+	if spec == nil || !spec.AutoCreate {
+		owned := &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: defaultName, Namespace: server.Namespace},
+		}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(owned), owned); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !metav1.IsControlledBy(owned, server) {
+			return nil
+		}
+		return client.IgnoreNotFound(r.Delete(ctx, owned))
+	}
+
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceAccountName(server),
+			Namespace: server.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, serviceAccount, func() error {
+		// Set user-defined labels and annotations; the labels below take precedence
+		applyCustomMetadata(serviceAccount, server)
+
+		// Set labels
+		if serviceAccount.Labels == nil {
+			serviceAccount.Labels = make(map[string]string)
+		}
+		serviceAccount.Labels[TritonServerLabelKey] = server.Name
+		serviceAccount.Labels[ApplicationLabelKey] = serverApplicationKey(server).Name
+		serviceAccount.Labels[ManagedByLabelKey] = ManagedByLabelValue
+
+		// Set workload identity annotations, keeping annotations added by other controllers
+		if serviceAccount.Annotations == nil && len(spec.Annotations) > 0 {
+			serviceAccount.Annotations = make(map[string]string)
+		}
+		for k, v := range spec.Annotations {
+			serviceAccount.Annotations[k] = v
+		}
+
+		// Set owner reference
+		return controllerutil.SetControllerReference(server, serviceAccount, r.Scheme)
+	})
+
+	return err
+}
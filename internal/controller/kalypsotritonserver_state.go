@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// virtualServiceGVK is the Istio VirtualService kind
+var virtualServiceGVK = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1", Kind: "VirtualService"}
+
+// isArchived reports whether the server is retired with spec.state Archived
+func isArchived(server *servingv1alpha1.KalypsoTritonServer) bool {
+	return server.Spec.State == servingv1alpha1.ServerStateArchived
+}
+
+// scaledToZero reports whether the server runs no replicas, because it is suspended or archived
+func scaledToZero(server *servingv1alpha1.KalypsoTritonServer) bool {
+	return server.Spec.Suspend || isArchived(server)
+}
+
+// reconcileArchivedRoute ensures the VirtualService answering mesh requests to the Service of an
+// archived server with 410 Gone, and removes it when the server is not archived
+func (r *KalypsoTritonServerReconciler) reconcileArchivedRoute(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, routeName string) error {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(virtualServiceGVK)
+	route.SetName(routeName)
+	route.SetNamespace(server.Namespace)
+
+	if !isArchived(server) {
+		if err := r.deleteOwned(ctx, server, route); err != nil && !meta.IsNoMatchError(err) {
+			return err
+		}
+		return nil
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, route, func() error {
+		labels := route.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[TritonServerLabelKey] = server.Name
+		labels[ApplicationLabelKey] = serverApplicationKey(server).Name
+		labels[ManagedByLabelKey] = ManagedByLabelValue
+		route.SetLabels(labels)
+
+		if err := unstructured.SetNestedMap(route.Object, buildArchivedRouteSpec(server), "spec"); err != nil {
+			return err
+		}
+
+		// Set owner reference
+		return controllerutil.SetControllerReference(server, route, r.Scheme)
+	})
+	return err
+}
+
+// buildArchivedRouteSpec builds the VirtualService spec answering every request to the server's
+// Service with a Triton-style JSON error
+func buildArchivedRouteSpec(server *servingv1alpha1.KalypsoTritonServer) map[string]interface{} {
+	host := fmt.Sprintf("%s.%s.svc.cluster.local", naming.Service(server.Name), server.Namespace)
+	return map[string]interface{}{
+		"hosts":    []interface{}{host},
+		"gateways": []interface{}{"mesh"},
+		"http": []interface{}{
+			map[string]interface{}{
+				"name": "archived",
+				"directResponse": map[string]interface{}{
+					"status": int64(410),
+					"body": map[string]interface{}{
+						"string": fmt.Sprintf(`{"error":"model server %s is archived"}`, server.Name),
+					},
+				},
+				"headers": map[string]interface{}{
+					"response": map[string]interface{}{
+						"set": map[string]interface{}{"content-type": "application/json"},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// isStatefulSet reports whether the server runs as a StatefulSet instead of a Deployment
+func isStatefulSet(server *servingv1alpha1.KalypsoTritonServer) bool {
+	return server.Spec.WorkloadType == servingv1alpha1.WorkloadTypeStatefulSet
+}
+
+// workloadKind returns the kind of the workload running the Triton pods
+func workloadKind(server *servingv1alpha1.KalypsoTritonServer) string {
+	switch {
+	case isStatefulSet(server):
+		return servingv1alpha1.WorkloadTypeStatefulSet
+	case isArgoRollout(server):
+		return servingv1alpha1.WorkloadTypeRollout
+	}
+	return servingv1alpha1.WorkloadTypeDeployment
+}
+
+// workloadName returns the name of the workload running the Triton pods
+func workloadName(server *servingv1alpha1.KalypsoTritonServer) string {
+	switch {
+	case isStatefulSet(server):
+		return naming.StatefulSet(server.Name)
+	case isArgoRollout(server):
+		return naming.ArgoRollout(server.Name)
+	}
+	return naming.Deployment(server.Name)
+}
+
+// reconcileWorkload ensures the Deployment, the Rollout, or the StatefulSet and its governing
+// Service, and removes the workloads of the other types after a workloadType change
+func (r *KalypsoTritonServerReconciler) reconcileWorkload(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication, surge int32) error {
+	namespace := server.Namespace
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: naming.Deployment(server.Name), Namespace: namespace}}
+	statefulSet := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: naming.StatefulSet(server.Name), Namespace: namespace}}
+	headlessService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: naming.HeadlessService(server.Name), Namespace: namespace}}
+
+	switch {
+	case isArgoRollout(server):
+		rollout := newArgoRollout(naming.ArgoRollout(server.Name), namespace)
+		if err := r.reconcileArgoRollout(ctx, server, app, rollout, surge); err != nil {
+			return err
+		}
+		for _, obj := range []client.Object{deployment, statefulSet, headlessService} {
+			if err := r.deleteOwned(ctx, server, obj); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case isStatefulSet(server):
+		if err := r.reconcileHeadlessService(ctx, server, headlessService); err != nil {
+			return err
+		}
+		if err := r.reconcileStatefulSet(ctx, server, app, statefulSet, surge); err != nil {
+			return err
+		}
+		if err := r.deleteOwned(ctx, server, deployment); err != nil {
+			return err
+		}
+		return r.deleteArgoRollout(ctx, server)
+	}
+
+	if err := r.reconcileDeployment(ctx, server, app, deployment.Name, surge); err != nil {
+		return err
+	}
+	if err := r.deleteOwned(ctx, server, statefulSet); err != nil {
+		return err
+	}
+	if err := r.deleteOwned(ctx, server, headlessService); err != nil {
+		return err
+	}
+	return r.deleteArgoRollout(ctx, server)
+}
+
+// deleteOwned deletes a child resource if it exists and is controlled by the server
+func (r *KalypsoTritonServerReconciler) deleteOwned(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, obj client.Object) error {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(obj, server) {
+		return nil
+	}
+	return client.IgnoreNotFound(r.Delete(ctx, obj))
+}
+
+// reconcileStatefulSet ensures the StatefulSet exists with proper configuration
+func (r *KalypsoTritonServerReconciler) reconcileStatefulSet(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication, statefulSet *appsv1.StatefulSet, surge int32) error {
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, statefulSet, func() error {
+		if err := r.mutateStatefulSet(statefulSet, server, app); err != nil {
+			return err
+		}
+		if !scaledToZero(server) {
+			*statefulSet.Spec.Replicas += surge
+		}
+		return nil
+	})
+
+	return err
+}
+
+// mutateStatefulSet applies the desired Triton configuration to the StatefulSet. The pod
+// template is rendered like the Deployment one, so both workload types run identical pods
+func (r *KalypsoTritonServerReconciler) mutateStatefulSet(statefulSet *appsv1.StatefulSet, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) error {
+	triton := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: server.Namespace}}
+	if err := r.mutateDeployment(triton, server, app); err != nil {
+		return err
+	}
+
+	// Set labels
+	if statefulSet.Labels == nil {
+		statefulSet.Labels = make(map[string]string)
+	}
+	for k, v := range triton.Labels {
+		statefulSet.Labels[k] = v
+	}
+	if len(triton.Annotations) > 0 && statefulSet.Annotations == nil {
+		statefulSet.Annotations = make(map[string]string)
+	}
+	for k, v := range triton.Annotations {
+		statefulSet.Annotations[k] = v
+	}
+
+	// Set spec
+	statefulSet.Spec.Replicas = triton.Spec.Replicas
+	statefulSet.Spec.Selector = triton.Spec.Selector
+	statefulSet.Spec.Template = triton.Spec.Template
+	statefulSet.Spec.ServiceName = naming.HeadlessService(server.Name)
+	// Replicas load their models independently, so there is no need to start them one by one
+	if statefulSet.CreationTimestamp.IsZero() {
+		statefulSet.Spec.PodManagementPolicy = appsv1.ParallelPodManagement
+		// Claim templates are immutable, so they are only set on creation
+		statefulSet.Spec.VolumeClaimTemplates = buildVolumeClaimTemplates(server)
+	}
+
+	// Set owner reference
+	return controllerutil.SetControllerReference(server, statefulSet, r.Scheme)
+}
+
+// buildVolumeClaimTemplates builds the per-replica claims of the StatefulSet
+func buildVolumeClaimTemplates(server *servingv1alpha1.KalypsoTritonServer) []corev1.PersistentVolumeClaim {
+	var claims []corev1.PersistentVolumeClaim
+	for _, template := range server.Spec.VolumeClaimTemplates {
+		claims = append(claims, corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: template.Name,
+				Labels: map[string]string{
+					TritonServerLabelKey: server.Name,
+					ManagedByLabelKey:    ManagedByLabelValue,
+				},
+			},
+			Spec: *template.Spec.DeepCopy(),
+		})
+	}
+	return claims
+}
+
+// reconcileHeadlessService ensures the governing Service giving StatefulSet replicas stable DNS
+// names. Not-ready replicas are published so peers can be resolved while models load
+func (r *KalypsoTritonServerReconciler) reconcileHeadlessService(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, service *corev1.Service) error {
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+		if err := r.mutateService(service, server); err != nil {
+			return err
+		}
+		service.Spec.ClusterIP = corev1.ClusterIPNone
+		service.Spec.PublishNotReadyAddresses = true
+		return nil
+	})
+
+	return err
+}
+
+// currentPodTemplate returns the pod template and replica count of the current workload, or
+// nil when it does not exist yet
+func (r *KalypsoTritonServerReconciler) currentPodTemplate(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer) (*corev1.PodTemplateSpec, int32, error) {
+	key := client.ObjectKey{Namespace: server.Namespace, Name: workloadName(server)}
+	if isArgoRollout(server) {
+		rollout := newArgoRollout(key.Name, key.Namespace)
+		if err := r.Get(ctx, key, rollout); err != nil {
+			if errors.IsNotFound(err) {
+				return nil, 0, nil
+			}
+			return nil, 0, err
+		}
+		return argoRolloutPodTemplate(rollout)
+	}
+	if isStatefulSet(server) {
+		statefulSet := &appsv1.StatefulSet{}
+		if err := r.Get(ctx, key, statefulSet); err != nil {
+			if errors.IsNotFound(err) {
+				return nil, 0, nil
+			}
+			return nil, 0, err
+		}
+		return &statefulSet.Spec.Template, statefulSet.Status.Replicas, nil
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, key, deployment); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, 0, nil
+		}
+		return nil, 0, err
+	}
+	return &deployment.Spec.Template, deployment.Status.Replicas, nil
+}
+
+// workloadAvailableReplicas returns the available replicas of the Deployment, StatefulSet, or Rollout
+func (r *KalypsoTritonServerReconciler) workloadAvailableReplicas(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer) (int32, error) {
+	key := client.ObjectKey{Namespace: server.Namespace, Name: workloadName(server)}
+	if isArgoRollout(server) {
+		rollout := newArgoRollout(key.Name, key.Namespace)
+		if err := r.Get(ctx, key, rollout); err != nil {
+			return 0, err
+		}
+		available, _, _ := unstructured.NestedInt64(rollout.Object, "status", "availableReplicas")
+		return int32(available), nil
+	}
+	if isStatefulSet(server) {
+		statefulSet := &appsv1.StatefulSet{}
+		if err := r.Get(ctx, key, statefulSet); err != nil {
+			return 0, err
+		}
+		return statefulSet.Status.AvailableReplicas, nil
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := r.Get(ctx, key, deployment); err != nil {
+		return 0, err
+	}
+	return deployment.Status.AvailableReplicas, nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+const (
+	// GCSCredentialsVolumeName is the volume holding the service account key of gs:// repositories
+	GCSCredentialsVolumeName = "kalypso-gcs-credentials"
+	// GCSCredentialsMountPath is where the service account key is mounted in the Triton container
+	GCSCredentialsMountPath = "/kalypso/gcs"
+	// AzureWorkloadIdentityLabelKey opts pods into the Azure Workload Identity webhook
+	AzureWorkloadIdentityLabelKey = "azure.workload.identity/use"
+)
+
+// withServerStorage returns a copy of the application whose storage configuration carries the
+// overrides of the server, or the application itself when the server overrides nothing
+func withServerStorage(app *servingv1alpha1.KalypsoApplication, server *servingv1alpha1.KalypsoTritonServer) *servingv1alpha1.KalypsoApplication {
+	overrides := server.Spec.Storage
+	if overrides == nil || (overrides.SecretName == "" && overrides.Endpoint == "" && overrides.Region == "") {
+		return app
+	}
+
+	merged := app.DeepCopy()
+	if merged.Spec.Storage == nil {
+		merged.Spec.Storage = &servingv1alpha1.StorageSpec{}
+	}
+	if overrides.SecretName != "" {
+		merged.Spec.Storage.SecretName = overrides.SecretName
+	}
+	if overrides.Endpoint != "" {
+		merged.Spec.Storage.Endpoint = overrides.Endpoint
+	}
+	if overrides.Region != "" {
+		merged.Spec.Storage.Region = overrides.Region
+	}
+	return merged
+}
+
+// gcsCredentials returns the service account key Secret and key of a gs:// model repository,
+// nil when Triton falls back to the workload identity of the pod
+func gcsCredentials(server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) (*servingv1alpha1.GCSStorageSpec, string) {
+	if !strings.HasPrefix(server.Spec.StorageURI, "gs://") || app.Spec.Storage == nil || app.Spec.Storage.GCS == nil ||
+		storageWorkloadIdentity(app.Spec.Storage) != nil {
+		return nil, ""
+	}
+	key := app.Spec.Storage.GCS.Key
+	if key == "" {
+		key = "key.json"
+	}
+	return app.Spec.Storage.GCS, key
+}
+
+// buildGCSCredentialsEnv points GOOGLE_APPLICATION_CREDENTIALS at the mounted service account key
+func buildGCSCredentialsEnv(server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) []corev1.EnvVar {
+	gcs, key := gcsCredentials(server, app)
+	if gcs == nil {
+		return nil
+	}
+	return []corev1.EnvVar{{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: path.Join(GCSCredentialsMountPath, key)}}
+}
+
+// applyGCSCredentials mounts the service account key in the Triton container
+func applyGCSCredentials(podSpec *corev1.PodSpec, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) {
+	gcs, key := gcsCredentials(server, app)
+	if gcs == nil {
+		return
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: GCSCredentialsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: gcs.CredentialsSecret,
+				Items:      []corev1.KeyToPath{{Key: key, Path: key}},
+			},
+		},
+	})
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      GCSCredentialsVolumeName,
+		MountPath: GCSCredentialsMountPath,
+		ReadOnly:  true,
+	})
+}
+
+// azureStorageAccount returns the storage account of an as://<account>/<container>/<path>
+// model repository, empty for other schemes
+func azureStorageAccount(server *servingv1alpha1.KalypsoTritonServer) string {
+	if !strings.HasPrefix(server.Spec.StorageURI, "as://") {
+		return ""
+	}
+	account, _, _ := strings.Cut(strings.TrimPrefix(server.Spec.StorageURI, "as://"), "/")
+	return account
+}
+
+// buildAzureStorageEnv names the storage account of as:// repositories and injects the account
+// key Secret, or the client ID of the managed identity
+func buildAzureStorageEnv(server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) ([]corev1.EnvVar, []corev1.EnvFromSource) {
+	account := azureStorageAccount(server)
+	if account == "" {
+		return nil, nil
+	}
+	env := []corev1.EnvVar{{Name: "AZURE_STORAGE_ACCOUNT", Value: account}}
+	if identity := storageWorkloadIdentity(app.Spec.Storage); identity != nil {
+		if identity.AzureClientID != "" {
+			env = append(env, corev1.EnvVar{Name: "AZURE_CLIENT_ID", Value: identity.AzureClientID})
+		}
+		return env, nil
+	}
+	if app.Spec.Storage == nil || app.Spec.Storage.Azure == nil {
+		return env, nil
+	}
+
+	azure := app.Spec.Storage.Azure
+	if azure.ManagedIdentity != nil {
+		return append(env, corev1.EnvVar{Name: "AZURE_CLIENT_ID", Value: azure.ManagedIdentity.ClientID}), nil
+	}
+	return env, []corev1.EnvFromSource{{
+		SecretRef: &corev1.SecretEnvSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: azure.CredentialsSecret},
+		},
+	}}
+}
+
+// azureWorkloadIdentityLabels opts the pods of as:// repositories, or bound to an Azure workload
+// identity, into the Azure Workload Identity webhook, which projects the federated token of the
+// managed identity
+func azureWorkloadIdentityLabels(server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) map[string]string {
+	if identity := storageWorkloadIdentity(app.Spec.Storage); identity != nil && identity.AzureClientID != "" {
+		return map[string]string{AzureWorkloadIdentityLabelKey: "true"}
+	}
+	if azureStorageAccount(server) == "" || app.Spec.Storage == nil || app.Spec.Storage.Azure == nil || app.Spec.Storage.Azure.ManagedIdentity == nil {
+		return nil
+	}
+	return map[string]string{AzureWorkloadIdentityLabelKey: "true"}
+}
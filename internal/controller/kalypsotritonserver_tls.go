@@ -0,0 +1,161 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+const (
+	// TLSCertificateRevisionAnnotation identifies the certificate served by the Triton pods, so a
+	// renewed certificate rolls them: Triton only reads its certificate at startup
+	TLSCertificateRevisionAnnotation = "serving.kalypso.io/tls-certificate-revision"
+	// tlsVolumeName is the name of the volume holding the Triton gRPC certificate
+	tlsVolumeName = "kalypso-tls"
+	// tlsMountPath is where the Triton gRPC certificate is mounted
+	tlsMountPath = "/etc/kalypso/tls"
+	// tlsCAKey is the secret key of the CA verifying client certificates, as written by cert-manager
+	tlsCAKey = "ca.crt"
+)
+
+// tlsSecretName returns the secret holding the certificate of the Triton gRPC endpoint, empty
+// when the endpoint is served in plain text. The server's own secret overrides the
+// application certificate
+func tlsSecretName(server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) string {
+	if tls := serverTLS(server); tls != nil {
+		return tls.SecretName
+	}
+	if app.Spec.Certificate != nil && app.Spec.Certificate.ServerTLS {
+		return naming.ApplicationCertificate(app.Name)
+	}
+	return ""
+}
+
+// serverTLS returns the TLS settings of the server, nil when it relies on the application
+func serverTLS(server *servingv1alpha1.KalypsoTritonServer) *servingv1alpha1.TLSSpec {
+	if server.Spec.Networking == nil {
+		return nil
+	}
+	return server.Spec.Networking.TLS
+}
+
+// buildTLSArgs enables TLS on the Triton gRPC endpoint with the mounted certificate, and
+// client certificate verification against the mounted CA when requested
+func buildTLSArgs(server *servingv1alpha1.KalypsoTritonServer, secretName string, args []string) []string {
+	if secretName == "" {
+		return args
+	}
+	args = append(args,
+		"--grpc-use-ssl=true",
+		"--grpc-server-cert="+path.Join(tlsMountPath, corev1.TLSCertKey),
+		"--grpc-server-key="+path.Join(tlsMountPath, corev1.TLSPrivateKeyKey),
+	)
+	if tls := serverTLS(server); tls != nil && tls.ClientAuth {
+		args = append(args,
+			"--grpc-use-ssl-mutual=true",
+			"--grpc-root-cert="+path.Join(tlsMountPath, tlsCAKey),
+		)
+	}
+	return args
+}
+
+// applyTritonTLS mounts the certificate secret in the Triton container
+func applyTritonTLS(podSpec *corev1.PodSpec, secretName string) {
+	if secretName == "" {
+		return
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: tlsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: secretName},
+		},
+	})
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      tlsVolumeName,
+		MountPath: tlsMountPath,
+		ReadOnly:  true,
+	})
+}
+
+// tlsCertificateRevision returns a short hash of the certificate served by the Triton gRPC
+// endpoint, empty when TLS is disabled or the certificate is not issued yet
+func (r *KalypsoTritonServerReconciler) tlsCertificateRevision(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) (string, error) {
+	secretName := tlsSecretName(server, app)
+	if secretName == "" {
+		return "", nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.secretReader().Get(ctx, client.ObjectKey{Namespace: server.Namespace, Name: secretName}, secret); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	certificate := secret.Data[corev1.TLSCertKey]
+	if len(certificate) == 0 {
+		return "", nil
+	}
+	sum := sha256.Sum256(certificate)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// withTLSCertificateRevision returns a copy of the server annotating its pods with the
+// certificate revision
+func withTLSCertificateRevision(server *servingv1alpha1.KalypsoTritonServer, revision string) *servingv1alpha1.KalypsoTritonServer {
+	if revision == "" {
+		return server
+	}
+	annotated := server.DeepCopy()
+	annotated.Spec.PodAnnotations = defaults.MergeStringMaps(annotated.Spec.PodAnnotations, map[string]string{
+		TLSCertificateRevisionAnnotation: revision,
+	})
+	return annotated
+}
+
+// serversForTLSSecret maps a certificate secret to the servers serving it: the servers
+// referencing it directly, or the servers of the application when it is an application
+// certificate, labeled by cert-manager from the Certificate secret template
+func (r *KalypsoTritonServerReconciler) serversForTLSSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	appName := obj.GetLabels()[ApplicationLabelKey]
+	isApplicationCertificate := appName != "" && obj.GetName() == naming.ApplicationCertificate(appName)
+
+	servers := &servingv1alpha1.KalypsoTritonServerList{}
+	if err := r.List(ctx, servers, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, server := range servers.Items {
+		tls := serverTLS(&server)
+		if (tls != nil && tls.SecretName == obj.GetName()) || (tls == nil && isApplicationCertificate && serverApplicationKey(&server) == (types.NamespacedName{Namespace: obj.GetNamespace(), Name: appName})) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: server.Namespace, Name: server.Name},
+			})
+		}
+	}
+	return requests
+}
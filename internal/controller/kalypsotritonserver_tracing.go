@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+const (
+	// TracingHelperVolumeName is the volume holding the Python tracing helper module
+	TracingHelperVolumeName = "kalypso-tracing"
+	// TracingHelperMountPath is added to PYTHONPATH so models can import kalypso_tracing
+	TracingHelperMountPath = "/opt/kalypso/python"
+	// TracingHelperModuleFile is the ConfigMap key and file name of the helper module
+	TracingHelperModuleFile = "kalypso_tracing.py"
+)
+
+// tracingHelperModule records spans of Python backend models as children of the Triton request
+// trace. Triton exposes the W3C trace context of each request when tracing in opentelemetry mode;
+// the SDK is configured by the OTEL_* variables injected by the operator
+const tracingHelperModule = `"""Child spans of the Triton request trace for Python backend models.
+
+Requires the opentelemetry-sdk and opentelemetry-exporter-otlp-proto-http packages in the
+Python backend environment.
+
+    from kalypso_tracing import start_span
+
+    def execute(self, requests):
+        for request in requests:
+            with start_span(request, "preprocess"):
+                ...
+"""
+import contextlib
+import json
+
+from opentelemetry import trace
+from opentelemetry.exporter.otlp.proto.http.trace_exporter import OTLPSpanExporter
+from opentelemetry.propagate import extract
+from opentelemetry.sdk.trace import TracerProvider
+from opentelemetry.sdk.trace.export import BatchSpanProcessor
+
+_provider = TracerProvider()
+_provider.add_span_processor(BatchSpanProcessor(OTLPSpanExporter()))
+trace.set_tracer_provider(_provider)
+_tracer = trace.get_tracer("kalypso.python_backend")
+
+
+def request_context(request):
+    """Returns the OpenTelemetry context of the request trace, or None when it is not traced."""
+    try:
+        carrier = request.trace().get_context()
+    except AttributeError:
+        return None
+    if not carrier:
+        return None
+    return extract(json.loads(carrier))
+
+
+@contextlib.contextmanager
+def start_span(request, name, **attributes):
+    """Records a span named name as a child of the request trace."""
+    with _tracer.start_as_current_span(name, context=request_context(request), attributes=attributes or None) as span:
+        yield span
+`
+
+// pythonTracingEnabled reports whether Python backend models get the trace context propagation
+func pythonTracingEnabled(server *servingv1alpha1.KalypsoTritonServer) bool {
+	obs := server.Spec.Observability
+	return obs != nil && obs.Enabled && obs.CollectorEndpoint != "" &&
+		obs.Tracing != nil && obs.Tracing.Enabled && obs.Tracing.PythonBackendPropagation
+}
+
+// tracingSamplingRate returns the trace sampling rate, defaulting to 0.1
+func tracingSamplingRate(tracing *servingv1alpha1.TracingSpec) string {
+	if tracing.SamplingRate != "" {
+		return tracing.SamplingRate
+	}
+	return "0.1"
+}
+
+// buildPythonTracingEnv configures the OpenTelemetry SDK of the Python backend stub processes,
+// which inherit the Triton container environment. Spans are sampled by their parent, so the
+// Python spans follow the sampling decision of the request trace
+func buildPythonTracingEnv(server *servingv1alpha1.KalypsoTritonServer) []corev1.EnvVar {
+	if !pythonTracingEnabled(server) {
+		return nil
+	}
+
+	obs := server.Spec.Observability
+	env := []corev1.EnvVar{
+		{Name: "OTEL_SERVICE_NAME", Value: server.Name},
+		{Name: "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", Value: obs.CollectorEndpoint},
+		{Name: "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL", Value: "http/protobuf"},
+		{Name: "OTEL_PROPAGATORS", Value: "tracecontext,baggage"},
+		{Name: "OTEL_TRACES_SAMPLER", Value: "parentbased_traceidratio"},
+		{Name: "OTEL_TRACES_SAMPLER_ARG", Value: tracingSamplingRate(obs.Tracing)},
+		{Name: "PYTHONPATH", Value: TracingHelperMountPath},
+	}
+
+	// Match the lineage resource attributes of the Triton spans
+	var attributes []string
+	for _, field := range lineageFields(server.Spec.Metadata) {
+		attributes = append(attributes, field.attribute+"="+field.value)
+	}
+	if len(attributes) > 0 {
+		env = append(env, corev1.EnvVar{Name: "OTEL_RESOURCE_ATTRIBUTES", Value: strings.Join(attributes, ",")})
+	}
+	return env
+}
+
+// applyPythonTracing mounts the tracing helper module in the Triton container
+func applyPythonTracing(podSpec *corev1.PodSpec, server *servingv1alpha1.KalypsoTritonServer, configMapName string) {
+	if !pythonTracingEnabled(server) {
+		return
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: TracingHelperVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+			},
+		},
+	})
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      TracingHelperVolumeName,
+		MountPath: TracingHelperMountPath,
+		ReadOnly:  true,
+	})
+}
+
+// reconcileTracingHelper ensures the ConfigMap holding the Python tracing helper module, and
+// removes it when the propagation is disabled
+func (r *KalypsoTritonServerReconciler) reconcileTracingHelper(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, name string) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: server.Namespace,
+		},
+	}
+
+	if !pythonTracingEnabled(server) {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(configMap), configMap); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !metav1.IsControlledBy(configMap, server) {
+			return nil
+		}
+		return client.IgnoreNotFound(r.Delete(ctx, configMap))
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		// Set user-defined labels and annotations; the labels below take precedence
+		applyCustomMetadata(configMap, server)
+
+		// Set labels
+		if configMap.Labels == nil {
+			configMap.Labels = make(map[string]string)
+		}
+		configMap.Labels[TritonServerLabelKey] = server.Name
+		configMap.Labels[ApplicationLabelKey] = serverApplicationKey(server).Name
+		configMap.Labels[ManagedByLabelKey] = ManagedByLabelValue
+
+		// Set data
+		configMap.Data = map[string]string{TracingHelperModuleFile: tracingHelperModule}
+
+		// Set owner reference
+		return controllerutil.SetControllerReference(server, configMap, r.Scheme)
+	})
+
+	return err
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+const (
+	// CosignKeyVolumeName is the volume holding the public key of the manifest signer
+	CosignKeyVolumeName = "kalypso-cosign-key"
+	// CosignKeyMountPath is where the public key is mounted in the signature verification container
+	CosignKeyMountPath = "/kalypso/cosign"
+	// verificationDefaultManifest is the sha256sum manifest at the root of the model repository
+	verificationDefaultManifest = "SHA256SUMS"
+	// verificationDefaultImage runs the checksum verification script
+	verificationDefaultImage = "busybox:1.36"
+	// cosignDefaultImage verifies the signature of the manifest
+	cosignDefaultImage = "gcr.io/projectsigstore/cosign:v2.4.1"
+)
+
+// verificationScript checks every file of the model repository against the manifest, and
+// rejects files the manifest does not list
+const verificationScript = `set -eu
+cd "$MODEL_REPOSITORY"
+if [ ! -f "$MANIFEST" ]; then
+  echo "the model repository has no $MANIFEST manifest" >&2
+  exit 1
+fi
+failed=$(sha256sum -c "$MANIFEST" 2>&1 | grep -v ': OK$' || true)
+if [ -n "$failed" ]; then
+  echo "$failed" >&2
+  exit 1
+fi
+listed=$(awk '{ sub(/^[0-9a-f]+ [ *]/, ""); sub(/^\.\//, ""); print }' "$MANIFEST")
+unlisted=$(find . -type f ! -path './.git' ! -path './.git/*' | sed 's|^\./||' | while read -r file; do
+  if [ "$file" = "$MANIFEST" ] || [ "$file" = "$MANIFEST.sig" ]; then
+    continue
+  fi
+  echo "$listed" | grep -qxF "$file" || echo "$file"
+done)
+if [ -n "$unlisted" ]; then
+  echo "files missing from $MANIFEST: $unlisted" >&2
+  exit 1
+fi
+echo "verified the model repository against $MANIFEST"
+`
+
+// modelVerification returns the verification of the application model repositories, nil when
+// they are served unverified
+func modelVerification(app *servingv1alpha1.KalypsoApplication) *servingv1alpha1.ModelVerificationSpec {
+	if app.Spec.Storage == nil {
+		return nil
+	}
+	return app.Spec.Storage.Verification
+}
+
+// verificationUnsupported returns why the model repository cannot be verified before Triton
+// serves it, empty when it is downloaded at startup and not changed afterwards
+func verificationUnsupported(server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) string {
+	if !strings.HasPrefix(modelRepositoryPath(server, app), ModelDownloadMountPath+"/") {
+		return fmt.Sprintf("storage URI %s is read remotely by Triton and cannot be verified", server.Spec.StorageURI)
+	}
+	if gitSyncPolling(server) {
+		return "changes pulled by git polling cannot be verified; disable spec.git.poll"
+	}
+	return ""
+}
+
+// applyModelVerification adds the init containers verifying the signature of the manifest and
+// the checksums of the downloaded model repository, after the download and before Triton starts
+func applyModelVerification(podSpec *corev1.PodSpec, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) {
+	spec := modelVerification(app)
+	if spec == nil || verificationUnsupported(server, app) != "" {
+		return
+	}
+
+	repository := modelRepositoryPath(server, app)
+	manifest := spec.Manifest
+	if manifest == "" {
+		manifest = verificationDefaultManifest
+	}
+	// The verification reads the repository through the Triton container mounts, following
+	// links into the node model cache
+	var mounts []corev1.VolumeMount
+	for _, mount := range podSpec.Containers[0].VolumeMounts {
+		if mount.Name == ModelDownloadVolumeName || mount.Name == ModelCacheVolumeName {
+			mounts = append(mounts, mount)
+		}
+	}
+
+	if cosign := spec.Cosign; cosign != nil {
+		image := cosign.Image
+		if image == "" {
+			image = cosignDefaultImage
+		}
+		args := []string{
+			"verify-blob",
+			"--key=" + path.Join(CosignKeyMountPath, "cosign.pub"),
+			"--signature=" + path.Join(repository, manifest+".sig"),
+		}
+		if !cosign.TransparencyLog {
+			args = append(args, "--insecure-ignore-tlog=true")
+		}
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: CosignKeyVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: cosign.PublicKeySecret},
+			},
+		})
+		podSpec.InitContainers = append(podSpec.InitContainers, corev1.Container{
+			Name:  "model-signature",
+			Image: image,
+			Args:  append(args, path.Join(repository, manifest)),
+			VolumeMounts: append(append([]corev1.VolumeMount(nil), mounts...), corev1.VolumeMount{
+				Name:      CosignKeyVolumeName,
+				MountPath: CosignKeyMountPath,
+				ReadOnly:  true,
+			}),
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+			SecurityContext:          buildContainerSecurityContext(server.Spec.ContainerSecurityContext),
+		})
+	}
+
+	image := spec.Image
+	if image == "" {
+		image = verificationDefaultImage
+	}
+	podSpec.InitContainers = append(podSpec.InitContainers, corev1.Container{
+		Name:    "model-checksums",
+		Image:   image,
+		Command: []string{"sh", "-c", verificationScript},
+		Env: []corev1.EnvVar{
+			{Name: "MODEL_REPOSITORY", Value: repository},
+			{Name: "MANIFEST", Value: manifest},
+		},
+		VolumeMounts:             mounts,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		SecurityContext:          buildContainerSecurityContext(server.Spec.ContainerSecurityContext),
+	})
+}
+
+// verificationResult is the outcome of the model repository verification of the pods
+type verificationResult struct {
+	// unsupported is why the repository cannot be verified; the server is not deployed
+	unsupported string
+	// failures are the pods whose verification failed, with the reason
+	failures []string
+	// verified is true once a pod passed the verification and became available
+	verified bool
+}
+
+// evaluateVerification collects the verification failures of the server's pods. Returns nil
+// when the model repositories are not verified
+func (r *KalypsoTritonServerReconciler) evaluateVerification(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication, availableReplicas int32) *verificationResult {
+	if modelVerification(app) == nil {
+		return nil
+	}
+	result := &verificationResult{verified: availableReplicas > 0}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(server.Namespace), client.MatchingLabels{TritonServerLabelKey: server.Name}); err != nil {
+		return result
+	}
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.InitContainerStatuses {
+			if status.Name != "model-signature" && status.Name != "model-checksums" {
+				continue
+			}
+			terminated := status.State.Terminated
+			if terminated == nil {
+				terminated = status.LastTerminationState.Terminated
+			}
+			if terminated != nil && terminated.ExitCode != 0 {
+				result.failures = append(result.failures, fmt.Sprintf("%s: %s", pod.Name, strings.TrimSpace(terminated.Message)))
+				break
+			}
+		}
+	}
+	return result
+}
+
+// applyVerificationStatus records whether the served model repository was verified
+func applyVerificationStatus(server *servingv1alpha1.KalypsoTritonServer, result *verificationResult) {
+	if result == nil {
+		meta.RemoveStatusCondition(&server.Status.Conditions, "Verified")
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               "Verified",
+		Status:             metav1.ConditionTrue,
+		Reason:             "ArtifactsVerified",
+		Message:            "The pods verified the model repository before serving it",
+		LastTransitionTime: metav1.Now(),
+	}
+	switch {
+	case result.unsupported != "":
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "VerificationUnsupported"
+		condition.Message = result.unsupported
+	case len(result.failures) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "VerificationFailed"
+		condition.Message = strings.Join(result.failures, "; ")
+	case !result.verified:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "VerificationPending"
+		condition.Message = "Waiting for a pod to verify the model repository"
+	}
+	meta.SetStatusCondition(&server.Status.Conditions, condition)
+}
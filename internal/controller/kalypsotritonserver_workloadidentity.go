@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+const (
+	// AWSRoleARNAnnotation binds a ServiceAccount to an IAM role through IAM Roles for Service Accounts
+	AWSRoleARNAnnotation = "eks.amazonaws.com/role-arn"
+	// GCPServiceAccountAnnotation binds a ServiceAccount to a Google service account through GKE Workload Identity
+	GCPServiceAccountAnnotation = "iam.gke.io/gcp-service-account"
+	// AzureClientIDAnnotation binds a ServiceAccount to a managed identity through Microsoft Entra Workload ID
+	AzureClientIDAnnotation = "azure.workload.identity/client-id"
+)
+
+// storageWorkloadIdentity returns the cloud identity of the storage in the workloadIdentity auth,
+// nil when the storage credentials are injected from Secrets
+func storageWorkloadIdentity(storage *servingv1alpha1.StorageSpec) *servingv1alpha1.WorkloadIdentitySpec {
+	if storage == nil || storage.Auth != servingv1alpha1.StorageAuthWorkloadIdentity {
+		return nil
+	}
+	if storage.WorkloadIdentity == nil {
+		return &servingv1alpha1.WorkloadIdentitySpec{}
+	}
+	return storage.WorkloadIdentity
+}
+
+// workloadIdentityAnnotations returns the ServiceAccount annotations binding it to the identities
+func workloadIdentityAnnotations(identity *servingv1alpha1.WorkloadIdentitySpec) map[string]string {
+	annotations := make(map[string]string)
+	if identity.AWSRoleARN != "" {
+		annotations[AWSRoleARNAnnotation] = identity.AWSRoleARN
+	}
+	if identity.GCPServiceAccount != "" {
+		annotations[GCPServiceAccountAnnotation] = identity.GCPServiceAccount
+	}
+	if identity.AzureClientID != "" {
+		annotations[AzureClientIDAnnotation] = identity.AzureClientID
+	}
+	return annotations
+}
+
+// withWorkloadIdentity returns a copy of the server auto-creating its ServiceAccount with the
+// workload identity annotations of the application storage. Servers naming an existing
+// ServiceAccount keep it as is; annotations set on the server take precedence
+func withWorkloadIdentity(server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) *servingv1alpha1.KalypsoTritonServer {
+	identity := storageWorkloadIdentity(app.Spec.Storage)
+	if identity == nil {
+		return server
+	}
+	if sa := server.Spec.ServiceAccount; sa != nil && !sa.AutoCreate {
+		return server
+	}
+
+	bound := server.DeepCopy()
+	if bound.Spec.ServiceAccount == nil {
+		bound.Spec.ServiceAccount = &servingv1alpha1.ServiceAccountSpec{}
+	}
+	bound.Spec.ServiceAccount.AutoCreate = true
+	annotations := workloadIdentityAnnotations(identity)
+	for k, v := range bound.Spec.ServiceAccount.Annotations {
+		annotations[k] = v
+	}
+	bound.Spec.ServiceAccount.Annotations = annotations
+	return bound
+}
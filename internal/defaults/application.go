@@ -0,0 +1,153 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaults
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+// WithApplicationDefaults returns the server with the resources, networking, observability and
+// scheduling it leaves unset merged from the server defaults of its application. The server
+// itself is returned when the application has no server defaults
+func WithApplicationDefaults(server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) *servingv1alpha1.KalypsoTritonServer {
+	if app == nil || app.Spec.ServerDefaults == nil {
+		return server
+	}
+	defaults := app.Spec.ServerDefaults
+
+	merged := server.DeepCopy()
+	spec := &merged.Spec
+	if defaults.Resources != nil {
+		if spec.Resources == nil {
+			spec.Resources = &corev1.ResourceRequirements{}
+		}
+		spec.Resources.Requests = mergeResourceLists(defaults.Resources.Requests, spec.Resources.Requests)
+		spec.Resources.Limits = mergeResourceLists(defaults.Resources.Limits, spec.Resources.Limits)
+	}
+	if defaults.Networking != nil {
+		spec.Networking = mergeNetworking(defaults.Networking, spec.Networking)
+	}
+	if spec.Observability == nil && defaults.Observability != nil {
+		spec.Observability = defaults.Observability.DeepCopy()
+	}
+	if defaults.Scheduling != nil {
+		spec.Scheduling = mergeScheduling(defaults.Scheduling, spec.Scheduling)
+	}
+	return merged
+}
+
+// mergeResourceLists returns base with the quantities of overrides, nil when both are empty
+func mergeResourceLists(base, overrides corev1.ResourceList) corev1.ResourceList {
+	if len(base) == 0 && len(overrides) == 0 {
+		return nil
+	}
+	merged := make(corev1.ResourceList, len(base)+len(overrides))
+	for name, quantity := range base {
+		merged[name] = quantity.DeepCopy()
+	}
+	for name, quantity := range overrides {
+		merged[name] = quantity
+	}
+	return merged
+}
+
+// mergeNetworking returns a copy of override with the fields it leaves unset taken from base
+func mergeNetworking(base, override *servingv1alpha1.NetworkingSpec) *servingv1alpha1.NetworkingSpec {
+	merged := base.DeepCopy()
+	if override == nil {
+		return merged
+	}
+	override = override.DeepCopy()
+	if override.HTTPPort != nil {
+		merged.HTTPPort = override.HTTPPort
+	}
+	if override.GrpcPort != nil {
+		merged.GrpcPort = override.GrpcPort
+	}
+	if override.MetricsPort != nil {
+		merged.MetricsPort = override.MetricsPort
+	}
+	if override.ServiceType != "" {
+		merged.ServiceType = override.ServiceType
+		merged.LoadBalancerClass = override.LoadBalancerClass
+	}
+	merged.ServiceAnnotations = MergeStringMaps(merged.ServiceAnnotations, override.ServiceAnnotations)
+	if override.SessionAffinity != "" {
+		merged.SessionAffinity = override.SessionAffinity
+		merged.SessionAffinityTimeoutSeconds = override.SessionAffinityTimeoutSeconds
+	}
+	if override.GRPCKeepalive != nil {
+		merged.GRPCKeepalive = override.GRPCKeepalive
+	}
+	if override.NetworkPolicy != nil {
+		merged.NetworkPolicy = override.NetworkPolicy
+	}
+	if override.MetricsService != nil {
+		merged.MetricsService = override.MetricsService
+	}
+	if override.Ingress != nil {
+		merged.Ingress = override.Ingress
+	}
+	if override.TLS != nil {
+		merged.TLS = override.TLS
+	}
+	return merged
+}
+
+// mergeScheduling returns a copy of override with the fields it leaves unset taken from base.
+// Node selector labels of both are kept, those of override winning
+func mergeScheduling(base, override *servingv1alpha1.SchedulingSpec) *servingv1alpha1.SchedulingSpec {
+	merged := base.DeepCopy()
+	if override == nil {
+		return merged
+	}
+	override = override.DeepCopy()
+	merged.NodeSelector = MergeStringMaps(merged.NodeSelector, override.NodeSelector)
+	if len(override.Tolerations) > 0 {
+		merged.Tolerations = override.Tolerations
+	}
+	if override.Affinity != nil {
+		merged.Affinity = override.Affinity
+	}
+	if len(override.TopologySpreadConstraints) > 0 {
+		merged.TopologySpreadConstraints = override.TopologySpreadConstraints
+	}
+	if override.SchedulerName != "" {
+		merged.SchedulerName = override.SchedulerName
+	}
+	if len(override.SchedulingGates) > 0 {
+		merged.SchedulingGates = override.SchedulingGates
+	}
+	return merged
+}
+
+// MergeStringMaps returns a new map with the entries of base overridden by those of overrides
+func MergeStringMaps(base, overrides map[string]string) map[string]string {
+	if len(base) == 0 && len(overrides) == 0 {
+		return nil
+	}
+	merged := make(map[string]string, len(base)+len(overrides))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
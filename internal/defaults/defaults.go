@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package defaults resolves the settings the Kalypso resources leave unset, shared by the
+// controllers and the defaulting webhooks: the server presets, the server defaults of
+// applications, the project and application references they come from, and the labels
+// identifying them.
+package defaults
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+const (
+	// ProjectLabelKey is the label key for project identification
+	ProjectLabelKey = "kalypso-serving.io/project"
+	// ProjectNamespaceLabelKey is the label key for the namespace of the identified project
+	ProjectNamespaceLabelKey = "kalypso-serving.io/project-namespace"
+	// ApplicationLabelKey is the label key for application identification
+	ApplicationLabelKey = "kalypso-serving.io/application"
+)
+
+// Built-in Triton ports used when neither the server nor the defaults of its application set them
+const (
+	HTTPPort    int32 = 8000
+	GRPCPort    int32 = 8001
+	MetricsPort int32 = 8002
+)
+
+// TritonServer fills the ports the server leaves unset with those it is deployed with: the
+// ports of the server defaults of its application, else the built-in ports. A missing
+// application contributes no defaults. The Triton image and tag are left unset, so the
+// servers keep following the Triton defaults of their project
+func TritonServer(ctx context.Context, c client.Reader, server *servingv1alpha1.KalypsoTritonServer) error {
+	var app *servingv1alpha1.KalypsoApplication
+	if server.Spec.ApplicationRef != "" {
+		var err error
+		if app, err = ServerApplication(ctx, c, server); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	networking := WithApplicationDefaults(server, app).Spec.Networking
+	if networking == nil {
+		networking = &servingv1alpha1.NetworkingSpec{}
+	}
+
+	spec := &server.Spec
+	if spec.Networking == nil {
+		spec.Networking = &servingv1alpha1.NetworkingSpec{}
+	}
+	spec.Networking.HTTPPort = defaultPort(networking.HTTPPort, HTTPPort)
+	spec.Networking.GrpcPort = defaultPort(networking.GrpcPort, GRPCPort)
+	spec.Networking.MetricsPort = defaultPort(networking.MetricsPort, MetricsPort)
+	return nil
+}
+
+// defaultPort returns a copy of the resolved port, or the built-in port when it is unset
+func defaultPort(port *int32, builtIn int32) *int32 {
+	if port != nil {
+		value := *port
+		return &value
+	}
+	return &builtIn
+}
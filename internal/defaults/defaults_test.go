@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaults
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+var _ = Describe("Defaults", func() {
+	var (
+		ctx     context.Context
+		scheme  *runtime.Scheme
+		project *servingv1alpha1.KalypsoProject
+		app     *servingv1alpha1.KalypsoApplication
+		server  *servingv1alpha1.KalypsoTritonServer
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		project = &servingv1alpha1.KalypsoProject{
+			ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "sample-project"},
+			Spec: servingv1alpha1.KalypsoProjectSpec{
+				Defaults: &servingv1alpha1.ProjectDefaultsSpec{
+					TritonConfig: &servingv1alpha1.ProjectTritonDefaults{Image: "registry.example.com/tritonserver", Tag: "24.10-py3"},
+				},
+			},
+		}
+		httpPort := int32(9000)
+		app = &servingv1alpha1.KalypsoApplication{
+			ObjectMeta: metav1.ObjectMeta{Name: "recommendation", Namespace: "sample-project"},
+			Spec: servingv1alpha1.KalypsoApplicationSpec{
+				ProjectRef: "sample-project",
+				ServerDefaults: &servingv1alpha1.ServerDefaultsSpec{
+					Networking: &servingv1alpha1.NetworkingSpec{HTTPPort: &httpPort},
+				},
+			},
+		}
+		server = &servingv1alpha1.KalypsoTritonServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "sample-project"},
+			Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: "recommendation"},
+		}
+	})
+
+	It("should store the ports of the application and leave the Triton image to the project", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(project, app).Build()
+		Expect(TritonServer(ctx, c, server)).To(Succeed())
+
+		Expect(*server.Spec.Networking.HTTPPort).To(Equal(int32(9000)))
+		Expect(*server.Spec.Networking.GrpcPort).To(Equal(GRPCPort))
+		Expect(*server.Spec.Networking.MetricsPort).To(Equal(MetricsPort))
+		Expect(server.Spec.TritonConfig.Image).To(BeEmpty())
+		Expect(server.Spec.TritonConfig.Tag).To(BeEmpty())
+	})
+
+	It("should use the built-in ports for applications the server may not reference", func() {
+		server.Namespace = "other-team"
+		server.Spec.ApplicationRef = "sample-project/recommendation"
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(project, app).Build()
+
+		_, err := ServerApplication(ctx, c, server)
+		Expect(err).To(HaveOccurred())
+		Expect(TritonServer(ctx, c, server)).To(Succeed())
+		Expect(*server.Spec.Networking.HTTPPort).To(Equal(HTTPPort))
+	})
+})
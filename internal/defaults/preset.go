@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaults
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+// GPUProductLabelKey is the node label set by GPU feature discovery for the GPU model
+const GPUProductLabelKey = "nvidia.com/gpu.product"
+
+// preset is a vetted configuration for a model size on a GPU type
+type preset struct {
+	gpuCount   int32
+	gpuProduct string
+	cpu        string
+	memory     string
+	shm        string
+	parameters []servingv1alpha1.TritonParameter
+	// startupFailureThreshold bounds model loading at 10s periods
+	startupFailureThreshold int32
+	// maxSurge is 1 with no unavailable replica, or 0 when GPUs for an extra replica are scarce
+	maxSurge int
+}
+
+// presets are the built-in presets, keyed by spec.preset
+var presets = map[string]preset{
+	servingv1alpha1.PresetLLM7BA10G: {
+		gpuCount:   1,
+		gpuProduct: "NVIDIA-A10G",
+		cpu:        "8",
+		memory:     "32Gi",
+		shm:        "8Gi",
+		parameters: []servingv1alpha1.TritonParameter{
+			{Name: "model-load-thread-count", Value: "2"},
+			{Name: "pinned-memory-pool-byte-size", Value: "268435456"},
+		},
+		startupFailureThreshold: 60, // 10 minutes
+		maxSurge:                1,
+	},
+	servingv1alpha1.PresetLLM13BA100: {
+		gpuCount:   1,
+		gpuProduct: "NVIDIA-A100-SXM4-80GB",
+		cpu:        "12",
+		memory:     "64Gi",
+		shm:        "16Gi",
+		parameters: []servingv1alpha1.TritonParameter{
+			{Name: "model-load-thread-count", Value: "2"},
+			{Name: "pinned-memory-pool-byte-size", Value: "536870912"},
+		},
+		startupFailureThreshold: 90, // 15 minutes
+		maxSurge:                1,
+	},
+	servingv1alpha1.PresetLLM70BH100: {
+		gpuCount:   4,
+		gpuProduct: "NVIDIA-H100-80GB-HBM3",
+		cpu:        "32",
+		memory:     "256Gi",
+		shm:        "64Gi",
+		parameters: []servingv1alpha1.TritonParameter{
+			{Name: "model-load-thread-count", Value: "4"},
+			{Name: "pinned-memory-pool-byte-size", Value: "1073741824"},
+		},
+		startupFailureThreshold: 180, // 30 minutes
+		maxSurge:                0,
+	},
+}
+
+// WithPreset returns the server with the fields left unset filled from its preset. The server
+// itself is returned when it has no known preset
+func WithPreset(server *servingv1alpha1.KalypsoTritonServer) *servingv1alpha1.KalypsoTritonServer {
+	p, ok := presets[server.Spec.Preset]
+	if !ok {
+		return server
+	}
+
+	expanded := server.DeepCopy()
+	spec := &expanded.Spec
+	if spec.Resources == nil {
+		spec.Resources = &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(p.cpu),
+				corev1.ResourceMemory: resource.MustParse(p.memory),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse(p.memory),
+			},
+		}
+	}
+	// The GPU product only applies to the preset GPUs
+	if spec.GPU == nil {
+		count := p.gpuCount
+		spec.GPU = &servingv1alpha1.GPUSpec{Count: &count}
+		if spec.Scheduling == nil {
+			spec.Scheduling = &servingv1alpha1.SchedulingSpec{}
+		}
+		spec.Scheduling.NodeSelector = MergeStringMaps(map[string]string{GPUProductLabelKey: p.gpuProduct}, spec.Scheduling.NodeSelector)
+	}
+	if spec.SharedMemory == nil {
+		spec.SharedMemory = &servingv1alpha1.SharedMemorySpec{Size: resource.MustParse(p.shm)}
+	}
+	spec.TritonConfig.Parameters = MergeTritonParameters(p.parameters, spec.TritonConfig.Parameters)
+	if spec.Probes == nil {
+		spec.Probes = &servingv1alpha1.ProbesSpec{}
+	}
+	if spec.Probes.Startup == nil {
+		threshold := p.startupFailureThreshold
+		spec.Probes.Startup = &servingv1alpha1.ProbeSpec{FailureThreshold: &threshold}
+	}
+	if spec.DeploymentStrategy == nil {
+		maxSurge := intstr.FromInt(p.maxSurge)
+		maxUnavailable := intstr.FromInt(1 - p.maxSurge)
+		spec.DeploymentStrategy = &servingv1alpha1.DeploymentStrategySpec{
+			Type:           string(appsv1.RollingUpdateDeploymentStrategyType),
+			MaxSurge:       &maxSurge,
+			MaxUnavailable: &maxUnavailable,
+		}
+	}
+	return expanded
+}
+
+// MergeTritonParameters appends the override parameters to base, replacing parameters with the
+// same name
+func MergeTritonParameters(base, overrides []servingv1alpha1.TritonParameter) []servingv1alpha1.TritonParameter {
+	merged := make([]servingv1alpha1.TritonParameter, 0, len(base)+len(overrides))
+	for _, param := range base {
+		overridden := false
+		for _, override := range overrides {
+			if override.Name == param.Name {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, param)
+		}
+	}
+	return append(merged, overrides...)
+}
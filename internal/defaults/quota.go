@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaults
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+const (
+	// GPUResourceName is the extended resource name for whole NVIDIA GPUs
+	GPUResourceName = "nvidia.com/gpu"
+	// SharedGPUResourceName is the extended resource name of shared GPUs when the sharing
+	// configuration renames them
+	SharedGPUResourceName = "nvidia.com/gpu.shared"
+)
+
+// QuotaUsage returns what all replicas of the server's Triton containers count against a
+// ResourceQuota, keyed like the quota hard limits: the compute requests under both their bare
+// and requests.-prefixed names, the compute limits, the extended resource requests such as GPUs, and the
+// pods. Requests default to the limits like in the API server, and the preset and the server
+// defaults of the application, which may be nil, are applied first. Suspended and archived
+// servers use nothing
+func QuotaUsage(server *servingv1alpha1.KalypsoTritonServer, app *servingv1alpha1.KalypsoApplication) corev1.ResourceList {
+	usage := corev1.ResourceList{}
+	if server.Spec.Suspend || server.Spec.State == servingv1alpha1.ServerStateArchived {
+		return usage
+	}
+	server = WithApplicationDefaults(WithPreset(server), app)
+
+	replicas := int64(1)
+	if server.Spec.Replicas != nil {
+		replicas = int64(*server.Spec.Replicas)
+	}
+	if replicas == 0 {
+		return usage
+	}
+
+	container := corev1.ResourceRequirements{}
+	if server.Spec.Resources != nil {
+		container = *server.Spec.Resources.DeepCopy()
+	}
+	ApplyGPUResources(&container, server.Spec.GPU)
+
+	requests := corev1.ResourceList{}
+	for name, quantity := range container.Limits {
+		requests[name] = quantity
+	}
+	for name, quantity := range container.Requests {
+		requests[name] = quantity
+	}
+
+	add := func(name corev1.ResourceName, perPod resource.Quantity) {
+		total := usage[name]
+		total.Add(*resource.NewMilliQuantity(perPod.MilliValue()*replicas, perPod.Format))
+		usage[name] = total
+	}
+	for name, quantity := range requests {
+		add("requests."+name, quantity)
+		// Quotas only accept extended resources such as nvidia.com/gpu with the requests. prefix
+		if !isExtendedResource(name) {
+			add(name, quantity)
+		}
+	}
+	for name, quantity := range container.Limits {
+		if !isExtendedResource(name) {
+			add("limits."+name, quantity)
+		}
+	}
+	usage[corev1.ResourcePods] = *resource.NewQuantity(replicas, resource.DecimalSI)
+	return usage
+}
+
+// isExtendedResource reports whether the resource is a domain-prefixed extended resource
+func isExtendedResource(name corev1.ResourceName) bool {
+	return strings.Contains(string(name), "/")
+}
+
+// ApplyGPUResources sets the GPU extended resource limits for whole GPUs, shared GPUs or MIG slices
+func ApplyGPUResources(resources *corev1.ResourceRequirements, gpu *servingv1alpha1.GPUSpec) {
+	if gpu == nil {
+		return
+	}
+
+	var name corev1.ResourceName
+	var count int32
+	switch {
+	case gpu.MIG != nil:
+		count = 1
+		if gpu.MIG.Count != nil {
+			count = *gpu.MIG.Count
+		}
+		// With the single strategy every MIG slice is advertised as a whole GPU
+		name = GPUResourceName
+		if gpu.MIG.Strategy != "single" {
+			name = corev1.ResourceName(fmt.Sprintf("nvidia.com/mig-%s", gpu.MIG.Profile))
+		}
+	case gpu.Count != nil && *gpu.Count > 0:
+		name = GPUResourceName
+		if gpu.Sharing != nil && gpu.Sharing.RenameByDefault {
+			name = SharedGPUResourceName
+		}
+		count = *gpu.Count
+	default:
+		return
+	}
+
+	if resources.Limits == nil {
+		resources.Limits = corev1.ResourceList{}
+	}
+	resources.Limits[name] = *resource.NewQuantity(int64(count), resource.DecimalSI)
+}
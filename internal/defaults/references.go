@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaults
+
+import (
+	"context"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// ProjectAllowsNamespace reports whether the KalypsoApplications of the namespace may reference
+// the project: those of the project namespace, of its environment namespaces and of the
+// namespaces listed in spec.allowedNamespaces
+func ProjectAllowsNamespace(project *servingv1alpha1.KalypsoProject, namespace string) bool {
+	if namespace == project.Namespace || slices.Contains(project.Spec.AllowedNamespaces, namespace) {
+		return true
+	}
+	for _, env := range project.Spec.Environments {
+		if env.Namespace == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplicationProject fetches the project referenced by the application. A project of another
+// namespace not allowing the application namespace is reported as not found
+func ApplicationProject(ctx context.Context, c client.Reader, app *servingv1alpha1.KalypsoApplication) (*servingv1alpha1.KalypsoProject, error) {
+	return ReferencedProject(ctx, c, app.Namespace, app.Spec.ProjectRef)
+}
+
+// ReferencedProject fetches the project referenced from the namespace by its name or by
+// <namespace>/<name>. A project not allowing the namespace is reported as not found
+func ReferencedProject(ctx context.Context, c client.Reader, namespace, ref string) (*servingv1alpha1.KalypsoProject, error) {
+	key := naming.Reference(namespace, ref)
+	project := &servingv1alpha1.KalypsoProject{}
+	if err := c.Get(ctx, key, project); err != nil {
+		return nil, err
+	}
+	if !ProjectAllowsNamespace(project, namespace) {
+		return nil, errors.NewNotFound(servingv1alpha1.GroupVersion.WithResource("kalypsoprojects").GroupResource(), key.String())
+	}
+	return project, nil
+}
+
+// ServerApplication fetches the application referenced by the server. An application of
+// another namespace is only found when its project allows the server namespace, the same
+// allow list guarding project references
+func ServerApplication(ctx context.Context, c client.Reader, server *servingv1alpha1.KalypsoTritonServer) (*servingv1alpha1.KalypsoApplication, error) {
+	key := naming.Reference(server.Namespace, server.Spec.ApplicationRef)
+	app := &servingv1alpha1.KalypsoApplication{}
+	if err := c.Get(ctx, key, app); err != nil {
+		return nil, err
+	}
+	if key.Namespace == server.Namespace {
+		return app, nil
+	}
+	allowed, err := ApplicationAllowsNamespace(ctx, c, app, server.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.NewNotFound(servingv1alpha1.GroupVersion.WithResource("kalypsoapplications").GroupResource(), key.String())
+	}
+	return app, nil
+}
+
+// ApplicationAllowsNamespace reports whether the servers of the namespace may reference the
+// application: those of the application namespace, and of the namespaces its project allows
+func ApplicationAllowsNamespace(ctx context.Context, c client.Reader, app *servingv1alpha1.KalypsoApplication, namespace string) (bool, error) {
+	if namespace == app.Namespace {
+		return true, nil
+	}
+	project, err := ApplicationProject(ctx, c, app)
+	if err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return ProjectAllowsNamespace(project, namespace), nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package features defines the operator feature gates used to roll out
+// experimental subsystems gradually per cluster.
+package features
+
+import (
+	"flag"
+	"strings"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/component-base/featuregate"
+)
+
+const (
+	// RetrainingHook enables drift/SLO trigger evaluation and retraining CloudEvents
+	// owner: @kalypsoServing
+	// alpha: v0.1
+	RetrainingHook featuregate.Feature = "RetrainingHook"
+
+	// ImageArchitectureCheck enables registry lookups verifying the Triton image is published
+	// for the node architecture selected by the scheduling constraints
+	// owner: @kalypsoServing
+	// alpha: v0.1
+	ImageArchitectureCheck featuregate.Feature = "ImageArchitectureCheck"
+
+	// ConformanceSelfTest periodically deploys a reference model server in a dedicated namespace
+	// and exports whether it served an inference as a conformance metric
+	// owner: @kalypsoServing
+	// alpha: v0.1
+	ConformanceSelfTest featuregate.Feature = "ConformanceSelfTest"
+
+	// AuditExport records Kalypso resource changes with the requesting user through an admission
+	// webhook and streams them to the SIEM sink configured on each project
+	// owner: @kalypsoServing
+	// alpha: v0.1
+	AuditExport featuregate.Feature = "AuditExport"
+)
+
+// Gate is the operator-wide feature gate, populated from the --feature-gates flag
+var Gate = featuregate.NewFeatureGate()
+
+// defaultFeatureGates lists every known feature and its default state.
+// To add a new feature, define a key above and add it here.
+var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
+	RetrainingHook:         {Default: false, PreRelease: featuregate.Alpha},
+	ImageArchitectureCheck: {Default: false, PreRelease: featuregate.Alpha},
+	ConformanceSelfTest:    {Default: false, PreRelease: featuregate.Alpha},
+	AuditExport:            {Default: false, PreRelease: featuregate.Alpha},
+}
+
+func init() {
+	utilruntime.Must(Gate.Add(defaultFeatureGates))
+}
+
+// AddFlag registers the --feature-gates flag setting Gate on the flag set
+func AddFlag(fs *flag.FlagSet) {
+	fs.Func("feature-gates", "A set of key=value pairs that describe feature gates for "+
+		"experimental operator capabilities. Options are:\n"+strings.Join(Gate.KnownFeatures(), "\n"),
+		Gate.Set)
+}
+
+// Enabled reports whether the given feature is enabled
+func Enabled(feature featuregate.Feature) bool {
+	return Gate.Enabled(feature)
+}
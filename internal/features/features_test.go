@@ -0,0 +1,56 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"flag"
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Feature gates", func() {
+	newFlagSet := func() *flag.FlagSet {
+		fs := flag.NewFlagSet("manager", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		AddFlag(fs)
+		return fs
+	}
+
+	It("should disable every alpha feature by default", func() {
+		for _, feature := range []string{string(RetrainingHook), string(ImageArchitectureCheck), string(ConformanceSelfTest), string(AuditExport)} {
+			Expect(Gate.KnownFeatures()).To(ContainElement(HavePrefix(feature + "=true|false (ALPHA - default=false)")))
+		}
+		Expect(Enabled(RetrainingHook)).To(BeFalse())
+	})
+
+	It("should enable the features listed in --feature-gates", func() {
+		DeferCleanup(Gate.Set, "RetrainingHook=false,AuditExport=false")
+
+		Expect(newFlagSet().Parse([]string{"--feature-gates=RetrainingHook=true,AuditExport=true"})).To(Succeed())
+		Expect(Enabled(RetrainingHook)).To(BeTrue())
+		Expect(Enabled(AuditExport)).To(BeTrue())
+		Expect(Enabled(ImageArchitectureCheck)).To(BeFalse())
+	})
+
+	It("should reject unknown features and malformed values", func() {
+		Expect(newFlagSet().Parse([]string{"--feature-gates=ModelSharding=true"})).To(MatchError(ContainSubstring("unrecognized feature gate")))
+		Expect(newFlagSet().Parse([]string{"--feature-gates=RetrainingHook=yes"})).To(MatchError(ContainSubstring("invalid value")))
+		Expect(Enabled(RetrainingHook)).To(BeFalse())
+	})
+})
@@ -0,0 +1,355 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package imagearch resolves the CPU architectures a container image is published for
+// by reading its manifest list from the registry, and the manifest digests of references.
+// It also reads the raw manifests and blobs of a repository for signature verification.
+package imagearch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRegistry is used for image references without a registry host
+	DefaultRegistry = "registry-1.docker.io"
+	// DefaultCacheTTL is how long resolved platforms are reused before the registry is queried again
+	DefaultCacheTTL = time.Hour
+)
+
+// ErrNotFound is returned when the registry has no manifest or blob at the requested path
+var ErrNotFound = errors.New("not found in registry")
+
+// manifestMediaTypes are the manifest formats accepted from the registry, most specific first
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// Resolver returns the architectures (e.g. amd64, arm64) an image is available for
+type Resolver interface {
+	Architectures(ctx context.Context, image string) ([]string, error)
+}
+
+// DigestResolver returns the manifest digest a reference currently points to
+type DigestResolver interface {
+	Digest(ctx context.Context, reference string) (string, error)
+}
+
+// RegistryResolver reads image manifests anonymously from OCI distribution registries
+type RegistryResolver struct {
+	Client *http.Client
+	TTL    time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	architectures []string
+	digest        string
+	expires       time.Time
+}
+
+// NewRegistryResolver creates a RegistryResolver with a bounded request timeout
+func NewRegistryResolver() *RegistryResolver {
+	return &RegistryResolver{
+		Client: &http.Client{Timeout: 10 * time.Second},
+		TTL:    DefaultCacheTTL,
+		cache:  make(map[string]cacheEntry),
+	}
+}
+
+// Reference is a parsed image reference
+type Reference struct {
+	Registry   string
+	Repository string
+	// Reference is the tag or digest
+	Reference string
+}
+
+// ParseReference splits an image such as nvcr.io/nvidia/tritonserver:24.12-py3 into its parts
+func ParseReference(image string) (Reference, error) {
+	if image == "" {
+		return Reference{}, fmt.Errorf("empty image reference")
+	}
+
+	ref := Reference{Registry: DefaultRegistry, Reference: "latest"}
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Reference = name[i+1:]
+		name = name[:i]
+	} else if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.Reference = name[i+1:]
+		name = name[:i]
+	}
+
+	// The first component is a registry host when it looks like one
+	if i := strings.Index(name, "/"); i >= 0 {
+		host := name[:i]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			ref.Registry = host
+			name = name[i+1:]
+		}
+	}
+	if ref.Registry == DefaultRegistry && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+	ref.Repository = name
+	return ref, nil
+}
+
+type manifest struct {
+	MediaType string `json:"mediaType"`
+	Manifests []struct {
+		Platform *struct {
+			Architecture string `json:"architecture"`
+			OS           string `json:"os"`
+		} `json:"platform,omitempty"`
+	} `json:"manifests,omitempty"`
+	Config *struct {
+		Digest string `json:"digest"`
+	} `json:"config,omitempty"`
+}
+
+// Architectures returns the linux architectures the image is published for
+func (r *RegistryResolver) Architectures(ctx context.Context, image string) ([]string, error) {
+	r.mu.Lock()
+	if entry, ok := r.cache[image]; ok && time.Now().Before(entry.expires) {
+		r.mu.Unlock()
+		return entry.architectures, nil
+	}
+	r.mu.Unlock()
+
+	ref, err := ParseReference(image)
+	if err != nil {
+		return nil, err
+	}
+
+	var m manifest
+	if err := r.get(ctx, ref, "manifests/"+ref.Reference, strings.Join(manifestMediaTypes, ", "), &m); err != nil {
+		return nil, err
+	}
+
+	var architectures []string
+	switch {
+	case len(m.Manifests) > 0:
+		for _, entry := range m.Manifests {
+			// Attestation manifests are listed with an unknown platform
+			if entry.Platform == nil || entry.Platform.OS != "linux" || entry.Platform.Architecture == "unknown" {
+				continue
+			}
+			architectures = append(architectures, entry.Platform.Architecture)
+		}
+	case m.Config != nil:
+		// Single-platform images record the architecture in the image config
+		var config struct {
+			Architecture string `json:"architecture"`
+		}
+		if err := r.get(ctx, ref, "blobs/"+m.Config.Digest, "*/*", &config); err != nil {
+			return nil, err
+		}
+		architectures = append(architectures, config.Architecture)
+	default:
+		return nil, fmt.Errorf("unsupported manifest media type %q", m.MediaType)
+	}
+
+	r.mu.Lock()
+	r.cache[image] = cacheEntry{architectures: architectures, expires: time.Now().Add(r.TTL)}
+	r.mu.Unlock()
+	return architectures, nil
+}
+
+// Digest returns the digest of the manifest a reference points to, computed from the manifest
+// content. Tags are re-resolved once the cache TTL expires, so moved tags are followed
+func (r *RegistryResolver) Digest(ctx context.Context, reference string) (string, error) {
+	key := "digest:" + reference
+	r.mu.Lock()
+	if entry, ok := r.cache[key]; ok && time.Now().Before(entry.expires) {
+		r.mu.Unlock()
+		return entry.digest, nil
+	}
+	r.mu.Unlock()
+
+	_, digest, err := r.Manifest(ctx, reference)
+	if err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	r.cache[key] = cacheEntry{digest: digest, expires: time.Now().Add(r.TTL)}
+	r.mu.Unlock()
+	return digest, nil
+}
+
+// Manifest returns the manifest a reference points to and its digest, uncached. References
+// by digest are checked against the manifest content
+func (r *RegistryResolver) Manifest(ctx context.Context, reference string) ([]byte, string, error) {
+	ref, err := ParseReference(reference)
+	if err != nil {
+		return nil, "", err
+	}
+	// Artifacts pushed by ORAS use image manifests with an artifact type
+	accept := strings.Join(append(manifestMediaTypes, "application/vnd.oci.artifact.manifest.v1+json"), ", ")
+	body, err := r.fetch(ctx, ref, "manifests/"+ref.Reference, accept)
+	if err != nil {
+		return nil, "", err
+	}
+	digest := sha256Digest(body)
+	if strings.HasPrefix(ref.Reference, "sha256:") && ref.Reference != digest {
+		return nil, "", fmt.Errorf("manifest of %s has digest %s", reference, digest)
+	}
+	return body, digest, nil
+}
+
+// Blob returns the content of a blob in the repository of a reference, checked against its
+// digest
+func (r *RegistryResolver) Blob(ctx context.Context, reference, digest string) ([]byte, error) {
+	ref, err := ParseReference(reference)
+	if err != nil {
+		return nil, err
+	}
+	body, err := r.fetch(ctx, ref, "blobs/"+digest, "*/*")
+	if err != nil {
+		return nil, err
+	}
+	if actual := sha256Digest(body); actual != digest {
+		return nil, fmt.Errorf("blob %s of %s has digest %s", digest, reference, actual)
+	}
+	return body, nil
+}
+
+// sha256Digest returns the OCI digest of content
+func sha256Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// get fetches a registry API path into out
+func (r *RegistryResolver) get(ctx context.Context, ref Reference, path, accept string, out interface{}) error {
+	body, err := r.fetch(ctx, ref, path, accept)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, out)
+}
+
+// fetch reads a registry API path, requesting an anonymous bearer token when challenged
+func (r *RegistryResolver) fetch(ctx context.Context, ref Reference, path, accept string) ([]byte, error) {
+	endpoint := fmt.Sprintf("https://%s/v2/%s/%s", ref.Registry, ref.Repository, path)
+
+	resp, err := r.do(ctx, endpoint, accept, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		_ = resp.Body.Close()
+
+		token, err := r.token(ctx, challenge)
+		if err != nil {
+			return nil, err
+		}
+		if resp, err = r.do(ctx, endpoint, accept, token); err != nil {
+			return nil, err
+		}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s: %w", endpoint, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry returned %s for %s", resp.Status, endpoint)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (r *RegistryResolver) do(ctx context.Context, endpoint, accept, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return r.Client.Do(req)
+}
+
+// token requests an anonymous pull token from the realm named in a Bearer challenge
+func (r *RegistryResolver) token(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported registry auth challenge %q", challenge)
+	}
+
+	values := url.Values{}
+	var realm string
+	for _, param := range strings.Split(params, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, `"`)
+		if key == "realm" {
+			realm = value
+			continue
+		}
+		values.Set(key, value)
+	}
+	if realm == "" {
+		return "", fmt.Errorf("registry auth challenge has no realm")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+values.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %s", resp.Status)
+	}
+	var parsed struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if parsed.Token != "" {
+		return parsed.Token, nil
+	}
+	return parsed.AccessToken, nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagearch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Image architecture resolver", func() {
+	ctx := context.Background()
+
+	Context("When parsing image references", func() {
+		It("should default the registry and tag", func() {
+			ref, err := ParseReference("tritonserver")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ref).To(Equal(Reference{Registry: DefaultRegistry, Repository: "library/tritonserver", Reference: "latest"}))
+
+			ref, err = ParseReference("nvcr.io/nvidia/tritonserver:24.12-py3")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ref).To(Equal(Reference{Registry: "nvcr.io", Repository: "nvidia/tritonserver", Reference: "24.12-py3"}))
+
+			ref, err = ParseReference("localhost:5000/triton@sha256:abc")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ref).To(Equal(Reference{Registry: "localhost:5000", Repository: "triton", Reference: "sha256:abc"}))
+		})
+	})
+
+	Context("When resolving a manifest list", func() {
+		It("should authenticate anonymously and list the linux architectures", func() {
+			requests := 0
+			var registry *httptest.Server
+			registry = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				switch {
+				case r.URL.Path == "/token":
+					Expect(r.URL.Query().Get("scope")).To(Equal("repository:nvidia/tritonserver:pull"))
+					_, _ = fmt.Fprint(w, `{"token":"anonymous"}`)
+				case r.Header.Get("Authorization") != "Bearer anonymous":
+					w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:nvidia/tritonserver:pull"`, registry.URL))
+					w.WriteHeader(http.StatusUnauthorized)
+				default:
+					Expect(r.URL.Path).To(Equal("/v2/nvidia/tritonserver/manifests/24.12-py3"))
+					Expect(r.Header.Get("Accept")).To(ContainSubstring("application/vnd.oci.image.index.v1+json"))
+					_, _ = fmt.Fprint(w, `{"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[`+
+						`{"platform":{"os":"linux","architecture":"amd64"}},`+
+						`{"platform":{"os":"linux","architecture":"arm64"}},`+
+						`{"platform":{"os":"unknown","architecture":"unknown"}}]}`)
+				}
+			}))
+			defer registry.Close()
+
+			resolver := NewRegistryResolver()
+			resolver.Client = registry.Client()
+			image := strings.TrimPrefix(registry.URL, "https://") + "/nvidia/tritonserver:24.12-py3"
+
+			architectures, err := resolver.Architectures(ctx, image)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(architectures).To(ConsistOf("amd64", "arm64"))
+			Expect(requests).To(Equal(3))
+
+			_, err = resolver.Architectures(ctx, image)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests).To(Equal(3), "resolved architectures are cached")
+		})
+	})
+
+	Context("When resolving a manifest digest", func() {
+		It("should hash the manifest content and reject mismatching digests", func() {
+			manifest := `{"mediaType":"application/vnd.oci.image.manifest.v1+json","artifactType":"application/vnd.kalypso.model-repository"}`
+			sum := sha256.Sum256([]byte(manifest))
+			digest := "sha256:" + hex.EncodeToString(sum[:])
+			registry := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Path).To(HavePrefix("/v2/models/recommender/manifests/"))
+				_, _ = fmt.Fprint(w, manifest)
+			}))
+			defer registry.Close()
+
+			resolver := NewRegistryResolver()
+			resolver.Client = registry.Client()
+			repository := strings.TrimPrefix(registry.URL, "https://") + "/models/recommender"
+
+			resolved, err := resolver.Digest(ctx, repository+":v3")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolved).To(Equal(digest))
+
+			_, err = resolver.Digest(ctx, repository+"@sha256:0000")
+			Expect(err).To(MatchError(ContainSubstring("has digest " + digest)))
+		})
+	})
+})
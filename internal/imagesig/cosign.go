@@ -0,0 +1,336 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package imagesig verifies the cosign signatures of container images. Signatures are read
+// from the sha256-<digest>.sig tag of the image repository and checked against a public key
+// and, optionally, the signed entry timestamp of the Rekor transparency log.
+package imagesig
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kalypsoServing/KalypsoServing/internal/imagearch"
+)
+
+const (
+	// SignatureAnnotation holds the base64 signature of a cosign signature layer
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// BundleAnnotation holds the Rekor bundle of a cosign signature layer
+	BundleAnnotation = "dev.sigstore.cosign/bundle"
+	// DefaultCacheTTL is how long a verified image digest is reused before it is verified again
+	DefaultCacheTTL = 10 * time.Minute
+
+	// signatureType is the critical type of cosign simple signing payloads
+	signatureType = "cosign container image signature"
+)
+
+var (
+	// ErrSignatureNotFound is returned when the image has no cosign signature
+	ErrSignatureNotFound = errors.New("no cosign signature found")
+	// ErrSignatureInvalid is returned when no signature of the image verifies against the policy
+	ErrSignatureInvalid = errors.New("no valid cosign signature")
+)
+
+// Policy is what an image signature is verified against
+type Policy struct {
+	// PublicKey is the PEM encoded public key the image must be signed with
+	PublicKey []byte
+	// RekorPublicKey is the PEM encoded public key of the Rekor log; when set, signatures must
+	// carry a bundle whose signed entry timestamp proves they were recorded in the log
+	RekorPublicKey []byte
+}
+
+// Verifier verifies the signature of an image and returns the digest it verified
+type Verifier interface {
+	Verify(ctx context.Context, image string, policy Policy) (string, error)
+}
+
+// Registry reads the manifests and blobs of image repositories
+type Registry interface {
+	Manifest(ctx context.Context, reference string) ([]byte, string, error)
+	Blob(ctx context.Context, reference, digest string) ([]byte, error)
+}
+
+// CosignVerifier verifies cosign signatures stored next to the images in their registry
+type CosignVerifier struct {
+	Registry Registry
+	TTL      time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	digest  string
+	expires time.Time
+}
+
+// NewCosignVerifier creates a CosignVerifier reading signatures through the registry
+func NewCosignVerifier(registry Registry) *CosignVerifier {
+	return &CosignVerifier{
+		Registry: registry,
+		TTL:      DefaultCacheTTL,
+		cache:    make(map[string]cacheEntry),
+	}
+}
+
+type signatureManifest struct {
+	Layers []struct {
+		Digest      string            `json:"digest"`
+		Annotations map[string]string `json:"annotations,omitempty"`
+	} `json:"layers"`
+}
+
+// payload is the simple signing payload cosign signs
+type payload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+// Verify resolves the image to its manifest digest and checks that one of its signatures is
+// made by the policy key over that digest. Successful verifications are cached per policy
+func (v *CosignVerifier) Verify(ctx context.Context, image string, policy Policy) (string, error) {
+	key := cacheKey(image, policy)
+	v.mu.Lock()
+	if entry, ok := v.cache[key]; ok && time.Now().Before(entry.expires) {
+		v.mu.Unlock()
+		return entry.digest, nil
+	}
+	v.mu.Unlock()
+
+	publicKey, err := parsePublicKey(policy.PublicKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid public key: %w", err)
+	}
+	var rekorKey crypto.PublicKey
+	if len(policy.RekorPublicKey) > 0 {
+		if rekorKey, err = parsePublicKey(policy.RekorPublicKey); err != nil {
+			return "", fmt.Errorf("invalid Rekor public key: %w", err)
+		}
+	}
+
+	ref, err := imagearch.ParseReference(image)
+	if err != nil {
+		return "", err
+	}
+	_, digest, err := v.Registry.Manifest(ctx, image)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", image, err)
+	}
+
+	signatures := fmt.Sprintf("%s/%s:%s.sig", ref.Registry, ref.Repository, strings.Replace(digest, ":", "-", 1))
+	body, _, err := v.Registry.Manifest(ctx, signatures)
+	if errors.Is(err, imagearch.ErrNotFound) {
+		return "", fmt.Errorf("%w for %s", ErrSignatureNotFound, digest)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read the signatures of %s: %w", digest, err)
+	}
+	var manifest signatureManifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return "", fmt.Errorf("failed to decode the signature manifest: %w", err)
+	}
+
+	var reasons []string
+	for _, layer := range manifest.Layers {
+		encoded, ok := layer.Annotations[SignatureAnnotation]
+		if !ok {
+			continue
+		}
+		content, err := v.Registry.Blob(ctx, signatures, layer.Digest)
+		if err != nil {
+			return "", fmt.Errorf("failed to read signature payload %s: %w", layer.Digest, err)
+		}
+		if err := verifyLayer(content, encoded, layer.Annotations[BundleAnnotation], digest, publicKey, rekorKey); err != nil {
+			reasons = append(reasons, err.Error())
+			continue
+		}
+
+		v.mu.Lock()
+		v.cache[key] = cacheEntry{digest: digest, expires: time.Now().Add(v.TTL)}
+		v.mu.Unlock()
+		return digest, nil
+	}
+	if len(reasons) == 0 {
+		return "", fmt.Errorf("%w for %s", ErrSignatureNotFound, digest)
+	}
+	return "", fmt.Errorf("%w for %s: %s", ErrSignatureInvalid, digest, strings.Join(reasons, "; "))
+}
+
+// verifyLayer checks one signature layer: its signature over the payload, the payload naming
+// the image digest, and the Rekor bundle when a Rekor key is given
+func verifyLayer(content []byte, encoded, bundle, digest string, publicKey, rekorKey crypto.PublicKey) error {
+	signature, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("malformed signature: %w", err)
+	}
+	if err := verifySignature(publicKey, content, signature); err != nil {
+		return fmt.Errorf("signature does not match the public key")
+	}
+
+	var signed payload
+	if err := json.Unmarshal(content, &signed); err != nil {
+		return fmt.Errorf("malformed signature payload: %w", err)
+	}
+	if signed.Critical.Type != signatureType {
+		return fmt.Errorf("unexpected signature payload type %q", signed.Critical.Type)
+	}
+	if signed.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf("signature is for %s", signed.Critical.Image.DockerManifestDigest)
+	}
+
+	if rekorKey == nil {
+		return nil
+	}
+	if bundle == "" {
+		return fmt.Errorf("signature is not recorded in the transparency log")
+	}
+	return verifyBundle(bundle, content, signature, rekorKey)
+}
+
+// rekorBundle is the transparency log entry cosign attaches to a signature
+type rekorBundle struct {
+	SignedEntryTimestamp []byte             `json:"SignedEntryTimestamp"`
+	Payload              rekorBundlePayload `json:"Payload"`
+}
+
+// rekorBundlePayload is the log entry signed by Rekor; its fields are declared in canonical
+// JSON order so that marshalling it reproduces the signed bytes
+type rekorBundlePayload struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+}
+
+// hashedRekord is the log entry body recording a signature over a payload hash
+type hashedRekord struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Data struct {
+			Hash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"hash"`
+		} `json:"data"`
+		Signature struct {
+			Content []byte `json:"content"`
+		} `json:"signature"`
+	} `json:"spec"`
+}
+
+// verifyBundle checks that Rekor signed a log entry recording this signature of the payload
+func verifyBundle(encoded string, content, signature []byte, rekorKey crypto.PublicKey) error {
+	var bundle rekorBundle
+	if err := json.Unmarshal([]byte(encoded), &bundle); err != nil {
+		return fmt.Errorf("malformed Rekor bundle: %w", err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(rekorKey)
+	if err != nil {
+		return err
+	}
+	logID := sha256.Sum256(der)
+	if bundle.Payload.LogID != hex.EncodeToString(logID[:]) {
+		return fmt.Errorf("signature is recorded in another transparency log")
+	}
+
+	var canonical bytes.Buffer
+	encoder := json.NewEncoder(&canonical)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(bundle.Payload); err != nil {
+		return err
+	}
+	if err := verifySignature(rekorKey, bytes.TrimSuffix(canonical.Bytes(), []byte("\n")), bundle.SignedEntryTimestamp); err != nil {
+		return fmt.Errorf("signed entry timestamp does not match the Rekor public key")
+	}
+
+	body, err := base64.StdEncoding.DecodeString(bundle.Payload.Body)
+	if err != nil {
+		return fmt.Errorf("malformed Rekor entry: %w", err)
+	}
+	var entry hashedRekord
+	if err := json.Unmarshal(body, &entry); err != nil {
+		return fmt.Errorf("malformed Rekor entry: %w", err)
+	}
+	sum := sha256.Sum256(content)
+	if entry.Kind != "hashedrekord" || entry.Spec.Data.Hash.Algorithm != "sha256" ||
+		entry.Spec.Data.Hash.Value != hex.EncodeToString(sum[:]) ||
+		!bytes.Equal(entry.Spec.Signature.Content, signature) {
+		return fmt.Errorf("transparency log entry records another signature")
+	}
+	return nil
+}
+
+// parsePublicKey decodes a PEM encoded PKIX public key
+func parsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// verifySignature checks a signature over the SHA-256 digest of message, or over the message
+// itself for Ed25519 keys
+func verifySignature(key crypto.PublicKey, message, signature []byte) error {
+	sum := sha256.Sum256(message)
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, sum[:], signature) {
+			return fmt.Errorf("invalid ECDSA signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, sum[:], signature)
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, message, signature) {
+			return fmt.Errorf("invalid Ed25519 signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// cacheKey identifies an image verified against a policy
+func cacheKey(image string, policy Policy) string {
+	hash := sha256.New()
+	hash.Write(policy.PublicKey)
+	hash.Write([]byte{0})
+	hash.Write(policy.RekorPublicKey)
+	return image + "@" + hex.EncodeToString(hash.Sum(nil)[:8])
+}
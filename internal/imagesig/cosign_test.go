@@ -0,0 +1,209 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagesig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/kalypsoServing/KalypsoServing/internal/imagearch"
+)
+
+// fakeRegistry serves manifests and blobs from memory, counting the manifest reads
+type fakeRegistry struct {
+	manifests map[string][]byte
+	blobs     map[string][]byte
+	reads     int
+}
+
+func (f *fakeRegistry) Manifest(_ context.Context, reference string) ([]byte, string, error) {
+	f.reads++
+	body, ok := f.manifests[reference]
+	if !ok {
+		return nil, "", fmt.Errorf("%s: %w", reference, imagearch.ErrNotFound)
+	}
+	sum := sha256.Sum256(body)
+	return body, "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+func (f *fakeRegistry) Blob(_ context.Context, _ string, digest string) ([]byte, error) {
+	body, ok := f.blobs[digest]
+	if !ok {
+		return nil, imagearch.ErrNotFound
+	}
+	return body, nil
+}
+
+func generateKey() (*ecdsa.PrivateKey, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	Expect(err).NotTo(HaveOccurred())
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func sign(key *ecdsa.PrivateKey, message []byte) []byte {
+	sum := sha256.Sum256(message)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, sum[:])
+	Expect(err).NotTo(HaveOccurred())
+	return signature
+}
+
+// rekorBundleFor returns the bundle of a log entry recording the signature of content
+func rekorBundleFor(rekorKey *ecdsa.PrivateKey, content, signature []byte) string {
+	sum := sha256.Sum256(content)
+	body, err := json.Marshal(map[string]interface{}{
+		"apiVersion": "0.0.1",
+		"kind":       "hashedrekord",
+		"spec": map[string]interface{}{
+			"data":      map[string]interface{}{"hash": map[string]string{"algorithm": "sha256", "value": hex.EncodeToString(sum[:])}},
+			"signature": map[string]interface{}{"content": base64.StdEncoding.EncodeToString(signature)},
+		},
+	})
+	Expect(err).NotTo(HaveOccurred())
+	der, err := x509.MarshalPKIXPublicKey(&rekorKey.PublicKey)
+	Expect(err).NotTo(HaveOccurred())
+	logID := sha256.Sum256(der)
+
+	entry := rekorBundlePayload{
+		Body:           base64.StdEncoding.EncodeToString(body),
+		IntegratedTime: 1735689600,
+		LogID:          hex.EncodeToString(logID[:]),
+		LogIndex:       42,
+	}
+	canonical, err := json.Marshal(entry)
+	Expect(err).NotTo(HaveOccurred())
+	bundle, err := json.Marshal(rekorBundle{SignedEntryTimestamp: sign(rekorKey, canonical), Payload: entry})
+	Expect(err).NotTo(HaveOccurred())
+	return string(bundle)
+}
+
+var _ = Describe("Cosign verifier", func() {
+	const image = "registry.example.com/nvidia/tritonserver:24.12-py3"
+	ctx := context.Background()
+
+	var (
+		registry   *fakeRegistry
+		signingKey *ecdsa.PrivateKey
+		publicKey  []byte
+		digest     string
+		content    []byte
+	)
+
+	// publish signs the image with key, attaching the given annotations to the signature layer
+	publish := func(signature []byte, annotations map[string]string) {
+		sum := sha256.Sum256(content)
+		layerDigest := "sha256:" + hex.EncodeToString(sum[:])
+		registry.blobs[layerDigest] = content
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[SignatureAnnotation] = base64.StdEncoding.EncodeToString(signature)
+		manifest, err := json.Marshal(map[string]interface{}{
+			"mediaType": "application/vnd.oci.image.manifest.v1+json",
+			"layers": []map[string]interface{}{{
+				"mediaType":   "application/vnd.dev.cosign.simplesigning.v1+json",
+				"digest":      layerDigest,
+				"annotations": annotations,
+			}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		registry.manifests["registry.example.com/nvidia/tritonserver:"+strings.Replace(digest, ":", "-", 1)+".sig"] = manifest
+	}
+
+	BeforeEach(func() {
+		imageManifest := []byte(`{"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[]}`)
+		sum := sha256.Sum256(imageManifest)
+		digest = "sha256:" + hex.EncodeToString(sum[:])
+		registry = &fakeRegistry{
+			manifests: map[string][]byte{image: imageManifest},
+			blobs:     map[string][]byte{},
+		}
+		signingKey, publicKey = generateKey()
+		content = []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"registry.example.com/nvidia/tritonserver"},`+
+			`"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, digest))
+	})
+
+	It("should return the digest of an image signed with the public key and cache it", func() {
+		publish(sign(signingKey, content), nil)
+		verifier := NewCosignVerifier(registry)
+
+		verified, err := verifier.Verify(ctx, image, Policy{PublicKey: publicKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(verified).To(Equal(digest))
+		Expect(registry.reads).To(Equal(2))
+
+		_, err = verifier.Verify(ctx, image, Policy{PublicKey: publicKey})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(registry.reads).To(Equal(2), "verified digests are cached")
+	})
+
+	It("should report unsigned images", func() {
+		_, err := NewCosignVerifier(registry).Verify(ctx, image, Policy{PublicKey: publicKey})
+		Expect(err).To(MatchError(ErrSignatureNotFound))
+	})
+
+	It("should reject signatures made with another key", func() {
+		otherKey, _ := generateKey()
+		publish(sign(otherKey, content), nil)
+
+		_, err := NewCosignVerifier(registry).Verify(ctx, image, Policy{PublicKey: publicKey})
+		Expect(err).To(MatchError(ErrSignatureInvalid))
+		Expect(err).To(MatchError(ContainSubstring("does not match the public key")))
+	})
+
+	It("should reject signatures of another digest", func() {
+		content = []byte(`{"critical":{"image":{"docker-manifest-digest":"sha256:0000"},"type":"cosign container image signature"}}`)
+		publish(sign(signingKey, content), nil)
+
+		_, err := NewCosignVerifier(registry).Verify(ctx, image, Policy{PublicKey: publicKey})
+		Expect(err).To(MatchError(ContainSubstring("signature is for sha256:0000")))
+	})
+
+	It("should require a transparency log entry signed by Rekor when a Rekor key is set", func() {
+		rekorKey, rekorPublicKey := generateKey()
+		signature := sign(signingKey, content)
+		policy := Policy{PublicKey: publicKey, RekorPublicKey: rekorPublicKey}
+
+		publish(signature, nil)
+		_, err := NewCosignVerifier(registry).Verify(ctx, image, policy)
+		Expect(err).To(MatchError(ContainSubstring("not recorded in the transparency log")))
+
+		otherRekorKey, _ := generateKey()
+		publish(signature, map[string]string{BundleAnnotation: rekorBundleFor(otherRekorKey, content, signature)})
+		_, err = NewCosignVerifier(registry).Verify(ctx, image, policy)
+		Expect(err).To(MatchError(ContainSubstring("another transparency log")))
+
+		publish(signature, map[string]string{BundleAnnotation: rekorBundleFor(rekorKey, content, signature)})
+		verified, err := NewCosignVerifier(registry).Verify(ctx, image, policy)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(verified).To(Equal(digest))
+	})
+})
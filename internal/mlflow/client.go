@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mlflow resolves models of the MLflow Model Registry to the artifact location of the
+// version currently in a stage, so a server can follow stage transitions.
+package mlflow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Scheme is the storage URI scheme of registered models: mlflow://<registry>/<model>/<stage>
+const Scheme = "mlflow://"
+
+// ModelURI identifies a registered model stage
+type ModelURI struct {
+	// Registry is the host, and optional port, of the MLflow tracking server
+	Registry string
+	// Model is the registered model name
+	Model string
+	// Stage is the model stage, e.g. Production
+	Stage string
+}
+
+// ParseURI parses a mlflow:// storage URI; ok is false for other schemes
+func ParseURI(uri string) (ModelURI, bool, error) {
+	if !strings.HasPrefix(uri, Scheme) {
+		return ModelURI{}, false, nil
+	}
+	path := strings.TrimPrefix(uri, Scheme)
+	registry, rest, _ := strings.Cut(path, "/")
+	separator := strings.LastIndex(rest, "/")
+	if registry == "" || separator <= 0 || separator == len(rest)-1 {
+		return ModelURI{}, true, fmt.Errorf("storage URI %q is not of the form %s<registry>/<model>/<stage>", uri, Scheme)
+	}
+	return ModelURI{Registry: registry, Model: rest[:separator], Stage: rest[separator+1:]}, true, nil
+}
+
+// Credentials authenticate to the tracking server with a bearer token or basic auth
+type Credentials struct {
+	Token    string
+	Username string
+	Password string
+}
+
+// ModelVersion is a registered model version and the location of its artifacts
+type ModelVersion struct {
+	Version     string
+	ArtifactURI string
+}
+
+// Client resolves the version of a registered model in a stage
+type Client interface {
+	LatestVersion(ctx context.Context, baseURL string, credentials Credentials, model, stage string) (*ModelVersion, error)
+}
+
+// HTTPClient calls the MLflow REST API
+type HTTPClient struct {
+	Client *http.Client
+}
+
+// NewHTTPClient creates an HTTPClient with a bounded request timeout
+func NewHTTPClient() *HTTPClient {
+	return &HTTPClient{Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// LatestVersion returns the latest version of a model in a stage, with its artifact location
+func (c *HTTPClient) LatestVersion(ctx context.Context, baseURL string, credentials Credentials, model, stage string) (*ModelVersion, error) {
+	body, err := json.Marshal(map[string]interface{}{"name": model, "stages": []string{stage}})
+	if err != nil {
+		return nil, err
+	}
+	var versions struct {
+		ModelVersions []struct {
+			Version string `json:"version"`
+		} `json:"model_versions"`
+	}
+	endpoint := strings.TrimSuffix(baseURL, "/") + "/api/2.0/mlflow/registered-models/get-latest-versions"
+	if err := c.call(ctx, http.MethodPost, endpoint, body, credentials, &versions); err != nil {
+		return nil, fmt.Errorf("failed to get the latest versions of %s: %w", model, err)
+	}
+	if len(versions.ModelVersions) == 0 {
+		return nil, fmt.Errorf("model %s has no version in stage %s", model, stage)
+	}
+	version := versions.ModelVersions[0].Version
+
+	var download struct {
+		ArtifactURI string `json:"artifact_uri"`
+	}
+	query := url.Values{"name": {model}, "version": {version}}
+	endpoint = strings.TrimSuffix(baseURL, "/") + "/api/2.0/mlflow/model-versions/get-download-uri?" + query.Encode()
+	if err := c.call(ctx, http.MethodGet, endpoint, nil, credentials, &download); err != nil {
+		return nil, fmt.Errorf("failed to get the download URI of %s version %s: %w", model, version, err)
+	}
+	if download.ArtifactURI == "" {
+		return nil, fmt.Errorf("model %s version %s has no artifact URI", model, version)
+	}
+	return &ModelVersion{Version: version, ArtifactURI: download.ArtifactURI}, nil
+}
+
+// call sends an authenticated request and decodes the JSON response
+func (c *HTTPClient) call(ctx context.Context, method, endpoint string, body []byte, credentials Credentials, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	switch {
+	case credentials.Token != "":
+		req.Header.Set("Authorization", "Bearer "+credentials.Token)
+	case credentials.Username != "":
+		req.SetBasicAuth(credentials.Username, credentials.Password)
+	}
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		var failure struct {
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&failure)
+		return fmt.Errorf("%s returned %s: %s", req.URL.Path, resp.Status, failure.Message)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
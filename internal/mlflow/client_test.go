@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mlflow
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseURI", func() {
+	It("should split the registry, model and stage", func() {
+		uri, ok, err := ParseURI("mlflow://mlflow.mlops:5000/team/recommender/Production")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(uri).To(Equal(ModelURI{Registry: "mlflow.mlops:5000", Model: "team/recommender", Stage: "Production"}))
+	})
+
+	It("should ignore other schemes and reject incomplete URIs", func() {
+		_, ok, err := ParseURI("s3://models/recommender")
+		Expect(ok).To(BeFalse())
+		Expect(err).NotTo(HaveOccurred())
+
+		_, ok, err = ParseURI("mlflow://mlflow.mlops/recommender")
+		Expect(ok).To(BeTrue())
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("HTTPClient", func() {
+	It("should resolve the artifacts of the latest version in the stage", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.Header.Get("Authorization")).To(Equal("Bearer secret"))
+			switch r.URL.Path {
+			case "/api/2.0/mlflow/registered-models/get-latest-versions":
+				var request struct {
+					Name   string   `json:"name"`
+					Stages []string `json:"stages"`
+				}
+				Expect(json.NewDecoder(r.Body).Decode(&request)).To(Succeed())
+				Expect(request.Name).To(Equal("recommender"))
+				Expect(request.Stages).To(Equal([]string{"Production"}))
+				_, _ = w.Write([]byte(`{"model_versions":[{"name":"recommender","version":"7","current_stage":"Production"}]}`))
+			case "/api/2.0/mlflow/model-versions/get-download-uri":
+				Expect(r.URL.Query().Get("version")).To(Equal("7"))
+				_, _ = w.Write([]byte(`{"artifact_uri":"s3://mlflow/artifacts/3/abc/artifacts/model"}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		version, err := NewHTTPClient().LatestVersion(context.Background(), server.URL, Credentials{Token: "secret"}, "recommender", "Production")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(version).To(Equal(&ModelVersion{Version: "7", ArtifactURI: "s3://mlflow/artifacts/3/abc/artifacts/model"}))
+	})
+
+	It("should report a stage without versions", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+
+		_, err := NewHTTPClient().LatestVersion(context.Background(), server.URL, Credentials{}, "recommender", "Staging")
+		Expect(err).To(MatchError("model recommender has no version in stage Staging"))
+	})
+})
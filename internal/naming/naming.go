@@ -0,0 +1,350 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package naming derives the names of the Kubernetes resources generated for
+// Kalypso custom resources.
+package naming
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// MaxNameLength is the maximum length of a DNS-1035 label, which bounds Service names
+	MaxNameLength = 63
+	// MaxStatefulSetNameLength bounds StatefulSet names, so the controller-revision-hash label
+	// of their pods, the name followed by a dash and a hash of up to 10 characters, fits in a
+	// label value
+	MaxStatefulSetNameLength = 52
+	// DeploymentSuffix is appended to the KalypsoTritonServer name for its Deployment
+	DeploymentSuffix = "-deploy"
+	// StatefulSetSuffix is appended to the KalypsoTritonServer name for its StatefulSet
+	StatefulSetSuffix = "-sts"
+	// ArgoRolloutSuffix is appended to the KalypsoTritonServer name for its Argo Rollouts Rollout
+	ArgoRolloutSuffix = "-rollout"
+	// HeadlessServiceSuffix is appended to the KalypsoTritonServer name for the governing Service of its StatefulSet
+	HeadlessServiceSuffix = "-headless"
+	// ServiceSuffix is appended to the KalypsoTritonServer name for its Service
+	ServiceSuffix = "-svc"
+	// MetricsServiceSuffix is appended to the KalypsoTritonServer name for its dedicated metrics Service
+	MetricsServiceSuffix = "-metrics"
+	// NetworkPolicySuffix is appended to the KalypsoTritonServer name for its NetworkPolicy
+	NetworkPolicySuffix = "-netpol"
+	// IngressSuffix is appended to the KalypsoTritonServer name for its Ingress
+	IngressSuffix = "-ingress"
+	// ServiceMonitorSuffix is appended to the KalypsoTritonServer name for its ServiceMonitor
+	ServiceMonitorSuffix = "-monitor"
+	// PodDisruptionBudgetSuffix is appended to the KalypsoTritonServer name for its PodDisruptionBudget
+	PodDisruptionBudgetSuffix = "-pdb"
+	// ProvenanceFilterSuffix is appended to the KalypsoTritonServer name for its provenance EnvoyFilter
+	ProvenanceFilterSuffix = "-provenance"
+	// CapacityReservationSuffix is appended to the KalypsoTritonServer name for its placeholder Deployment
+	CapacityReservationSuffix = "-reserve"
+	// ServiceAccountSuffix is appended to the KalypsoTritonServer name for its auto-created ServiceAccount
+	ServiceAccountSuffix = "-sa"
+	// TracingHelperSuffix is appended to the KalypsoTritonServer name for its Python tracing helper ConfigMap
+	TracingHelperSuffix = "-tracing"
+	// GPUClaimTemplateSuffix is appended to the KalypsoTritonServer and claim names for a managed ResourceClaimTemplate
+	GPUClaimTemplateSuffix = "-gpu"
+	// ArchivedRouteSuffix is appended to the KalypsoTritonServer name for the VirtualService answering requests to an archived server
+	ArchivedRouteSuffix = "-archived"
+	// DestinationRuleSuffix is appended to the KalypsoTritonServer name for the DestinationRule of its application traffic policy
+	DestinationRuleSuffix = "-traffic"
+	// GatewaySuffix is appended to the KalypsoApplication name for its Istio Gateway
+	GatewaySuffix = "-gateway"
+	// RoutesSuffix is appended to the KalypsoApplication name for the VirtualService routing its gateway to its servers
+	RoutesSuffix = "-routes"
+	// HTTPRouteSuffix is appended to the KalypsoApplication name for its Gateway API HTTPRoute
+	HTTPRouteSuffix = "-http"
+	// GRPCRouteSuffix is appended to the KalypsoApplication name for its Gateway API GRPCRoute
+	GRPCRouteSuffix = "-grpc"
+	// APIVersioningSuffix is appended to the KalypsoApplication name for its API versioning EnvoyFilter and Telemetry
+	APIVersioningSuffix = "-api-versions"
+	// AuthSuffix is appended to the KalypsoApplication name for its RequestAuthentication and AuthorizationPolicy
+	AuthSuffix = "-auth"
+	// RateLimitSuffix is appended to the KalypsoApplication name for its rate limit EnvoyFilter
+	RateLimitSuffix = "-ratelimit"
+	// RequestSizeSuffix is appended to the KalypsoApplication name for its request body size EnvoyFilter
+	RequestSizeSuffix = "-requestsize"
+	// RolloutRouteSuffix is appended to the KalypsoRollout name for its traffic splitting VirtualService
+	RolloutRouteSuffix = "-rollout"
+	// ModelCacheSuffix is appended to the KalypsoModelCache name for its DaemonSet
+	ModelCacheSuffix = "-model-cache"
+	// ExperimentRouteSuffix is appended to the KalypsoExperiment name for its traffic splitting VirtualService
+	ExperimentRouteSuffix = "-experiment"
+	// ActiveServiceSuffix is appended to the KalypsoApplication name for the Service selecting its active server
+	ActiveServiceSuffix = "-active"
+	// PreviewServiceSuffix is appended to the KalypsoApplication name for the Service selecting its preview server
+	PreviewServiceSuffix = "-preview"
+	// BlueGreenRouteSuffix is appended to the KalypsoApplication name for the VirtualService routing its custom domains to the active server
+	BlueGreenRouteSuffix = "-bluegreen"
+	// ApplicationCertificateSuffix is appended to the KalypsoApplication name for its certificate and secret
+	ApplicationCertificateSuffix = "-cert"
+	// CertificateSuffix is appended to the custom domain certificate and secret names
+	CertificateSuffix = "-tls"
+)
+
+// ChildName derives a child resource name from the owner name and suffix,
+// truncating the owner name so the result fits MaxNameLength
+func ChildName(owner, suffix string) string {
+	return truncatedChildName(owner, suffix, MaxNameLength)
+}
+
+// truncatedChildName derives a child resource name from the owner name and suffix,
+// truncating the owner name so the result fits maxLength
+func truncatedChildName(owner, suffix string, maxLength int) string {
+	maxOwnerLength := maxLength - len(suffix)
+	if len(owner) > maxOwnerLength {
+		owner = owner[:maxOwnerLength]
+	}
+	return owner + suffix
+}
+
+// Deployment returns the Deployment name of a KalypsoTritonServer
+func Deployment(serverName string) string {
+	return ChildName(serverName, DeploymentSuffix)
+}
+
+// StatefulSet returns the StatefulSet name of a KalypsoTritonServer, which fits
+// MaxStatefulSetNameLength
+func StatefulSet(serverName string) string {
+	return truncatedChildName(serverName, StatefulSetSuffix, MaxStatefulSetNameLength)
+}
+
+// ArgoRollout returns the Argo Rollouts Rollout name of a KalypsoTritonServer
+func ArgoRollout(serverName string) string {
+	return ChildName(serverName, ArgoRolloutSuffix)
+}
+
+// HeadlessService returns the governing Service name of a KalypsoTritonServer StatefulSet
+func HeadlessService(serverName string) string {
+	return ChildName(serverName, HeadlessServiceSuffix)
+}
+
+// Service returns the Service name of a KalypsoTritonServer
+func Service(serverName string) string {
+	return ChildName(serverName, ServiceSuffix)
+}
+
+// MetricsService returns the dedicated metrics Service name of a KalypsoTritonServer
+func MetricsService(serverName string) string {
+	return ChildName(serverName, MetricsServiceSuffix)
+}
+
+// Ingress returns the Ingress name of a KalypsoTritonServer
+func Ingress(serverName string) string {
+	return ChildName(serverName, IngressSuffix)
+}
+
+// NetworkPolicy returns the NetworkPolicy name of a KalypsoTritonServer
+func NetworkPolicy(serverName string) string {
+	return ChildName(serverName, NetworkPolicySuffix)
+}
+
+// ServiceMonitor returns the ServiceMonitor name of a KalypsoTritonServer
+func ServiceMonitor(serverName string) string {
+	return ChildName(serverName, ServiceMonitorSuffix)
+}
+
+// PodDisruptionBudget returns the PodDisruptionBudget name of a KalypsoTritonServer
+func PodDisruptionBudget(serverName string) string {
+	return ChildName(serverName, PodDisruptionBudgetSuffix)
+}
+
+// ProvenanceFilter returns the provenance header EnvoyFilter name of a KalypsoTritonServer
+func ProvenanceFilter(serverName string) string {
+	return ChildName(serverName, ProvenanceFilterSuffix)
+}
+
+// ServiceAccount returns the auto-created ServiceAccount name of a KalypsoTritonServer
+func ServiceAccount(serverName string) string {
+	return ChildName(serverName, ServiceAccountSuffix)
+}
+
+// CapacityReservation returns the placeholder Deployment name of a KalypsoTritonServer
+func CapacityReservation(serverName string) string {
+	return ChildName(serverName, CapacityReservationSuffix)
+}
+
+// TracingHelper returns the Python tracing helper ConfigMap name of a KalypsoTritonServer
+func TracingHelper(serverName string) string {
+	return ChildName(serverName, TracingHelperSuffix)
+}
+
+// GPUClaimTemplate returns the ResourceClaimTemplate name of a GPU resource claim requesting a DeviceClass
+func GPUClaimTemplate(serverName, claimName string) string {
+	return ChildName(serverName+"-"+claimName, GPUClaimTemplateSuffix)
+}
+
+// ArchivedRoute returns the VirtualService name of an archived KalypsoTritonServer
+func ArchivedRoute(serverName string) string {
+	return ChildName(serverName, ArchivedRouteSuffix)
+}
+
+// DestinationRule returns the name of the DestinationRule applying the application traffic
+// policy to a KalypsoTritonServer
+func DestinationRule(serverName string) string {
+	return ChildName(serverName, DestinationRuleSuffix)
+}
+
+// Gateway returns the Istio Gateway name of a KalypsoApplication
+func Gateway(appName string) string {
+	return ChildName(appName, GatewaySuffix)
+}
+
+// Routes returns the VirtualService name routing the gateway of a KalypsoApplication to its servers
+func Routes(appName string) string {
+	return ChildName(appName, RoutesSuffix)
+}
+
+// HTTPRoute returns the Gateway API HTTPRoute name of a KalypsoApplication
+func HTTPRoute(appName string) string {
+	return ChildName(appName, HTTPRouteSuffix)
+}
+
+// GRPCRoute returns the Gateway API GRPCRoute name of a KalypsoApplication
+func GRPCRoute(appName string) string {
+	return ChildName(appName, GRPCRouteSuffix)
+}
+
+// APIVersioning returns the API versioning EnvoyFilter and Telemetry name of a KalypsoApplication
+func APIVersioning(appName string) string {
+	return ChildName(appName, APIVersioningSuffix)
+}
+
+// ActiveService returns the Service name selecting the active server of a KalypsoApplication
+func ActiveService(appName string) string {
+	return ChildName(appName, ActiveServiceSuffix)
+}
+
+// PreviewService returns the Service name selecting the preview server of a KalypsoApplication
+func PreviewService(appName string) string {
+	return ChildName(appName, PreviewServiceSuffix)
+}
+
+// BlueGreenRoute returns the VirtualService name routing the custom domains of a KalypsoApplication to its active server
+func BlueGreenRoute(appName string) string {
+	return ChildName(appName, BlueGreenRouteSuffix)
+}
+
+// Auth returns the RequestAuthentication and AuthorizationPolicy name of a KalypsoApplication
+func Auth(appName string) string {
+	return ChildName(appName, AuthSuffix)
+}
+
+// RateLimit returns the rate limit EnvoyFilter name of a KalypsoApplication
+func RateLimit(appName string) string {
+	return ChildName(appName, RateLimitSuffix)
+}
+
+// RequestSize returns the request body size EnvoyFilter name of a KalypsoApplication
+func RequestSize(appName string) string {
+	return ChildName(appName, RequestSizeSuffix)
+}
+
+// RolloutRoute returns the traffic splitting VirtualService name of a KalypsoRollout
+func RolloutRoute(rolloutName string) string {
+	return ChildName(rolloutName, RolloutRouteSuffix)
+}
+
+// ModelCache returns the DaemonSet name of a KalypsoModelCache
+func ModelCache(cacheName string) string {
+	return ChildName(cacheName, ModelCacheSuffix)
+}
+
+// ExperimentRoute returns the traffic splitting VirtualService name of a KalypsoExperiment
+func ExperimentRoute(experimentName string) string {
+	return ChildName(experimentName, ExperimentRouteSuffix)
+}
+
+// ApplicationCertificate returns the certificate and secret name of a KalypsoApplication
+func ApplicationCertificate(appName string) string {
+	return ChildName(appName, ApplicationCertificateSuffix)
+}
+
+// DomainCertificate returns the Certificate and TLS secret name of an application custom domain
+func DomainCertificate(appNamespace, appName, host string) string {
+	host = strings.ReplaceAll(strings.ReplaceAll(host, "*", "wildcard"), ".", "-")
+	return ChildName(appNamespace+"-"+appName+"-"+host, CertificateSuffix)
+}
+
+// ProjectRole returns the name of the Role and RoleBinding granting a KalypsoProject role
+func ProjectRole(projectName, role string) string {
+	return ChildName(projectName, "-"+role)
+}
+
+// TritonServerChildNames returns every resource name derived from a KalypsoTritonServer name
+func TritonServerChildNames(serverName string) []string {
+	return []string{
+		Deployment(serverName),
+		StatefulSet(serverName),
+		ArgoRollout(serverName),
+		HeadlessService(serverName),
+		Service(serverName),
+		MetricsService(serverName),
+		Ingress(serverName),
+		NetworkPolicy(serverName),
+		ServiceMonitor(serverName),
+		PodDisruptionBudget(serverName),
+		ProvenanceFilter(serverName),
+		ServiceAccount(serverName),
+		CapacityReservation(serverName),
+		TracingHelper(serverName),
+		ArchivedRoute(serverName),
+		DestinationRule(serverName),
+	}
+}
+
+// FindCollisions groups server names by derived resource name and returns the
+// derived names shared by more than one server, with the sorted server names
+func FindCollisions(serverNames []string) map[string][]string {
+	owners := make(map[string][]string)
+	for _, serverName := range serverNames {
+		for _, childName := range TritonServerChildNames(serverName) {
+			owners[childName] = append(owners[childName], serverName)
+		}
+	}
+
+	collisions := make(map[string][]string)
+	for childName, servers := range owners {
+		if len(servers) > 1 {
+			sort.Strings(servers)
+			collisions[childName] = servers
+		}
+	}
+	return collisions
+}
+
+// Reference returns the object referenced by ref from an object of the namespace: ref is either
+// a name in that namespace or <namespace>/<name>
+func Reference(namespace, ref string) types.NamespacedName {
+	if refNamespace, name, ok := strings.Cut(ref, "/"); ok {
+		return types.NamespacedName{Namespace: refNamespace, Name: name}
+	}
+	return types.NamespacedName{Namespace: namespace, Name: ref}
+}
+
+// RelativeReference returns the reference to key from an object of the namespace, the inverse of
+// Reference: the name for an object of that namespace, else <namespace>/<name>
+func RelativeReference(namespace string, key types.NamespacedName) string {
+	if key.Namespace == namespace {
+		return key.Name
+	}
+	return key.String()
+}
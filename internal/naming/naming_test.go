@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package naming
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+var _ = Describe("Naming", func() {
+	It("should keep short names untouched", func() {
+		Expect(Deployment("recommendation-v1")).To(Equal("recommendation-v1-deploy"))
+		Expect(Service("recommendation-v1")).To(Equal("recommendation-v1-svc"))
+		Expect(PodDisruptionBudget("recommendation-v1")).To(Equal("recommendation-v1-pdb"))
+		Expect(ServiceAccount("recommendation-v1")).To(Equal("recommendation-v1-sa"))
+	})
+
+	It("should truncate long names to the DNS label limit", func() {
+		name := Deployment(strings.Repeat("a", 80))
+		Expect(name).To(HaveLen(MaxNameLength))
+		Expect(name).To(HaveSuffix(DeploymentSuffix))
+	})
+
+	It("should keep StatefulSet names short enough for the controller-revision-hash label", func() {
+		name := StatefulSet(strings.Repeat("a", 63))
+		Expect(name).To(HaveLen(MaxStatefulSetNameLength))
+		Expect(name).To(HaveSuffix(StatefulSetSuffix))
+		Expect(validation.IsValidLabelValue(name + "-7b9c6d5f4d")).To(BeEmpty())
+		Expect(StatefulSet("recommendation-v1")).To(Equal("recommendation-v1-sts"))
+	})
+
+	It("should report servers whose derived names collide after truncation", func() {
+		prefix := strings.Repeat("a", 60)
+		collisions := FindCollisions([]string{prefix + "-one", prefix + "-two", "other"})
+
+		Expect(collisions).To(HaveKeyWithValue(Deployment(prefix+"-one"), []string{prefix + "-one", prefix + "-two"}))
+		Expect(collisions).NotTo(HaveKey(Deployment("other")))
+	})
+
+	It("should resolve references within and across namespaces", func() {
+		Expect(Reference("proj-dev", "sample-project")).To(Equal(types.NamespacedName{Namespace: "proj-dev", Name: "sample-project"}))
+		Expect(Reference("proj-dev", "kalypso-system/sample-project")).To(Equal(types.NamespacedName{Namespace: "kalypso-system", Name: "sample-project"}))
+	})
+})
@@ -0,0 +1,177 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Record is a captured inference request, stored one JSON object per line
+type Record struct {
+	// Timestamp is when the request was received in production
+	Timestamp time.Time `json:"timestamp"`
+	// Method defaults to POST
+	Method string `json:"method,omitempty"`
+	// Path is the request path, e.g. /v2/models/resnet50/infer
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+	// LatencyMs is the latency observed in production
+	LatencyMs float64 `json:"latencyMs"`
+	// StatusCode is the status returned in production
+	StatusCode int `json:"statusCode,omitempty"`
+}
+
+// Result is the outcome of replaying a single record
+type Result struct {
+	Record     *Record
+	Latency    time.Duration
+	StatusCode int
+	Err        error
+}
+
+// Failed reports whether the replayed request errored or got a non-2xx response
+func (r Result) Failed() bool {
+	return r.Err != nil || r.StatusCode < 200 || r.StatusCode >= 300
+}
+
+// ReadRecords reads captured records and sorts them by timestamp
+func ReadRecords(r io.Reader) ([]Record, error) {
+	var records []Record
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(text, &record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if record.Path == "" {
+			return nil, fmt.Errorf("line %d: path is required", line)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(records[j].Timestamp)
+	})
+	return records, nil
+}
+
+// Replayer sends captured records to a target server, preserving their relative timing
+type Replayer struct {
+	Client *http.Client
+	// Target is the base URL of the server under test, e.g. http://resnet50-staging.ml.svc:8000
+	Target string
+	// Speed divides the gaps between requests; 2 replays the traffic at twice the production rate
+	Speed float64
+}
+
+// NewReplayer creates a Replayer with a bounded request timeout
+func NewReplayer(target string, speed float64) *Replayer {
+	return &Replayer{
+		Client: &http.Client{Timeout: 30 * time.Second},
+		Target: target,
+		Speed:  speed,
+	}
+}
+
+// Run replays the records in timestamp order and returns one result per record. Requests are
+// sent concurrently so a slow server does not lower the offered load
+func (r *Replayer) Run(ctx context.Context, records []Record) ([]Result, error) {
+	if r.Speed <= 0 {
+		return nil, fmt.Errorf("speed must be positive, got %v", r.Speed)
+	}
+
+	results := make([]Result, len(records))
+	if len(records) == 0 {
+		return results, nil
+	}
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	origin := records[0].Timestamp
+	for i := range records {
+		offset := time.Duration(float64(records[i].Timestamp.Sub(origin)) / r.Speed)
+		if wait := time.Until(start.Add(offset)); wait > 0 {
+			select {
+			case <-ctx.Done():
+				wg.Wait()
+				return results[:i], ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = r.send(ctx, &records[i])
+		}(i)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+// send issues a single request and measures its latency up to the end of the response body
+func (r *Replayer) send(ctx context.Context, record *Record) Result {
+	result := Result{Record: record}
+
+	method := record.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	endpoint := strings.TrimSuffix(r.Target, "/") + "/" + strings.TrimPrefix(record.Path, "/")
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(record.Body))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	for k, v := range record.Headers {
+		req.Header.Set(k, v)
+	}
+	if len(record.Body) > 0 && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	sent := time.Now()
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	_, err = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	result.Latency = time.Since(sent)
+	result.StatusCode = resp.StatusCode
+	result.Err = err
+	return result
+}
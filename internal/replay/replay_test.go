@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Replay", func() {
+	It("should read records in timestamp order", func() {
+		log := `{"timestamp":"2025-01-01T00:00:01Z","path":"/v2/models/resnet50/infer","latencyMs":20}
+
+{"timestamp":"2025-01-01T00:00:00Z","path":"/v2/models/resnet50/infer","latencyMs":10}
+`
+		records, err := ReadRecords(strings.NewReader(log))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(2))
+		Expect(records[0].LatencyMs).To(Equal(10.0))
+	})
+
+	It("should reject records without a path", func() {
+		_, err := ReadRecords(strings.NewReader(`{"timestamp":"2025-01-01T00:00:00Z"}`))
+		Expect(err).To(MatchError(ContainSubstring("line 1")))
+	})
+
+	It("should replay the records against the target and compare latencies", func() {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			if r.URL.Path != "/v2/models/resnet50/infer" || r.Header.Get("Content-Type") != "application/json" {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		origin := time.Now()
+		records := []Record{
+			{Timestamp: origin, Path: "/v2/models/resnet50/infer", Body: []byte(`{}`), LatencyMs: 10},
+			{Timestamp: origin.Add(time.Second), Path: "v2/models/resnet50/infer", Body: []byte(`{}`), LatencyMs: 30},
+			{Timestamp: origin.Add(2 * time.Second), Path: "/v2/models/missing/infer", Body: []byte(`{}`), LatencyMs: 5},
+		}
+		replayer := NewReplayer(server.URL, 100)
+
+		started := time.Now()
+		results, err := replayer.Run(context.Background(), records)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(time.Since(started)).To(BeNumerically("<", time.Second), "gaps are divided by the speed")
+		Expect(requests.Load()).To(Equal(int32(3)))
+		Expect(results[2].Failed()).To(BeTrue())
+
+		comparison := NewComparison(results)
+		Expect(comparison.Production.Count).To(Equal(3))
+		Expect(comparison.Production.P50).To(Equal(10 * time.Millisecond))
+		Expect(comparison.Replay.Errors).To(Equal(1))
+
+		var out strings.Builder
+		Expect(comparison.Write(&out)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("1/3"))
+	})
+
+	It("should reject a non-positive speed", func() {
+		_, err := NewReplayer("http://localhost", 0).Run(context.Background(), nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should compute nearest-rank percentiles", func() {
+		var latencies []time.Duration
+		for i := 1; i <= 100; i++ {
+			latencies = append(latencies, time.Duration(i)*time.Millisecond)
+		}
+
+		dist := Summarize(latencies, 0)
+
+		Expect(dist.P50).To(Equal(50 * time.Millisecond))
+		Expect(dist.P99).To(Equal(99 * time.Millisecond))
+		Expect(dist.Max).To(Equal(100 * time.Millisecond))
+		Expect(delta(10*time.Millisecond, 15*time.Millisecond)).To(Equal("+50.0%"))
+	})
+})
@@ -0,0 +1,134 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// Distribution summarizes a set of latencies
+type Distribution struct {
+	Count  int
+	Errors int
+	Mean   time.Duration
+	P50    time.Duration
+	P90    time.Duration
+	P99    time.Duration
+	Max    time.Duration
+}
+
+// Summarize computes the latency distribution. Errors are counted but their latencies are
+// excluded
+func Summarize(latencies []time.Duration, errors int) Distribution {
+	dist := Distribution{Count: len(latencies) + errors, Errors: errors}
+	if len(latencies) == 0 {
+		return dist
+	}
+
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, latency := range sorted {
+		total += latency
+	}
+	dist.Mean = total / time.Duration(len(sorted))
+	dist.P50 = percentile(sorted, 0.50)
+	dist.P90 = percentile(sorted, 0.90)
+	dist.P99 = percentile(sorted, 0.99)
+	dist.Max = sorted[len(sorted)-1]
+	return dist
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// Comparison compares the latencies observed in production with those of the replay
+type Comparison struct {
+	Production Distribution
+	Replay     Distribution
+}
+
+// NewComparison builds a comparison from the replay results and the production latencies of the
+// replayed records
+func NewComparison(results []Result) Comparison {
+	var production, replayed []time.Duration
+	productionErrors, replayErrors := 0, 0
+	for _, result := range results {
+		if result.Record.StatusCode >= 400 {
+			productionErrors++
+		} else {
+			production = append(production, time.Duration(result.Record.LatencyMs*float64(time.Millisecond)))
+		}
+		if result.Failed() {
+			replayErrors++
+		} else {
+			replayed = append(replayed, result.Latency)
+		}
+	}
+	return Comparison{
+		Production: Summarize(production, productionErrors),
+		Replay:     Summarize(replayed, replayErrors),
+	}
+}
+
+// Write prints the comparison as a table with the relative change of each statistic
+func (c Comparison) Write(w io.Writer) error {
+	rows := []struct {
+		name               string
+		production, replay time.Duration
+	}{
+		{"mean", c.Production.Mean, c.Replay.Mean},
+		{"p50", c.Production.P50, c.Replay.P50},
+		{"p90", c.Production.P90, c.Replay.P90},
+		{"p99", c.Production.P99, c.Replay.P99},
+		{"max", c.Production.Max, c.Replay.Max},
+	}
+
+	if _, err := fmt.Fprintf(w, "%-6s %12s %12s %9s\n", "", "production", "replay", "delta"); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if _, err := fmt.Fprintf(w, "%-6s %12s %12s %9s\n", row.name,
+			row.production.Round(time.Microsecond), row.replay.Round(time.Microsecond),
+			delta(row.production, row.replay)); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%-6s %12s %12s\n", "errors",
+		fmt.Sprintf("%d/%d", c.Production.Errors, c.Production.Count),
+		fmt.Sprintf("%d/%d", c.Replay.Errors, c.Replay.Count))
+	return err
+}
+
+// delta formats the relative change from production to replay
+func delta(production, replay time.Duration) string {
+	if production == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%+.1f%%", (float64(replay)/float64(production)-1)*100)
+}
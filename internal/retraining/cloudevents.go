@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package retraining evaluates drift/SLO triggers and emits CloudEvents so that
+// retraining pipelines can subscribe to model degradation signals.
+package retraining
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// CloudEventsSpecVersion is the CloudEvents specification version emitted
+	CloudEventsSpecVersion = "1.0"
+	// EventTypeDrift is the CloudEvent type emitted for drift triggers
+	EventTypeDrift = "io.kalypso.serving.retraining.drift"
+	// EventTypeSLOBurn is the CloudEvent type emitted for SLO burn triggers
+	EventTypeSLOBurn = "io.kalypso.serving.retraining.sloburn"
+)
+
+// Event is a retraining CloudEvent
+type Event struct {
+	// ID uniquely identifies the event
+	ID string
+	// Type is the CloudEvent type
+	Type string
+	// Source identifies the KalypsoTritonServer that produced the event
+	Source string
+	// Subject is the trigger name
+	Subject string
+	// Time is when the threshold crossing was observed
+	Time time.Time
+	// Data is the JSON payload
+	Data EventData
+}
+
+// EventData carries the model/application identifiers of a retraining event
+type EventData struct {
+	Project     string  `json:"project,omitempty"`
+	Application string  `json:"application"`
+	Server      string  `json:"server"`
+	Namespace   string  `json:"namespace"`
+	StorageURI  string  `json:"storageUri"`
+	Trigger     string  `json:"trigger"`
+	Value       float64 `json:"value"`
+	Threshold   float64 `json:"threshold"`
+}
+
+// EventTypeFor returns the CloudEvent type for a trigger type
+func EventTypeFor(triggerType string) string {
+	if triggerType == "SLOBurn" {
+		return EventTypeSLOBurn
+	}
+	return EventTypeDrift
+}
+
+// Sender delivers CloudEvents to a sink
+type Sender interface {
+	Send(ctx context.Context, sinkURI string, event Event) error
+}
+
+// HTTPSender delivers CloudEvents using the HTTP binary content mode,
+// which is accepted by Knative Brokers and plain HTTP receivers alike
+type HTTPSender struct {
+	Client *http.Client
+}
+
+// NewHTTPSender creates an HTTPSender with a bounded request timeout
+func NewHTTPSender() *HTTPSender {
+	return &HTTPSender{Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Send posts the event to the sink
+func (s *HTTPSender) Send(ctx context.Context, sinkURI string, event Event) error {
+	body, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("failed to encode event data: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sinkURI, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("ce-specversion", CloudEventsSpecVersion)
+	req.Header.Set("ce-id", event.ID)
+	req.Header.Set("ce-type", event.Type)
+	req.Header.Set("ce-source", event.Source)
+	req.Header.Set("ce-subject", event.Subject)
+	req.Header.Set("ce-time", event.Time.UTC().Format(time.RFC3339))
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("sink %s responded with status %d", sinkURI, resp.StatusCode)
+	}
+	return nil
+}
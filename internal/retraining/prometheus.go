@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retraining
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoSamples is returned when a query yields no samples
+var ErrNoSamples = errors.New("query returned no samples")
+
+// Querier evaluates an instant query and returns a single value
+type Querier interface {
+	Query(ctx context.Context, baseURL, query string) (float64, error)
+}
+
+// PrometheusQuerier queries the Prometheus HTTP API (also served by Mimir and Thanos)
+type PrometheusQuerier struct {
+	Client *http.Client
+}
+
+// NewPrometheusQuerier creates a PrometheusQuerier with a bounded request timeout
+func NewPrometheusQuerier() *PrometheusQuerier {
+	return &PrometheusQuerier{Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+type queryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+type vectorSample struct {
+	Value [2]interface{} `json:"value"`
+}
+
+// Query runs an instant query and returns the value of the first sample
+func (q *PrometheusQuerier) Query(ctx context.Context, baseURL, query string) (float64, error) {
+	endpoint := strings.TrimSuffix(baseURL, "/") + "/api/v1/query?query=" + url.QueryEscape(query)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := q.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var parsed queryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return 0, fmt.Errorf("failed to decode query response: %w", err)
+	}
+	if parsed.Status != "success" {
+		return 0, fmt.Errorf("query failed: %s", parsed.Error)
+	}
+
+	var sample [2]interface{}
+	switch parsed.Data.ResultType {
+	case "vector":
+		var samples []vectorSample
+		if err := json.Unmarshal(parsed.Data.Result, &samples); err != nil {
+			return 0, err
+		}
+		if len(samples) == 0 {
+			return 0, ErrNoSamples
+		}
+		sample = samples[0].Value
+	case "scalar":
+		if err := json.Unmarshal(parsed.Data.Result, &sample); err != nil {
+			return 0, err
+		}
+	default:
+		return 0, fmt.Errorf("unsupported result type %q", parsed.Data.ResultType)
+	}
+
+	value, ok := sample[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected sample value %v", sample[1])
+	}
+	return strconv.ParseFloat(value, 64)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retraining
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Retraining hook", func() {
+	ctx := context.Background()
+
+	Context("When sending a CloudEvent", func() {
+		It("should use the binary content mode", func() {
+			var headers http.Header
+			var data EventData
+			sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				headers = r.Header.Clone()
+				Expect(json.NewDecoder(r.Body).Decode(&data)).To(Succeed())
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer sink.Close()
+
+			event := Event{
+				ID:      "event-1",
+				Type:    EventTypeFor("Drift"),
+				Source:  "/apis/serving.serving.kalypso.io/v1alpha1/namespaces/default/kalypsotritonservers/test",
+				Subject: "feature-drift",
+				Time:    time.Now(),
+				Data:    EventData{Application: "app", Server: "test", Trigger: "feature-drift", Value: 0.4, Threshold: 0.3},
+			}
+			Expect(NewHTTPSender().Send(ctx, sink.URL, event)).To(Succeed())
+
+			Expect(headers.Get("ce-specversion")).To(Equal(CloudEventsSpecVersion))
+			Expect(headers.Get("ce-type")).To(Equal(EventTypeDrift))
+			Expect(headers.Get("ce-id")).To(Equal("event-1"))
+			Expect(headers.Get("ce-subject")).To(Equal("feature-drift"))
+			Expect(data.Server).To(Equal("test"))
+		})
+
+		It("should report non-2xx sink responses as errors", func() {
+			sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer sink.Close()
+
+			Expect(NewHTTPSender().Send(ctx, sink.URL, Event{Type: EventTypeSLOBurn})).NotTo(Succeed())
+		})
+	})
+
+	Context("When querying Prometheus", func() {
+		It("should return the first vector sample", func() {
+			prom := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Path).To(Equal("/api/v1/query"))
+				Expect(r.URL.Query().Get("query")).To(Equal("drift_score"))
+				_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"0.42"]}]}}`))
+			}))
+			defer prom.Close()
+
+			value, err := NewPrometheusQuerier().Query(ctx, prom.URL, "drift_score")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(BeNumerically("~", 0.42))
+		})
+
+		It("should return ErrNoSamples for empty results", func() {
+			prom := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+			}))
+			defer prom.Close()
+
+			_, err := NewPrometheusQuerier().Query(ctx, prom.URL, "drift_score")
+			Expect(err).To(MatchError(ErrNoSamples))
+		})
+	})
+})
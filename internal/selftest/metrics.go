@@ -0,0 +1,64 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selftest
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// conformant is 1 when the last self-test passed
+	conformant = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "kalypso_selftest_conformant",
+		Help: "Whether the last operator self-test deployed the reference model and served an inference (1) or not (0).",
+	})
+	// runsTotal counts the self-test runs by result and failed stage
+	runsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kalypso_selftest_runs_total",
+		Help: "Number of operator self-test runs, by result and failed stage.",
+	}, []string{"result", "stage"})
+	// durationSeconds is the duration of the last self-test
+	durationSeconds = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "kalypso_selftest_duration_seconds",
+		Help: "Duration of the last operator self-test, from deployment to inference.",
+	})
+	// lastRunTimestamp is when the last self-test started
+	lastRunTimestamp = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "kalypso_selftest_last_run_timestamp_seconds",
+		Help: "Unix time the last operator self-test started.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(conformant, runsTotal, durationSeconds, lastRunTimestamp)
+}
+
+// recordRun exports the outcome of a self-test run
+func recordRun(started time.Time, duration time.Duration, stage string, err error) {
+	lastRunTimestamp.Set(float64(started.Unix()))
+	durationSeconds.Set(duration.Seconds())
+	if err != nil {
+		conformant.Set(0)
+		runsTotal.WithLabelValues("failure", stage).Inc()
+		return
+	}
+	conformant.Set(1)
+	runsTotal.WithLabelValues("success", "").Inc()
+}
@@ -0,0 +1,354 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package selftest periodically deploys a reference KalypsoTritonServer serving a tiny CPU
+// identity model, runs an inference through it, and exports the outcome as a conformance
+// metric: a live canary for the platform rather than for user workloads.
+package selftest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/controller"
+)
+
+const (
+	// DefaultNamespace is the namespace the reference server is deployed to
+	DefaultNamespace = "kalypso-selftest"
+	// DefaultInterval is the default delay between two self-test runs
+	DefaultInterval = 15 * time.Minute
+	// DefaultReadyTimeout bounds the time for the reference server to become available,
+	// including the Triton image pull on a fresh node
+	DefaultReadyTimeout = 10 * time.Minute
+
+	// resourceName is the name of the reference project, application, server, and model ConfigMap
+	resourceName = "kalypso-selftest"
+	// modelName is the name of the reference model
+	modelName = "identity"
+	// modelRepositoryPath is where the reference model repository is mounted
+	modelRepositoryPath = "/models"
+)
+
+// Stages of a self-test run, reported in the metrics when a run fails
+const (
+	StageSetup     = "setup"
+	StageDeploy    = "deploy"
+	StageReady     = "ready"
+	StageInference = "inference"
+)
+
+// Config configures the self-test runs
+type Config struct {
+	// Namespace is the dedicated namespace of the reference server
+	Namespace string
+	// Interval is the delay between two runs
+	Interval time.Duration
+	// ReadyTimeout bounds the time for the reference server to become available
+	ReadyTimeout time.Duration
+}
+
+// Runner deploys the reference server on every interval and checks it serves inferences
+type Runner struct {
+	client       client.Client
+	httpClient   *http.Client
+	config       Config
+	pollInterval time.Duration
+}
+
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsoprojects;kalypsoapplications,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=serving.serving.kalypso.io,resources=kalypsotritonservers,verbs=get;list;watch;create;update;patch;delete
+
+// NewRunner creates a Runner writing through the given client
+func NewRunner(c client.Client, config Config) *Runner {
+	if config.Namespace == "" {
+		config.Namespace = DefaultNamespace
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+	if config.ReadyTimeout <= 0 {
+		config.ReadyTimeout = DefaultReadyTimeout
+	}
+	return &Runner{
+		client:       c,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+		config:       config,
+		pollInterval: 5 * time.Second,
+	}
+}
+
+// Start runs the self-test on every interval until the context is cancelled
+func (r *Runner) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, r.run, r.config.Interval)
+	return nil
+}
+
+// NeedLeaderElection ensures a single replica deploys the reference server
+func (r *Runner) NeedLeaderElection() bool {
+	return true
+}
+
+// run performs a self-test and records its outcome
+func (r *Runner) run(ctx context.Context) {
+	log := logf.FromContext(ctx).WithName("selftest")
+
+	started := time.Now()
+	stage, err := r.runOnce(ctx)
+	recordRun(started, time.Since(started), stage, err)
+	if err != nil {
+		log.Error(err, "Self-test failed", "stage", stage)
+		return
+	}
+	log.Info("Self-test passed", "duration", time.Since(started))
+}
+
+// runOnce deploys a fresh reference server, waits for it, runs an inference, and removes the
+// server. It returns the stage that failed
+func (r *Runner) runOnce(ctx context.Context) (string, error) {
+	if err := r.ensureFixture(ctx); err != nil {
+		return StageSetup, err
+	}
+
+	server := r.buildServer()
+	// Start from a clean server so every run exercises the full deployment path
+	if err := r.deleteServer(ctx, server); err != nil {
+		return StageDeploy, err
+	}
+	if err := r.client.Create(ctx, server); err != nil {
+		return StageDeploy, err
+	}
+	defer func() {
+		// Use a fresh context so the server is removed even when the run timed out
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+		defer cancel()
+		if err := r.deleteServer(cleanupCtx, server); err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to remove the self-test server")
+		}
+	}()
+
+	endpoint, err := r.waitForServer(ctx, server)
+	if err != nil {
+		return StageReady, err
+	}
+
+	// The Service may route to the pod shortly after it becomes available, so retry briefly
+	var inferErr error
+	for attempt := 0; attempt < 3; attempt++ {
+		if inferErr = r.infer(ctx, endpoint); inferErr == nil {
+			return "", nil
+		}
+		select {
+		case <-ctx.Done():
+			return StageInference, ctx.Err()
+		case <-time.After(r.pollInterval):
+		}
+	}
+	return StageInference, inferErr
+}
+
+// ensureFixture creates the namespace, model ConfigMap, project, and application shared by runs
+func (r *Runner) ensureFixture(ctx context.Context) error {
+	labels := map[string]string{controller.ManagedByLabelKey: controller.ManagedByLabelValue}
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: r.config.Namespace, Labels: labels}}
+	if err := r.client.Create(ctx, namespace); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create namespace %s: %w", r.config.Namespace, err)
+	}
+
+	model := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: r.config.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.client, model, func() error {
+		model.Labels = labels
+		model.Data = map[string]string{
+			"config.pbtxt": identityModelConfig,
+			"model.py":     identityModelCode,
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile the reference model: %w", err)
+	}
+
+	project := &servingv1alpha1.KalypsoProject{ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: r.config.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.client, project, func() error {
+		project.Labels = labels
+		project.Spec.DisplayName = "Kalypso self-test"
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile the self-test project: %w", err)
+	}
+
+	app := &servingv1alpha1.KalypsoApplication{ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: r.config.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.client, app, func() error {
+		app.Labels = labels
+		app.Spec.ProjectRef = project.Name
+		app.Spec.Description = "Reference model deployed by the operator self-test"
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile the self-test application: %w", err)
+	}
+	return nil
+}
+
+// buildServer builds the reference server serving the identity model from the ConfigMap
+func (r *Runner) buildServer() *servingv1alpha1.KalypsoTritonServer {
+	replicas := int32(1)
+	return &servingv1alpha1.KalypsoTritonServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resourceName,
+			Namespace: r.config.Namespace,
+			Labels:    map[string]string{controller.ManagedByLabelKey: controller.ManagedByLabelValue},
+		},
+		Spec: servingv1alpha1.KalypsoTritonServerSpec{
+			ApplicationRef: resourceName,
+			StorageURI:     modelRepositoryPath,
+			Replicas:       &replicas,
+			Resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+					corev1.ResourceMemory: resource.MustParse("512Mi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+			Volumes: []corev1.Volume{{
+				Name: "model-repository",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: resourceName},
+						Items: []corev1.KeyToPath{
+							{Key: "config.pbtxt", Path: modelName + "/config.pbtxt"},
+							{Key: "model.py", Path: modelName + "/1/model.py"},
+						},
+					},
+				},
+			}},
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "model-repository",
+				MountPath: modelRepositoryPath,
+				ReadOnly:  true,
+			}},
+		},
+	}
+}
+
+// deleteServer removes the reference server and waits until it is gone
+func (r *Runner) deleteServer(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer) error {
+	if err := r.client.Delete(ctx, server.DeepCopy()); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return wait.PollUntilContextTimeout(ctx, r.pollInterval, r.config.ReadyTimeout, true, func(ctx context.Context) (bool, error) {
+		err := r.client.Get(ctx, client.ObjectKeyFromObject(server), &servingv1alpha1.KalypsoTritonServer{})
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+}
+
+// waitForServer waits until the reference server has an available replica and returns its
+// HTTP endpoint
+func (r *Runner) waitForServer(ctx context.Context, server *servingv1alpha1.KalypsoTritonServer) (string, error) {
+	current := &servingv1alpha1.KalypsoTritonServer{}
+	err := wait.PollUntilContextTimeout(ctx, r.pollInterval, r.config.ReadyTimeout, true, func(ctx context.Context) (bool, error) {
+		if err := r.client.Get(ctx, client.ObjectKeyFromObject(server), current); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		if current.Status.Phase == servingv1alpha1.TritonServerPhaseFailed {
+			return false, fmt.Errorf("reference server failed: %s", failureMessage(current))
+		}
+		return current.Status.Phase == servingv1alpha1.TritonServerPhaseRunning &&
+			current.Status.AvailableReplicas > 0 && current.Status.ServiceEndpoint != "", nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("reference server not available (phase %q): %w", current.Status.Phase, err)
+	}
+	return current.Status.ServiceEndpoint, nil
+}
+
+// failureMessage returns the message of the Ready condition of a failed server
+func failureMessage(server *servingv1alpha1.KalypsoTritonServer) string {
+	for _, condition := range server.Status.Conditions {
+		if condition.Type == "Ready" {
+			return condition.Message
+		}
+	}
+	return "unknown reason"
+}
+
+// inferenceTensor is a tensor of the KServe v2 inference protocol
+type inferenceTensor struct {
+	Name     string    `json:"name"`
+	Shape    []int     `json:"shape"`
+	Datatype string    `json:"datatype"`
+	Data     []float32 `json:"data"`
+}
+
+// infer sends a request to the identity model and checks it echoes the input
+func (r *Runner) infer(ctx context.Context, endpoint string) error {
+	input := []float32{1, 2, 3, 4}
+	body, err := json.Marshal(map[string]interface{}{
+		"inputs": []inferenceTensor{{Name: "INPUT0", Shape: []int{len(input)}, Datatype: "FP32", Data: input}},
+	})
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimSuffix(endpoint, "/") + "/v2/models/" + modelName + "/infer"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("inference returned status %d", resp.StatusCode)
+	}
+
+	var parsed struct {
+		Outputs []inferenceTensor `json:"outputs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return fmt.Errorf("failed to decode inference response: %w", err)
+	}
+	if len(parsed.Outputs) != 1 || !slices.Equal(parsed.Outputs[0].Data, input) {
+		return fmt.Errorf("inference returned %v, expected the input %v", parsed.Outputs, input)
+	}
+	return nil
+}
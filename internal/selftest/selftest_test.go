@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selftest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+var _ = Describe("SelfTest", func() {
+	newRunner := func(objects ...client.Object) *Runner {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+		runner := NewRunner(fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(), Config{ReadyTimeout: time.Second})
+		runner.pollInterval = 10 * time.Millisecond
+		return runner
+	}
+
+	It("should create the fixture shared by runs", func() {
+		ctx := context.Background()
+		runner := newRunner()
+
+		Expect(runner.ensureFixture(ctx)).To(Succeed())
+		Expect(runner.ensureFixture(ctx)).To(Succeed(), "the fixture is reused by later runs")
+
+		model := &corev1.ConfigMap{}
+		Expect(runner.client.Get(ctx, client.ObjectKey{Namespace: DefaultNamespace, Name: resourceName}, model)).To(Succeed())
+		Expect(model.Data).To(HaveKey("config.pbtxt"))
+		app := &servingv1alpha1.KalypsoApplication{}
+		Expect(runner.client.Get(ctx, client.ObjectKey{Namespace: DefaultNamespace, Name: resourceName}, app)).To(Succeed())
+		Expect(app.Spec.ProjectRef).To(Equal(resourceName))
+
+		server := runner.buildServer()
+		Expect(server.Spec.StorageURI).To(Equal(modelRepositoryPath))
+		Expect(server.Spec.Volumes[0].ConfigMap.Items).To(ContainElement(HaveField("Path", "identity/1/model.py")))
+	})
+
+	It("should return the endpoint once the server is available", func() {
+		runner := newRunner()
+		server := runner.buildServer()
+		server.Status = servingv1alpha1.KalypsoTritonServerStatus{
+			Phase:             servingv1alpha1.TritonServerPhaseRunning,
+			AvailableReplicas: 1,
+			ServiceEndpoint:   "http://kalypso-selftest-svc.kalypso-selftest.svc:8000",
+		}
+		Expect(runner.client.Create(context.Background(), server)).To(Succeed())
+
+		endpoint, err := runner.waitForServer(context.Background(), server)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(endpoint).To(Equal(server.Status.ServiceEndpoint))
+	})
+
+	It("should check the model echoes its input", func() {
+		echo := true
+		triton := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Path).To(Equal("/v2/models/identity/infer"))
+			var request struct {
+				Inputs []inferenceTensor `json:"inputs"`
+			}
+			Expect(json.NewDecoder(r.Body).Decode(&request)).To(Succeed())
+			output := request.Inputs[0]
+			output.Name = "OUTPUT0"
+			if !echo {
+				output.Data = []float32{0, 0, 0, 0}
+			}
+			Expect(json.NewEncoder(w).Encode(map[string]interface{}{"outputs": []inferenceTensor{output}})).To(Succeed())
+		}))
+		defer triton.Close()
+		runner := newRunner()
+
+		Expect(runner.infer(context.Background(), triton.URL)).To(Succeed())
+
+		echo = false
+		Expect(runner.infer(context.Background(), triton.URL)).To(MatchError(ContainSubstring("expected the input")))
+	})
+
+	It("should export the outcome of each run", func() {
+		recordRun(time.Now(), time.Second, StageReady, errors.New("timed out"))
+		Expect(testutil.ToFloat64(conformant)).To(Equal(0.0))
+		Expect(testutil.ToFloat64(runsTotal.WithLabelValues("failure", StageReady))).To(Equal(1.0))
+
+		recordRun(time.Now(), time.Second, "", nil)
+		Expect(testutil.ToFloat64(conformant)).To(Equal(1.0))
+	})
+})
@@ -0,0 +1,209 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package statusupdater writes custom resource status from a rate-limited worker
+// pool, coalescing successive updates of the same object into a single write.
+package statusupdater
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// DefaultWorkers is the default number of status write workers
+	DefaultWorkers = 4
+	// DefaultCoalesceWindow is the default delay during which updates of one object are merged
+	DefaultCoalesceWindow = time.Second
+)
+
+// MutateFunc applies the desired status to the latest version of an object
+type MutateFunc func(obj client.Object)
+
+type request struct {
+	// obj is a copy of the enqueued object, used as the template to fetch the latest version
+	obj    client.Object
+	mutate MutateFunc
+	// seq orders the update against later updates and discards of the same object
+	seq uint64
+}
+
+// Updater applies status updates asynchronously. Only the most recent update
+// enqueued for an object is written, and failed writes are retried with
+// per-object exponential backoff.
+type Updater struct {
+	client         client.Client
+	workers        int
+	coalesceWindow time.Duration
+	queue          workqueue.TypedRateLimitingInterface[string]
+
+	mu      sync.Mutex
+	pending map[string]request
+	// seq counts the updates and discards of every object, so a failed write is not retried
+	// after a newer status was enqueued or written directly
+	seq map[string]uint64
+	// writing tracks the objects whose update is being written; done is signalled when a write ends
+	writing map[string]bool
+	done    *sync.Cond
+}
+
+// NewUpdater creates an Updater writing through the given client
+func NewUpdater(c client.Client, workers int, coalesceWindow time.Duration) *Updater {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	u := &Updater{
+		client:         c,
+		workers:        workers,
+		coalesceWindow: coalesceWindow,
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: "status_updater"},
+		),
+		pending: make(map[string]request),
+		seq:     make(map[string]uint64),
+		writing: make(map[string]bool),
+	}
+	u.done = sync.NewCond(&u.mu)
+	return u
+}
+
+// Enqueue schedules a status write for obj, replacing any update of the same
+// object that has not been written yet
+func (u *Updater) Enqueue(obj client.Object, mutate MutateFunc) {
+	key := keyFor(obj)
+
+	u.mu.Lock()
+	u.seq[key]++
+	u.pending[key] = request{obj: obj.DeepCopyObject().(client.Object), mutate: mutate, seq: u.seq[key]}
+	u.mu.Unlock()
+
+	u.queue.AddAfter(key, u.coalesceWindow)
+}
+
+// Discard drops the pending update of obj, e.g. before its status is written directly.
+// It waits for an update of obj being written, and prevents a failed one from being retried
+func (u *Updater) Discard(obj client.Object) {
+	key := keyFor(obj)
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.seq[key]++
+	delete(u.pending, key)
+	for u.writing[key] {
+		u.done.Wait()
+	}
+	u.pruneSeq(key)
+}
+
+// Start runs the workers until the context is cancelled
+func (u *Updater) Start(ctx context.Context) error {
+	for i := 0; i < u.workers; i++ {
+		go wait.UntilWithContext(ctx, func(ctx context.Context) {
+			for u.processNextItem(ctx) {
+			}
+		}, time.Second)
+	}
+
+	<-ctx.Done()
+	u.queue.ShutDown()
+	return nil
+}
+
+// NeedLeaderElection ensures only the leader writes status
+func (u *Updater) NeedLeaderElection() bool {
+	return true
+}
+
+// processNextItem writes the pending update of the next queued object
+func (u *Updater) processNextItem(ctx context.Context) bool {
+	key, shutdown := u.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer u.queue.Done(key)
+
+	u.mu.Lock()
+	req, ok := u.pending[key]
+	delete(u.pending, key)
+	if ok {
+		u.writing[key] = true
+	}
+	u.mu.Unlock()
+	if !ok {
+		u.queue.Forget(key)
+		return true
+	}
+
+	err := u.write(ctx, req)
+
+	u.mu.Lock()
+	delete(u.writing, key)
+	u.done.Broadcast()
+	// Retry the failed update unless a newer one was enqueued or written in the meantime
+	retry := err != nil && !errors.IsNotFound(err) && u.seq[key] == req.seq
+	if retry {
+		u.pending[key] = req
+	} else {
+		u.pruneSeq(key)
+	}
+	u.mu.Unlock()
+
+	if retry {
+		logf.FromContext(ctx).Info("Failed to write status, retrying", "object", key, "error", err)
+		u.queue.AddRateLimited(key)
+		return true
+	}
+	u.queue.Forget(key)
+	return true
+}
+
+// pruneSeq forgets the sequence of an object with no update pending or being written,
+// as no request is left to compare it against. The caller must hold mu
+func (u *Updater) pruneSeq(key string) {
+	if _, ok := u.pending[key]; !ok && !u.writing[key] {
+		delete(u.seq, key)
+	}
+}
+
+// write applies the update to the latest version of the object, skipping unchanged status
+func (u *Updater) write(ctx context.Context, req request) error {
+	latest := req.obj.DeepCopyObject().(client.Object)
+	if err := u.client.Get(ctx, client.ObjectKeyFromObject(latest), latest); err != nil {
+		return err
+	}
+
+	current := latest.DeepCopyObject()
+	req.mutate(latest)
+	if equality.Semantic.DeepEqual(current, latest) {
+		return nil
+	}
+	return u.client.Status().Update(ctx, latest)
+}
+
+// keyFor identifies an object by type, namespace and name
+func keyFor(obj client.Object) string {
+	return fmt.Sprintf("%T/%s/%s", obj, obj.GetNamespace(), obj.GetName())
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statusupdater
+
+import (
+	"context"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+var _ = Describe("Updater", func() {
+	var (
+		c      client.Client
+		writes atomic.Int32
+		server *servingv1alpha1.KalypsoTritonServer
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+
+	setMessage := func(message string) MutateFunc {
+		return func(obj client.Object) {
+			obj.(*servingv1alpha1.KalypsoTritonServer).Status.Message = message
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		writes.Store(0)
+		server = &servingv1alpha1.KalypsoTritonServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "default"},
+		}
+		c = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(server).
+			WithStatusSubresource(server).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourceUpdate: func(ctx context.Context, c client.Client, subResource string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					writes.Add(1)
+					return c.SubResource(subResource).Update(ctx, obj, opts...)
+				},
+			}).
+			Build()
+
+		ctx, cancel = context.WithCancel(context.Background())
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	It("should coalesce successive updates into a single write", func() {
+		updater := NewUpdater(c, 2, 0)
+		updater.Enqueue(server, setMessage("Waiting for Triton Server to become ready."))
+		updater.Enqueue(server, setMessage("Triton Server is ready to serve inference."))
+		go func() { _ = updater.Start(ctx) }()
+
+		Eventually(func(g Gomega) {
+			latest := &servingv1alpha1.KalypsoTritonServer{}
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(server), latest)).To(Succeed())
+			g.Expect(latest.Status.Message).To(Equal("Triton Server is ready to serve inference."))
+		}).Should(Succeed())
+		Consistently(writes.Load).Should(Equal(int32(1)))
+	})
+
+	It("should forget the sequence of objects once their updates are written or discarded", func() {
+		other := server.DeepCopy()
+		other.Name = "recommendation-v2"
+		updater := NewUpdater(c, 2, 0)
+		updater.Enqueue(server, setMessage("Waiting for Triton Server to become ready."))
+		updater.Enqueue(other, setMessage("Waiting for Triton Server to become ready."))
+		updater.Discard(other)
+		go func() { _ = updater.Start(ctx) }()
+
+		Eventually(writes.Load).Should(Equal(int32(1)))
+		Eventually(func() int {
+			updater.mu.Lock()
+			defer updater.mu.Unlock()
+			return len(updater.seq)
+		}).Should(BeZero())
+	})
+
+	It("should skip writes that do not change the status", func() {
+		updater := NewUpdater(c, 1, 0)
+		updater.Enqueue(server, setMessage(""))
+		go func() { _ = updater.Start(ctx) }()
+
+		Consistently(writes.Load).Should(Equal(int32(0)))
+	})
+
+	It("should not write discarded updates", func() {
+		updater := NewUpdater(c, 1, 0)
+		updater.Enqueue(server, setMessage("Waiting for Triton Server to become ready."))
+		updater.Discard(server)
+		go func() { _ = updater.Start(ctx) }()
+
+		Consistently(writes.Load).Should(Equal(int32(0)))
+	})
+
+	It("should not retry a failed update over a status written directly during the write", func() {
+		failing := make(chan struct{})
+		release := make(chan struct{})
+		var calls atomic.Int32
+		scheme := runtime.NewScheme()
+		Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+		c = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(server).
+			WithStatusSubresource(server).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourceUpdate: func(ctx context.Context, c client.Client, subResource string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					if calls.Add(1) == 1 {
+						close(failing)
+						<-release
+						return errors.NewConflict(schema.GroupResource{Resource: "kalypsotritonservers"}, obj.GetName(), nil)
+					}
+					return c.SubResource(subResource).Update(ctx, obj, opts...)
+				},
+			}).
+			Build()
+
+		updater := NewUpdater(c, 1, 0)
+		updater.Enqueue(server, setMessage("Waiting for Triton Server to become ready."))
+		go func() { _ = updater.Start(ctx) }()
+		Eventually(failing).Should(BeClosed())
+
+		written := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			updater.Discard(server)
+			latest := &servingv1alpha1.KalypsoTritonServer{}
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(server), latest)).To(Succeed())
+			latest.Status.Message = "Rolled back to revision 1."
+			Expect(c.Status().Update(ctx, latest)).To(Succeed())
+			close(written)
+		}()
+		Eventually(func() uint64 {
+			updater.mu.Lock()
+			defer updater.mu.Unlock()
+			return updater.seq[keyFor(server)]
+		}).Should(Equal(uint64(2)))
+		Consistently(written).ShouldNot(BeClosed(), "the direct write waits for the write in progress")
+		close(release)
+		Eventually(written).Should(BeClosed())
+
+		Consistently(func(g Gomega) {
+			latest := &servingv1alpha1.KalypsoTritonServer{}
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(server), latest)).To(Succeed())
+			g.Expect(latest.Status.Message).To(Equal("Rolled back to revision 1."))
+		}).Should(Succeed())
+		Expect(calls.Load()).To(Equal(int32(2)))
+	})
+})
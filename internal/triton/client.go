@@ -0,0 +1,197 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package triton queries the HTTP/REST API of Triton Inference Server, so the controllers can
+// follow the models actually loaded by the servers.
+package triton
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ModelStateReady is the repository index state of a model ready for inference
+const ModelStateReady = "READY"
+
+// Model is an entry of the Triton model repository index
+type Model struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	State   string `json:"state,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// Client lists and configures the models of a Triton server
+type Client interface {
+	RepositoryIndex(ctx context.Context, endpoint string) ([]Model, error)
+	ModelConfig(ctx context.Context, endpoint, model string) (map[string]interface{}, error)
+	LoadModel(ctx context.Context, endpoint, model string, config map[string]interface{}) error
+}
+
+// HTTPClient calls the Triton HTTP/REST endpoint
+type HTTPClient struct {
+	Client *http.Client
+}
+
+// NewHTTPClient creates an HTTPClient with a bounded request timeout
+func NewHTTPClient() *HTTPClient {
+	return &HTTPClient{Client: &http.Client{Timeout: 5 * time.Second}}
+}
+
+// RepositoryIndex returns every model of the server's repository with its load state
+func (c *HTTPClient) RepositoryIndex(ctx context.Context, endpoint string) ([]Model, error) {
+	url := strings.TrimSuffix(endpoint, "/") + "/v2/repository/index"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBufferString("{}"))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("repository index returned %s", resp.Status)
+	}
+
+	var models []Model
+	if err := json.NewDecoder(resp.Body).Decode(&models); err != nil {
+		return nil, fmt.Errorf("failed to decode repository index: %w", err)
+	}
+	return models, nil
+}
+
+// ModelConfig returns the configuration of a loaded model, as completed by Triton
+func (c *HTTPClient) ModelConfig(ctx context.Context, endpoint, model string) (map[string]interface{}, error) {
+	url := strings.TrimSuffix(endpoint, "/") + "/v2/models/" + model + "/config"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("model config of %s returned %s", model, resp.Status)
+	}
+
+	var config map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed to decode model config of %s: %w", model, err)
+	}
+	return config, nil
+}
+
+// LoadModel (re)loads a model with the given configuration in place of its config.pbtxt.
+// It requires the explicit model control mode
+func (c *HTTPClient) LoadModel(ctx context.Context, endpoint, model string, config map[string]interface{}) error {
+	encoded, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"parameters": map[string]interface{}{"config": string(encoded)},
+	})
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimSuffix(endpoint, "/") + "/v2/repository/models/" + model + "/load"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		var failure struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&failure)
+		return fmt.Errorf("loading %s returned %s: %s", model, resp.Status, failure.Error)
+	}
+	return nil
+}
+
+// ReadyModels returns the names of the models ready for inference, in index order and without
+// duplicates across versions
+func ReadyModels(models []Model) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, model := range models {
+		if model.State != ModelStateReady || seen[model.Name] {
+			continue
+		}
+		seen[model.Name] = true
+		names = append(names, model.Name)
+	}
+	return names
+}
+
+// UnreadyModels describes the models not ready for inference, sorted by name. Required models
+// must be ready; without required models, every model Triton attempted to load must be ready,
+// while models never loaded, e.g. under explicit model control, are ignored
+func UnreadyModels(models []Model, required []string) []string {
+	states := make(map[string]Model)
+	for _, model := range models {
+		if current, ok := states[model.Name]; !ok || current.State != ModelStateReady {
+			states[model.Name] = model
+		}
+	}
+
+	checked := required
+	if len(checked) == 0 {
+		for name, model := range states {
+			if model.State != "" {
+				checked = append(checked, name)
+			}
+		}
+	}
+
+	var unready []string
+	for _, name := range checked {
+		model, ok := states[name]
+		switch {
+		case !ok:
+			unready = append(unready, name+" (not in the repository)")
+		case model.State == ModelStateReady:
+		case model.Reason != "":
+			unready = append(unready, fmt.Sprintf("%s (%s: %s)", name, model.State, model.Reason))
+		case model.State == "":
+			unready = append(unready, name+" (not loaded)")
+		default:
+			unready = append(unready, fmt.Sprintf("%s (%s)", name, model.State))
+		}
+	}
+	sort.Strings(unready)
+	return unready
+}
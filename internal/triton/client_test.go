@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package triton
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HTTPClient", func() {
+	It("should list the ready models of the repository index", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(r.URL.Path).To(Equal("/v2/repository/index"))
+			_, _ = w.Write([]byte(`[
+				{"name":"resnet50","version":"1","state":"READY"},
+				{"name":"resnet50","version":"2","state":"READY"},
+				{"name":"bert","version":"1","state":"UNAVAILABLE","reason":"failed to load"},
+				{"name":"ensemble"}
+			]`))
+		}))
+		defer server.Close()
+
+		models, err := NewHTTPClient().RepositoryIndex(context.Background(), server.URL+"/")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(models).To(HaveLen(4))
+		Expect(models[2].Reason).To(Equal("failed to load"))
+		Expect(ReadyModels(models)).To(Equal([]string{"resnet50"}))
+	})
+
+	It("should report unexpected responses", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		_, err := NewHTTPClient().RepositoryIndex(context.Background(), server.URL)
+		Expect(err).To(MatchError(ContainSubstring("503")))
+	})
+
+	It("should describe the models blocking readiness", func() {
+		models := []Model{
+			{Name: "resnet50", Version: "1", State: ModelStateReady},
+			{Name: "resnet50", Version: "2", State: "LOADING"},
+			{Name: "bert", Version: "1", State: "UNAVAILABLE", Reason: "failed to load"},
+			{Name: "ensemble"},
+		}
+
+		Expect(UnreadyModels(models, nil)).To(Equal([]string{"bert (UNAVAILABLE: failed to load)"}))
+		Expect(UnreadyModels(models, []string{"resnet50", "ensemble", "gpt"})).To(Equal([]string{
+			"ensemble (not loaded)",
+			"gpt (not in the repository)",
+		}))
+	})
+
+	It("should reload a model with an overridden configuration", func() {
+		var loaded map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/v2/models/resnet50/config":
+				_, _ = w.Write([]byte(`{"name":"resnet50","max_batch_size":8}`))
+			case "/v2/repository/models/resnet50/load":
+				var body struct {
+					Parameters struct {
+						Config string `json:"config"`
+					} `json:"parameters"`
+				}
+				Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+				Expect(json.Unmarshal([]byte(body.Parameters.Config), &loaded)).To(Succeed())
+			case "/v2/repository/models/bert/load":
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"explicit model load / unload is not allowed if polling is enabled"}`))
+			}
+		}))
+		defer server.Close()
+
+		client := NewHTTPClient()
+		config, err := client.ModelConfig(context.Background(), server.URL, "resnet50")
+		Expect(err).NotTo(HaveOccurred())
+		config["version_policy"] = map[string]interface{}{"latest": map[string]interface{}{"num_versions": 2}}
+		Expect(client.LoadModel(context.Background(), server.URL, "resnet50", config)).To(Succeed())
+		Expect(loaded).To(HaveKeyWithValue("max_batch_size", BeNumerically("==", 8)))
+		Expect(loaded).To(HaveKey("version_policy"))
+
+		Expect(client.LoadModel(context.Background(), server.URL, "bert", config)).To(MatchError(ContainSubstring("polling is enabled")))
+	})
+})
@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/audit"
+)
+
+// AuditWebhookPath is the path of the audit webhook
+const AuditWebhookPath = "/audit-serving-serving-kalypso-io-v1alpha1"
+
+var auditlog = logf.Log.WithName("audit-webhook")
+
+// AuditRecorder queues audit events for export
+type AuditRecorder interface {
+	Record(event audit.Event)
+}
+
+// SetupAuditWebhookWithManager registers the audit webhook recording Kalypso resource changes.
+func SetupAuditWebhookWithManager(mgr ctrl.Manager, recorder AuditRecorder) error {
+	mgr.GetWebhookServer().Register(AuditWebhookPath, &webhook.Admission{
+		Handler: &AuditHandler{
+			Client:   mgr.GetClient(),
+			Decoder:  admission.NewDecoder(mgr.GetScheme()),
+			Recorder: recorder,
+		},
+	})
+	return nil
+}
+
+// +kubebuilder:webhook:path=/audit-serving-serving-kalypso-io-v1alpha1,mutating=false,failurePolicy=ignore,sideEffects=NoneOnDryRun,timeoutSeconds=5,groups=serving.serving.kalypso.io,resources=kalypsoprojects;kalypsoapplications;kalypsotritonservers,verbs=create;update;delete,versions=v1alpha1,name=vaudit-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// AuditHandler records every admitted change of a Kalypso resource with the identity of the
+// requesting user. It never denies a request, and failures to record are only logged
+type AuditHandler struct {
+	Client   client.Client
+	Decoder  admission.Decoder
+	Recorder AuditRecorder
+}
+
+var _ admission.Handler = &AuditHandler{}
+
+// Handle implements admission.Handler
+func (h *AuditHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	allowed := admission.Allowed("")
+	if req.DryRun != nil && *req.DryRun {
+		return allowed
+	}
+
+	event, err := h.buildEvent(ctx, req)
+	if err != nil {
+		auditlog.Error(err, "Failed to record audit event", "kind", req.Kind.Kind, "namespace", req.Namespace, "name", req.Name)
+		return allowed
+	}
+	h.Recorder.Record(event)
+	return allowed
+}
+
+// buildEvent builds the audit event of an admission request
+func (h *AuditHandler) buildEvent(ctx context.Context, req admission.Request) (audit.Event, error) {
+	var obj client.Object
+	switch req.Kind.Kind {
+	case "KalypsoProject":
+		obj = &servingv1alpha1.KalypsoProject{}
+	case "KalypsoApplication":
+		obj = &servingv1alpha1.KalypsoApplication{}
+	case "KalypsoTritonServer":
+		obj = &servingv1alpha1.KalypsoTritonServer{}
+	default:
+		return audit.Event{}, fmt.Errorf("unexpected kind %s", req.Kind.Kind)
+	}
+
+	// Deleted objects are only sent as the old object
+	raw := req.Object
+	if req.Operation == admissionv1.Delete {
+		raw = req.OldObject
+	}
+	if err := h.decode(raw, obj); err != nil {
+		return audit.Event{}, err
+	}
+
+	project, err := audit.ProjectOf(ctx, h.Client, obj)
+	if err != nil {
+		return audit.Event{}, err
+	}
+
+	event := audit.Event{
+		ID:        string(req.UID),
+		Time:      time.Now().UTC(),
+		Operation: string(req.Operation),
+		Kind:      req.Kind.Kind,
+		Namespace: req.Namespace,
+		Name:      obj.GetName(),
+		Project:   project.Name,
+		User: audit.User{
+			Username: req.UserInfo.Username,
+			UID:      req.UserInfo.UID,
+			Groups:   req.UserInfo.Groups,
+		},
+	}
+	if project.Namespace != req.Namespace {
+		event.ProjectNamespace = project.Namespace
+	}
+	return event, nil
+}
+
+// decode decodes the raw object of the request
+func (h *AuditHandler) decode(raw runtime.RawExtension, obj client.Object) error {
+	if len(raw.Raw) == 0 {
+		return fmt.Errorf("admission request has no object")
+	}
+	if err := h.Decoder.DecodeRaw(raw, obj); err != nil {
+		return fmt.Errorf("failed to decode object: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/audit"
+)
+
+// recordedEvents collects the events recorded by the audit webhook
+type recordedEvents []audit.Event
+
+func (r *recordedEvents) Record(event audit.Event) {
+	*r = append(*r, event)
+}
+
+var _ = Describe("Audit Webhook", func() {
+	It("Should record deletions with the requesting user and always allow", func() {
+		scheme := runtime.NewScheme()
+		Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		app := &servingv1alpha1.KalypsoApplication{
+			ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "default"},
+			Spec:       servingv1alpha1.KalypsoApplicationSpec{ProjectRef: "sample-project"},
+		}
+		server := &servingv1alpha1.KalypsoTritonServer{
+			TypeMeta:   metav1.TypeMeta{APIVersion: servingv1alpha1.GroupVersion.String(), Kind: "KalypsoTritonServer"},
+			ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "default"},
+			Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: app.Name},
+		}
+		raw, err := json.Marshal(server)
+		Expect(err).NotTo(HaveOccurred())
+
+		recorded := &recordedEvents{}
+		handler := &AuditHandler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(app).Build(),
+			Decoder:  admission.NewDecoder(scheme),
+			Recorder: recorded,
+		}
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			UID:       "request-uid",
+			Kind:      metav1.GroupVersionKind{Group: servingv1alpha1.GroupVersion.Group, Version: "v1alpha1", Kind: "KalypsoTritonServer"},
+			Namespace: "default",
+			Name:      server.Name,
+			Operation: admissionv1.Delete,
+			UserInfo:  authenticationv1.UserInfo{Username: "alice@example.com", Groups: []string{"ml-platform"}},
+			OldObject: runtime.RawExtension{Raw: raw},
+		}}
+
+		Expect(handler.Handle(ctx, req).Allowed).To(BeTrue())
+		Expect(*recorded).To(HaveLen(1))
+		Expect((*recorded)[0].Project).To(Equal("sample-project"))
+		Expect((*recorded)[0].Operation).To(Equal("DELETE"))
+		Expect((*recorded)[0].User.Username).To(Equal("alice@example.com"))
+
+		By("skipping dry-run requests")
+		dryRun := true
+		req.DryRun = &dryRun
+		Expect(handler.Handle(ctx, req).Allowed).To(BeTrue())
+		Expect(*recorded).To(HaveLen(1))
+	})
+})
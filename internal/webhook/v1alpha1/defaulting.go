@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// setDefaultLabel sets a label of the object unless it is already set or the value is empty
+func setDefaultLabel(obj client.Object, key, value string) {
+	if value == "" {
+		return
+	}
+	labels := obj.GetLabels()
+	if _, ok := labels[key]; ok {
+		return
+	}
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[key] = value
+	obj.SetLabels(labels)
+}
+
+// setDefaultProjectLabels labels an object with the project referenced from its namespace by
+// its name or by <namespace>/<name>, keeping same-named projects of different namespaces apart
+func setDefaultProjectLabels(obj client.Object, projectRef string) {
+	if projectRef == "" {
+		return
+	}
+	project := naming.Reference(obj.GetNamespace(), projectRef)
+	setDefaultLabel(obj, defaults.ProjectLabelKey, project.Name)
+	setDefaultLabel(obj, defaults.ProjectNamespaceLabelKey, project.Namespace)
+}
+
+// defaultApplicationLabels labels an object referencing a KalypsoApplication with the
+// application and the project of the application. A missing application is left to the
+// controllers to report, so only the application label is set then
+func defaultApplicationLabels(ctx context.Context, c client.Reader, obj client.Object, applicationRef string) error {
+	if applicationRef == "" {
+		return nil
+	}
+	key := naming.Reference(obj.GetNamespace(), applicationRef)
+	setDefaultLabel(obj, defaults.ApplicationLabelKey, key.Name)
+
+	app := &servingv1alpha1.KalypsoApplication{}
+	if err := c.Get(ctx, key, app); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to resolve application %s: %w", applicationRef, err)
+	}
+	project := naming.Reference(app.Namespace, app.Spec.ProjectRef)
+	setDefaultLabel(obj, defaults.ProjectLabelKey, project.Name)
+	setDefaultLabel(obj, defaults.ProjectNamespaceLabelKey, project.Namespace)
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+)
+
+var _ = Describe("Defaulting Webhooks", func() {
+	app := &servingv1alpha1.KalypsoApplication{
+		ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "default"},
+		Spec:       servingv1alpha1.KalypsoApplicationSpec{ProjectRef: "sample-project"},
+	}
+
+	It("Should label projects, applications and promotions with their project", func() {
+		project := &servingv1alpha1.KalypsoProject{
+			ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "default"},
+			Spec: servingv1alpha1.KalypsoProjectSpec{
+				Members: []servingv1alpha1.ProjectMember{{Name: "alice", Role: servingv1alpha1.ProjectRoleViewer}},
+			},
+		}
+		Expect((&KalypsoProjectCustomDefaulter{}).Default(ctx, project)).To(Succeed())
+		Expect(project.Labels).To(HaveKeyWithValue(defaults.ProjectLabelKey, "sample-project"))
+		Expect(project.Labels).To(HaveKeyWithValue(defaults.ProjectNamespaceLabelKey, "default"))
+		Expect(project.Spec.Members[0].Kind).To(Equal(rbacv1.UserKind))
+
+		application := app.DeepCopy()
+		Expect((&KalypsoApplicationCustomDefaulter{}).Default(ctx, application)).To(Succeed())
+		Expect(application.Labels).To(HaveKeyWithValue(defaults.ProjectLabelKey, "sample-project"))
+		Expect(application.Labels).To(HaveKeyWithValue(defaults.ProjectNamespaceLabelKey, "default"))
+
+		promotion := &servingv1alpha1.KalypsoPromotion{
+			ObjectMeta: metav1.ObjectMeta{Name: "recommendation-staging", Namespace: "default"},
+			Spec:       servingv1alpha1.KalypsoPromotionSpec{ProjectRef: "sample-project", ServerRef: "recommendation-v1", From: "dev", To: "staging"},
+		}
+		Expect((&KalypsoPromotionCustomDefaulter{}).Default(ctx, promotion)).To(Succeed())
+		Expect(promotion.Labels).To(HaveKeyWithValue(defaults.ProjectLabelKey, "sample-project"))
+		Expect(promotion.Labels).To(HaveKeyWithValue(defaults.ProjectNamespaceLabelKey, "default"))
+
+		By("labeling with the name and namespace of a project of another namespace")
+		shared := &servingv1alpha1.KalypsoPromotion{
+			ObjectMeta: metav1.ObjectMeta{Name: "recommendation-staging", Namespace: "search-dev"},
+			Spec:       servingv1alpha1.KalypsoPromotionSpec{ProjectRef: "default/sample-project", ServerRef: "recommendation-v1", From: "dev", To: "staging"},
+		}
+		Expect((&KalypsoPromotionCustomDefaulter{}).Default(ctx, shared)).To(Succeed())
+		Expect(shared.Labels).To(HaveKeyWithValue(defaults.ProjectLabelKey, "sample-project"))
+		Expect(shared.Labels).To(HaveKeyWithValue(defaults.ProjectNamespaceLabelKey, "default"))
+	})
+
+	It("Should default the analysis settings and label resources with their application and project", func() {
+		c := fake.NewClientBuilder().WithObjects(app.DeepCopy()).Build()
+
+		rollout := &servingv1alpha1.KalypsoRollout{
+			ObjectMeta: metav1.ObjectMeta{Name: "recommendation-rollout", Namespace: "default"},
+			Spec: servingv1alpha1.KalypsoRolloutSpec{
+				ApplicationRef: app.Name,
+				StableRef:      "recommendation-v1",
+				CanaryRef:      "recommendation-v2",
+				Steps:          []servingv1alpha1.RolloutStep{{Weight: 10}, {Weight: 50, Pause: "10m"}},
+				Analysis:       &servingv1alpha1.RolloutAnalysisSpec{PrometheusURL: "http://prometheus:9090"},
+			},
+		}
+		Expect((&KalypsoRolloutCustomDefaulter{Client: c}).Default(ctx, rollout)).To(Succeed())
+		Expect(rollout.Spec.Steps[0].Pause).To(Equal("1m"))
+		Expect(rollout.Spec.Steps[1].Pause).To(Equal("10m"))
+		Expect(rollout.Spec.Analysis.Interval).To(Equal("1m"))
+		Expect(rollout.Spec.Analysis.FailureLimit).To(Equal(int32(3)))
+		Expect(rollout.Labels).To(HaveKeyWithValue(defaults.ApplicationLabelKey, app.Name))
+		Expect(rollout.Labels).To(HaveKeyWithValue(defaults.ProjectLabelKey, "sample-project"))
+		Expect(rollout.Labels).To(HaveKeyWithValue(defaults.ProjectNamespaceLabelKey, "default"))
+
+		cache := &servingv1alpha1.KalypsoModelCache{
+			ObjectMeta: metav1.ObjectMeta{Name: "recommendation-cache", Namespace: "default"},
+			Spec:       servingv1alpha1.KalypsoModelCacheSpec{ApplicationRef: app.Name},
+		}
+		Expect((&KalypsoModelCacheCustomDefaulter{Client: c}).Default(ctx, cache)).To(Succeed())
+		Expect(cache.Spec.RefreshInterval).To(Equal("10m"))
+		Expect(cache.Labels).To(HaveKeyWithValue(defaults.ProjectLabelKey, "sample-project"))
+
+		experiment := &servingv1alpha1.KalypsoExperiment{
+			ObjectMeta: metav1.ObjectMeta{Name: "recommendation-experiment", Namespace: "default"},
+			Spec: servingv1alpha1.KalypsoExperimentSpec{
+				ApplicationRef: "missing-application",
+				Analysis:       servingv1alpha1.ExperimentAnalysisSpec{PrometheusURL: "http://prometheus:9090"},
+			},
+		}
+		Expect((&KalypsoExperimentCustomDefaulter{Client: c}).Default(ctx, experiment)).To(Succeed())
+		Expect(experiment.Spec.Analysis.Interval).To(Equal("5m"))
+		Expect(experiment.Spec.Analysis.Objective).To(Equal(servingv1alpha1.ExperimentObjectiveP99Latency))
+		Expect(experiment.Spec.Analysis.MinRequests).To(Equal(int64(1000)))
+		Expect(experiment.Labels).To(HaveKeyWithValue(defaults.ApplicationLabelKey, "missing-application"))
+		Expect(experiment.Labels).NotTo(HaveKey(defaults.ProjectLabelKey))
+
+		By("labeling a server of another namespace with the name of its application")
+		server := &servingv1alpha1.KalypsoTritonServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "proj-dev"},
+			Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: "default/recommendation-application"},
+		}
+		Expect(defaultApplicationLabels(ctx, c, server, server.Spec.ApplicationRef)).To(Succeed())
+		Expect(server.Labels).To(HaveKeyWithValue(defaults.ApplicationLabelKey, app.Name))
+		Expect(server.Labels).To(HaveKeyWithValue(defaults.ProjectLabelKey, "sample-project"))
+		Expect(server.Labels).To(HaveKeyWithValue(defaults.ProjectNamespaceLabelKey, "default"))
+	})
+})
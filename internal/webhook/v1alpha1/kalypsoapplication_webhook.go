@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// log is for logging in this package.
+var kalypsoapplicationlog = logf.Log.WithName("kalypsoapplication-resource")
+
+// SetupKalypsoApplicationWebhookWithManager registers the webhook for KalypsoApplication in the manager.
+func SetupKalypsoApplicationWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&servingv1alpha1.KalypsoApplication{}).
+		WithDefaulter(&KalypsoApplicationCustomDefaulter{}).
+		WithValidator(&KalypsoApplicationCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-serving-serving-kalypso-io-v1alpha1-kalypsoapplication,mutating=true,failurePolicy=fail,sideEffects=None,groups=serving.serving.kalypso.io,resources=kalypsoapplications,verbs=create;update,versions=v1alpha1,name=mkalypsoapplication-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KalypsoApplicationCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind KalypsoApplication when those are created or updated.
+type KalypsoApplicationCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &KalypsoApplicationCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind KalypsoApplication.
+func (d *KalypsoApplicationCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	kalypsoapplication, ok := obj.(*servingv1alpha1.KalypsoApplication)
+	if !ok {
+		return fmt.Errorf("expected a KalypsoApplication object but got %T", obj)
+	}
+	kalypsoapplicationlog.Info("Defaulting for KalypsoApplication", "name", kalypsoapplication.GetName())
+
+	setDefaultProjectLabels(kalypsoapplication, kalypsoapplication.Spec.ProjectRef)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-serving-serving-kalypso-io-v1alpha1-kalypsoapplication,mutating=false,failurePolicy=fail,sideEffects=None,groups=serving.serving.kalypso.io,resources=kalypsoapplications,verbs=create;update;delete,versions=v1alpha1,name=vkalypsoapplication-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KalypsoApplicationCustomValidator struct is responsible for validating the KalypsoApplication resource
+// when it is created, updated, or deleted.
+type KalypsoApplicationCustomValidator struct {
+	// Client lists the KalypsoTritonServers referencing an application being deleted
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &KalypsoApplicationCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type KalypsoApplication.
+func (v *KalypsoApplicationCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	kalypsoapplication, ok := obj.(*servingv1alpha1.KalypsoApplication)
+	if !ok {
+		return nil, fmt.Errorf("expected a KalypsoApplication object but got %T", obj)
+	}
+	kalypsoapplicationlog.Info("Validation for KalypsoApplication upon creation", "name", kalypsoapplication.GetName())
+
+	// The name is the application label value and the prefix of its Service names. Both it and
+	// the project reference are immutable, so they are only checked on creation
+	var errs field.ErrorList
+	if err := validateLabelName(kalypsoapplication.Name, validation.IsDNS1035Label); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateReference(field.NewPath("spec", "projectRef"), kalypsoapplication.Spec.ProjectRef); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: servingv1alpha1.GroupVersion.Group, Kind: "KalypsoApplication"},
+			kalypsoapplication.Name, errs)
+	}
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type KalypsoApplication.
+func (v *KalypsoApplicationCustomValidator) ValidateUpdate(_ context.Context, _, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type KalypsoApplication.
+func (v *KalypsoApplicationCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	kalypsoapplication, ok := obj.(*servingv1alpha1.KalypsoApplication)
+	if !ok {
+		return nil, fmt.Errorf("expected a KalypsoApplication object but got %T", obj)
+	}
+	kalypsoapplicationlog.Info("Validation for KalypsoApplication upon deletion", "name", kalypsoapplication.GetName())
+
+	// Servers of other namespaces may reference the application as <namespace>/<name>
+	servers := &servingv1alpha1.KalypsoTritonServerList{}
+	if err := v.Client.List(ctx, servers); err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	var referrers []string
+	for _, server := range servers.Items {
+		if naming.Reference(server.Namespace, server.Spec.ApplicationRef) == client.ObjectKeyFromObject(kalypsoapplication) && server.DeletionTimestamp.IsZero() {
+			referrers = append(referrers, naming.RelativeReference(kalypsoapplication.Namespace, client.ObjectKeyFromObject(&server)))
+		}
+	}
+	return validateNoReferrers(kalypsoapplication, "kalypsoapplications", "KalypsoTritonServer", referrers)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var kalypsoexperimentlog = logf.Log.WithName("kalypsoexperiment-resource")
+
+// SetupKalypsoExperimentWebhookWithManager registers the webhook for KalypsoExperiment in the manager.
+func SetupKalypsoExperimentWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&servingv1alpha1.KalypsoExperiment{}).
+		WithDefaulter(&KalypsoExperimentCustomDefaulter{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-serving-serving-kalypso-io-v1alpha1-kalypsoexperiment,mutating=true,failurePolicy=fail,sideEffects=None,groups=serving.serving.kalypso.io,resources=kalypsoexperiments,verbs=create;update,versions=v1alpha1,name=mkalypsoexperiment-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KalypsoExperimentCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind KalypsoExperiment when those are created or updated.
+type KalypsoExperimentCustomDefaulter struct {
+	// Client resolves the application of the experiment for its project label
+	Client client.Reader
+}
+
+var _ webhook.CustomDefaulter = &KalypsoExperimentCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind KalypsoExperiment.
+func (d *KalypsoExperimentCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	kalypsoexperiment, ok := obj.(*servingv1alpha1.KalypsoExperiment)
+	if !ok {
+		return fmt.Errorf("expected a KalypsoExperiment object but got %T", obj)
+	}
+	kalypsoexperimentlog.Info("Defaulting for KalypsoExperiment", "name", kalypsoexperiment.GetName())
+
+	analysis := &kalypsoexperiment.Spec.Analysis
+	if analysis.Interval == "" {
+		analysis.Interval = "5m"
+	}
+	if analysis.Objective == "" {
+		analysis.Objective = servingv1alpha1.ExperimentObjectiveP99Latency
+	}
+	if analysis.MinRequests == 0 {
+		analysis.MinRequests = 1000
+	}
+	return defaultApplicationLabels(ctx, d.Client, kalypsoexperiment, kalypsoexperiment.Spec.ApplicationRef)
+}
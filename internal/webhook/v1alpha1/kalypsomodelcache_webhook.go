@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var kalypsomodelcachelog = logf.Log.WithName("kalypsomodelcache-resource")
+
+// SetupKalypsoModelCacheWebhookWithManager registers the webhook for KalypsoModelCache in the manager.
+func SetupKalypsoModelCacheWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&servingv1alpha1.KalypsoModelCache{}).
+		WithDefaulter(&KalypsoModelCacheCustomDefaulter{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-serving-serving-kalypso-io-v1alpha1-kalypsomodelcache,mutating=true,failurePolicy=fail,sideEffects=None,groups=serving.serving.kalypso.io,resources=kalypsomodelcaches,verbs=create;update,versions=v1alpha1,name=mkalypsomodelcache-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KalypsoModelCacheCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind KalypsoModelCache when those are created or updated.
+type KalypsoModelCacheCustomDefaulter struct {
+	// Client resolves the application of the cache for its project label
+	Client client.Reader
+}
+
+var _ webhook.CustomDefaulter = &KalypsoModelCacheCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind KalypsoModelCache.
+func (d *KalypsoModelCacheCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	kalypsomodelcache, ok := obj.(*servingv1alpha1.KalypsoModelCache)
+	if !ok {
+		return fmt.Errorf("expected a KalypsoModelCache object but got %T", obj)
+	}
+	kalypsomodelcachelog.Info("Defaulting for KalypsoModelCache", "name", kalypsomodelcache.GetName())
+
+	if kalypsomodelcache.Spec.RefreshInterval == "" {
+		kalypsomodelcache.Spec.RefreshInterval = "10m"
+	}
+	return defaultApplicationLabels(ctx, d.Client, kalypsomodelcache, kalypsomodelcache.Spec.ApplicationRef)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// log is for logging in this package.
+var kalypsoprojectlog = logf.Log.WithName("kalypsoproject-resource")
+
+// SetupKalypsoProjectWebhookWithManager registers the webhook for KalypsoProject in the manager.
+func SetupKalypsoProjectWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&servingv1alpha1.KalypsoProject{}).
+		WithDefaulter(&KalypsoProjectCustomDefaulter{}).
+		WithValidator(&KalypsoProjectCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-serving-serving-kalypso-io-v1alpha1-kalypsoproject,mutating=true,failurePolicy=fail,sideEffects=None,groups=serving.serving.kalypso.io,resources=kalypsoprojects,verbs=create;update,versions=v1alpha1,name=mkalypsoproject-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KalypsoProjectCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind KalypsoProject when those are created or updated.
+type KalypsoProjectCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &KalypsoProjectCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind KalypsoProject.
+func (d *KalypsoProjectCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	kalypsoproject, ok := obj.(*servingv1alpha1.KalypsoProject)
+	if !ok {
+		return fmt.Errorf("expected a KalypsoProject object but got %T", obj)
+	}
+	kalypsoprojectlog.Info("Defaulting for KalypsoProject", "name", kalypsoproject.GetName())
+
+	setDefaultLabel(kalypsoproject, defaults.ProjectLabelKey, kalypsoproject.Name)
+	setDefaultLabel(kalypsoproject, defaults.ProjectNamespaceLabelKey, kalypsoproject.Namespace)
+	for i := range kalypsoproject.Spec.Members {
+		if kalypsoproject.Spec.Members[i].Kind == "" {
+			kalypsoproject.Spec.Members[i].Kind = rbacv1.UserKind
+		}
+	}
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-serving-serving-kalypso-io-v1alpha1-kalypsoproject,mutating=false,failurePolicy=fail,sideEffects=None,groups=serving.serving.kalypso.io,resources=kalypsoprojects,verbs=create;update;delete,versions=v1alpha1,name=vkalypsoproject-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KalypsoProjectCustomValidator struct is responsible for validating the KalypsoProject resource
+// when it is created, updated, or deleted.
+type KalypsoProjectCustomValidator struct {
+	// Client lists the KalypsoApplications referencing a project being deleted
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &KalypsoProjectCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type KalypsoProject.
+func (v *KalypsoProjectCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	kalypsoproject, ok := obj.(*servingv1alpha1.KalypsoProject)
+	if !ok {
+		return nil, fmt.Errorf("expected a KalypsoProject object but got %T", obj)
+	}
+	kalypsoprojectlog.Info("Validation for KalypsoProject upon creation", "name", kalypsoproject.GetName())
+
+	// The name is the project label value and the prefix of its Role names. It is immutable,
+	// so it is only checked on creation
+	if err := validateLabelName(kalypsoproject.Name, validation.IsDNS1123Label); err != nil {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: servingv1alpha1.GroupVersion.Group, Kind: "KalypsoProject"},
+			kalypsoproject.Name, field.ErrorList{err})
+	}
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type KalypsoProject.
+func (v *KalypsoProjectCustomValidator) ValidateUpdate(_ context.Context, _, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type KalypsoProject.
+func (v *KalypsoProjectCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	kalypsoproject, ok := obj.(*servingv1alpha1.KalypsoProject)
+	if !ok {
+		return nil, fmt.Errorf("expected a KalypsoProject object but got %T", obj)
+	}
+	kalypsoprojectlog.Info("Validation for KalypsoProject upon deletion", "name", kalypsoproject.GetName())
+
+	// Applications of other namespaces reference the project as <namespace>/<name>
+	apps := &servingv1alpha1.KalypsoApplicationList{}
+	if err := v.Client.List(ctx, apps); err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	var referrers []string
+	for _, app := range apps.Items {
+		if naming.Reference(app.Namespace, app.Spec.ProjectRef) != client.ObjectKeyFromObject(kalypsoproject) || !app.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if app.Namespace == kalypsoproject.Namespace {
+			referrers = append(referrers, app.Name)
+		} else {
+			referrers = append(referrers, app.Namespace+"/"+app.Name)
+		}
+	}
+	return validateNoReferrers(kalypsoproject, "kalypsoprojects", "KalypsoApplication", referrers)
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var kalypsopromotionlog = logf.Log.WithName("kalypsopromotion-resource")
+
+// SetupKalypsoPromotionWebhookWithManager registers the webhook for KalypsoPromotion in the manager.
+func SetupKalypsoPromotionWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&servingv1alpha1.KalypsoPromotion{}).
+		WithDefaulter(&KalypsoPromotionCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-serving-serving-kalypso-io-v1alpha1-kalypsopromotion,mutating=true,failurePolicy=fail,sideEffects=None,groups=serving.serving.kalypso.io,resources=kalypsopromotions,verbs=create;update,versions=v1alpha1,name=mkalypsopromotion-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KalypsoPromotionCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind KalypsoPromotion when those are created or updated.
+type KalypsoPromotionCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &KalypsoPromotionCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind KalypsoPromotion.
+func (d *KalypsoPromotionCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	kalypsopromotion, ok := obj.(*servingv1alpha1.KalypsoPromotion)
+	if !ok {
+		return fmt.Errorf("expected a KalypsoPromotion object but got %T", obj)
+	}
+	kalypsopromotionlog.Info("Defaulting for KalypsoPromotion", "name", kalypsopromotion.GetName())
+
+	setDefaultProjectLabels(kalypsopromotion, kalypsopromotion.Spec.ProjectRef)
+	return nil
+}
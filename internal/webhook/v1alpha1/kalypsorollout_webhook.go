@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var kalypsorolloutlog = logf.Log.WithName("kalypsorollout-resource")
+
+// SetupKalypsoRolloutWebhookWithManager registers the webhook for KalypsoRollout in the manager.
+func SetupKalypsoRolloutWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&servingv1alpha1.KalypsoRollout{}).
+		WithDefaulter(&KalypsoRolloutCustomDefaulter{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-serving-serving-kalypso-io-v1alpha1-kalypsorollout,mutating=true,failurePolicy=fail,sideEffects=None,groups=serving.serving.kalypso.io,resources=kalypsorollouts,verbs=create;update,versions=v1alpha1,name=mkalypsorollout-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KalypsoRolloutCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind KalypsoRollout when those are created or updated.
+type KalypsoRolloutCustomDefaulter struct {
+	// Client resolves the application of the rollout for its project label
+	Client client.Reader
+}
+
+var _ webhook.CustomDefaulter = &KalypsoRolloutCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind KalypsoRollout.
+func (d *KalypsoRolloutCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	kalypsorollout, ok := obj.(*servingv1alpha1.KalypsoRollout)
+	if !ok {
+		return fmt.Errorf("expected a KalypsoRollout object but got %T", obj)
+	}
+	kalypsorolloutlog.Info("Defaulting for KalypsoRollout", "name", kalypsorollout.GetName())
+
+	for i := range kalypsorollout.Spec.Steps {
+		if kalypsorollout.Spec.Steps[i].Pause == "" {
+			kalypsorollout.Spec.Steps[i].Pause = "1m"
+		}
+	}
+	if analysis := kalypsorollout.Spec.Analysis; analysis != nil {
+		if analysis.Interval == "" {
+			analysis.Interval = "1m"
+		}
+		if analysis.FailureLimit == 0 {
+			analysis.FailureLimit = 3
+		}
+	}
+	return defaultApplicationLabels(ctx, d.Client, kalypsorollout, kalypsorollout.Spec.ApplicationRef)
+}
@@ -0,0 +1,349 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+	"github.com/kalypsoServing/KalypsoServing/internal/mlflow"
+	"github.com/kalypsoServing/KalypsoServing/internal/naming"
+)
+
+// storageURISchemes are the model repository locations served by the operator; absolute paths
+// are repositories mounted in the Triton container
+var storageURISchemes = []string{"s3://", "gs://", "as://", "mlflow://", "hf://", "oci://", "git+https://", "git+ssh://", "https://", "http://"}
+
+// log is for logging in this package.
+var kalypsotritonserverlog = logf.Log.WithName("kalypsotritonserver-resource")
+
+// SetupKalypsoTritonServerWebhookWithManager registers the webhook for KalypsoTritonServer in the manager.
+func SetupKalypsoTritonServerWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&servingv1alpha1.KalypsoTritonServer{}).
+		WithValidator(&KalypsoTritonServerCustomValidator{Client: mgr.GetClient()}).
+		WithDefaulter(&KalypsoTritonServerCustomDefaulter{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-serving-serving-kalypso-io-v1alpha1-kalypsotritonserver,mutating=true,failurePolicy=fail,sideEffects=None,groups=serving.serving.kalypso.io,resources=kalypsotritonservers,verbs=create;update,versions=v1alpha1,name=mkalypsotritonserver-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KalypsoTritonServerCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind KalypsoTritonServer when those are created or updated.
+type KalypsoTritonServerCustomDefaulter struct {
+	// Client resolves the application and project of the server for its defaults and project label
+	Client client.Reader
+}
+
+var _ webhook.CustomDefaulter = &KalypsoTritonServerCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind KalypsoTritonServer.
+func (d *KalypsoTritonServerCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	kalypsotritonserver, ok := obj.(*servingv1alpha1.KalypsoTritonServer)
+	if !ok {
+		return fmt.Errorf("expected a KalypsoTritonServer object but got %T", obj)
+	}
+	kalypsotritonserverlog.Info("Defaulting for KalypsoTritonServer", "name", kalypsotritonserver.GetName())
+
+	if err := defaultKalypsoTritonServerSpec(ctx, d.Client, kalypsotritonserver); err != nil {
+		return err
+	}
+	return defaultApplicationLabels(ctx, d.Client, kalypsotritonserver, kalypsotritonserver.Spec.ApplicationRef)
+}
+
+// defaultKalypsoTritonServerSpec fills in the replicas, ports and observability settings the
+// controller would otherwise assume. The ports are resolved from the application like the
+// controller does; the Triton image and tag and the fields filled by a preset are left unset so
+// the project defaults and the preset still apply
+func defaultKalypsoTritonServerSpec(ctx context.Context, c client.Reader, kalypsotritonserver *servingv1alpha1.KalypsoTritonServer) error {
+	if err := defaults.TritonServer(ctx, c, kalypsotritonserver); err != nil {
+		return fmt.Errorf("failed to resolve the defaults of %s: %w", kalypsotritonserver.Name, err)
+	}
+
+	spec := &kalypsotritonserver.Spec
+	if spec.Replicas == nil {
+		replicas := int32(1)
+		spec.Replicas = &replicas
+	}
+
+	if observability := spec.Observability; observability != nil {
+		if observability.Logging != nil && observability.Logging.Level == "" {
+			observability.Logging.Level = "INFO"
+		}
+		if observability.Tracing != nil && observability.Tracing.SamplingRate == "" {
+			observability.Tracing.SamplingRate = "0.1"
+		}
+		if observability.Metrics != nil && observability.Metrics.Interval == "" {
+			observability.Metrics.Interval = "15s"
+		}
+	}
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-serving-serving-kalypso-io-v1alpha1-kalypsotritonserver,mutating=false,failurePolicy=fail,sideEffects=None,groups=serving.serving.kalypso.io,resources=kalypsotritonservers,verbs=create;update,versions=v1alpha1,name=vkalypsotritonserver-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KalypsoTritonServerCustomValidator struct is responsible for validating the KalypsoTritonServer resource
+// when it is created, updated, or deleted.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+type KalypsoTritonServerCustomValidator struct {
+	// Client lists sibling KalypsoTritonServers for cross-object validation
+	Client client.Client
+}
+
+var _ webhook.CustomValidator = &KalypsoTritonServerCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type KalypsoTritonServer.
+func (v *KalypsoTritonServerCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	kalypsotritonserver, ok := obj.(*servingv1alpha1.KalypsoTritonServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a KalypsoTritonServer object but got %T", obj)
+	}
+	kalypsotritonserverlog.Info("Validation for KalypsoTritonServer upon creation", "name", kalypsotritonserver.GetName())
+
+	return nil, v.validateKalypsoTritonServer(ctx, kalypsotritonserver, nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type KalypsoTritonServer.
+func (v *KalypsoTritonServerCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	kalypsotritonserver, ok := newObj.(*servingv1alpha1.KalypsoTritonServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a KalypsoTritonServer object for the newObj but got %T", newObj)
+	}
+	oldKalypsoTritonServer, ok := oldObj.(*servingv1alpha1.KalypsoTritonServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a KalypsoTritonServer object for the oldObj but got %T", oldObj)
+	}
+	kalypsotritonserverlog.Info("Validation for KalypsoTritonServer upon update", "name", kalypsotritonserver.GetName())
+
+	return nil, v.validateKalypsoTritonServer(ctx, kalypsotritonserver, oldKalypsoTritonServer)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type KalypsoTritonServer.
+func (v *KalypsoTritonServerCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	kalypsotritonserver, ok := obj.(*servingv1alpha1.KalypsoTritonServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a KalypsoTritonServer object but got %T", obj)
+	}
+	kalypsotritonserverlog.Info("Validation for KalypsoTritonServer upon deletion", "name", kalypsotritonserver.GetName())
+
+	return nil, nil
+}
+
+// validateKalypsoTritonServer validates the KalypsoTritonServer; old is nil on creation
+func (v *KalypsoTritonServerCustomValidator) validateKalypsoTritonServer(ctx context.Context, kalypsotritonserver, old *servingv1alpha1.KalypsoTritonServer) error {
+	// Updates of deleting servers, such as the finalizer removal, and metadata changes leave the
+	// deployed spec as it is, so they are not held to the quotas and rules of today
+	if old != nil && (!kalypsotritonserver.DeletionTimestamp.IsZero() || equality.Semantic.DeepEqual(kalypsotritonserver.Spec, old.Spec)) {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	// Derived names only change with metadata.name, which is immutable, so they are checked on creation
+	if old == nil {
+		if err := validateLabelName(kalypsotritonserver.Name, validation.IsDNS1035Label); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		if err := v.validateDerivedNames(ctx, kalypsotritonserver); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	if err := validateStorageURI(kalypsotritonserver); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, validateGPU(kalypsotritonserver.Spec.GPU, field.NewPath("spec", "gpu"))...)
+	if profile := kalypsotritonserver.Spec.DegradedProfile; profile != nil {
+		allErrs = append(allErrs, validateGPU(profile.GPU, field.NewPath("spec", "degradedProfile", "gpu"))...)
+	}
+
+	if old != nil {
+		if err := v.validateStateChange(ctx, kalypsotritonserver, old); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	if err := v.validateQuota(ctx, kalypsotritonserver, old); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: servingv1alpha1.GroupVersion.Group, Kind: "KalypsoTritonServer"},
+		kalypsotritonserver.Name, allErrs)
+}
+
+// validateDerivedNames rejects servers whose derived resource names (after truncation)
+// collide with those of another KalypsoTritonServer in the same namespace
+func (v *KalypsoTritonServerCustomValidator) validateDerivedNames(ctx context.Context, kalypsotritonserver *servingv1alpha1.KalypsoTritonServer) *field.Error {
+	servers := &servingv1alpha1.KalypsoTritonServerList{}
+	if err := v.Client.List(ctx, servers, client.InNamespace(kalypsotritonserver.Namespace)); err != nil {
+		return field.InternalError(field.NewPath("metadata").Child("name"), err)
+	}
+
+	serverNames := []string{kalypsotritonserver.Name}
+	for _, server := range servers.Items {
+		if server.Name != kalypsotritonserver.Name {
+			serverNames = append(serverNames, server.Name)
+		}
+	}
+
+	collisions := naming.FindCollisions(serverNames)
+	var conflicts []string
+	for _, childName := range naming.TritonServerChildNames(kalypsotritonserver.Name) {
+		if owners, ok := collisions[childName]; ok {
+			conflicts = append(conflicts, fmt.Sprintf("%s is also derived from %s", childName, strings.Join(owners, ", ")))
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	return field.Invalid(field.NewPath("metadata").Child("name"), kalypsotritonserver.Name,
+		fmt.Sprintf("derived resource names collide with other KalypsoTritonServers: %s", strings.Join(conflicts, "; ")))
+}
+
+// validateQuota rejects servers whose replicas do not fit in what is left of the ResourceQuotas of
+// their namespace, such as those of a project environment, rather than leaving the pods Pending.
+// Only resources the change adds are checked; the usage of the old server is released by the
+// update. GPUs are left to the controller when a degraded profile can take over
+func (v *KalypsoTritonServerCustomValidator) validateQuota(ctx context.Context, kalypsotritonserver, old *servingv1alpha1.KalypsoTritonServer) *field.Error {
+	// The resources may come from the server defaults of the application
+	app, err := defaults.ServerApplication(ctx, v.Client, kalypsotritonserver)
+	if client.IgnoreNotFound(err) != nil {
+		return field.InternalError(field.NewPath("spec", "applicationRef"), err)
+	}
+
+	requested := defaults.QuotaUsage(kalypsotritonserver, app)
+	if len(requested) == 0 {
+		return nil
+	}
+	held := corev1.ResourceList{}
+	if old != nil {
+		held = defaults.QuotaUsage(old, app)
+	}
+
+	quotas := &corev1.ResourceQuotaList{}
+	if err := v.Client.List(ctx, quotas, client.InNamespace(kalypsotritonserver.Namespace)); err != nil {
+		return field.InternalError(field.NewPath("spec"), err)
+	}
+
+	var exceeded []string
+	for _, quota := range quotas.Items {
+		for _, name := range slices.Sorted(maps.Keys(quota.Spec.Hard)) {
+			needed, ok := requested[name]
+			if !ok || (kalypsotritonserver.Spec.DegradedProfile != nil && strings.Contains(string(name), "nvidia.com/")) {
+				continue
+			}
+			released := held[name]
+			if needed.Cmp(released) <= 0 {
+				continue
+			}
+			available := quota.Spec.Hard[name].DeepCopy()
+			available.Sub(quota.Status.Used[name])
+			available.Add(released)
+			if needed.Cmp(available) > 0 {
+				if available.Sign() < 0 {
+					available = resource.Quantity{}
+				}
+				exceeded = append(exceeded, fmt.Sprintf("%s needs %s of %s but %s is left", quota.Name, needed.String(), name, available.String()))
+			}
+		}
+	}
+	if len(exceeded) == 0 {
+		return nil
+	}
+	return field.Forbidden(field.NewPath("spec"),
+		fmt.Sprintf("the replicas exceed the namespace ResourceQuotas: %s", strings.Join(exceeded, "; ")))
+}
+
+// validateGPU rejects whole GPUs requested together with MIG slices, which the pod
+// could not be scheduled with on a MIG-partitioned node
+func validateGPU(gpu *servingv1alpha1.GPUSpec, path *field.Path) field.ErrorList {
+	if gpu == nil || gpu.Count == nil || gpu.MIG == nil {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(path.Child("count"), *gpu.Count, "count cannot be combined with mig")}
+}
+
+// validateStorageURI rejects model repositories of unsupported schemes and malformed registry URIs
+func validateStorageURI(kalypsotritonserver *servingv1alpha1.KalypsoTritonServer) *field.Error {
+	uri := kalypsotritonserver.Spec.StorageURI
+	path := field.NewPath("spec").Child("storageUri")
+	if strings.HasPrefix(uri, "/") {
+		return nil
+	}
+	for _, scheme := range storageURISchemes {
+		if !strings.HasPrefix(uri, scheme) {
+			continue
+		}
+		if _, _, err := mlflow.ParseURI(uri); err != nil {
+			return field.Invalid(path, uri, err.Error())
+		}
+		return nil
+	}
+	return field.Invalid(path, uri,
+		fmt.Sprintf("unsupported model repository; expected an absolute path or one of the schemes %s", strings.Join(storageURISchemes, ", ")))
+}
+
+// validateStateChange rejects spec changes of ReadOnly and Archived servers other than their state,
+// so a retired model cannot be redeployed without first being made Active again
+func (v *KalypsoTritonServerCustomValidator) validateStateChange(ctx context.Context, kalypsotritonserver, old *servingv1alpha1.KalypsoTritonServer) *field.Error {
+	if old.Spec.State != servingv1alpha1.ServerStateReadOnly && old.Spec.State != servingv1alpha1.ServerStateArchived {
+		return nil
+	}
+
+	// Both sides are defaulted, since servers stored before the defaulting webhook lack the
+	// defaults it sets on the request
+	previous := old.DeepCopy()
+	if err := defaultKalypsoTritonServerSpec(ctx, v.Client, previous); err != nil {
+		return field.InternalError(field.NewPath("spec"), err)
+	}
+	desired := kalypsotritonserver.DeepCopy()
+	if err := defaultKalypsoTritonServerSpec(ctx, v.Client, desired); err != nil {
+		return field.InternalError(field.NewPath("spec"), err)
+	}
+
+	desired.Spec.State = old.Spec.State
+	if equality.Semantic.DeepEqual(desired.Spec, previous.Spec) {
+		return nil
+	}
+	return field.Forbidden(field.NewPath("spec"),
+		fmt.Sprintf("the spec of a %s server cannot change; set spec.state to Active first", old.Spec.State))
+}
@@ -0,0 +1,353 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/controller"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+)
+
+var _ = Describe("KalypsoTritonServer Webhook", func() {
+	var (
+		obj       *servingv1alpha1.KalypsoTritonServer
+		oldObj    *servingv1alpha1.KalypsoTritonServer
+		validator KalypsoTritonServerCustomValidator
+	)
+
+	// longPrefix makes derived names exceed the 63 character limit so they are truncated
+	longPrefix := strings.Repeat("a", 55)
+
+	newServer := func(name string) *servingv1alpha1.KalypsoTritonServer {
+		return &servingv1alpha1.KalypsoTritonServer{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: servingv1alpha1.KalypsoTritonServerSpec{
+				ApplicationRef: "test-application",
+				StorageURI:     "s3://models/",
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		obj = newServer(longPrefix + "-two")
+		oldObj = newServer(longPrefix + "-two")
+		validator = KalypsoTritonServerCustomValidator{
+			Client: fake.NewClientBuilder().WithObjects(newServer(longPrefix + "-one")).Build(),
+		}
+		Expect(validator).NotTo(BeNil(), "Expected validator to be initialized")
+		Expect(oldObj).NotTo(BeNil(), "Expected oldObj to be initialized")
+		Expect(obj).NotTo(BeNil(), "Expected obj to be initialized")
+	})
+
+	Context("When creating or updating KalypsoTritonServer under Validating Webhook", func() {
+		It("Should deny creation if derived names collide after truncation", func() {
+			By("creating a server sharing the truncated prefix of an existing server")
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("derived resource names collide"))
+		})
+
+		It("Should deny creation if the name is not a DNS-1035 label", func() {
+			_, err := validator.ValidateCreate(ctx, newServer(strings.Repeat("a", 64)))
+			Expect(err).To(MatchError(ContainSubstring("must be no more than 63 characters")))
+
+			_, err = validator.ValidateCreate(ctx, newServer("recommendation.v2"))
+			Expect(err).To(MatchError(ContainSubstring("a DNS-1035 label must consist of")))
+		})
+
+		It("Should admit creation if derived names are unique", func() {
+			By("creating a server with a distinct name")
+			Expect(validator.ValidateCreate(ctx, newServer("recommendation-v2"))).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should not re-check derived names on update", func() {
+			By("updating an existing server")
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should only admit supported model repository schemes", func() {
+			server := newServer("recommendation-v2")
+			for _, uri := range []string{"gs://models/recommendation", "as://kalypsomodels/models/recommendation", "git+https://github.com/kalypso/models.git@main", "https://nexus.internal/repository/models/recommendation.zip", "mlflow://mlflow.mlops/recommender/Production", "/models"} {
+				server.Spec.StorageURI = uri
+				Expect(validator.ValidateCreate(ctx, server)).Error().NotTo(HaveOccurred(), uri)
+			}
+
+			server.Spec.StorageURI = "ftp://models/recommendation"
+			Expect(validator.ValidateCreate(ctx, server)).Error().To(MatchError(ContainSubstring("unsupported model repository")))
+
+			server.Spec.StorageURI = "mlflow://mlflow.mlops/recommender"
+			Expect(validator.ValidateCreate(ctx, server)).Error().To(MatchError(ContainSubstring("is not of the form")))
+		})
+
+		It("Should deny whole GPUs combined with a MIG profile", func() {
+			server := newServer("recommendation-v2")
+			server.Spec.GPU = &servingv1alpha1.GPUSpec{MIG: &servingv1alpha1.MIGSpec{Profile: "1g.10gb"}}
+			Expect(validator.ValidateCreate(ctx, server)).Error().NotTo(HaveOccurred())
+
+			server.Spec.GPU.Count = ptrTo(int32(1))
+			Expect(validator.ValidateCreate(ctx, server)).Error().To(MatchError(ContainSubstring("spec.gpu.count: Invalid value: 1: count cannot be combined with mig")))
+
+			server.Spec.GPU = nil
+			server.Spec.DegradedProfile = &servingv1alpha1.DegradedProfileSpec{
+				GPU: &servingv1alpha1.GPUSpec{Count: ptrTo(int32(1)), MIG: &servingv1alpha1.MIGSpec{Profile: "1g.10gb"}},
+			}
+			Expect(validator.ValidateCreate(ctx, server)).Error().To(MatchError(ContainSubstring("spec.degradedProfile.gpu.count")))
+		})
+
+		It("Should only allow state changes of a ReadOnly server", func() {
+			oldObj.Spec.State = servingv1alpha1.ServerStateReadOnly
+
+			By("redeploying the read-only server")
+			obj.Spec.State = servingv1alpha1.ServerStateReadOnly
+			obj.Spec.StorageURI = "s3://models/v2/"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("set spec.state to Active first")))
+
+			By("archiving the read-only server")
+			obj.Spec.StorageURI = oldObj.Spec.StorageURI
+			obj.Spec.State = servingv1alpha1.ServerStateArchived
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny replicas exceeding the remaining ResourceQuota", func() {
+			quota := &corev1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project-quota", Namespace: "default"},
+				Spec: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{
+					"requests.cpu":            resource.MustParse("8"),
+					"requests.nvidia.com/gpu": resource.MustParse("4"),
+				}},
+				Status: corev1.ResourceQuotaStatus{Used: corev1.ResourceList{
+					"requests.cpu":            resource.MustParse("2"),
+					"requests.nvidia.com/gpu": resource.MustParse("2"),
+				}},
+			}
+			validator.Client = fake.NewClientBuilder().WithObjects(quota).Build()
+
+			server := newServer("recommendation-v2")
+			server.Spec.Replicas = ptrTo(int32(2))
+			server.Spec.Resources = &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			}
+			server.Spec.GPU = &servingv1alpha1.GPUSpec{Count: ptrTo(int32(1))}
+			Expect(validator.ValidateCreate(ctx, server)).Error().NotTo(HaveOccurred())
+
+			By("requesting more GPUs than are left")
+			server.Spec.Replicas = ptrTo(int32(3))
+			_, err := validator.ValidateCreate(ctx, server)
+			Expect(err).To(MatchError(ContainSubstring("sample-project-quota needs 3 of requests.nvidia.com/gpu but 2 is left")))
+
+			By("scaling up a server whose current replicas already use the quota")
+			old := server.DeepCopy()
+			old.Spec.Replicas = ptrTo(int32(2))
+			Expect(validator.ValidateUpdate(ctx, old, server)).Error().NotTo(HaveOccurred())
+
+			By("falling back to the degraded profile")
+			server.Spec.DegradedProfile = &servingv1alpha1.DegradedProfileSpec{}
+			Expect(validator.ValidateCreate(ctx, server)).Error().NotTo(HaveOccurred())
+
+			By("requesting more CPU than is left")
+			server.Spec.Replicas = ptrTo(int32(4))
+			_, err = validator.ValidateCreate(ctx, server)
+			Expect(err).To(MatchError(ContainSubstring("sample-project-quota needs 8 of requests.cpu but 6 is left")))
+		})
+
+		It("Should count the ResourceQuotas the operator does not manage", func() {
+			quota := &corev1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "namespace-quota", Namespace: "default"},
+				Spec: corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{
+					"requests.nvidia.com/gpu": resource.MustParse("1"),
+				}},
+			}
+			validator.Client = cachedView(fake.NewClientBuilder().WithObjects(quota).Build())
+
+			server := newServer("recommendation-v2")
+			server.Spec.Replicas = ptrTo(int32(2))
+			server.Spec.GPU = &servingv1alpha1.GPUSpec{Count: ptrTo(int32(1))}
+			_, err := validator.ValidateCreate(ctx, server)
+			Expect(err).To(MatchError(ContainSubstring("namespace-quota needs 2 of requests.nvidia.com/gpu but 1 is left")))
+		})
+
+		It("Should admit metadata updates and updates of deleting servers", func() {
+			quota := &corev1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "exhausted-quota", Namespace: "default"},
+				Spec:       corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("1")}},
+				Status:     corev1.ResourceQuotaStatus{Used: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("1")}},
+			}
+			validator.Client = fake.NewClientBuilder().WithObjects(quota).Build()
+			oldObj.Spec.StorageURI = "ftp://models/"
+			oldObj.Finalizers = []string{"serving.kalypso.io/tritonserver-finalizer"}
+			obj.Spec.StorageURI = oldObj.Spec.StorageURI
+
+			By("labelling the server")
+			obj.Finalizers = oldObj.Finalizers
+			obj.Labels = map[string]string{"team": "recommendation"}
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+
+			By("removing the finalizer of the deleting server")
+			now := metav1.Now()
+			oldObj.DeletionTimestamp = &now
+			obj.DeletionTimestamp = &now
+			obj.Finalizers = nil
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+
+			By("still checking spec changes of live servers")
+			oldObj.DeletionTimestamp = nil
+			obj.DeletionTimestamp = nil
+			obj.Spec.Replicas = ptrTo(int32(2))
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring("unsupported model repository")))
+			Expect(err).To(MatchError(ContainSubstring("exhausted-quota needs 2 of pods but 1 is left")))
+		})
+
+		It("Should admit defaulted updates of a ReadOnly server stored without defaults", func() {
+			oldObj.Spec.State = servingv1alpha1.ServerStateReadOnly
+			obj.Spec.State = servingv1alpha1.ServerStateReadOnly
+			Expect(defaultKalypsoTritonServerSpec(ctx, validator.Client, obj)).To(Succeed())
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When creating or updating KalypsoTritonServer under Defaulting Webhook", func() {
+		It("Should fill in the replicas, ports and observability defaults", func() {
+			obj.Spec.Observability = &servingv1alpha1.ObservabilitySpec{
+				Logging: &servingv1alpha1.LoggingSpec{Enabled: true},
+				Tracing: &servingv1alpha1.TracingSpec{Enabled: true},
+			}
+			defaulter := KalypsoTritonServerCustomDefaulter{Client: fake.NewClientBuilder().Build()}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+
+			Expect(*obj.Spec.Replicas).To(Equal(int32(1)))
+			Expect(*obj.Spec.Networking.HTTPPort).To(Equal(defaults.HTTPPort))
+			Expect(*obj.Spec.Networking.GrpcPort).To(Equal(defaults.GRPCPort))
+			Expect(*obj.Spec.Networking.MetricsPort).To(Equal(defaults.MetricsPort))
+			Expect(obj.Spec.TritonConfig.Image).To(BeEmpty())
+			Expect(obj.Spec.TritonConfig.Tag).To(BeEmpty())
+			Expect(obj.Spec.Observability.Logging.Level).To(Equal("INFO"))
+			Expect(obj.Spec.Observability.Tracing.SamplingRate).To(Equal("0.1"))
+			Expect(obj.Spec.Observability.Metrics).To(BeNil())
+			Expect(obj.Labels).To(HaveKeyWithValue(defaults.ApplicationLabelKey, "test-application"))
+		})
+
+		It("Should keep explicit values and label the server with the project of its application", func() {
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-application", Namespace: "default"},
+				Spec:       servingv1alpha1.KalypsoApplicationSpec{ProjectRef: "sample-project"},
+			}
+			replicas := int32(3)
+			obj.Spec.Replicas = &replicas
+			obj.Spec.TritonConfig.Tag = "24.08-py3"
+			obj.Labels = map[string]string{defaults.ApplicationLabelKey: "custom"}
+
+			defaulter := KalypsoTritonServerCustomDefaulter{Client: fake.NewClientBuilder().WithObjects(app).Build()}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+
+			Expect(*obj.Spec.Replicas).To(Equal(int32(3)))
+			Expect(obj.Spec.TritonConfig.Tag).To(Equal("24.08-py3"))
+			Expect(obj.Labels).To(HaveKeyWithValue(defaults.ApplicationLabelKey, "custom"))
+			Expect(obj.Labels).To(HaveKeyWithValue(defaults.ProjectLabelKey, "sample-project"))
+		})
+
+		It("Should store the ports inherited from the application and leave the project image unset", func() {
+			project := &servingv1alpha1.KalypsoProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "default"},
+				Spec: servingv1alpha1.KalypsoProjectSpec{
+					Defaults: &servingv1alpha1.ProjectDefaultsSpec{
+						TritonConfig: &servingv1alpha1.ProjectTritonDefaults{Image: "registry.example.com/tritonserver", Tag: "24.10-py3"},
+					},
+				},
+			}
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-application", Namespace: "default"},
+				Spec: servingv1alpha1.KalypsoApplicationSpec{
+					ProjectRef: "sample-project",
+					ServerDefaults: &servingv1alpha1.ServerDefaultsSpec{
+						Networking: &servingv1alpha1.NetworkingSpec{HTTPPort: ptrTo(int32(9000))},
+					},
+				},
+			}
+			obj.Spec.Networking = &servingv1alpha1.NetworkingSpec{GrpcPort: ptrTo(int32(9001))}
+
+			defaulter := KalypsoTritonServerCustomDefaulter{Client: fake.NewClientBuilder().WithObjects(project, app).Build()}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+
+			Expect(*obj.Spec.Networking.HTTPPort).To(Equal(int32(9000)))
+			Expect(*obj.Spec.Networking.GrpcPort).To(Equal(int32(9001)))
+			Expect(*obj.Spec.Networking.MetricsPort).To(Equal(defaults.MetricsPort))
+			// A new Triton release of the project defaults still reaches the server
+			Expect(obj.Spec.TritonConfig.Image).To(BeEmpty())
+			Expect(obj.Spec.TritonConfig.Tag).To(BeEmpty())
+		})
+	})
+
+})
+
+// cachedView returns a client listing through c only the objects the manager cache selects, the way
+// the webhook client reads them
+func cachedView(c client.WithWatch) client.WithWatch {
+	return interceptor.NewClient(c, interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if err := c.List(ctx, list, opts...); err != nil {
+				return err
+			}
+			items, err := meta.ExtractList(list)
+			if err != nil {
+				return err
+			}
+			kept := make([]runtime.Object, 0, len(items))
+			for _, item := range items {
+				if cacheSelects(item) {
+					kept = append(kept, item)
+				}
+			}
+			return meta.SetList(list, kept)
+		},
+	})
+}
+
+// cacheSelects reports whether the label selectors of the manager cache keep obj
+func cacheSelects(obj runtime.Object) bool {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	for cached, byObject := range controller.NewCacheOptions().ByObject {
+		if reflect.TypeOf(cached) == reflect.TypeOf(obj) && byObject.Label != nil {
+			return byObject.Label.Matches(labels.Set(accessor.GetLabels()))
+		}
+	}
+	return true
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
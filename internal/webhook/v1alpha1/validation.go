@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+// ForceDeleteAnnotation set to "true" allows deleting a KalypsoProject or KalypsoApplication
+// that is still referenced, cascading the deletion to its children
+const ForceDeleteAnnotation = "serving.kalypso.io/force-delete"
+
+// validateLabelName rejects names that cannot be used as a label value and as the prefix of
+// the derived resource names, checked with isValid. The API server accepts longer custom
+// resource names, up to 253 characters, and dots
+func validateLabelName(name string, isValid func(string) []string) *field.Error {
+	if errs := isValid(name); len(errs) > 0 {
+		return field.Invalid(field.NewPath("metadata").Child("name"), name, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// validateReference rejects references to another namespace that are not <namespace>/<name>
+func validateReference(path *field.Path, ref string) *field.Error {
+	namespace, name, ok := strings.Cut(ref, "/")
+	if !ok {
+		return nil
+	}
+	errs := validation.IsDNS1123Label(namespace)
+	errs = append(errs, validation.IsDNS1123Subdomain(name)...)
+	if len(errs) > 0 {
+		return field.Invalid(path, ref, "must be a name or <namespace>/<name>: "+strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// validateNoReferrers forbids deleting obj while the live resources of kind named by referrers
+// reference it, unless the deletion is forced, in which case a warning lists them
+func validateNoReferrers(obj client.Object, resource, kind string, referrers []string) (admission.Warnings, error) {
+	if len(referrers) == 0 {
+		return nil, nil
+	}
+	if obj.GetAnnotations()[ForceDeleteAnnotation] == "true" {
+		return admission.Warnings{fmt.Sprintf("deleting %s along with the %ss referencing it: %s", obj.GetName(), kind, strings.Join(referrers, ", "))}, nil
+	}
+	return nil, apierrors.NewForbidden(
+		schema.GroupResource{Group: servingv1alpha1.GroupVersion.Group, Resource: resource}, obj.GetName(),
+		fmt.Errorf("%d %ss still reference it: %s; delete them first or set the %s annotation to \"true\"",
+			len(referrers), kind, strings.Join(referrers, ", "), ForceDeleteAnnotation))
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/defaults"
+)
+
+var _ = Describe("Name Validation", func() {
+	It("Should deny applications whose name is not a DNS-1035 label", func() {
+		validator := &KalypsoApplicationCustomValidator{}
+		app := &servingv1alpha1.KalypsoApplication{
+			ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "default"},
+			Spec:       servingv1alpha1.KalypsoApplicationSpec{ProjectRef: "sample-project"},
+		}
+		Expect(validator.ValidateCreate(ctx, app)).Error().NotTo(HaveOccurred())
+
+		app.Name = "1-recommendation"
+		Expect(validator.ValidateCreate(ctx, app)).Error().To(MatchError(ContainSubstring("a DNS-1035 label must consist of")))
+	})
+
+	It("Should deny projects whose name is longer than a label value", func() {
+		validator := &KalypsoProjectCustomValidator{}
+		project := &servingv1alpha1.KalypsoProject{
+			ObjectMeta: metav1.ObjectMeta{Name: "2025-sample-project", Namespace: "default"},
+		}
+		Expect(validator.ValidateCreate(ctx, project)).Error().NotTo(HaveOccurred())
+
+		project.Name = strings.Repeat("a", 64)
+		Expect(validator.ValidateCreate(ctx, project)).Error().To(MatchError(ContainSubstring("must be no more than 63 characters")))
+	})
+
+	It("Should deny deleting projects and applications that are still referenced", func() {
+		project := &servingv1alpha1.KalypsoProject{
+			ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "default"},
+		}
+		app := &servingv1alpha1.KalypsoApplication{
+			ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "default"},
+			Spec:       servingv1alpha1.KalypsoApplicationSpec{ProjectRef: project.Name},
+		}
+		server := &servingv1alpha1.KalypsoTritonServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "recommendation-v1", Namespace: "default"},
+			Spec:       servingv1alpha1.KalypsoTritonServerSpec{ApplicationRef: app.Name, StorageURI: "s3://models/"},
+		}
+		c := fake.NewClientBuilder().WithObjects(app.DeepCopy(), server.DeepCopy()).Build()
+
+		By("deleting the project of a live application")
+		_, err := (&KalypsoProjectCustomValidator{Client: c}).ValidateDelete(ctx, project)
+		Expect(apierrors.IsForbidden(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("1 KalypsoApplications still reference it: recommendation-application")))
+
+		By("deleting the application of a live server")
+		_, err = (&KalypsoApplicationCustomValidator{Client: c}).ValidateDelete(ctx, app)
+		Expect(err).To(MatchError(ContainSubstring("1 KalypsoTritonServers still reference it: recommendation-v1")))
+
+		By("forcing the deletion")
+		app.Annotations = map[string]string{ForceDeleteAnnotation: "true"}
+		warnings, err := (&KalypsoApplicationCustomValidator{Client: c}).ValidateDelete(ctx, app)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(ContainSubstring("recommendation-v1")))
+
+		By("deleting an application without servers")
+		Expect(c.Delete(ctx, server)).To(Succeed())
+		app.Annotations = nil
+		Expect((&KalypsoApplicationCustomValidator{Client: c}).ValidateDelete(ctx, app)).Error().NotTo(HaveOccurred())
+	})
+
+	It("Should accept project references to another namespace", func() {
+		validator := &KalypsoApplicationCustomValidator{}
+		app := &servingv1alpha1.KalypsoApplication{
+			ObjectMeta: metav1.ObjectMeta{Name: "recommendation-application", Namespace: "proj-dev"},
+			Spec:       servingv1alpha1.KalypsoApplicationSpec{ProjectRef: "kalypso-system/sample-project"},
+		}
+		Expect(validator.ValidateCreate(ctx, app)).Error().NotTo(HaveOccurred())
+
+		app.Spec.ProjectRef = "kalypso-system/sample/project"
+		Expect(validator.ValidateCreate(ctx, app)).Error().To(MatchError(ContainSubstring("spec.projectRef")))
+
+		By("counting the applications of other namespaces as referrers")
+		project := &servingv1alpha1.KalypsoProject{
+			ObjectMeta: metav1.ObjectMeta{Name: "sample-project", Namespace: "kalypso-system"},
+		}
+		app.Spec.ProjectRef = "kalypso-system/sample-project"
+		c := fake.NewClientBuilder().WithObjects(app).Build()
+		_, err := (&KalypsoProjectCustomValidator{Client: c}).ValidateDelete(ctx, project)
+		Expect(err).To(MatchError(ContainSubstring("still reference it: proj-dev/recommendation-application")))
+
+		By("labeling the application with the project name")
+		Expect((&KalypsoApplicationCustomDefaulter{}).Default(ctx, app)).To(Succeed())
+		Expect(app.Labels).To(HaveKeyWithValue(defaults.ProjectLabelKey, "sample-project"))
+	})
+})
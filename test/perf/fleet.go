@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+// FleetLabelKey marks the synthetic resources with the fleet prefix, so they can be cleaned up
+const FleetLabelKey = "kalypso-serving.io/scale-test"
+
+// FleetConfig sizes the synthetic fleet
+type FleetConfig struct {
+	// Prefix names the fleet's namespaces and labels its resources
+	Prefix string
+	// Projects is the number of projects, each in its own namespace
+	Projects int
+	// ApplicationsPerProject is the number of applications of each project
+	ApplicationsPerProject int
+	// ServersPerApplication is the number of servers of each application
+	ServersPerApplication int
+}
+
+// Fleet is a synthetic set of projects, applications, and servers
+type Fleet struct {
+	Namespaces   []*corev1.Namespace
+	Projects     []*servingv1alpha1.KalypsoProject
+	Applications []*servingv1alpha1.KalypsoApplication
+	Servers      []*servingv1alpha1.KalypsoTritonServer
+}
+
+// GenerateFleet builds the fleet's resources. Names are deterministic, so a fleet can be
+// regenerated to clean up after an interrupted run
+func GenerateFleet(config FleetConfig) *Fleet {
+	labels := map[string]string{FleetLabelKey: config.Prefix}
+	replicas := int32(1)
+
+	fleet := &Fleet{}
+	for p := 0; p < config.Projects; p++ {
+		namespace := fmt.Sprintf("%s-%03d", config.Prefix, p)
+		fleet.Namespaces = append(fleet.Namespaces, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace, Labels: labels},
+		})
+
+		project := &servingv1alpha1.KalypsoProject{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("project-%03d", p), Namespace: namespace, Labels: labels},
+			Spec:       servingv1alpha1.KalypsoProjectSpec{DisplayName: "Scale test project"},
+		}
+		fleet.Projects = append(fleet.Projects, project)
+
+		for a := 0; a < config.ApplicationsPerProject; a++ {
+			app := &servingv1alpha1.KalypsoApplication{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("application-%03d", a), Namespace: namespace, Labels: labels},
+				Spec:       servingv1alpha1.KalypsoApplicationSpec{ProjectRef: project.Name},
+			}
+			fleet.Applications = append(fleet.Applications, app)
+
+			for s := 0; s < config.ServersPerApplication; s++ {
+				fleet.Servers = append(fleet.Servers, &servingv1alpha1.KalypsoTritonServer{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fmt.Sprintf("server-%03d-%03d", a, s),
+						Namespace: namespace,
+						Labels:    labels,
+					},
+					Spec: servingv1alpha1.KalypsoTritonServerSpec{
+						ApplicationRef: app.Name,
+						StorageURI:     fmt.Sprintf("s3://scale-test/%s/%s/%03d", project.Name, app.Name, s),
+						Replicas:       &replicas,
+						TritonConfig: servingv1alpha1.TritonConfigSpec{
+							Image: "nvcr.io/nvidia/tritonserver",
+							Tag:   "24.12-py3",
+						},
+					},
+				})
+			}
+		}
+	}
+	return fleet
+}
+
+// Objects returns the fleet's resources in creation order, parents first
+func (f *Fleet) Objects() []client.Object {
+	objects := make([]client.Object, 0, len(f.Namespaces)+len(f.Projects)+len(f.Applications)+len(f.Servers))
+	for _, namespace := range f.Namespaces {
+		objects = append(objects, namespace)
+	}
+	for _, project := range f.Projects {
+		objects = append(objects, project)
+	}
+	for _, app := range f.Applications {
+		objects = append(objects, app)
+	}
+	for _, server := range f.Servers {
+		objects = append(objects, server)
+	}
+	return objects
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command perf generates a synthetic fleet of projects, applications, and servers, and reports
+// how the operator reconciles it: convergence latency, reconcile time, API request rate, and
+// memory. It runs the controllers in-process against envtest, or measures an operator deployed
+// to a cluster such as Kind through its metrics endpoint.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+	"github.com/kalypsoServing/KalypsoServing/internal/controller"
+)
+
+type options struct {
+	fleet              FleetConfig
+	timeout            time.Duration
+	pollInterval       time.Duration
+	useEnvtest         bool
+	crdDir             string
+	metricsURL         string
+	metricsToken       string
+	insecureSkipVerify bool
+	cleanup            bool
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.fleet.Prefix, "prefix", "kalypso-scale", "Prefix of the fleet's namespaces.")
+	flag.IntVar(&opts.fleet.Projects, "projects", 10, "Number of projects, each in its own namespace.")
+	flag.IntVar(&opts.fleet.ApplicationsPerProject, "applications", 5, "Number of applications per project.")
+	flag.IntVar(&opts.fleet.ServersPerApplication, "servers", 4, "Number of servers per application.")
+	flag.DurationVar(&opts.timeout, "timeout", 10*time.Minute, "Time allowed for the fleet to converge.")
+	flag.DurationVar(&opts.pollInterval, "poll-interval", 500*time.Millisecond, "Resolution of the convergence measurements.")
+	flag.BoolVar(&opts.useEnvtest, "envtest", false, "Run the controllers in-process against envtest (requires KUBEBUILDER_ASSETS).")
+	flag.StringVar(&opts.crdDir, "crd-dir", filepath.Join("config", "crd", "bases"), "CRD manifests installed into envtest.")
+	flag.StringVar(&opts.metricsURL, "metrics-url", "", "Metrics endpoint of an operator deployed to the cluster, e.g. a port-forward to https://localhost:8443/metrics.")
+	flag.StringVar(&opts.metricsToken, "metrics-token", "", "Bearer token for the metrics endpoint.")
+	flag.BoolVar(&opts.insecureSkipVerify, "metrics-insecure-skip-verify", false, "Skip verifying the metrics endpoint certificate.")
+	flag.BoolVar(&opts.cleanup, "cleanup", true, "Delete the fleet after the run.")
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New(zap.UseDevMode(false)))
+
+	if err := run(opts); err != nil {
+		fmt.Fprintln(os.Stderr, "perf:", err)
+		os.Exit(1)
+	}
+}
+
+func run(opts options) (err error) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(servingv1alpha1.AddToScheme(scheme))
+
+	var cfg *rest.Config
+	runner := &Runner{pollInterval: opts.pollInterval}
+	if opts.useEnvtest {
+		env := &envtest.Environment{CRDDirectoryPaths: []string{opts.crdDir}, ErrorIfCRDPathMissing: true}
+		if cfg, err = env.Start(); err != nil {
+			return fmt.Errorf("starting envtest: %w", err)
+		}
+		defer func() { err = errors.Join(err, env.Stop()) }()
+
+		if err := startControllers(ctx, cfg, scheme); err != nil {
+			return err
+		}
+		runner.gatherer = metrics.Registry
+	} else {
+		if cfg, err = ctrl.GetConfig(); err != nil {
+			return err
+		}
+		if opts.metricsURL != "" {
+			runner.gatherer = newScrapeGatherer(opts.metricsURL, opts.metricsToken, opts.insecureSkipVerify)
+		}
+	}
+
+	// Count the harness requests, which share the client metrics with in-process controllers
+	var ownRequests atomic.Int64
+	harnessCfg := rest.CopyConfig(cfg)
+	harnessCfg.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ownRequests.Add(1)
+			return rt.RoundTrip(req)
+		})
+	}
+	if runner.client, err = client.New(harnessCfg, client.Options{Scheme: scheme}); err != nil {
+		return err
+	}
+	if opts.useEnvtest {
+		runner.ownRequests = func() float64 { return float64(ownRequests.Load()) }
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+	report, err := runner.Run(runCtx, opts.fleet)
+	if opts.cleanup && !opts.useEnvtest {
+		// Use a fresh context so the fleet is removed even when the run was interrupted
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Minute)
+		defer cancel()
+		err = errors.Join(err, runner.Cleanup(cleanupCtx, opts.fleet))
+	}
+	if report != nil {
+		err = errors.Join(err, report.Write(os.Stdout))
+	}
+	return err
+}
+
+// startControllers runs the operator's controllers in-process until the context is done
+func startControllers(ctx context.Context, cfg *rest.Config, scheme *runtime.Scheme) error {
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:  scheme,
+		Cache:   controller.NewCacheOptions(),
+		Metrics: metricsserver.Options{BindAddress: "0"},
+	})
+	if err != nil {
+		return fmt.Errorf("creating manager: %w", err)
+	}
+
+	if err := (&controller.KalypsoProjectReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+	if err := (&controller.KalypsoApplicationReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+	if err := (&controller.KalypsoTritonServerReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	go func() {
+		if err := mgr.Start(ctx); err != nil {
+			ctrl.Log.Error(err, "Manager stopped")
+		}
+	}()
+	// Keep the informers' initial list out of the measurements
+	if !mgr.GetCache().WaitForCacheSync(ctx) {
+		return errors.New("timed out waiting for the controller caches")
+	}
+	return nil
+}
+
+// roundTripperFunc adapts a function to an http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// Operator metrics read by the harness
+const (
+	reconcileTimeMetric   = "controller_runtime_reconcile_time_seconds"
+	reconcileErrorsMetric = "controller_runtime_reconcile_errors_total"
+	apiRequestsMetric     = "rest_client_requests_total"
+	residentMemoryMetric  = "process_resident_memory_bytes"
+)
+
+// scrapeGatherer gathers the metrics exposed by an operator's metrics endpoint
+type scrapeGatherer struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// newScrapeGatherer creates a gatherer scraping the metrics endpoint at url, authenticating with
+// the bearer token when set
+func newScrapeGatherer(url, token string, insecureSkipVerify bool) *scrapeGatherer {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: insecureSkipVerify} // nolint:gosec
+	return &scrapeGatherer{url: url, token: token, client: &http.Client{Transport: transport}}
+}
+
+// Gather scrapes the endpoint and parses the text exposition format
+func (g *scrapeGatherer) Gather() ([]*dto.MetricFamily, error) {
+	req, err := http.NewRequest(http.MethodGet, g.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if g.token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.token)
+	}
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scraping %s: %s", g.url, resp.Status)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", g.url, err)
+	}
+	gathered := make([]*dto.MetricFamily, 0, len(families))
+	for _, family := range families {
+		gathered = append(gathered, family)
+	}
+	return gathered, nil
+}
+
+// snapshot holds the metric families gathered at one point of a run
+type snapshot map[string]*dto.MetricFamily
+
+// takeSnapshot gathers the current metrics
+func takeSnapshot(gatherer prometheus.Gatherer) (snapshot, error) {
+	families, err := gatherer.Gather()
+	if err != nil {
+		return nil, err
+	}
+	s := make(snapshot, len(families))
+	for _, family := range families {
+		s[family.GetName()] = family
+	}
+	return s, nil
+}
+
+// sum adds the values of every series of a counter or gauge
+func (s snapshot) sum(name string) float64 {
+	total := 0.0
+	for _, metric := range s[name].GetMetric() {
+		switch {
+		case metric.Counter != nil:
+			total += metric.Counter.GetValue()
+		case metric.Gauge != nil:
+			total += metric.Gauge.GetValue()
+		case metric.Untyped != nil:
+			total += metric.Untyped.GetValue()
+		}
+	}
+	return total
+}
+
+// sumBy adds the values of the series of a counter by the value of a label
+func (s snapshot) sumBy(name, label string) map[string]float64 {
+	totals := map[string]float64{}
+	for _, metric := range s[name].GetMetric() {
+		totals[labelValue(metric, label)] += metric.GetCounter().GetValue() + metric.GetUntyped().GetValue()
+	}
+	return totals
+}
+
+// histogram is a cumulative histogram aggregated over series
+type histogram struct {
+	count   uint64
+	sum     float64
+	buckets map[float64]uint64
+}
+
+// histogramsBy aggregates the series of a histogram by the value of a label
+func (s snapshot) histogramsBy(name, label string) map[string]histogram {
+	histograms := map[string]histogram{}
+	for _, metric := range s[name].GetMetric() {
+		if metric.Histogram == nil {
+			continue
+		}
+		key := labelValue(metric, label)
+		h, ok := histograms[key]
+		if !ok {
+			h = histogram{buckets: map[float64]uint64{}}
+		}
+		h.count += metric.Histogram.GetSampleCount()
+		h.sum += metric.Histogram.GetSampleSum()
+		for _, bucket := range metric.Histogram.GetBucket() {
+			h.buckets[bucket.GetUpperBound()] += bucket.GetCumulativeCount()
+		}
+		histograms[key] = h
+	}
+	return histograms
+}
+
+// sub returns the observations made since the previous histogram
+func (h histogram) sub(previous histogram) histogram {
+	delta := histogram{count: h.count - previous.count, sum: h.sum - previous.sum, buckets: map[float64]uint64{}}
+	for upper, count := range h.buckets {
+		delta.buckets[upper] = count - previous.buckets[upper]
+	}
+	return delta
+}
+
+// mean returns the mean observation
+func (h histogram) mean() float64 {
+	if h.count == 0 {
+		return 0
+	}
+	return h.sum / float64(h.count)
+}
+
+// quantile estimates a quantile by linear interpolation within its bucket, like the PromQL
+// histogram_quantile function
+func (h histogram) quantile(q float64) float64 {
+	if h.count == 0 {
+		return 0
+	}
+	uppers := make([]float64, 0, len(h.buckets))
+	for upper := range h.buckets {
+		uppers = append(uppers, upper)
+	}
+	sort.Float64s(uppers)
+
+	rank := q * float64(h.count)
+	lower, below := 0.0, uint64(0)
+	for _, upper := range uppers {
+		count := h.buckets[upper]
+		if float64(count) >= rank {
+			if math.IsInf(upper, 1) {
+				return lower
+			}
+			if count == below {
+				return upper
+			}
+			return lower + (upper-lower)*(rank-float64(below))/float64(count-below)
+		}
+		lower, below = upper, count
+	}
+	return lower
+}
+
+// labelValue returns the value of a label of the series
+func labelValue(metric *dto.Metric, name string) string {
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+var _ = Describe("Perf", func() {
+	It("should generate a fleet referencing its parents", func() {
+		fleet := GenerateFleet(FleetConfig{Prefix: "scale", Projects: 2, ApplicationsPerProject: 3, ServersPerApplication: 4})
+
+		Expect(fleet.Namespaces).To(HaveLen(2))
+		Expect(fleet.Applications).To(HaveLen(6))
+		Expect(fleet.Servers).To(HaveLen(24))
+		Expect(fleet.Objects()).To(HaveLen(34))
+		Expect(fleet.Objects()[0]).To(BeIdenticalTo(fleet.Namespaces[0]), "parents are created first")
+
+		server := fleet.Servers[23]
+		Expect(server.Namespace).To(Equal("scale-001"))
+		Expect(server.Spec.ApplicationRef).To(Equal("application-002"))
+		Expect(server.Labels).To(HaveKeyWithValue(FleetLabelKey, "scale"))
+	})
+
+	It("should summarize reconciles from metric snapshots", func() {
+		registry := prometheus.NewRegistry()
+		reconcileTime := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    reconcileTimeMetric,
+			Buckets: []float64{0.01, 0.1, 1},
+		}, []string{"controller"})
+		requests := prometheus.NewCounterVec(prometheus.CounterOpts{Name: apiRequestsMetric}, []string{"code"})
+		registry.MustRegister(reconcileTime, requests)
+
+		reconcileTime.WithLabelValues("kalypsotritonserver").Observe(0.5)
+		requests.WithLabelValues("200").Add(10)
+		before, err := takeSnapshot(registry)
+		Expect(err).NotTo(HaveOccurred())
+
+		for i := 0; i < 99; i++ {
+			reconcileTime.WithLabelValues("kalypsotritonserver").Observe(0.005)
+		}
+		reconcileTime.WithLabelValues("kalypsotritonserver").Observe(0.05)
+		requests.WithLabelValues("409").Add(5)
+		after, err := takeSnapshot(registry)
+		Expect(err).NotTo(HaveOccurred())
+
+		summaries := summarizeControllers(before, after)
+		Expect(summaries).To(HaveLen(1))
+		Expect(summaries[0].Reconciles).To(Equal(uint64(100)), "observations before the run are excluded")
+		Expect(summaries[0].P99).To(Equal(10 * time.Millisecond))
+		Expect(after.sum(apiRequestsMetric) - before.sum(apiRequestsMetric)).To(Equal(5.0))
+	})
+
+	It("should scrape an operator metrics endpoint", func() {
+		registry := prometheus.NewRegistry()
+		memory := prometheus.NewGauge(prometheus.GaugeOpts{Name: residentMemoryMetric})
+		registry.MustRegister(memory)
+		memory.Set(64 << 20)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			promhttp.HandlerFor(registry, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+		}))
+		defer server.Close()
+
+		s, err := takeSnapshot(newScrapeGatherer(server.URL, "token", false))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.sum(residentMemoryMetric)).To(Equal(float64(64 << 20)))
+
+		_, err = takeSnapshot(newScrapeGatherer(server.URL, "", false))
+		Expect(err).To(MatchError(ContainSubstring("401")))
+	})
+
+	It("should measure the convergence of the fleet", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(servingv1alpha1.AddToScheme(scheme)).To(Succeed())
+		c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&servingv1alpha1.KalypsoTritonServer{}).Build()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		// Stand in for the operator by reporting every server reconciled
+		go func() {
+			defer GinkgoRecover()
+			for ctx.Err() == nil {
+				servers := &servingv1alpha1.KalypsoTritonServerList{}
+				Expect(c.List(ctx, servers)).To(Succeed())
+				for i := range servers.Items {
+					servers.Items[i].Status.DeploymentName = servers.Items[i].Name
+					_ = c.Status().Update(ctx, &servers.Items[i])
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		}()
+
+		config := FleetConfig{Prefix: "scale", Projects: 2, ApplicationsPerProject: 2, ServersPerApplication: 2}
+		runner := &Runner{client: c, pollInterval: 10 * time.Millisecond}
+		report, err := runner.Run(ctx, config)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Servers).To(Equal(8))
+		Expect(report.Converged).To(Equal(8))
+		Expect(report.Convergence.Max).To(BeNumerically(">", 0))
+
+		var out bytes.Buffer
+		Expect(report.Write(&out)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("converged     8/8"))
+		Expect(out.String()).To(ContainSubstring("peak memory   n/a"))
+
+		Expect(runner.Cleanup(ctx, config)).To(Succeed())
+		Expect(c.Get(ctx, client.ObjectKey{Name: "scale-000"}, GenerateFleet(config).Namespaces[0])).NotTo(Succeed())
+	})
+})
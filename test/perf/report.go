@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// RunReport summarizes a scale test run
+type RunReport struct {
+	Fleet FleetConfig
+	// Servers is the number of servers created, Converged the number reconciled before the timeout
+	Servers   int
+	Converged int
+	// CreateDuration is the time taken to create the fleet
+	CreateDuration time.Duration
+	// Elapsed is the time from the first creation to the last convergence or the timeout
+	Elapsed time.Duration
+	// Convergence is the distribution of the time from the creation of a server to its first
+	// reconciled status
+	Convergence Latencies
+	// Controllers summarizes the reconciles of each controller during the run
+	Controllers []ControllerSummary
+	// APIRequests is the number of API server requests made by the operator during the run
+	APIRequests float64
+	// PeakMemoryBytes is the highest operator resident memory sampled, or 0 when not exposed
+	PeakMemoryBytes float64
+}
+
+// Latencies summarizes a latency distribution
+type Latencies struct {
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+// ControllerSummary summarizes the reconciles of a controller
+type ControllerSummary struct {
+	Name       string
+	Reconciles uint64
+	Errors     float64
+	Mean       time.Duration
+	P99        time.Duration
+}
+
+// summarizeLatencies computes nearest-rank percentiles of the latencies
+func summarizeLatencies(latencies []time.Duration) Latencies {
+	if len(latencies) == 0 {
+		return Latencies{}
+	}
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := func(p float64) time.Duration {
+		return sorted[max(int(math.Ceil(p*float64(len(sorted))))-1, 0)]
+	}
+	return Latencies{P50: rank(0.50), P90: rank(0.90), P99: rank(0.99), Max: sorted[len(sorted)-1]}
+}
+
+// summarizeControllers computes the reconciles made by each controller between two snapshots
+func summarizeControllers(before, after snapshot) []ControllerSummary {
+	previous := before.histogramsBy(reconcileTimeMetric, "controller")
+	errorsBefore := before.sumBy(reconcileErrorsMetric, "controller")
+	errorsAfter := after.sumBy(reconcileErrorsMetric, "controller")
+
+	var summaries []ControllerSummary
+	for name, h := range after.histogramsBy(reconcileTimeMetric, "controller") {
+		delta := h.sub(previous[name])
+		if delta.count == 0 {
+			continue
+		}
+		summaries = append(summaries, ControllerSummary{
+			Name:       name,
+			Reconciles: delta.count,
+			Errors:     errorsAfter[name] - errorsBefore[name],
+			Mean:       seconds(delta.mean()),
+			P99:        seconds(delta.quantile(0.99)),
+		})
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Name < summaries[j].Name })
+	return summaries
+}
+
+// seconds converts a metric value in seconds to a duration
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
+
+// APIQPS returns the mean operator API request rate over the run
+func (r *RunReport) APIQPS() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return r.APIRequests / r.Elapsed.Seconds()
+}
+
+// Write prints the report
+func (r *RunReport) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "fleet\t%d projects, %d applications, %d servers\n", r.Fleet.Projects,
+		r.Fleet.Projects*r.Fleet.ApplicationsPerProject, r.Servers)
+	fmt.Fprintf(tw, "created\t%s\n", r.CreateDuration.Round(time.Millisecond))
+	fmt.Fprintf(tw, "converged\t%d/%d in %s (p50 %s, p90 %s, p99 %s, max %s)\n", r.Converged, r.Servers,
+		r.Elapsed.Round(time.Millisecond), r.Convergence.P50.Round(time.Millisecond),
+		r.Convergence.P90.Round(time.Millisecond), r.Convergence.P99.Round(time.Millisecond),
+		r.Convergence.Max.Round(time.Millisecond))
+	fmt.Fprintf(tw, "api requests\t%.0f (%.1f qps)\n", r.APIRequests, r.APIQPS())
+	memory := "n/a"
+	if r.PeakMemoryBytes > 0 {
+		memory = fmt.Sprintf("%.1f MiB", r.PeakMemoryBytes/(1<<20))
+	}
+	fmt.Fprintf(tw, "peak memory\t%s\n", memory)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(r.Controllers) == 0 {
+		return nil
+	}
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "controller\treconciles\terrors\tmean\tp99")
+	for _, c := range r.Controllers {
+		fmt.Fprintf(tw, "%s\t%d\t%.0f\t%s\t%s\n", c.Name, c.Reconciles, c.Errors,
+			c.Mean.Round(time.Microsecond), c.P99.Round(time.Microsecond))
+	}
+	return tw.Flush()
+}
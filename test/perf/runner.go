@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	servingv1alpha1 "github.com/kalypsoServing/KalypsoServing/api/v1alpha1"
+)
+
+// Runner creates a synthetic fleet and measures how the operator reconciles it
+type Runner struct {
+	client client.Client
+	// gatherer reads the operator metrics; without it only convergence is measured
+	gatherer prometheus.Gatherer
+	// ownRequests counts the API requests made by the harness itself, subtracted from the
+	// operator's when both share the client metrics of one process
+	ownRequests func() float64
+	// pollInterval is the resolution of the convergence measurements
+	pollInterval time.Duration
+}
+
+// Run creates the fleet and waits until every server is reconciled or the context is done
+func (r *Runner) Run(ctx context.Context, config FleetConfig) (*RunReport, error) {
+	fleet := GenerateFleet(config)
+	report := &RunReport{Fleet: config, Servers: len(fleet.Servers)}
+
+	before, err := r.snapshot()
+	if err != nil {
+		return nil, fmt.Errorf("gathering operator metrics: %w", err)
+	}
+	ownBefore := r.countOwnRequests()
+
+	started := time.Now()
+	created := make(map[client.ObjectKey]time.Time, len(fleet.Servers))
+	for _, obj := range fleet.Objects() {
+		if err := r.client.Create(ctx, obj); err != nil {
+			return nil, fmt.Errorf("creating %T %s: %w", obj, client.ObjectKeyFromObject(obj), err)
+		}
+		if _, ok := obj.(*servingv1alpha1.KalypsoTritonServer); ok {
+			created[client.ObjectKeyFromObject(obj)] = time.Now()
+		}
+	}
+	report.CreateDuration = time.Since(started)
+
+	convergence, err := r.waitForConvergence(ctx, config.Prefix, created, report)
+	if err != nil {
+		return nil, err
+	}
+	report.Elapsed = time.Since(started)
+	report.Converged = len(convergence)
+	report.Convergence = summarizeLatencies(convergence)
+
+	after, err := r.snapshot()
+	if err != nil {
+		return nil, fmt.Errorf("gathering operator metrics: %w", err)
+	}
+	report.Controllers = summarizeControllers(before, after)
+	report.APIRequests = after.sum(apiRequestsMetric) - before.sum(apiRequestsMetric) - (r.countOwnRequests() - ownBefore)
+	return report, nil
+}
+
+// waitForConvergence polls the fleet's servers until each has reported a reconciled status,
+// sampling the operator memory on every poll. It returns the convergence latency of each server
+func (r *Runner) waitForConvergence(ctx context.Context, prefix string, created map[client.ObjectKey]time.Time, report *RunReport) ([]time.Duration, error) {
+	converged := make(map[client.ObjectKey]time.Duration, len(created))
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		servers := &servingv1alpha1.KalypsoTritonServerList{}
+		if err := r.client.List(ctx, servers, client.MatchingLabels{FleetLabelKey: prefix}); err != nil {
+			if ctx.Err() != nil {
+				// Report the servers converged before the timeout
+				return latencies(converged), nil
+			}
+			return nil, fmt.Errorf("listing servers: %w", err)
+		}
+		now := time.Now()
+		for _, server := range servers.Items {
+			key := client.ObjectKeyFromObject(&server)
+			start, ok := created[key]
+			if _, done := converged[key]; !ok || done || server.Status.DeploymentName == "" {
+				continue
+			}
+			converged[key] = now.Sub(start)
+		}
+
+		if current, err := r.snapshot(); err == nil {
+			report.PeakMemoryBytes = max(report.PeakMemoryBytes, current.sum(residentMemoryMetric))
+		}
+
+		if len(converged) == len(created) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return latencies(converged), nil
+		case <-ticker.C:
+		}
+	}
+	return latencies(converged), nil
+}
+
+// latencies returns the convergence latencies of the servers
+func latencies(converged map[client.ObjectKey]time.Duration) []time.Duration {
+	values := make([]time.Duration, 0, len(converged))
+	for _, latency := range converged {
+		values = append(values, latency)
+	}
+	return values
+}
+
+// Cleanup deletes the fleet's namespaces; the operator removes the servers' finalizers
+func (r *Runner) Cleanup(ctx context.Context, config FleetConfig) error {
+	for _, namespace := range GenerateFleet(config).Namespaces {
+		if err := r.client.Delete(ctx, namespace); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("deleting namespace %s: %w", namespace.Name, err)
+		}
+	}
+	return nil
+}
+
+// snapshot gathers the operator metrics, or returns an empty snapshot without a gatherer
+func (r *Runner) snapshot() (snapshot, error) {
+	if r.gatherer == nil {
+		return snapshot{}, nil
+	}
+	return takeSnapshot(r.gatherer)
+}
+
+// countOwnRequests returns the number of API requests made by the harness so far
+func (r *Runner) countOwnRequests() float64 {
+	if r.ownRequests == nil {
+		return 0
+	}
+	return r.ownRequests()
+}